@@ -0,0 +1,88 @@
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+	"workoutstudy_chatting/model"
+)
+
+// record 는 아카이브 파일의 한 줄(JSON)입니다.
+// model.ChatMessage 의 UnmarshalJSON 은 클라이언트 시간 형식만 허용하므로 아카이브 전용 형식을 따로 둡니다.
+type record struct {
+	ID          string            `json:"messageId"`
+	UserID      int               `json:"userId"`
+	FitGroupID  int               `json:"fitGroupId"`
+	Message     string            `json:"message"`
+	MessageTime time.Time         `json:"messageTime"`
+	MessageType model.MessageType `json:"messageType"`
+	DeletedAt   *time.Time        `json:"deletedAt,omitempty"` // 삭제된 메시지도 아카이브하고 복원 시 삭제 상태를 유지
+	Poll        *model.ChatPoll   `json:"poll,omitempty"`      // 투표 메시지는 선택지와 투표 결과를 함께 아카이브
+}
+
+// EncodeMessages 는 메시지들을 gzip 압축된 JSONL 로 인코딩합니다.
+func EncodeMessages(messages []model.ChatMessage) (*bytes.Buffer, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	enc := json.NewEncoder(zw)
+	for _, msg := range messages {
+		rec := record{
+			ID:          msg.ID,
+			UserID:      msg.UserID,
+			FitGroupID:  msg.FitGroupID,
+			Message:     msg.Message,
+			MessageTime: msg.MessageTime,
+			MessageType: msg.MessageType,
+			DeletedAt:   msg.DeletedAt,
+			Poll:        msg.Poll,
+		}
+		if err := enc.Encode(rec); err != nil {
+			return nil, fmt.Errorf("error encoding archive record %s: %w", msg.ID, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return &buf, nil
+}
+
+// DecodeMessages 는 gzip 압축된 JSONL 아카이브를 메시지 목록으로 디코딩합니다.
+func DecodeMessages(r io.Reader) ([]model.ChatMessage, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("error opening gzip archive: %w", err)
+	}
+	defer zr.Close()
+
+	var messages []model.ChatMessage
+	scanner := bufio.NewScanner(zr)
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		var rec record
+		if err := json.Unmarshal(line, &rec); err != nil {
+			return nil, fmt.Errorf("error decoding archive record: %w", err)
+		}
+		messages = append(messages, model.ChatMessage{
+			ID:          rec.ID,
+			UserID:      rec.UserID,
+			FitGroupID:  rec.FitGroupID,
+			Message:     rec.Message,
+			MessageTime: rec.MessageTime,
+			MessageType: rec.MessageType,
+			DeletedAt:   rec.DeletedAt,
+			Poll:        rec.Poll,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
@@ -0,0 +1,17 @@
+package archive
+
+import (
+	"errors"
+	"io"
+)
+
+// ErrNotFound 는 요청한 아카이브 객체가 저장소에 없을 때 반환됩니다.
+var ErrNotFound = errors.New("archive object not found")
+
+// Storage 는 콜드 아카이브 파일을 보관하는 저장소 인터페이스입니다.
+// 로컬 파일시스템 구현(LocalStorage)을 우선 제공하고, 이후 S3 등으로 교체할 수 있도록 분리합니다.
+type Storage interface {
+	Put(key string, r io.Reader) error
+	Get(key string) (io.ReadCloser, error)
+	List(prefix string) ([]string, error)
+}
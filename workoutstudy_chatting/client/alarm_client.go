@@ -0,0 +1,34 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"workoutstudy_chatting/config"
+	"workoutstudy_chatting/model"
+)
+
+// AlarmClient 는 alarm-service 푸시 알림 웹훅입니다. 응답 본문은 사용하지 않습니다.
+// 알림 전송은 다시 보내면 같은 푸시가 중복되므로 재시도하지 않습니다.
+type AlarmClient interface {
+	SendChatAlarm(ctx context.Context, alarm model.ChatAlarmRequest) error
+	SendDirectMessageAlarm(ctx context.Context, alarm model.DirectMessageAlarmRequest) error
+}
+
+type HTTPAlarmClient struct {
+	http *httpClient
+}
+
+var _ AlarmClient = (*HTTPAlarmClient)(nil)
+
+func NewAlarmClient(baseURL string, cfg config.ClientConfig) *HTTPAlarmClient {
+	return &HTTPAlarmClient{http: newHTTPClient("alarm-service", baseURL, cfg)}
+}
+
+func (c *HTTPAlarmClient) SendChatAlarm(ctx context.Context, alarm model.ChatAlarmRequest) error {
+	return c.http.doOnce(ctx, http.MethodPost, "/chat/real-time-chat", alarm, nil)
+}
+
+func (c *HTTPAlarmClient) SendDirectMessageAlarm(ctx context.Context, alarm model.DirectMessageAlarmRequest) error {
+	return c.http.doOnce(ctx, http.MethodPost, "/chat/direct-message", alarm, nil)
+}
@@ -0,0 +1,33 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"workoutstudy_chatting/config"
+	"workoutstudy_chatting/model"
+)
+
+// AuthClient 는 auth-service 의 사용자 정보 조회 API 입니다.
+type AuthClient interface {
+	GetUserInfo(ctx context.Context, userID int) (*model.GetUserInfoApiResponse, error)
+}
+
+type HTTPAuthClient struct {
+	http *httpClient
+}
+
+var _ AuthClient = (*HTTPAuthClient)(nil)
+
+func NewAuthClient(baseURL string, cfg config.ClientConfig) *HTTPAuthClient {
+	return &HTTPAuthClient{http: newHTTPClient("auth-service", baseURL, cfg)}
+}
+
+func (c *HTTPAuthClient) GetUserInfo(ctx context.Context, userID int) (*model.GetUserInfoApiResponse, error) {
+	var userInfo model.GetUserInfoApiResponse
+	if err := c.http.do(ctx, http.MethodGet, fmt.Sprintf("/user/user-info?userId=%d", userID), nil, &userInfo); err != nil {
+		return nil, err
+	}
+	return &userInfo, nil
+}
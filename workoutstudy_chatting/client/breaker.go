@@ -0,0 +1,85 @@
+package client
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+type breakerState int
+
+const (
+	breakerClosed   breakerState = iota
+	breakerOpen                  // 요청을 보내지 않고 바로 실패
+	breakerHalfOpen              // cooldown 이 지나 시험 요청 하나를 보낸 상태
+)
+
+/*
+circuitBreaker 는 서비스가 연속으로 threshold 번 실패하면 cooldown 동안 요청을 보내지 않습니다.
+cooldown 이 지나면 시험 요청 하나만 보내고, 성공하면 닫고 실패하면 다시 cooldown 동안 엽니다.
+*/
+type circuitBreaker struct {
+	mu        sync.Mutex
+	service   string
+	threshold int
+	cooldown  time.Duration
+	state     breakerState
+	failures  int
+	openedAt  time.Time
+}
+
+func newCircuitBreaker(service string, threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{service: service, threshold: threshold, cooldown: cooldown}
+}
+
+// allow 는 요청을 보내도 되는지 확인합니다. true 면 결과를 record 로 알려야 합니다.
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if time.Since(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state = breakerHalfOpen
+		return true
+	case breakerHalfOpen:
+		return false
+	}
+	return true
+}
+
+// release 는 결과를 알 수 없는 요청(호출자 취소)을 기록 없이 반납합니다.
+// 상태와 연속 실패 횟수는 그대로 두고, 시험 요청이었다면 다음 요청이 다시 시험 요청이 되도록 합니다.
+func (b *circuitBreaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == breakerHalfOpen {
+		// openedAt 은 이미 cooldown 이 지났으므로 다음 allow 에서 바로 half-open 이 됨
+		b.state = breakerOpen
+	}
+}
+
+func (b *circuitBreaker) record(healthy bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if healthy {
+		if b.state != breakerClosed {
+			log.Printf("Circuit breaker for %s closed", b.service)
+		}
+		b.state = breakerClosed
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.state == breakerHalfOpen || b.failures >= b.threshold {
+		if b.state != breakerOpen {
+			log.Printf("Circuit breaker for %s opened after %d consecutive failures", b.service, b.failures)
+		}
+		b.state = breakerOpen
+		b.openedAt = time.Now()
+	}
+}
@@ -0,0 +1,109 @@
+package client
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCircuitBreakerTransitions(t *testing.T) {
+	const cooldown = time.Minute
+	// step 은 breaker 에 적용할 동작입니다.
+	type step struct {
+		op        string // allow, success, failure, release, cooldown(cooldown 경과)
+		wantAllow bool   // op 가 allow 일 때 기대값
+		wantState breakerState
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "연속 실패가 threshold 에 닿으면 열림",
+			steps: []step{
+				{op: "failure", wantState: breakerClosed},
+				{op: "failure", wantState: breakerClosed},
+				{op: "failure", wantState: breakerOpen},
+				{op: "allow", wantAllow: false, wantState: breakerOpen},
+			},
+		},
+		{
+			name: "성공하면 연속 실패 횟수 초기화",
+			steps: []step{
+				{op: "failure", wantState: breakerClosed},
+				{op: "failure", wantState: breakerClosed},
+				{op: "success", wantState: breakerClosed},
+				{op: "failure", wantState: breakerClosed},
+				{op: "failure", wantState: breakerClosed},
+				{op: "allow", wantAllow: true, wantState: breakerClosed},
+			},
+		},
+		{
+			name: "cooldown 뒤 시험 요청 하나만 허용하고 성공하면 닫힘",
+			steps: []step{
+				{op: "failure"}, {op: "failure"}, {op: "failure", wantState: breakerOpen},
+				{op: "cooldown", wantState: breakerOpen},
+				{op: "allow", wantAllow: true, wantState: breakerHalfOpen},
+				{op: "allow", wantAllow: false, wantState: breakerHalfOpen},
+				{op: "success", wantState: breakerClosed},
+				{op: "allow", wantAllow: true, wantState: breakerClosed},
+			},
+		},
+		{
+			name: "시험 요청이 실패하면 다시 cooldown 동안 열림",
+			steps: []step{
+				{op: "failure"}, {op: "failure"}, {op: "failure", wantState: breakerOpen},
+				{op: "cooldown", wantState: breakerOpen},
+				{op: "allow", wantAllow: true, wantState: breakerHalfOpen},
+				{op: "failure", wantState: breakerOpen},
+				{op: "allow", wantAllow: false, wantState: breakerOpen},
+			},
+		},
+		{
+			name: "취소된 시험 요청은 상태를 바꾸지 않고 다음 요청이 다시 시험",
+			steps: []step{
+				{op: "failure"}, {op: "failure"}, {op: "failure", wantState: breakerOpen},
+				{op: "cooldown", wantState: breakerOpen},
+				{op: "allow", wantAllow: true, wantState: breakerHalfOpen},
+				{op: "release", wantState: breakerOpen},
+				{op: "allow", wantAllow: true, wantState: breakerHalfOpen},
+				{op: "success", wantState: breakerClosed},
+			},
+		},
+		{
+			name: "닫힌 상태에서 취소된 요청은 실패 횟수에 영향 없음",
+			steps: []step{
+				{op: "failure"}, {op: "failure", wantState: breakerClosed},
+				{op: "release", wantState: breakerClosed},
+				{op: "release", wantState: breakerClosed},
+				{op: "failure", wantState: breakerOpen},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newCircuitBreaker("test", 3, cooldown)
+			for i, s := range tt.steps {
+				switch s.op {
+				case "allow":
+					if got := b.allow(); got != s.wantAllow {
+						t.Fatalf("step %d: allow() = %v, want %v", i, got, s.wantAllow)
+					}
+				case "success":
+					b.record(true)
+				case "failure":
+					b.record(false)
+				case "release":
+					b.release()
+				case "cooldown":
+					b.openedAt = b.openedAt.Add(-cooldown)
+				default:
+					t.Fatalf("step %d: unknown op %q", i, s.op)
+				}
+				if b.state != s.wantState {
+					t.Fatalf("step %d (%s): state = %v, want %v", i, s.op, b.state, s.wantState)
+				}
+			}
+		})
+	}
+}
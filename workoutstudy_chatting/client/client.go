@@ -0,0 +1,174 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"workoutstudy_chatting/config"
+)
+
+// 응답 상태 코드별 오류. errors.Is 로 구분합니다.
+var (
+	ErrBadRequest   = errors.New("bad request")         // 다시 보내도 실패하는 4xx
+	ErrUnauthorized = errors.New("unauthorized")        // 401, 403
+	ErrNotFound     = errors.New("not found")           // 404
+	ErrUnavailable  = errors.New("service unavailable") // 429, 5xx, 연결 실패, 시간 초과. 재시도 대상
+	ErrCircuitOpen  = errors.New("circuit breaker open")
+)
+
+// StatusError 는 2xx 가 아닌 응답입니다. Unwrap 하면 상태 코드에 맞는 Err* 를 반환합니다.
+type StatusError struct {
+	Service    string
+	Method     string
+	Path       string
+	StatusCode int
+	Body       string // 응답 본문 앞부분
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s %s %s: status %d: %s", e.Service, e.Method, e.Path, e.StatusCode, e.Body)
+}
+
+func (e *StatusError) Unwrap() error {
+	switch {
+	case e.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden:
+		return ErrUnauthorized
+	case e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500:
+		return ErrUnavailable
+	default:
+		return ErrBadRequest
+	}
+}
+
+// 오류 응답 본문은 이 길이까지만 읽습니다.
+const errorBodyLimit = 512
+
+// httpClient 는 서비스별 클라이언트가 공유하는 JSON HTTP 호출입니다.
+// 요청마다 cfg.Timeout 을 적용하고, ErrUnavailable 이면 jitter 를 둔 backoff 로 재시도하며, 연속 실패 시 회로를 엽니다.
+type httpClient struct {
+	service string
+	baseURL string
+	http    *http.Client
+	cfg     config.ClientConfig
+	breaker *circuitBreaker
+}
+
+func newHTTPClient(service, baseURL string, cfg config.ClientConfig) *httpClient {
+	return &httpClient{
+		service: service,
+		baseURL: baseURL,
+		http:    &http.Client{Timeout: cfg.Timeout},
+		cfg:     cfg,
+		breaker: newCircuitBreaker(service, cfg.BreakerThreshold, cfg.BreakerCooldown),
+	}
+}
+
+// do 는 body 를 JSON 으로 보내고 2xx 응답 본문을 out 에 읽습니다. body, out 이 nil 이면 생략합니다.
+func (c *httpClient) do(ctx context.Context, method, path string, body, out interface{}) error {
+	return c.call(ctx, method, path, body, out, c.cfg.MaxAttempts)
+}
+
+// doOnce 는 재시도하지 않는 do 입니다.
+// 시간 초과는 서비스가 요청을 이미 처리한 뒤일 수 있으므로, 다시 보내면 중복되는 요청(알림 전송 등)에 사용합니다.
+func (c *httpClient) doOnce(ctx context.Context, method, path string, body, out interface{}) error {
+	return c.call(ctx, method, path, body, out, 1)
+}
+
+func (c *httpClient) call(ctx context.Context, method, path string, body, out interface{}, maxAttempts int) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return fmt.Errorf("encode %s request: %w", c.service, err)
+		}
+	}
+	if !c.breaker.allow() {
+		return fmt.Errorf("%s %s %s: %w", c.service, method, path, ErrCircuitOpen)
+	}
+
+	backoff := c.cfg.Backoff
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = c.send(ctx, method, path, payload, out)
+		if err == nil || !errors.Is(err, ErrUnavailable) || attempt >= maxAttempts {
+			break
+		}
+		wait := jitter(backoff)
+		log.Printf("%s %s %s failed (attempt %d/%d), retrying in %s: %v", c.service, method, path, attempt, maxAttempts, wait, err)
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			err = fmt.Errorf("%s %s %s: %w", c.service, method, path, ctx.Err())
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		if backoff *= 2; backoff > c.cfg.MaxBackoff {
+			backoff = c.cfg.MaxBackoff
+		}
+	}
+	// 호출자가 취소했거나 호출자의 기한이 지난 요청은 서비스 상태를 알 수 없으므로 기록하지 않음
+	if err != nil && ctx.Err() != nil {
+		c.breaker.release()
+		return err
+	}
+	// 4xx 나 응답 형식 오류는 서비스가 응답한 것이므로 실패로 세지 않음
+	c.breaker.record(!errors.Is(err, ErrUnavailable))
+	return err
+}
+
+func (c *httpClient) send(ctx context.Context, method, path string, payload []byte, out interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return fmt.Errorf("create %s request: %w", c.service, err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("%s %s %s: %w", c.service, method, path, ctx.Err())
+		}
+		return fmt.Errorf("%s %s %s: %v: %w", c.service, method, path, err, ErrUnavailable)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, errorBodyLimit))
+		return &StatusError{Service: c.service, Method: method, Path: path, StatusCode: resp.StatusCode, Body: string(snippet)}
+	}
+	if out == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode %s %s response: %w", c.service, path, err)
+	}
+	return nil
+}
+
+// jitter 는 d/2 ~ d 사이의 임의 시간입니다. 여러 요청이 동시에 재시도하지 않도록 분산합니다.
+func jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(d-half)+1))
+}
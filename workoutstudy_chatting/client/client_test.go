@@ -0,0 +1,210 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"workoutstudy_chatting/config"
+	"workoutstudy_chatting/model"
+)
+
+func testClientConfig() config.ClientConfig {
+	return config.ClientConfig{
+		Timeout:          200 * time.Millisecond,
+		MaxAttempts:      3,
+		Backoff:          time.Millisecond,
+		MaxBackoff:       2 * time.Millisecond,
+		BreakerThreshold: 5,
+		BreakerCooldown:  time.Minute,
+	}
+}
+
+// statusServer 는 요청마다 statuses 의 상태 코드를 차례로 응답하고, 다 쓰면 마지막 상태 코드를 반복합니다.
+// 200 이면 body 를 응답합니다.
+func statusServer(t *testing.T, statuses []int, body string) (*httptest.Server, *int32) {
+	t.Helper()
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(&requests, 1))
+		status := statuses[len(statuses)-1]
+		if n <= len(statuses) {
+			status = statuses[n-1]
+		}
+		w.WriteHeader(status)
+		if status == http.StatusOK {
+			_, _ = w.Write([]byte(body))
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &requests
+}
+
+func TestFitGroupClientRetryAndErrorMapping(t *testing.T) {
+	const fitGroupBody = `{"fitGroupId":3,"fitLeaderUserId":7,"fitGroupName":"morning run"}`
+	tests := []struct {
+		name         string
+		statuses     []int
+		body         string
+		wantErr      error // nil 이면 성공
+		wantRequests int32
+		wantFailures int // 회로 차단기에 기록된 연속 실패 횟수
+	}{
+		{name: "5xx 후 성공하면 재시도", statuses: []int{503, 502, 200}, body: fitGroupBody, wantRequests: 3},
+		{name: "429 는 재시도", statuses: []int{429, 200}, body: fitGroupBody, wantRequests: 2},
+		{name: "5xx 가 계속되면 maxAttempts 까지 시도", statuses: []int{500}, wantErr: ErrUnavailable, wantRequests: 3, wantFailures: 1},
+		{name: "404 는 재시도하지 않음", statuses: []int{404}, wantErr: ErrNotFound, wantRequests: 1},
+		{name: "400 은 재시도하지 않음", statuses: []int{400}, wantErr: ErrBadRequest, wantRequests: 1},
+		{name: "401 은 ErrUnauthorized", statuses: []int{401}, wantErr: ErrUnauthorized, wantRequests: 1},
+		{name: "403 은 ErrUnauthorized", statuses: []int{403}, wantErr: ErrUnauthorized, wantRequests: 1},
+		{name: "409 는 ErrBadRequest", statuses: []int{409}, wantErr: ErrBadRequest, wantRequests: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, requests := statusServer(t, tt.statuses, tt.body)
+			c := NewFitGroupClient(srv.URL, testClientConfig())
+
+			fitGroup, err := c.GetFitGroup(context.Background(), 3)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("GetFitGroup: %v", err)
+				}
+				if fitGroup.FitGroupId != 3 || fitGroup.FitLeaderUserId != 7 || fitGroup.FitGroupName != "morning run" {
+					t.Fatalf("fitGroup = %+v", fitGroup)
+				}
+			} else if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetFitGroup err = %v, want %v", err, tt.wantErr)
+			}
+			if got := atomic.LoadInt32(requests); got != tt.wantRequests {
+				t.Fatalf("requests = %d, want %d", got, tt.wantRequests)
+			}
+			if c.http.breaker.failures != tt.wantFailures {
+				t.Fatalf("breaker failures = %d, want %d", c.http.breaker.failures, tt.wantFailures)
+			}
+		})
+	}
+}
+
+func TestStatusErrorKeepsStatusAndBody(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "no such group", http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	_, err := NewFitGroupClient(srv.URL, testClientConfig()).GetFitMates(context.Background(), 9)
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) {
+		t.Fatalf("err = %v, want *StatusError", err)
+	}
+	if statusErr.StatusCode != http.StatusNotFound || statusErr.Path != "/fit-group-service/mates/9" || statusErr.Body != "no such group\n" {
+		t.Fatalf("StatusError = %+v", statusErr)
+	}
+}
+
+func TestInvalidResponseIsNotRetried(t *testing.T) {
+	srv, requests := statusServer(t, []int{200}, `{"fitGroupId":`)
+	c := NewFitGroupClient(srv.URL, testClientConfig())
+
+	if _, err := c.GetFitGroup(context.Background(), 3); err == nil || errors.Is(err, ErrUnavailable) {
+		t.Fatalf("err = %v, want decode error", err)
+	}
+	if got := atomic.LoadInt32(requests); got != 1 {
+		t.Fatalf("requests = %d, want 1", got)
+	}
+	if c.http.breaker.failures != 0 {
+		t.Fatalf("breaker failures = %d, want 0", c.http.breaker.failures)
+	}
+}
+
+func TestTimeoutIsRetried(t *testing.T) {
+	var requests int32
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	cfg := testClientConfig()
+	cfg.Timeout = 20 * time.Millisecond
+	cfg.MaxAttempts = 2
+	if _, err := NewFitGroupClient(srv.URL, cfg).GetFitGroup(context.Background(), 3); !errors.Is(err, ErrUnavailable) {
+		t.Fatalf("err = %v, want ErrUnavailable", err)
+	}
+	if got := atomic.LoadInt32(&requests); got != 2 {
+		t.Fatalf("requests = %d, want 2", got)
+	}
+}
+
+func TestCancelledRequestIsNotRecorded(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cancel() // 첫 응답 뒤 backoff 중에 종료
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	cfg := testClientConfig()
+	cfg.Backoff, cfg.MaxBackoff = time.Second, time.Second
+	c := NewFitGroupClient(srv.URL, cfg)
+	if _, err := c.GetFitGroup(ctx, 3); !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	if c.http.breaker.failures != 0 || c.http.breaker.state != breakerClosed {
+		t.Fatalf("breaker = %d failures, state %v, want untouched", c.http.breaker.failures, c.http.breaker.state)
+	}
+}
+
+func TestAlarmClientSendsOnce(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr error
+	}{
+		{name: "성공", status: http.StatusOK},
+		{name: "5xx 도 다시 보내지 않음", status: http.StatusServiceUnavailable, wantErr: ErrUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int32
+			var got map[string]interface{}
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&requests, 1)
+				if r.Method != http.MethodPost || r.URL.Path != "/chat/real-time-chat" || r.Header.Get("Content-Type") != "application/json" {
+					t.Errorf("request = %s %s (%s)", r.Method, r.URL.Path, r.Header.Get("Content-Type"))
+				}
+				if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+					t.Errorf("decode body: %v", err)
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			alarm := model.ChatAlarmRequest{
+				ChatMessage: model.ChatMessage{ID: "m1", UserID: 7, FitGroupID: 3, Message: "hi", MessageType: model.Chatting},
+				Priority:    model.AlarmPriorityNormal,
+			}
+			err := NewAlarmClient(srv.URL, testClientConfig()).SendChatAlarm(context.Background(), alarm)
+			if tt.wantErr == nil && err != nil || tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SendChatAlarm err = %v, want %v", err, tt.wantErr)
+			}
+			if n := atomic.LoadInt32(&requests); n != 1 {
+				t.Fatalf("requests = %d, want 1", n)
+			}
+			if got["messageId"] != "m1" || got["fitGroupId"] != float64(3) || got["priority"] != string(model.AlarmPriorityNormal) {
+				t.Fatalf("body = %+v", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"workoutstudy_chatting/config"
+	"workoutstudy_chatting/model"
+)
+
+// FitGroupClient 는 fit-group-service 의 fit group, fit mate 조회 API 입니다.
+type FitGroupClient interface {
+	GetFitGroup(ctx context.Context, fitGroupID int) (*model.GetFitGroupDetailApiResponse, error)
+	GetFitMates(ctx context.Context, fitGroupID int) (*model.GetFitMatesApiResponse, error)
+}
+
+type HTTPFitGroupClient struct {
+	http *httpClient
+}
+
+var _ FitGroupClient = (*HTTPFitGroupClient)(nil)
+
+func NewFitGroupClient(baseURL string, cfg config.ClientConfig) *HTTPFitGroupClient {
+	return &HTTPFitGroupClient{http: newHTTPClient("fit-group-service", baseURL, cfg)}
+}
+
+func (c *HTTPFitGroupClient) GetFitGroup(ctx context.Context, fitGroupID int) (*model.GetFitGroupDetailApiResponse, error) {
+	var fitGroup model.GetFitGroupDetailApiResponse
+	if err := c.http.do(ctx, http.MethodGet, fmt.Sprintf("/fit-group-service/groups/%d", fitGroupID), nil, &fitGroup); err != nil {
+		return nil, err
+	}
+	return &fitGroup, nil
+}
+
+func (c *HTTPFitGroupClient) GetFitMates(ctx context.Context, fitGroupID int) (*model.GetFitMatesApiResponse, error) {
+	var fitMates model.GetFitMatesApiResponse
+	if err := c.http.do(ctx, http.MethodGet, fmt.Sprintf("/fit-group-service/mates/%d", fitGroupID), nil, &fitMates); err != nil {
+		return nil, err
+	}
+	return &fitMates, nil
+}
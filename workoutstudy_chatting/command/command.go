@@ -0,0 +1,151 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Visibility 는 명령어 응답을 누구에게 보낼지 나타냅니다.
+type Visibility int
+
+const (
+	Private Visibility = iota // 명령어를 입력한 사용자에게만
+	Public                    // 채팅방 전체에 핏봇 메시지로
+)
+
+// Request 는 채팅방에서 입력된 명령어입니다. "/progress all" 이면 Name 은 "progress", Args 는 ["all"] 입니다.
+type Request struct {
+	FitGroupID int
+	UserID     int
+	Name       string
+	Args       []string
+	Text       string // 입력한 원문
+}
+
+// Response 는 명령어 처리 결과입니다.
+type Response struct {
+	Visibility Visibility
+	Text       string
+}
+
+// HandlerFunc 는 명령어를 처리합니다. 잘못된 사용법이면 ErrUsage 를 감싼 에러를 반환하면 사용법을 안내합니다.
+type HandlerFunc func(req Request) (Response, error)
+
+// Command 는 등록할 명령어입니다.
+type Command struct {
+	Name        string // 앞의 "/" 를 제외한 이름, 영문 소문자/숫자/-/_
+	Usage       string // 예: "/progress [all]"
+	Description string
+	Handler     HandlerFunc
+}
+
+var (
+	ErrUnknownCommand = errors.New("unknown command")
+	ErrUsage          = errors.New("invalid command usage")
+)
+
+var namePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,31}$`)
+
+// Router 는 등록된 명령어로 요청을 전달합니다. 동시 사용에 안전합니다.
+type Router struct {
+	mu       sync.RWMutex
+	commands map[string]Command
+}
+
+// NewRouter 는 등록된 명령어 목록을 보여주는 /help 가 포함된 Router 를 반환합니다.
+func NewRouter() *Router {
+	r := &Router{commands: make(map[string]Command)}
+	r.MustRegister(Command{
+		Name:        "help",
+		Usage:       "/help",
+		Description: "사용할 수 있는 명령어 목록을 보여줍니다.",
+		Handler:     r.help,
+	})
+	return r
+}
+
+// Register 는 명령어를 등록합니다. 같은 이름이 이미 있으면 에러를 반환합니다.
+func (r *Router) Register(cmd Command) error {
+	name := strings.ToLower(strings.TrimPrefix(cmd.Name, "/"))
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("invalid command name: %q", cmd.Name)
+	}
+	if cmd.Handler == nil {
+		return fmt.Errorf("command /%s has no handler", name)
+	}
+	if cmd.Usage == "" {
+		cmd.Usage = "/" + name
+	}
+	cmd.Name = name
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.commands[name]; ok {
+		return fmt.Errorf("command /%s is already registered", name)
+	}
+	r.commands[name] = cmd
+	return nil
+}
+
+// MustRegister 는 Register 와 같지만 실패하면 panic 합니다. 서버 시작 시 기본 명령어 등록에 사용합니다.
+func (r *Router) MustRegister(cmds ...Command) {
+	for _, cmd := range cmds {
+		if err := r.Register(cmd); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Commands 는 등록된 명령어를 이름 순으로 반환합니다.
+func (r *Router) Commands() []Command {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	cmds := make([]Command, 0, len(r.commands))
+	for _, cmd := range r.commands {
+		cmds = append(cmds, cmd)
+	}
+	sort.Slice(cmds, func(i, j int) bool { return cmds[i].Name < cmds[j].Name })
+	return cmds
+}
+
+// Dispatch 는 요청을 명령어 핸들러로 전달합니다. 등록되지 않은 명령어면 ErrUnknownCommand 를 반환합니다.
+func (r *Router) Dispatch(req Request) (Response, error) {
+	r.mu.RLock()
+	cmd, ok := r.commands[req.Name]
+	r.mu.RUnlock()
+	if !ok {
+		return Response{}, fmt.Errorf("%w: /%s", ErrUnknownCommand, req.Name)
+	}
+	resp, err := cmd.Handler(req)
+	if errors.Is(err, ErrUsage) {
+		return Response{Visibility: Private, Text: "사용법: " + cmd.Usage}, nil
+	}
+	return resp, err
+}
+
+// Parse 는 "/" 로 시작하는 메시지를 명령어 이름과 인자로 나눕니다. 명령어가 아니면 ok 가 false 입니다.
+// "/" 다음이 영문자가 아니면(예: "/ㅋㅋ", "//") 일반 메시지로 봅니다.
+func Parse(text string) (name string, args []string, ok bool) {
+	text = strings.TrimSpace(text)
+	if len(text) < 2 || text[0] != '/' {
+		return "", nil, false
+	}
+	if c := text[1]; !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z') {
+		return "", nil, false
+	}
+	fields := strings.Fields(text[1:])
+	return strings.ToLower(fields[0]), fields[1:], true
+}
+
+func (r *Router) help(req Request) (Response, error) {
+	var b strings.Builder
+	b.WriteString("사용할 수 있는 명령어")
+	for _, cmd := range r.Commands() {
+		fmt.Fprintf(&b, "\n%s - %s", cmd.Usage, cmd.Description)
+	}
+	return Response{Visibility: Private, Text: b.String()}, nil
+}
@@ -0,0 +1,140 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		wantName string
+		wantArgs []string
+		wantOK   bool
+	}{
+		{name: "인자 없음", text: "/members", wantName: "members", wantArgs: []string{}, wantOK: true},
+		{name: "인자", text: "/progress all", wantName: "progress", wantArgs: []string{"all"}, wantOK: true},
+		{name: "앞뒤 공백과 연속 공백", text: "  /pin   abc  def ", wantName: "pin", wantArgs: []string{"abc", "def"}, wantOK: true},
+		{name: "이름은 소문자로", text: "/Progress ALL", wantName: "progress", wantArgs: []string{"ALL"}, wantOK: true},
+		{name: "일반 메시지", text: "오늘 운동 완료", wantOK: false},
+		{name: "/ 만 입력", text: "/", wantOK: false},
+		{name: "/ 다음 공백", text: "/ help", wantOK: false},
+		{name: "/ 다음 한글", text: "/ㅋㅋ", wantOK: false},
+		{name: "/ 다음 숫자", text: "/1등", wantOK: false},
+		{name: "// 로 시작", text: "//help", wantOK: false},
+		{name: "중간의 /", text: "a/help", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, args, ok := Parse(tt.text)
+			if ok != tt.wantOK {
+				t.Fatalf("Parse(%q) ok = %v, want %v", tt.text, ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if name != tt.wantName || !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Fatalf("Parse(%q) = %q %q, want %q %q", tt.text, name, args, tt.wantName, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func reply(text string) HandlerFunc {
+	return func(req Request) (Response, error) {
+		return Response{Visibility: Public, Text: text}, nil
+	}
+}
+
+func TestRouterRegister(t *testing.T) {
+	tests := []struct {
+		name    string
+		cmd     Command
+		wantErr string
+	}{
+		{name: "앞의 / 와 대문자는 정리", cmd: Command{Name: "/Echo", Handler: reply("")}},
+		{name: "help 중복", cmd: Command{Name: "help", Handler: reply("")}, wantErr: "already registered"},
+		{name: "핸들러 없음", cmd: Command{Name: "echo"}, wantErr: "has no handler"},
+		{name: "공백이 있는 이름", cmd: Command{Name: "my cmd", Handler: reply("")}, wantErr: "invalid command name"},
+		{name: "한글 이름", cmd: Command{Name: "인증", Handler: reply("")}, wantErr: "invalid command name"},
+		{name: "빈 이름", cmd: Command{Name: "/", Handler: reply("")}, wantErr: "invalid command name"},
+		{name: "너무 긴 이름", cmd: Command{Name: strings.Repeat("a", 33), Handler: reply("")}, wantErr: "invalid command name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewRouter().Register(tt.cmd)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Register: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Register err = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRouterDispatch(t *testing.T) {
+	r := NewRouter()
+	var got Request
+	r.MustRegister(
+		Command{Name: "echo", Description: "따라 말하기", Handler: func(req Request) (Response, error) {
+			got = req
+			return Response{Visibility: Public, Text: strings.Join(req.Args, " ")}, nil
+		}},
+		Command{Name: "strict", Usage: "/strict <n>", Description: "인자 필요", Handler: func(req Request) (Response, error) {
+			return Response{}, fmt.Errorf("need one argument: %w", ErrUsage)
+		}},
+		Command{Name: "broken", Description: "실패", Handler: func(req Request) (Response, error) {
+			return Response{}, errors.New("db down")
+		}},
+	)
+
+	tests := []struct {
+		name     string
+		req      Request
+		want     Response
+		wantErr  error
+		checkErr string
+	}{
+		{name: "핸들러 응답", req: Request{Name: "echo", Args: []string{"a", "b"}}, want: Response{Visibility: Public, Text: "a b"}},
+		{name: "사용법 오류는 비공개 안내", req: Request{Name: "strict"}, want: Response{Visibility: Private, Text: "사용법: /strict <n>"}},
+		{name: "모르는 명령어", req: Request{Name: "nope"}, wantErr: ErrUnknownCommand, checkErr: "/nope"},
+		{name: "핸들러 오류는 그대로", req: Request{Name: "broken"}, checkErr: "db down"},
+		{
+			name: "help 는 이름 순 목록을 비공개로",
+			req:  Request{Name: "help"},
+			want: Response{Visibility: Private, Text: "사용할 수 있는 명령어\n/broken - 실패\n/echo - 따라 말하기\n/help - 사용할 수 있는 명령어 목록을 보여줍니다.\n/strict <n> - 인자 필요"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := r.Dispatch(tt.req)
+			if tt.checkErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.checkErr) || (tt.wantErr != nil && !errors.Is(err, tt.wantErr)) {
+					t.Fatalf("Dispatch err = %v, want %v containing %q", err, tt.wantErr, tt.checkErr)
+				}
+				return
+			}
+			if err != nil || resp != tt.want {
+				t.Fatalf("Dispatch = %+v, %v, want %+v", resp, err, tt.want)
+			}
+		})
+	}
+
+	if _, err := r.Dispatch(Request{FitGroupID: 1, UserID: 2, Name: "echo", Args: []string{"x"}, Text: "/echo x"}); err != nil {
+		t.Fatalf("Dispatch: %v", err)
+	}
+	if want := (Request{FitGroupID: 1, UserID: 2, Name: "echo", Args: []string{"x"}, Text: "/echo x"}); !reflect.DeepEqual(got, want) {
+		t.Fatalf("handler got %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,604 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+/*
+Config 는 서비스 설정입니다. 다음 순서로 덮어씁니다.
+1. Default() 의 기본값 (docker-compose 배포 환경 기준)
+2. CHATTING_CONFIG_FILE 로 지정한 YAML 파일 (선택, 예시는 config.example.yaml)
+3. 환경 변수 (CHATTING_ 접두사, envBindings 참고)
+비밀번호 등 Secret 타입 값은 로그나 fmt 출력에 마스킹됩니다.
+*/
+type Config struct {
+	HTTP           HTTPConfig           `yaml:"http"`
+	Database       DatabaseConfig       `yaml:"database"`
+	Kafka          KafkaConfig          `yaml:"kafka"`
+	Services       ServiceURLs          `yaml:"services"`
+	Clients        ClientConfig         `yaml:"clients"`
+	Reconciliation ReconciliationConfig `yaml:"reconciliation"`
+	Retention      RetentionConfig      `yaml:"retention"`
+	Moderation     ModerationConfig     `yaml:"moderation"`
+	RateLimit      RateLimitConfig      `yaml:"rateLimit"`
+	Jobs           JobsConfig           `yaml:"jobs"`
+}
+
+type HTTPConfig struct {
+	Addr            string        `yaml:"addr"`
+	ShutdownTimeout time.Duration `yaml:"shutdownTimeout"` // 종료 신호 후 연결 정리부터 DB 종료까지 최대 시간
+}
+
+// 저장소 종류 (database.driver)
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite" // 파일 하나에 저장, 소규모 자체 호스팅 및 오프라인 데모용
+	DriverMemory   = "memory" // DB 없이 메모리에 저장, 로컬 개발 및 테스트용
+)
+
+type DatabaseConfig struct {
+	Driver       string `yaml:"driver"`
+	SeedFile     string `yaml:"seedFile"` // memory 저장소 초기 데이터 JSON 파일 (선택)
+	Path         string `yaml:"path"`     // sqlite 데이터베이스 파일 경로
+	Host         string `yaml:"host"`
+	Port         int    `yaml:"port"`
+	User         string `yaml:"user"`
+	Password     Secret `yaml:"password"`
+	Name         string `yaml:"name"`
+	SSLMode      string `yaml:"sslMode"`
+	MaxOpenConns int    `yaml:"maxOpenConns"`
+	MaxIdleConns int    `yaml:"maxIdleConns"`
+	AutoMigrate  bool   `yaml:"autoMigrate"` // 시작 시 마이그레이션 적용. 끄면 migrate 서브커맨드로만 적용
+}
+
+// DSN 은 lib/pq 연결 문자열입니다. 비밀번호가 포함되므로 로그에 남기지 않습니다.
+func (c DatabaseConfig) DSN() string {
+	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		c.Host, c.Port, c.User, quoteDSN(c.Password.Value()), c.Name, c.SSLMode)
+}
+
+type KafkaConfig struct {
+	Enabled         bool                   `yaml:"enabled"` // 끄면 Kafka 이벤트를 컨슘하지 않음 (로컬 개발용)
+	Brokers         []string               `yaml:"brokers"`
+	GroupID         string                 `yaml:"groupId"`
+	Topics          KafkaTopics            `yaml:"topics"`
+	RetryTopic      string                 `yaml:"retryTopic"`      // 지연 재시도할 이벤트를 보내고 다시 컨슘하는 토픽
+	DeadLetterTopic string                 `yaml:"deadLetterTopic"` // 재시도를 모두 실패한 이벤트를 오류 정보와 함께 보내는 토픽
+	Retry           RetryPolicy            `yaml:"retry"`           // 기본 재시도 정책
+	TopicRetry      map[string]RetryPolicy `yaml:"topicRetry"`      // 토픽 이름별 재시도 정책. 지정한 토픽은 기본 정책 대신 사용
+	CommitInterval  time.Duration          `yaml:"commitInterval"`  // 처리를 마친 이벤트의 오프셋을 모아 커밋하는 주기
+	CommitBatchSize int                    `yaml:"commitBatchSize"` // 처리를 마친 이벤트가 이만큼 쌓이면 주기를 기다리지 않고 커밋
+	// 처리한 이벤트 기록을 보관하는 기간. 이 기간 안에 재전달된 이벤트는 다시 처리하지 않음
+	ProcessedEventRetention time.Duration `yaml:"processedEventRetention"`
+	// fit group 보다 먼저 도착해 보류한 fit mate 이벤트를 보관하는 기간. 이 기간 안에 fit group 이 생성되지 않으면 버림
+	PendingEventTTL time.Duration `yaml:"pendingEventTTL"`
+	// 보류한 fit mate 이벤트의 fit group 이 생성됐는지 확인해 다시 처리하는 주기
+	PendingEventReplayInterval time.Duration `yaml:"pendingEventReplayInterval"`
+	// processedEventRetention 이 지난 처리 기록을 정리하는 주기
+	ProcessedEventPruneInterval time.Duration `yaml:"processedEventPruneInterval"`
+}
+
+// ConsumedTopics 는 컨슘할 토픽 목록입니다. 이벤트 토픽과 재시도 토픽을 포함합니다.
+func (c KafkaConfig) ConsumedTopics() []string {
+	return append(c.Topics.All(), c.RetryTopic)
+}
+
+// RetryPolicyFor 는 topic 이벤트 처리에 사용할 재시도 정책입니다.
+func (c KafkaConfig) RetryPolicyFor(topic string) RetryPolicy {
+	if policy, ok := c.TopicRetry[topic]; ok {
+		return policy
+	}
+	return c.Retry
+}
+
+/*
+RetryPolicy 는 이벤트 처리가 실패했을 때의 재시도 정책입니다.
+1. 프로세스 안에서 Backoff 간격(시도마다 두 배, 최대 MaxBackoff)으로 최대 MaxAttempts 번 처리
+2. 모두 실패하면 재시도 토픽으로 보내 RetryDelay(재시도마다 두 배) 뒤에 1 부터 다시 처리. 최대 DelayedRetries 번
+3. 그래도 실패하면 dead-letter 토픽으로 보냄
+이벤트 형식이 잘못된 경우처럼 다시 처리해도 실패하는 오류는 재시도하지 않고 바로 dead-letter 토픽으로 보냅니다.
+*/
+type RetryPolicy struct {
+	MaxAttempts    int           `yaml:"maxAttempts"`
+	Backoff        time.Duration `yaml:"backoff"`
+	MaxBackoff     time.Duration `yaml:"maxBackoff"`
+	DelayedRetries int           `yaml:"delayedRetries"` // 0 이면 재시도 토픽을 거치지 않음
+	RetryDelay     time.Duration `yaml:"retryDelay"`
+}
+
+// KafkaTopics 는 컨슘하는 토픽 이름입니다.
+type KafkaTopics struct {
+	FitMate    string `yaml:"fitMate"`
+	FitGroup   string `yaml:"fitGroup"`
+	UserCreate string `yaml:"userCreate"`
+	UserInfo   string `yaml:"userInfo"`
+}
+
+// All 은 컨슘할 토픽 목록입니다.
+func (t KafkaTopics) All() []string {
+	return []string{t.FitMate, t.FitGroup, t.UserCreate, t.UserInfo}
+}
+
+// ServiceURLs 는 호출하는 다른 서비스의 기본 URL 입니다. 끝에 "/" 를 붙이지 않습니다.
+type ServiceURLs struct {
+	FitGroup string `yaml:"fitGroup"`
+	Auth     string `yaml:"auth"`
+	Alarm    string `yaml:"alarm"`
+}
+
+// ClientConfig 는 services 의 fit-group, auth, alarm 서비스를 호출하는 HTTP 클라이언트 설정입니다.
+type ClientConfig struct {
+	Timeout          time.Duration `yaml:"timeout"`          // 요청 한 번의 제한 시간
+	MaxAttempts      int           `yaml:"maxAttempts"`      // 연결 실패, 429, 5xx 응답 시 재시도를 포함한 최대 시도 횟수
+	Backoff          time.Duration `yaml:"backoff"`          // 재시도 간격. 시도마다 두 배로 늘리고 jitter 를 적용
+	MaxBackoff       time.Duration `yaml:"maxBackoff"`       // 재시도 간격 최대값
+	BreakerThreshold int           `yaml:"breakerThreshold"` // 서비스별로 연속 이만큼 실패하면 회로를 열어 요청을 바로 실패시킴
+	BreakerCooldown  time.Duration `yaml:"breakerCooldown"`  // 회로를 연 뒤 시험 요청을 보내기까지 기다리는 시간
+}
+
+// ReconciliationConfig 는 fit-group, auth 서비스와 DB 의 정합성을 주기적으로 맞추는 작업 설정입니다.
+type ReconciliationConfig struct {
+	Enabled  bool          `yaml:"enabled"`
+	Interval time.Duration `yaml:"interval"` // 실행 간격. 첫 실행도 시작 후 interval 뒤
+}
+
+/*
+RetentionConfig 는 보관 기간이 지난 채팅 메시지를 아카이브 파일로 옮기고 DB 에서 삭제하는 작업 설정입니다.
+ArchiveDir 가 아카이브의 유일한 사본이므로 재시작해도 남는 볼륨이어야 합니다. 비어 있으면 아카이브 작업과 복원을 하지 않습니다.
+*/
+type RetentionConfig struct {
+	ArchiveDir  string        `yaml:"archiveDir"`  // 아카이브 파일 디렉토리 (절대 경로)
+	DefaultDays int           `yaml:"defaultDays"` // 전역 보관 기간(일). 0 이면 fit group 별 정책이 있는 fit group 만 아카이브
+	Interval    time.Duration `yaml:"interval"`    // 실행 간격. 첫 실행도 시작 후 interval 뒤
+}
+
+/*
+ModerationConfig 는 채팅 메시지 moderation 필터(스팸 -> 금칙어 -> 링크) 설정입니다.
+조치(action)는 allow, mask, flag, reject 중 하나입니다. 스팸 필터는 가릴 부분이 없으므로 mask 를 쓸 수 없습니다.
+*/
+type ModerationConfig struct {
+	BannedWordsFile    string               `yaml:"bannedWordsFile"`    // 한 줄에 하나씩 금칙어가 적힌 파일. 비어 있으면 내장 목록 사용
+	BannedWordAction   string               `yaml:"bannedWordAction"`   // 금칙어가 포함된 메시지 조치
+	AllowedLinkDomains []string             `yaml:"allowedLinkDomains"` // 허용 링크 도메인 (하위 도메인 포함). 비어 있으면 모든 링크에 linkAction 적용
+	LinkAction         string               `yaml:"linkAction"`         // 허용되지 않은 링크가 포함된 메시지 조치
+	Spam               ModerationSpamConfig `yaml:"spam"`
+}
+
+// ModerationSpamConfig 는 스팸 휴리스틱 기준입니다. 0 이면 해당 검사를 하지 않습니다.
+type ModerationSpamConfig struct {
+	MaxLength        int    `yaml:"maxLength"`        // 최대 글자 수. 초과 시 항상 reject
+	MaxRepeatedRunes int    `yaml:"maxRepeatedRunes"` // 같은 글자 연속 반복 허용 횟수
+	MaxLinks         int    `yaml:"maxLinks"`         // 메시지 하나에 포함 가능한 링크 수
+	Action           string `yaml:"action"`           // 반복/링크 기준 초과 시 조치
+}
+
+// RateLimitConfig 는 채팅 메시지 전송 제한 설정입니다.
+type RateLimitConfig struct {
+	UserRoom        RateConfig    `yaml:"userRoom"`        // 사용자 + 채팅방 단위 제한
+	Connection      RateConfig    `yaml:"connection"`      // 웹소켓 연결 단위 제한
+	DuplicateWindow time.Duration `yaml:"duplicateWindow"` // 같은 내용의 메시지를 중복으로 간주하는 시간
+}
+
+// RateConfig 는 토큰 버킷 설정입니다. 초당 perSecond 개씩 채우고 최대 burst 개까지 쌓입니다.
+type RateConfig struct {
+	PerSecond float64 `yaml:"perSecond"`
+	Burst     int     `yaml:"burst"`
+}
+
+// JobsConfig 는 주기적으로 실행하는 백그라운드 작업의 실행 간격입니다.
+type JobsConfig struct {
+	PollCloserInterval     time.Duration `yaml:"pollCloserInterval"`     // 마감 시간이 지난 투표를 종료하는 주기
+	ReminderInterval       time.Duration `yaml:"reminderInterval"`       // 실행 시간이 된 리마인더를 보내는 주기
+	LongPollReaperInterval time.Duration `yaml:"longPollReaperInterval"` // 오래 조회하지 않은 long-polling 세션을 정리하는 주기
+}
+
+// moderation 조치 이름 (moderation.ParseAction)
+var (
+	moderationActions     = []string{"allow", "mask", "flag", "reject"}
+	moderationSpamActions = []string{"allow", "flag", "reject"}
+)
+
+// Secret 은 로그, fmt, JSON/YAML 출력에서 마스킹되는 문자열입니다. 실제 값은 Value 로만 꺼냅니다.
+type Secret string
+
+const redacted = "[REDACTED]"
+
+func (s Secret) Value() string { return string(s) }
+
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+	return redacted
+}
+
+func (s Secret) GoString() string { return s.String() }
+
+func (s Secret) MarshalJSON() ([]byte, error) { return []byte(strconv.Quote(s.String())), nil }
+
+func (s Secret) MarshalYAML() (interface{}, error) { return s.String(), nil }
+
+// Default 는 기존 docker-compose 배포 환경의 설정입니다. DB 비밀번호는 기본값이 없습니다.
+func Default() Config {
+	return Config{
+		HTTP: HTTPConfig{
+			Addr:            ":8888",
+			ShutdownTimeout: 30 * time.Second,
+		},
+		Database: DatabaseConfig{
+			Driver:       DriverPostgres,
+			Path:         "chatting.db",
+			Host:         "postgresql-chatting",
+			Port:         5432,
+			User:         "chatting",
+			Name:         "chatting-db",
+			SSLMode:      "disable",
+			MaxOpenConns: 25,
+			MaxIdleConns: 10,
+			AutoMigrate:  true,
+		},
+		Kafka: KafkaConfig{
+			Enabled: true,
+			Brokers: []string{"kafka-1:9092"},
+			GroupID: "chatting-service",
+			Topics: KafkaTopics{
+				FitMate:    "fit-mate",
+				FitGroup:   "fit-group",
+				UserCreate: "user-create-event",
+				UserInfo:   "user-info-event",
+			},
+			RetryTopic:      "chatting-service-retry",
+			DeadLetterTopic: "chatting-service-dlq",
+			Retry: RetryPolicy{
+				MaxAttempts:    3,
+				Backoff:        200 * time.Millisecond,
+				MaxBackoff:     5 * time.Second,
+				DelayedRetries: 3,
+				RetryDelay:     30 * time.Second,
+			},
+			CommitInterval:              time.Second,
+			CommitBatchSize:             100,
+			ProcessedEventRetention:     7 * 24 * time.Hour,
+			PendingEventTTL:             24 * time.Hour,
+			PendingEventReplayInterval:  30 * time.Second,
+			ProcessedEventPruneInterval: time.Hour,
+		},
+		Services: ServiceURLs{
+			FitGroup: "http://fit-group:8080",
+			Auth:     "http://auth-service:8080",
+			Alarm:    "http://alarm-service:8080",
+		},
+		Clients: ClientConfig{
+			Timeout:          5 * time.Second,
+			MaxAttempts:      3,
+			Backoff:          100 * time.Millisecond,
+			MaxBackoff:       2 * time.Second,
+			BreakerThreshold: 5,
+			BreakerCooldown:  30 * time.Second,
+		},
+		Reconciliation: ReconciliationConfig{
+			Enabled:  true,
+			Interval: 6 * time.Hour,
+		},
+		Retention: RetentionConfig{
+			Interval: 24 * time.Hour,
+		},
+		Moderation: ModerationConfig{
+			BannedWordAction:   "mask",
+			AllowedLinkDomains: []string{"youtube.com", "youtu.be", "instagram.com", "naver.me", "naver.com"},
+			LinkAction:         "flag",
+			Spam: ModerationSpamConfig{
+				MaxLength:        2000,
+				MaxRepeatedRunes: 30,
+				MaxLinks:         3,
+				Action:           "flag",
+			},
+		},
+		RateLimit: RateLimitConfig{
+			UserRoom:        RateConfig{PerSecond: 1, Burst: 5},
+			Connection:      RateConfig{PerSecond: 3, Burst: 10},
+			DuplicateWindow: 10 * time.Second,
+		},
+		Jobs: JobsConfig{
+			PollCloserInterval:     time.Minute,
+			ReminderInterval:       time.Minute,
+			LongPollReaperInterval: 10 * time.Second,
+		},
+	}
+}
+
+// Load 는 기본값, YAML 파일, 환경 변수 순서로 설정을 읽고 검증합니다.
+func Load() (Config, error) {
+	return load(os.Getenv("CHATTING_CONFIG_FILE"), os.LookupEnv)
+}
+
+func load(path string, lookup func(string) (string, bool)) (Config, error) {
+	cfg := Default()
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return Config{}, fmt.Errorf("read config file: %w", err)
+		}
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true) // 오타 난 키를 조용히 무시하지 않음
+		if err := decoder.Decode(&cfg); err != nil {
+			return Config{}, fmt.Errorf("parse config file %s: %w", path, err)
+		}
+	}
+	if err := applyEnv(&cfg, lookup); err != nil {
+		return Config{}, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// envBinding 은 환경 변수와 설정 필드의 연결입니다.
+type envBinding struct {
+	name string
+	set  func(cfg *Config, value string) error
+}
+
+var envBindings = []envBinding{
+	{"CHATTING_HTTP_ADDR", func(c *Config, v string) error { c.HTTP.Addr = v; return nil }},
+	{"CHATTING_HTTP_SHUTDOWN_TIMEOUT", func(c *Config, v string) error { return parseDuration(v, &c.HTTP.ShutdownTimeout) }},
+	{"CHATTING_DB_DRIVER", func(c *Config, v string) error { c.Database.Driver = v; return nil }},
+	{"CHATTING_DB_SEED_FILE", func(c *Config, v string) error { c.Database.SeedFile = v; return nil }},
+	{"CHATTING_DB_PATH", func(c *Config, v string) error { c.Database.Path = v; return nil }},
+	{"CHATTING_DB_HOST", func(c *Config, v string) error { c.Database.Host = v; return nil }},
+	{"CHATTING_DB_PORT", func(c *Config, v string) error { return parseInt(v, &c.Database.Port) }},
+	{"CHATTING_DB_USER", func(c *Config, v string) error { c.Database.User = v; return nil }},
+	{"CHATTING_DB_PASSWORD", func(c *Config, v string) error { c.Database.Password = Secret(v); return nil }},
+	{"CHATTING_DB_NAME", func(c *Config, v string) error { c.Database.Name = v; return nil }},
+	{"CHATTING_DB_SSLMODE", func(c *Config, v string) error { c.Database.SSLMode = v; return nil }},
+	{"CHATTING_DB_MAX_OPEN_CONNS", func(c *Config, v string) error { return parseInt(v, &c.Database.MaxOpenConns) }},
+	{"CHATTING_DB_MAX_IDLE_CONNS", func(c *Config, v string) error { return parseInt(v, &c.Database.MaxIdleConns) }},
+	{"CHATTING_DB_AUTO_MIGRATE", func(c *Config, v string) error { return parseBool(v, &c.Database.AutoMigrate) }},
+	{"CHATTING_KAFKA_ENABLED", func(c *Config, v string) error { return parseBool(v, &c.Kafka.Enabled) }},
+	{"CHATTING_KAFKA_BROKERS", func(c *Config, v string) error { c.Kafka.Brokers = splitList(v); return nil }},
+	{"CHATTING_KAFKA_GROUP_ID", func(c *Config, v string) error { c.Kafka.GroupID = v; return nil }},
+	{"CHATTING_KAFKA_TOPIC_FIT_MATE", func(c *Config, v string) error { c.Kafka.Topics.FitMate = v; return nil }},
+	{"CHATTING_KAFKA_TOPIC_FIT_GROUP", func(c *Config, v string) error { c.Kafka.Topics.FitGroup = v; return nil }},
+	{"CHATTING_KAFKA_TOPIC_USER_CREATE", func(c *Config, v string) error { c.Kafka.Topics.UserCreate = v; return nil }},
+	{"CHATTING_KAFKA_TOPIC_USER_INFO", func(c *Config, v string) error { c.Kafka.Topics.UserInfo = v; return nil }},
+	{"CHATTING_KAFKA_RETRY_TOPIC", func(c *Config, v string) error { c.Kafka.RetryTopic = v; return nil }},
+	{"CHATTING_KAFKA_DEAD_LETTER_TOPIC", func(c *Config, v string) error { c.Kafka.DeadLetterTopic = v; return nil }},
+	{"CHATTING_KAFKA_RETRY_MAX_ATTEMPTS", func(c *Config, v string) error { return parseInt(v, &c.Kafka.Retry.MaxAttempts) }},
+	{"CHATTING_KAFKA_RETRY_BACKOFF", func(c *Config, v string) error { return parseDuration(v, &c.Kafka.Retry.Backoff) }},
+	{"CHATTING_KAFKA_RETRY_MAX_BACKOFF", func(c *Config, v string) error { return parseDuration(v, &c.Kafka.Retry.MaxBackoff) }},
+	{"CHATTING_KAFKA_RETRY_DELAYED_RETRIES", func(c *Config, v string) error { return parseInt(v, &c.Kafka.Retry.DelayedRetries) }},
+	{"CHATTING_KAFKA_RETRY_DELAY", func(c *Config, v string) error { return parseDuration(v, &c.Kafka.Retry.RetryDelay) }},
+	{"CHATTING_KAFKA_COMMIT_INTERVAL", func(c *Config, v string) error { return parseDuration(v, &c.Kafka.CommitInterval) }},
+	{"CHATTING_KAFKA_COMMIT_BATCH_SIZE", func(c *Config, v string) error { return parseInt(v, &c.Kafka.CommitBatchSize) }},
+	{"CHATTING_KAFKA_PROCESSED_EVENT_RETENTION", func(c *Config, v string) error { return parseDuration(v, &c.Kafka.ProcessedEventRetention) }},
+	{"CHATTING_KAFKA_PENDING_EVENT_TTL", func(c *Config, v string) error { return parseDuration(v, &c.Kafka.PendingEventTTL) }},
+	{"CHATTING_KAFKA_PENDING_EVENT_REPLAY_INTERVAL", func(c *Config, v string) error { return parseDuration(v, &c.Kafka.PendingEventReplayInterval) }},
+	{"CHATTING_KAFKA_PROCESSED_EVENT_PRUNE_INTERVAL", func(c *Config, v string) error { return parseDuration(v, &c.Kafka.ProcessedEventPruneInterval) }},
+	{"CHATTING_FIT_GROUP_SERVICE_URL", func(c *Config, v string) error { c.Services.FitGroup = v; return nil }},
+	{"CHATTING_AUTH_SERVICE_URL", func(c *Config, v string) error { c.Services.Auth = v; return nil }},
+	{"CHATTING_ALARM_SERVICE_URL", func(c *Config, v string) error { c.Services.Alarm = v; return nil }},
+	{"CHATTING_CLIENT_TIMEOUT", func(c *Config, v string) error { return parseDuration(v, &c.Clients.Timeout) }},
+	{"CHATTING_CLIENT_MAX_ATTEMPTS", func(c *Config, v string) error { return parseInt(v, &c.Clients.MaxAttempts) }},
+	{"CHATTING_CLIENT_BACKOFF", func(c *Config, v string) error { return parseDuration(v, &c.Clients.Backoff) }},
+	{"CHATTING_CLIENT_MAX_BACKOFF", func(c *Config, v string) error { return parseDuration(v, &c.Clients.MaxBackoff) }},
+	{"CHATTING_CLIENT_BREAKER_THRESHOLD", func(c *Config, v string) error { return parseInt(v, &c.Clients.BreakerThreshold) }},
+	{"CHATTING_CLIENT_BREAKER_COOLDOWN", func(c *Config, v string) error { return parseDuration(v, &c.Clients.BreakerCooldown) }},
+	{"CHATTING_RECONCILIATION_ENABLED", func(c *Config, v string) error { return parseBool(v, &c.Reconciliation.Enabled) }},
+	{"CHATTING_RECONCILIATION_INTERVAL", func(c *Config, v string) error { return parseDuration(v, &c.Reconciliation.Interval) }},
+	{"CHATTING_RETENTION_ARCHIVE_DIR", func(c *Config, v string) error { c.Retention.ArchiveDir = v; return nil }},
+	{"CHATTING_RETENTION_DEFAULT_DAYS", func(c *Config, v string) error { return parseInt(v, &c.Retention.DefaultDays) }},
+	{"CHATTING_RETENTION_INTERVAL", func(c *Config, v string) error { return parseDuration(v, &c.Retention.Interval) }},
+	{"CHATTING_MODERATION_BANNED_WORDS_FILE", func(c *Config, v string) error { c.Moderation.BannedWordsFile = v; return nil }},
+	{"CHATTING_MODERATION_BANNED_WORD_ACTION", func(c *Config, v string) error { c.Moderation.BannedWordAction = v; return nil }},
+	{"CHATTING_MODERATION_ALLOWED_LINK_DOMAINS", func(c *Config, v string) error { c.Moderation.AllowedLinkDomains = splitList(v); return nil }},
+	{"CHATTING_MODERATION_LINK_ACTION", func(c *Config, v string) error { c.Moderation.LinkAction = v; return nil }},
+	{"CHATTING_MODERATION_SPAM_MAX_LENGTH", func(c *Config, v string) error { return parseInt(v, &c.Moderation.Spam.MaxLength) }},
+	{"CHATTING_MODERATION_SPAM_MAX_REPEATED_RUNES", func(c *Config, v string) error { return parseInt(v, &c.Moderation.Spam.MaxRepeatedRunes) }},
+	{"CHATTING_MODERATION_SPAM_MAX_LINKS", func(c *Config, v string) error { return parseInt(v, &c.Moderation.Spam.MaxLinks) }},
+	{"CHATTING_MODERATION_SPAM_ACTION", func(c *Config, v string) error { c.Moderation.Spam.Action = v; return nil }},
+	{"CHATTING_RATE_LIMIT_USER_ROOM_PER_SECOND", func(c *Config, v string) error { return parseFloat(v, &c.RateLimit.UserRoom.PerSecond) }},
+	{"CHATTING_RATE_LIMIT_USER_ROOM_BURST", func(c *Config, v string) error { return parseInt(v, &c.RateLimit.UserRoom.Burst) }},
+	{"CHATTING_RATE_LIMIT_CONNECTION_PER_SECOND", func(c *Config, v string) error { return parseFloat(v, &c.RateLimit.Connection.PerSecond) }},
+	{"CHATTING_RATE_LIMIT_CONNECTION_BURST", func(c *Config, v string) error { return parseInt(v, &c.RateLimit.Connection.Burst) }},
+	{"CHATTING_RATE_LIMIT_DUPLICATE_WINDOW", func(c *Config, v string) error { return parseDuration(v, &c.RateLimit.DuplicateWindow) }},
+	{"CHATTING_JOBS_POLL_CLOSER_INTERVAL", func(c *Config, v string) error { return parseDuration(v, &c.Jobs.PollCloserInterval) }},
+	{"CHATTING_JOBS_REMINDER_INTERVAL", func(c *Config, v string) error { return parseDuration(v, &c.Jobs.ReminderInterval) }},
+	{"CHATTING_JOBS_LONG_POLL_REAPER_INTERVAL", func(c *Config, v string) error { return parseDuration(v, &c.Jobs.LongPollReaperInterval) }},
+}
+
+func applyEnv(cfg *Config, lookup func(string) (string, bool)) error {
+	for _, b := range envBindings {
+		value, ok := lookup(b.name)
+		if !ok {
+			continue
+		}
+		// 값은 비밀번호일 수 있으므로 에러에 포함하지 않음
+		if err := b.set(cfg, strings.TrimSpace(value)); err != nil {
+			return fmt.Errorf("invalid %s: %w", b.name, err)
+		}
+	}
+	return nil
+}
+
+// Validate 는 모든 설정 오류를 한 번에 보고합니다.
+func (c Config) Validate() error {
+	var problems []string
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			problems = append(problems, fmt.Sprintf(format, args...))
+		}
+	}
+
+	check(c.HTTP.Addr != "", "http.addr is required")
+	check(c.HTTP.ShutdownTimeout > 0, "http.shutdownTimeout must be positive")
+
+	switch c.Database.Driver {
+	case DriverPostgres:
+		check(c.Database.Host != "", "database.host is required")
+		check(c.Database.Port > 0 && c.Database.Port <= 65535, "database.port must be between 1 and 65535")
+		check(c.Database.User != "", "database.user is required")
+		check(c.Database.Password != "", "database.password is required (CHATTING_DB_PASSWORD)")
+		check(c.Database.Name != "", "database.name is required")
+		check(c.Database.SSLMode != "", "database.sslMode is required")
+		check(c.Database.MaxOpenConns > 0, "database.maxOpenConns must be positive")
+		check(c.Database.MaxIdleConns >= 0 && c.Database.MaxIdleConns <= c.Database.MaxOpenConns, "database.maxIdleConns must be between 0 and maxOpenConns")
+	case DriverSQLite:
+		check(c.Database.Path != "", "database.path is required")
+		check(c.Database.MaxOpenConns > 0, "database.maxOpenConns must be positive")
+		check(c.Database.MaxIdleConns >= 0 && c.Database.MaxIdleConns <= c.Database.MaxOpenConns, "database.maxIdleConns must be between 0 and maxOpenConns")
+	case DriverMemory:
+		// 연결 설정을 사용하지 않음
+	default:
+		check(false, "database.driver must be %q, %q or %q", DriverPostgres, DriverSQLite, DriverMemory)
+	}
+	check(c.Database.SeedFile == "" || c.Database.Driver == DriverMemory, "database.seedFile is only supported by the memory driver")
+
+	if c.Kafka.Enabled {
+		check(len(c.Kafka.Brokers) > 0, "kafka.brokers is required")
+		for _, broker := range c.Kafka.Brokers {
+			check(strings.Contains(broker, ":"), "kafka.brokers entry %q must be host:port", broker)
+		}
+		check(c.Kafka.GroupID != "", "kafka.groupId is required")
+		check(c.Kafka.RetryTopic != "", "kafka.retryTopic is required")
+		check(c.Kafka.DeadLetterTopic != "", "kafka.deadLetterTopic is required")
+		seen := make(map[string]bool)
+		for _, topic := range append(c.Kafka.ConsumedTopics(), c.Kafka.DeadLetterTopic) {
+			check(topic != "", "kafka.topics entries are required")
+			check(topic == "" || !seen[topic], "kafka topic %q is used more than once", topic)
+			seen[topic] = true
+		}
+		check(c.Kafka.CommitInterval > 0, "kafka.commitInterval must be positive")
+		check(c.Kafka.CommitBatchSize > 0, "kafka.commitBatchSize must be positive")
+		check(c.Kafka.ProcessedEventRetention > 0, "kafka.processedEventRetention must be positive")
+		check(c.Kafka.PendingEventTTL > 0, "kafka.pendingEventTTL must be positive")
+		check(c.Kafka.PendingEventReplayInterval > 0, "kafka.pendingEventReplayInterval must be positive")
+		check(c.Kafka.ProcessedEventPruneInterval > 0, "kafka.processedEventPruneInterval must be positive")
+		c.Kafka.Retry.validate("kafka.retry", check)
+		for topic, policy := range c.Kafka.TopicRetry {
+			check(contains(c.Kafka.Topics.All(), topic), "kafka.topicRetry key %q is not a consumed event topic", topic)
+			policy.validate(fmt.Sprintf("kafka.topicRetry.%s", topic), check)
+		}
+	}
+
+	for name, raw := range map[string]string{
+		"services.fitGroup": c.Services.FitGroup,
+		"services.auth":     c.Services.Auth,
+		"services.alarm":    c.Services.Alarm,
+	} {
+		u, err := url.Parse(raw)
+		check(err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "", "%s must be an http(s) URL", name)
+		check(!strings.HasSuffix(raw, "/"), "%s must not end with /", name)
+	}
+	check(c.Clients.Timeout > 0, "clients.timeout must be positive")
+	check(c.Clients.MaxAttempts > 0, "clients.maxAttempts must be positive")
+	check(c.Clients.Backoff >= 0 && c.Clients.MaxBackoff >= c.Clients.Backoff, "clients.backoff must be between 0 and maxBackoff")
+	check(c.Clients.BreakerThreshold > 0, "clients.breakerThreshold must be positive")
+	check(c.Clients.BreakerCooldown > 0, "clients.breakerCooldown must be positive")
+	check(!c.Reconciliation.Enabled || c.Reconciliation.Interval > 0, "reconciliation.interval must be positive")
+	check(c.Retention.ArchiveDir == "" || filepath.IsAbs(c.Retention.ArchiveDir), "retention.archiveDir must be an absolute path on a persistent volume")
+	check(c.Retention.DefaultDays >= 0, "retention.defaultDays must not be negative")
+	check(c.Retention.DefaultDays == 0 || c.Retention.ArchiveDir != "", "retention.archiveDir is required when retention.defaultDays is set")
+	check(c.Retention.Interval > 0, "retention.interval must be positive")
+	check(contains(moderationActions, strings.ToLower(c.Moderation.BannedWordAction)), "moderation.bannedWordAction must be one of %s", strings.Join(moderationActions, ", "))
+	check(contains(moderationActions, strings.ToLower(c.Moderation.LinkAction)), "moderation.linkAction must be one of %s", strings.Join(moderationActions, ", "))
+	check(contains(moderationSpamActions, strings.ToLower(c.Moderation.Spam.Action)), "moderation.spam.action must be one of %s", strings.Join(moderationSpamActions, ", "))
+	check(c.Moderation.Spam.MaxLength >= 0, "moderation.spam.maxLength must not be negative")
+	check(c.Moderation.Spam.MaxRepeatedRunes >= 0, "moderation.spam.maxRepeatedRunes must not be negative")
+	check(c.Moderation.Spam.MaxLinks >= 0, "moderation.spam.maxLinks must not be negative")
+	c.RateLimit.UserRoom.validate("rateLimit.userRoom", check)
+	c.RateLimit.Connection.validate("rateLimit.connection", check)
+	check(c.RateLimit.DuplicateWindow >= 0, "rateLimit.duplicateWindow must not be negative")
+	check(c.Jobs.PollCloserInterval > 0, "jobs.pollCloserInterval must be positive")
+	check(c.Jobs.ReminderInterval > 0, "jobs.reminderInterval must be positive")
+	check(c.Jobs.LongPollReaperInterval > 0, "jobs.longPollReaperInterval must be positive")
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid config: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func (p RetryPolicy) validate(name string, check func(ok bool, format string, args ...interface{})) {
+	check(p.MaxAttempts > 0, "%s.maxAttempts must be positive", name)
+	check(p.Backoff >= 0 && p.MaxBackoff >= p.Backoff, "%s.backoff must be between 0 and maxBackoff", name)
+	check(p.DelayedRetries >= 0, "%s.delayedRetries must not be negative", name)
+	check(p.DelayedRetries == 0 || p.RetryDelay > 0, "%s.retryDelay must be positive when delayedRetries is set", name)
+}
+
+func (r RateConfig) validate(name string, check func(ok bool, format string, args ...interface{})) {
+	check(r.PerSecond > 0, "%s.perSecond must be positive", name)
+	check(r.Burst > 0, "%s.burst must be positive", name)
+}
+
+func contains(items []string, item string) bool {
+	for _, v := range items {
+		if v == item {
+			return true
+		}
+	}
+	return false
+}
+
+func parseInt(v string, dst *int) error {
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return err
+	}
+	*dst = n
+	return nil
+}
+
+func parseFloat(v string, dst *float64) error {
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return err
+	}
+	*dst = f
+	return nil
+}
+
+func parseBool(v string, dst *bool) error {
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return err
+	}
+	*dst = b
+	return nil
+}
+
+func parseDuration(v string, dst *time.Duration) error {
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return err
+	}
+	*dst = d
+	return nil
+}
+
+func splitList(v string) []string {
+	var items []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// quoteDSN 은 공백이나 따옴표가 있는 값을 lib/pq 연결 문자열 형식으로 감쌉니다.
+func quoteDSN(v string) string {
+	if v != "" && !strings.ContainsAny(v, ` '\`) {
+		return v
+	}
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(v) + "'"
+}
@@ -0,0 +1,216 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// env 는 map 을 환경 변수 조회 함수로 바꿉니다.
+func env(vars map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		v, ok := vars[name]
+		return v, ok
+	}
+}
+
+func writeConfigFile(t *testing.T, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatalf("write config file: %v", err)
+	}
+	return path
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	path := writeConfigFile(t, `
+http:
+  addr: ":9000"
+database:
+  port: 6543
+  password: from-yaml
+kafka:
+  groupId: yaml-group
+  topicRetry:
+    fit-mate:
+      maxAttempts: 1
+      backoff: 10ms
+      maxBackoff: 10ms
+clients:
+  timeout: 2s
+`)
+	cfg, err := load(path, env(map[string]string{
+		"CHATTING_HTTP_ADDR":     ":7000",
+		"CHATTING_DB_PASSWORD":   " from-env ",
+		"CHATTING_KAFKA_BROKERS": "a:9092, b:9092,,",
+	}))
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+
+	defaults := Default()
+	tests := []struct {
+		name      string
+		got, want interface{}
+	}{
+		{"환경 변수가 YAML 보다 우선", cfg.HTTP.Addr, ":7000"},
+		{"환경 변수 값은 앞뒤 공백 제거", cfg.Database.Password.Value(), "from-env"},
+		{"목록 환경 변수는 쉼표로 나눔", cfg.Kafka.Brokers, []string{"a:9092", "b:9092"}},
+		{"YAML 이 기본값보다 우선", cfg.Database.Port, 6543},
+		{"YAML 문자열", cfg.Kafka.GroupID, "yaml-group"},
+		{"YAML duration", cfg.Clients.Timeout, 2 * time.Second},
+		{"YAML 토픽별 재시도 정책", cfg.Kafka.RetryPolicyFor("fit-mate").MaxAttempts, 1},
+		{"토픽별 정책이 없으면 기본 정책", cfg.Kafka.RetryPolicyFor("fit-group"), defaults.Kafka.Retry},
+		{"지정하지 않은 구조체 필드는 기본값 유지", cfg.Database.Host, defaults.Database.Host},
+		{"지정하지 않은 섹션은 기본값 유지", cfg.Retention, defaults.Retention},
+	}
+	for _, tt := range tests {
+		if !reflect.DeepEqual(tt.got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string // 비어 있으면 파일 없이 읽음
+		env     map[string]string
+		wantErr string
+	}{
+		{name: "모르는 YAML 키", yaml: "http:\n  adress: \":9000\"\n", env: map[string]string{"CHATTING_DB_PASSWORD": "x"}, wantErr: "field adress not found"},
+		{name: "모르는 YAML 섹션", yaml: "metrics:\n  enabled: true\n", env: map[string]string{"CHATTING_DB_PASSWORD": "x"}, wantErr: "field metrics not found"},
+		{name: "YAML 타입 오류", yaml: "database:\n  port: five\n", env: map[string]string{"CHATTING_DB_PASSWORD": "x"}, wantErr: "parse config file"},
+		{name: "잘못된 환경 변수 값", env: map[string]string{"CHATTING_DB_PASSWORD": "x", "CHATTING_DB_PORT": "five"}, wantErr: "invalid CHATTING_DB_PORT"},
+		{name: "잘못된 duration 환경 변수", env: map[string]string{"CHATTING_DB_PASSWORD": "x", "CHATTING_CLIENT_TIMEOUT": "5"}, wantErr: "invalid CHATTING_CLIENT_TIMEOUT"},
+		{name: "읽은 뒤 검증", env: map[string]string{}, wantErr: "database.password is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := ""
+			if tt.yaml != "" {
+				path = writeConfigFile(t, tt.yaml)
+			}
+			if _, err := load(path, env(tt.env)); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("load err = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := load(filepath.Join(t.TempDir(), "missing.yaml"), env(nil)); err == nil || !strings.Contains(err.Error(), "read config file") {
+		t.Fatalf("load missing file err = %v", err)
+	}
+}
+
+func TestExampleConfigLoads(t *testing.T) {
+	if _, err := load(filepath.Join("..", "config.example.yaml"), env(map[string]string{"CHATTING_DB_PASSWORD": "x"})); err != nil {
+		t.Fatalf("config.example.yaml: %v", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(c *Config)
+		wantErr []string // 모두 한 오류에 보고되어야 함. 비어 있으면 오류 없음
+	}{
+		{name: "기본값 + 비밀번호", modify: func(c *Config) {}},
+		{name: "memory 는 연결 설정 불필요", modify: func(c *Config) { c.Database = DatabaseConfig{Driver: DriverMemory, SeedFile: "seed.json"} }},
+		{name: "Kafka 를 끄면 Kafka 설정 불필요", modify: func(c *Config) { c.Kafka = KafkaConfig{} }},
+		{name: "Postgres 비밀번호 필요", modify: func(c *Config) { c.Database.Password = "" }, wantErr: []string{"database.password is required"}},
+		{name: "모르는 driver", modify: func(c *Config) { c.Database.Driver = "mysql" }, wantErr: []string{"database.driver must be"}},
+		{name: "seedFile 은 memory 전용", modify: func(c *Config) { c.Database.SeedFile = "seed.json" }, wantErr: []string{"database.seedFile is only supported"}},
+		{
+			name:    "여러 오류를 한 번에 보고",
+			modify:  func(c *Config) { c.HTTP.Addr = ""; c.Database.Port = 0; c.Clients.MaxAttempts = 0 },
+			wantErr: []string{"http.addr is required", "database.port must be between", "clients.maxAttempts must be positive"},
+		},
+		{name: "Kafka broker 형식", modify: func(c *Config) { c.Kafka.Brokers = []string{"kafka-1"} }, wantErr: []string{`kafka.brokers entry "kafka-1" must be host:port`}},
+		{name: "Kafka 토픽 중복", modify: func(c *Config) { c.Kafka.DeadLetterTopic = c.Kafka.Topics.FitMate }, wantErr: []string{`kafka topic "fit-mate" is used more than once`}},
+		{
+			name:    "토픽별 재시도 정책은 컨슘하는 토픽만",
+			modify:  func(c *Config) { c.Kafka.TopicRetry = map[string]RetryPolicy{"unknown": c.Kafka.Retry} },
+			wantErr: []string{`kafka.topicRetry key "unknown" is not a consumed event topic`},
+		},
+		{name: "서비스 URL 형식", modify: func(c *Config) { c.Services.Auth = "auth-service:8080" }, wantErr: []string{"services.auth must be an http(s) URL"}},
+		{name: "서비스 URL 끝 /", modify: func(c *Config) { c.Services.Alarm = "http://alarm/" }, wantErr: []string{"services.alarm must not end with /"}},
+		{name: "보관 기간에는 보관 디렉터리 필요", modify: func(c *Config) { c.Retention.DefaultDays = 30 }, wantErr: []string{"retention.archiveDir is required"}},
+		{name: "보관 디렉터리는 절대 경로", modify: func(c *Config) { c.Retention.ArchiveDir = "archive" }, wantErr: []string{"retention.archiveDir must be an absolute path"}},
+		{name: "모더레이션 조치", modify: func(c *Config) { c.Moderation.Spam.Action = "mask" }, wantErr: []string{"moderation.spam.action must be one of"}},
+		{name: "모더레이션 조치는 대소문자 무시", modify: func(c *Config) { c.Moderation.LinkAction = "REJECT" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			cfg.Database.Password = "x"
+			tt.modify(&cfg)
+			err := cfg.Validate()
+			if len(tt.wantErr) == 0 {
+				if err != nil {
+					t.Fatalf("Validate: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Validate = nil, want %v", tt.wantErr)
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Validate err = %v, want it to contain %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestSecretIsMasked(t *testing.T) {
+	const password = "p@ss w0rd"
+	cfg := Default()
+	cfg.Database.Password = Secret(password)
+
+	outputs := map[string]string{
+		"%v":  fmt.Sprintf("%v", cfg),
+		"%+v": fmt.Sprintf("%+v", cfg),
+		"%#v": fmt.Sprintf("%#v", cfg),
+		"%s":  fmt.Sprintf("%s", cfg.Database.Password),
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("json.Marshal: %v", err)
+	}
+	outputs["json"] = string(data)
+	data, err = yaml.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("yaml.Marshal: %v", err)
+	}
+	outputs["yaml"] = string(data)
+
+	for name, out := range outputs {
+		if strings.Contains(out, password) {
+			t.Errorf("%s output contains the password: %s", name, out)
+		}
+		if !strings.Contains(out, redacted) {
+			t.Errorf("%s output does not contain %s: %s", name, redacted, out)
+		}
+	}
+
+	if cfg.Database.Password.Value() != password {
+		t.Fatalf("Value() = %q, want the raw password", cfg.Database.Password.Value())
+	}
+	if !strings.Contains(cfg.Database.DSN(), `password='p@ss w0rd'`) {
+		t.Fatalf("DSN() = %q, want the quoted raw password", cfg.Database.DSN())
+	}
+	if s := fmt.Sprint(Secret("")); s != "" {
+		t.Fatalf("empty secret prints %q, want empty", s)
+	}
+}
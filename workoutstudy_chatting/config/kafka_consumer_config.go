@@ -3,18 +3,37 @@ package config
 import (
 	"context"
 	"log"
+	"sync"
 	"time"
-	"workoutstudy_chatting/handler"
 
 	"github.com/segmentio/kafka-go"
 )
 
+// 오프셋 커밋 대기 시간
+const commitTimeout = 5 * time.Second
+
+// 처리에 실패한 이벤트를 다시 전달하기 전 대기 시간 (두 배씩 늘어남)
+const (
+	redeliveryBackoff    = time.Second
+	maxRedeliveryBackoff = 30 * time.Second
+)
+
+// messageReader 는 Consume 이 사용하는 kafka.Reader 의 메서드입니다.
+type messageReader interface {
+	FetchMessage(ctx context.Context) (kafka.Message, error)
+	CommitMessages(ctx context.Context, msgs ...kafka.Message) error
+}
+
+var _ messageReader = (*kafka.Reader)(nil)
+
 type KafkaConsumer struct {
-	Readers map[string]*kafka.Reader // 토픽 별로 Reader 저장
+	Readers         map[string]*kafka.Reader // 토픽 별로 Reader 저장
+	commitInterval  time.Duration
+	commitBatchSize int
 }
 
 // KafkaConsumer 생성자
-func NewKafkaConsumer(bootstrapServers []string, groupID string, topics []string) *KafkaConsumer {
+func NewKafkaConsumer(bootstrapServers []string, groupID string, topics []string, commitInterval time.Duration, commitBatchSize int) *KafkaConsumer {
 	readers := make(map[string]*kafka.Reader)
 	for _, topic := range topics {
 		reader := kafka.NewReader(kafka.ReaderConfig{
@@ -29,33 +48,145 @@ func NewKafkaConsumer(bootstrapServers []string, groupID string, topics []string
 		log.Printf("Kafka Reader created for topic: %s", topic)
 	}
 	return &KafkaConsumer{
-		Readers: readers,
+		Readers:         readers,
+		commitInterval:  commitInterval,
+		commitBatchSize: commitBatchSize,
 	}
 }
 
-// 메시지 Consume 메서드
-func (kc *KafkaConsumer) Consume(ctx context.Context, msgChan chan handler.MessageEvent) {
+/*
+Consume 은 토픽별로 메시지를 읽어 deliver 로 전달합니다.
+deliver 는 이벤트 처리(또는 재시도 토픽, dead-letter 토픽 전송)를 마친 뒤 반환하고, 성공한 메시지의 오프셋만 커밋합니다. (at-least-once)
+- 토픽마다 메시지를 하나씩 순서대로 전달하므로 파티션 내 순서가 유지됩니다.
+- deliver 가 오류를 반환하면 같은 메시지를 다시 전달합니다. 뒤의 메시지는 그동안 전달하지 않습니다.
+- 오프셋은 commitInterval 마다, 또는 commitBatchSize 개가 쌓이면 파티션별 마지막 오프셋만 모아 커밋합니다.
+ctx 가 취소되면 읽기를 멈추고, 처리를 마친 메시지의 오프셋을 커밋한 뒤 반환합니다.
+처리하지 못한 메시지는 커밋하지 않으므로 다음 실행에서 다시 전달됩니다.
+*/
+func (kc *KafkaConsumer) Consume(ctx context.Context, deliver func(topic string, m kafka.Message) error) {
+	var wg sync.WaitGroup
 	for topic, reader := range kc.Readers {
+		wg.Add(1)
 		go func(topic string, r *kafka.Reader) {
-			log.Printf("Starting Kafka Consumer for topic: %s", topic)
-			for { 
-				m, err := r.FetchMessage(ctx)
-				if err != nil {
-					log.Printf("Error fetching message from topic %s: %v\n", topic, err)
-					if err == context.Canceled {
-						return
-					}
-					time.Sleep(time.Second) // 재시도 전에 잠시 대기
-					continue
-				}
-				log.Printf("Message received from topic %s: %s\n", topic, string(m.Value))
-				msgChan <- handler.MessageEvent{Message: m, Topic: topic}
-
-				// 명시적으로 커밋
-				if err := r.CommitMessages(ctx, m); err != nil {
-					log.Printf("Failed to commit message for topic %s: %v\n", topic, err)
-				}
-			}
+			defer wg.Done()
+			kc.consumeTopic(ctx, topic, r, deliver)
 		}(topic, reader)
 	}
+	wg.Wait()
+}
+
+// consumeTopic 은 한 토픽의 메시지를 순서대로 전달하고, 처리를 마친 메시지를 commitLoop 로 넘깁니다.
+func (kc *KafkaConsumer) consumeTopic(ctx context.Context, topic string, r messageReader, deliver func(topic string, m kafka.Message) error) {
+	processed := make(chan kafka.Message, kc.commitBatchSize)
+	committed := make(chan struct{})
+	go func() {
+		kc.commitLoop(topic, r, processed)
+		close(committed)
+	}()
+	defer func() {
+		close(processed)
+		<-committed
+	}()
+
+	log.Printf("Starting Kafka Consumer for topic: %s", topic)
+	for {
+		m, err := r.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				log.Printf("Stopping Kafka Consumer for topic: %s", topic)
+				return
+			}
+			log.Printf("Error fetching message from topic %s: %v\n", topic, err)
+			time.Sleep(time.Second) // 재시도 전에 잠시 대기
+			continue
+		}
+		log.Printf("Message received from topic %s: %s\n", topic, string(m.Value))
+		if !redeliverUntilDone(ctx, topic, m, deliver) {
+			log.Printf("Stopping Kafka Consumer for topic: %s (partition %d offset %d not committed)", topic, m.Partition, m.Offset)
+			return
+		}
+		processed <- m
+	}
+}
+
+// redeliverUntilDone 은 deliver 가 성공할 때까지 같은 메시지를 다시 전달합니다. ctx 가 취소되어 멈추면 false 를 반환합니다.
+func redeliverUntilDone(ctx context.Context, topic string, m kafka.Message, deliver func(topic string, m kafka.Message) error) bool {
+	backoff := redeliveryBackoff
+	for {
+		err := deliver(topic, m)
+		if err == nil {
+			return true
+		}
+		if ctx.Err() != nil {
+			return false
+		}
+		log.Printf("Failed to process message from topic %s partition %d offset %d, redelivering in %s: %v\n", topic, m.Partition, m.Offset, backoff, err)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return false
+		}
+		if backoff *= 2; backoff > maxRedeliveryBackoff {
+			backoff = maxRedeliveryBackoff
+		}
+	}
+}
+
+// commitLoop 는 처리를 마친 메시지의 오프셋을 모아 커밋합니다. processed 가 닫히면 남은 오프셋을 커밋하고 반환합니다.
+func (kc *KafkaConsumer) commitLoop(topic string, r messageReader, processed <-chan kafka.Message) {
+	ticker := time.NewTicker(kc.commitInterval)
+	defer ticker.Stop()
+
+	latest := make(map[int]kafka.Message) // 파티션별 마지막으로 처리한 메시지
+	count := 0
+	commit := func() {
+		if len(latest) == 0 {
+			return
+		}
+		msgs := make([]kafka.Message, 0, len(latest))
+		for _, m := range latest {
+			msgs = append(msgs, m)
+		}
+		// 종료 중에도 처리를 마친 메시지는 커밋되도록 Consume 의 ctx 와 별도의 context 사용
+		commitCtx, cancel := context.WithTimeout(context.Background(), commitTimeout)
+		defer cancel()
+		if err := r.CommitMessages(commitCtx, msgs...); err != nil {
+			// 오프셋은 누적되므로 다음 커밋에서 함께 다시 시도
+			log.Printf("Failed to commit %d messages for topic %s: %v\n", count, topic, err)
+			count = 0
+			return
+		}
+		latest = make(map[int]kafka.Message)
+		count = 0
+	}
+
+	for {
+		select {
+		case m, ok := <-processed:
+			if !ok {
+				commit()
+				return
+			}
+			latest[m.Partition] = m
+			if count++; count >= kc.commitBatchSize {
+				commit()
+			}
+		case <-ticker.C:
+			commit()
+		}
+	}
+}
+
+// Close 는 모든 Reader 를 닫습니다. Consume 이 반환된 뒤 호출합니다.
+func (kc *KafkaConsumer) Close() error {
+	var firstErr error
+	for topic, reader := range kc.Readers {
+		if err := reader.Close(); err != nil {
+			log.Printf("Error closing Kafka Reader for topic %s: %v", topic, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
 }
@@ -0,0 +1,228 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// fakeReader 는 msgs 를 차례로 반환하고, 다 읽으면 ctx 가 끝날 때까지 기다리는 messageReader 입니다.
+type fakeReader struct {
+	mu        sync.Mutex
+	msgs      []kafka.Message
+	next      int
+	failFirst bool       // 첫 커밋을 실패시킴
+	commits   [][]string // 커밋마다 "partition:offset" 목록
+}
+
+func (r *fakeReader) FetchMessage(ctx context.Context) (kafka.Message, error) {
+	r.mu.Lock()
+	if r.next < len(r.msgs) {
+		m := r.msgs[r.next]
+		r.next++
+		r.mu.Unlock()
+		return m, nil
+	}
+	r.mu.Unlock()
+	<-ctx.Done()
+	return kafka.Message{}, ctx.Err()
+}
+
+func (r *fakeReader) CommitMessages(_ context.Context, msgs ...kafka.Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.failFirst {
+		r.failFirst = false
+		return errors.New("broker unavailable")
+	}
+	var offsets []string
+	for _, m := range msgs {
+		offsets = append(offsets, position(m))
+	}
+	sort.Strings(offsets)
+	r.commits = append(r.commits, offsets)
+	return nil
+}
+
+func (r *fakeReader) committed() [][]string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([][]string(nil), r.commits...)
+}
+
+func position(m kafka.Message) string {
+	return fmt.Sprintf("%d:%d", m.Partition, m.Offset)
+}
+
+func msg(partition int, offset int64) kafka.Message {
+	return kafka.Message{Topic: "fit-group", Partition: partition, Offset: offset}
+}
+
+// waitFor 는 cond 가 참이 될 때까지 최대 2초 기다립니다.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestConsumeCommitsOnlyAfterDeliverReturns(t *testing.T) {
+	reader := &fakeReader{msgs: []kafka.Message{msg(0, 0)}}
+	kc := &KafkaConsumer{commitInterval: 5 * time.Millisecond, commitBatchSize: 1}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	started, release := make(chan struct{}), make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		kc.consumeTopic(ctx, "fit-group", reader, func(topic string, m kafka.Message) error {
+			close(started)
+			<-release
+			return nil
+		})
+		close(done)
+	}()
+
+	<-started
+	time.Sleep(50 * time.Millisecond) // commitInterval 이 여러 번 지나도록
+	if got := reader.committed(); len(got) != 0 {
+		t.Fatalf("committed %v while handler was running", got)
+	}
+	close(release)
+	waitFor(t, "commit", func() bool { return len(reader.committed()) == 1 })
+	if got := reader.committed(); !reflect.DeepEqual(got, [][]string{{"0:0"}}) {
+		t.Fatalf("commits = %v", got)
+	}
+	cancel()
+	<-done
+}
+
+func TestConsumeRedeliversFailedMessage(t *testing.T) {
+	reader := &fakeReader{msgs: []kafka.Message{msg(0, 0), msg(0, 1), msg(0, 2)}}
+	kc := &KafkaConsumer{commitInterval: time.Hour, commitBatchSize: 100}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var mu sync.Mutex
+	var delivered []int64
+	failed := false
+	done := make(chan struct{})
+	go func() {
+		kc.consumeTopic(ctx, "fit-group", reader, func(topic string, m kafka.Message) error {
+			mu.Lock()
+			defer mu.Unlock()
+			delivered = append(delivered, m.Offset)
+			if m.Offset == 1 && !failed {
+				failed = true
+				return errors.New("database unavailable")
+			}
+			return nil
+		})
+		close(done)
+	}()
+
+	waitFor(t, "all messages", func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(delivered) == 4
+	})
+	cancel()
+	<-done
+
+	// 실패한 1 을 다시 전달한 뒤에야 2 를 전달하고, 종료 시 마지막 오프셋을 커밋
+	if want := []int64{0, 1, 1, 2}; !reflect.DeepEqual(delivered, want) {
+		t.Fatalf("delivered = %v, want %v", delivered, want)
+	}
+	if got := reader.committed(); !reflect.DeepEqual(got, [][]string{{"0:2"}}) {
+		t.Fatalf("commits = %v", got)
+	}
+}
+
+func TestConsumeStopsWithoutCommittingUnprocessedMessage(t *testing.T) {
+	reader := &fakeReader{msgs: []kafka.Message{msg(0, 0), msg(0, 1)}}
+	kc := &KafkaConsumer{commitInterval: time.Hour, commitBatchSize: 100}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan struct{})
+	go func() {
+		kc.consumeTopic(ctx, "fit-group", reader, func(topic string, m kafka.Message) error {
+			if m.Offset == 1 {
+				cancel() // 처리 중 종료
+				return errors.New("interrupted")
+			}
+			return nil
+		})
+		close(done)
+	}()
+	<-done
+
+	if got := reader.committed(); !reflect.DeepEqual(got, [][]string{{"0:0"}}) {
+		t.Fatalf("commits = %v, want only offset 0", got)
+	}
+}
+
+func TestCommitLoopBatchesPerPartition(t *testing.T) {
+	tests := []struct {
+		name      string
+		batchSize int
+		failFirst bool
+		processed []kafka.Message
+		want      [][]string
+	}{
+		{
+			name:      "파티션별 마지막 오프셋만 커밋",
+			batchSize: 100,
+			processed: []kafka.Message{msg(0, 0), msg(1, 0), msg(0, 1), msg(0, 2), msg(1, 1)},
+			want:      [][]string{{"0:2", "1:1"}},
+		},
+		{
+			name:      "batchSize 마다 커밋하고 종료 시 나머지 커밋",
+			batchSize: 3,
+			processed: []kafka.Message{msg(0, 0), msg(1, 0), msg(0, 1), msg(1, 1)},
+			want:      [][]string{{"0:1", "1:0"}, {"1:1"}},
+		},
+		{
+			name:      "커밋에 실패하면 다음 커밋에 누적",
+			batchSize: 2,
+			failFirst: true,
+			processed: []kafka.Message{msg(0, 0), msg(1, 0), msg(0, 1)},
+			want:      [][]string{{"0:1", "1:0"}},
+		},
+		{
+			name:      "처리한 메시지가 없으면 커밋하지 않음",
+			batchSize: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := &fakeReader{failFirst: tt.failFirst}
+			kc := &KafkaConsumer{commitInterval: time.Hour, commitBatchSize: tt.batchSize}
+			processed := make(chan kafka.Message)
+			done := make(chan struct{})
+			go func() {
+				kc.commitLoop("fit-group", reader, processed)
+				close(done)
+			}()
+			for _, m := range tt.processed {
+				processed <- m
+			}
+			close(processed)
+			<-done
+
+			if got := reader.committed(); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("commits = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package config
+
+import (
+	"context"
+	"log"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// KafkaProducer 는 재시도 토픽과 dead-letter 토픽으로 이벤트를 보냅니다. 메시지마다 Topic 을 지정합니다.
+type KafkaProducer struct {
+	Writer *kafka.Writer
+}
+
+// KafkaProducer 생성자
+func NewKafkaProducer(bootstrapServers []string) *KafkaProducer {
+	writer := &kafka.Writer{
+		Addr:                   kafka.TCP(bootstrapServers...),
+		Balancer:               &kafka.Hash{}, // 같은 키의 이벤트는 같은 파티션으로 보내 순서 유지
+		RequiredAcks:           kafka.RequireAll,
+		AllowAutoTopicCreation: true,
+	}
+	log.Printf("Kafka Writer created for brokers: %v", bootstrapServers)
+	return &KafkaProducer{Writer: writer}
+}
+
+// Publish 는 모든 메시지가 기록될 때까지 기다립니다.
+func (kp *KafkaProducer) Publish(ctx context.Context, msgs ...kafka.Message) error {
+	return kp.Writer.WriteMessages(ctx, msgs...)
+}
+
+// Close 는 보내는 중인 메시지를 기록하고 Writer 를 닫습니다.
+func (kp *KafkaProducer) Close() error {
+	return kp.Writer.Close()
+}
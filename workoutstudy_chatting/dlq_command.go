@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+	"workoutstudy_chatting/config"
+	"workoutstudy_chatting/handler"
+
+	"github.com/segmentio/kafka-go"
+)
+
+const dlqUsage = `usage: workoutstudy_chatting dlq <command>
+
+commands:
+  redrive [N]  dead-letter 토픽의 이벤트 N개(기본 전체)를 원래 토픽으로 다시 보냄`
+
+// dead-letter 토픽에서 이 시간 동안 새 이벤트가 없으면 끝까지 읽은 것으로 봄
+const dlqIdleTimeout = 10 * time.Second
+
+// runDLQCommand 는 dlq 서브커맨드를 실행합니다. 서버는 시작하지 않습니다.
+// 다시 보낸 이벤트는 dead-letter 컨슈머 그룹(<groupId>-dlq-redrive)에 커밋하므로 다음 실행에서 다시 보내지 않습니다.
+func runDLQCommand(cfg config.KafkaConfig, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing command\n%s", dlqUsage)
+	}
+	if args[0] != "redrive" {
+		return fmt.Errorf("unknown command %q\n%s", args[0], dlqUsage)
+	}
+	if len(args) > 2 {
+		return fmt.Errorf("unexpected arguments %v\n%s", args[2:], dlqUsage)
+	}
+	limit := 0
+	if len(args) == 2 {
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n <= 0 {
+			return fmt.Errorf("redrive count must be a positive integer: %q", args[1])
+		}
+		limit = n
+	}
+
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:        cfg.Brokers,
+		GroupID:        cfg.GroupID + "-dlq-redrive",
+		Topic:          cfg.DeadLetterTopic,
+		StartOffset:    kafka.FirstOffset,
+		CommitInterval: 0, // 다시 보낸 뒤에 커밋
+	})
+	defer reader.Close()
+	producer := config.NewKafkaProducer(cfg.Brokers)
+	defer producer.Close()
+
+	redriven, skipped := 0, 0
+	for limit == 0 || redriven+skipped < limit {
+		fetchCtx, cancel := context.WithTimeout(context.Background(), dlqIdleTimeout)
+		m, err := reader.FetchMessage(fetchCtx)
+		cancel()
+		if errors.Is(err, context.DeadlineExceeded) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("fetch from %s: %w", cfg.DeadLetterTopic, err)
+		}
+
+		msg, err := handler.RedriveMessage(m)
+		if err != nil {
+			// 원래 토픽을 알 수 없는 이벤트는 건너뜀
+			fmt.Printf("skip offset %d: %v\n", m.Offset, err)
+			skipped++
+		} else {
+			if err := publishWithTimeout(producer, msg); err != nil {
+				return fmt.Errorf("redrive offset %d to %s: %w", m.Offset, msg.Topic, err)
+			}
+			fmt.Printf("redrive offset %d -> %s (attempts %s, error: %s)\n", m.Offset, msg.Topic,
+				handler.EventHeader(m, handler.HeaderAttempts), handler.EventHeader(m, handler.HeaderError))
+			redriven++
+		}
+
+		commitCtx, cancel := context.WithTimeout(context.Background(), dlqIdleTimeout)
+		err = reader.CommitMessages(commitCtx, m)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("commit offset %d: %w", m.Offset, err)
+		}
+	}
+	fmt.Printf("%d events redriven, %d skipped\n", redriven, skipped)
+	return nil
+}
+
+func publishWithTimeout(producer *config.KafkaProducer, msg kafka.Message) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dlqIdleTimeout)
+	defer cancel()
+	return producer.Publish(ctx, msg)
+}
@@ -15,9 +15,80 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/archive/restore": {
+            "post": {
+                "description": "특정 피트그룹의 아카이브된 메시지를 날짜 구간(양 끝 포함)으로 복원. fit leader 만 복원 가능\n삭제된 메시지는 삭제된 상태로 복원하며, 복원한 메시지는 보관 기간이 지나도 다시 아카이브하지 않음",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "retention"
+                ],
+                "summary": "아카이브 메시지 복원 API",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "피트그룹 ID",
+                        "name": "fitGroupId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "요청한 사용자 ID (fit leader)",
+                        "name": "userId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "복원 시작 날짜 (yyyy-MM-dd)",
+                        "name": "start",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "복원 종료 날짜 (yyyy-MM-dd)",
+                        "name": "end",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "integer"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "fit leader 가 아님",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "아카이브 저장소가 설정되지 않음",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
         "/chat": {
             "get": {
-                "description": "실시간 채팅 초기 연결 요청입니다.\n첫 연결 요청 시 웹소켓 연결이 설정되며, 이후 채팅 메시지는 웹소켓을 통해 전송됩니다.",
+                "description": "실시간 채팅 초기 연결 요청입니다.\n첫 연결 요청 시 웹소켓 연결이 설정되며, 이후 채팅 메시지는 웹소켓을 통해 전송됩니다.\nSec-WebSocket-Protocol 로 json.v1, msgpack.v1, protobuf.v1 중 하나를 요청할 수 있으며 요청하지 않으면 JSON 텍스트 프레임을 사용합니다. protobuf 스키마는 wire/proto/chat.proto 입니다.",
                 "consumes": [
                     "application/json"
                 ],
@@ -54,9 +125,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/retrieve/fit-group": {
-            "get": {
-                "description": "userId 로 해당 사용자가 속해 있는 피트그룹들의 정보를 조희",
+        "/chat/message": {
+            "post": {
+                "description": "웹소켓 없이 채팅 메시지나 투표 프레임을 보냅니다. 본문은 채팅방 웹소켓(/chat)으로 보내는 프레임과 같습니다.\n저장과 전송 제한, 브로드캐스트는 웹소켓과 같은 경로로 처리되며, 거부되면 에러 프레임을 해당 상태 코드로 응답합니다.",
                 "consumes": [
                     "application/json"
                 ],
@@ -64,128 +135,1790 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "fitmate"
+                    "chat"
                 ],
-                "summary": "피트그룹 조회 API",
+                "summary": "send chat frame",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "사용자 ID, fitMateId 가 아님.",
+                        "description": "피트그룹 ID",
+                        "name": "fitGroupId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "사용자 ID",
                         "name": "userId",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "description": "채팅 메시지 또는 투표 프레임",
+                        "name": "frame",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ChatMessage"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.FitGroup"
+                            "$ref": "#/definitions/model.SendFrameResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청",
+                        "schema": {
+                            "$ref": "#/definitions/model.ChatErrorFrame"
+                        }
+                    },
+                    "403": {
+                        "description": "뮤트/차단/권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/model.ChatErrorFrame"
+                        }
+                    },
+                    "429": {
+                        "description": "전송 제한",
+                        "schema": {
+                            "$ref": "#/definitions/model.ChatErrorFrame"
                         }
                     }
                 }
             }
         },
-        "/retrieve/message": {
+        "/chat/poll": {
             "get": {
-                "description": "messageId 로 서버측 최신 채팅과 앱의 최신 채팅을 비교",
-                "consumes": [
+                "description": "웹소켓과 SSE 를 모두 사용할 수 없을 때 long-poll 로 채팅방 프레임을 받습니다.\nsessionId 없이 요청하면 세션을 만들고 바로 응답합니다. 이후 응답의 sessionId, cursor 로 다시 요청하면 새 프레임이 생기거나 25초가 지날 때까지 기다립니다.\n1분 동안 요청이 없거나 밀린 프레임이 너무 많으면 세션이 만료되어 410 을 응답하므로 메시지를 다시 조회한 뒤 새 세션을 만들어야 합니다.\n같은 사용자와 피트그룹의 세션은 3개까지 유지하며, 새 세션을 만들면 가장 오래 조회하지 않은 세션이 만료됩니다.",
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "chat"
+                ],
+                "summary": "long-poll chat",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "피트그룹 ID",
+                        "name": "fitGroupId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "사용자 ID",
+                        "name": "userId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "long-poll 세션 ID",
+                        "name": "sessionId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "마지막으로 받은 프레임 순번",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.LongPollResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "차단된 사용자",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "410": {
+                        "description": "만료된 세션",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/chat/setting": {
+            "get": {
+                "description": "피트그룹 채팅방의 슬로우 모드 등 설정을 조회",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "message"
+                    "chat"
                 ],
-                "summary": "최신 채팅 내역을 확인하고 동기화 하기 위한 API",
+                "summary": "채팅방 설정 조회 API",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "안드로이드 앱에서 생성된 message UUID",
-                        "name": "messageId",
+                        "description": "피트그룹 ID",
+                        "name": "fitGroupId",
                         "in": "query",
                         "required": true
-                    },
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ChatRoomSetting"
+                        }
+                    }
+                }
+            }
+        },
+        "/chat/slow-mode": {
+            "put": {
+                "description": "fit leader 가 채팅방의 슬로우 모드(사용자별 메시지 전송 최소 간격, 초)를 설정. 0 이면 해제",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "chat"
+                ],
+                "summary": "슬로우 모드 설정 API",
+                "parameters": [
                     {
                         "type": "integer",
-                        "description": "피트그룹 채팅방 ID",
+                        "description": "피트그룹 ID",
                         "name": "fitGroupId",
                         "in": "query",
                         "required": true
                     },
                     {
                         "type": "integer",
-                        "description": "사용자 ID, auth-server 의 userId, fit-mate-service 의 fitMateUserId",
+                        "description": "요청 사용자 ID (fit leader)",
                         "name": "userId",
                         "in": "query",
                         "required": true
                     },
                     {
                         "type": "integer",
-                        "description": "클라이언트측 메시지 생성 시간",
-                        "name": "messageTime",
+                        "description": "메시지 전송 최소 간격 (0 ~ 3600초)",
+                        "name": "seconds",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ChatRoomSetting"
+                        }
+                    }
+                }
+            }
+        },
+        "/chat/sse": {
+            "get": {
+                "description": "웹소켓을 사용할 수 없을 때 Server-Sent Events 로 채팅방 프레임을 받습니다.\n각 이벤트의 data 는 채팅방 웹소켓(/chat)과 같은 형식의 JSON 이며, 연결 유지를 위해 주기적으로 주석(: ping)을 보냅니다.\n메시지 전송은 POST /chat/message 를 사용합니다.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "chat"
+                ],
+                "summary": "SSE chat stream",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "피트그룹 ID",
+                        "name": "fitGroupId",
                         "in": "query",
                         "required": true
                     },
                     {
                         "type": "integer",
-                        "description": "메시지 타입 (CHATTING or TICKET)",
-                        "name": "messageType",
+                        "description": "사용자 ID",
+                        "name": "userId",
                         "in": "query",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "event stream",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.ChatMessage"
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 요청",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "차단된 사용자",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "model.ChatMessage": {
-            "type": "object",
-            "properties": {
-                "fitGroupId": {
-                    "type": "integer"
-                },
-                "fitMateId": {
-                    "type": "integer"
-                },
-                "message": {
-                    "type": "string"
-                },
-                "messageId": {
-                    "type": "string"
-                },
-                "messageTime": {
-                    "type": "string"
-                },
-                "messageType": {
-                    "$ref": "#/definitions/model.MessageType"
-                },
-                "userId": {
-                    "type": "integer"
+        },
+        "/chat/time-zone": {
+            "put": {
+                "description": "fit leader 가 리마인더 실행 기준 시간대를 설정합니다. 등록된 리마인더의 다음 실행 시간도 다시 계산됩니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "chat"
+                ],
+                "summary": "채팅방 시간대 설정 API",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "피트그룹 ID",
+                        "name": "fitGroupId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "요청 사용자 ID (fit leader)",
+                        "name": "userId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA 시간대 (예: Asia/Seoul)",
+                        "name": "timeZone",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ChatRoomSetting"
+                        }
+                    }
                 }
             }
         },
-        "model.FitGroup": {
-            "type": "object",
-            "properties": {
-                "category": {
-                    "type": "integer"
-                },
-                "createdAt": {
-                    "type": "string"
-                },
+        "/dm": {
+            "get": {
+                "description": "1:1 대화 실시간 연결 요청입니다. 대화 참여자만 연결할 수 있습니다.",
+                "tags": [
+                    "dm"
+                ],
+                "summary": "websocket direct message",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "대화방 ID",
+                        "name": "conversationId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "사용자 ID",
+                        "name": "userId",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "WebSocket 연결이 성공적으로 설정되었습니다.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/dm/conversation": {
+            "post": {
+                "description": "상대방과의 대화방을 반환하고, 없으면 생성합니다. 활성 fit group 을 함께 하는 사용자와만 대화할 수 있습니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "dm"
+                ],
+                "summary": "1:1 대화방 생성 API",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "사용자 ID",
+                        "name": "userId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "상대방 사용자 ID",
+                        "name": "peerUserId",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.DirectConversation"
+                        }
+                    }
+                }
+            }
+        },
+        "/dm/conversations": {
+            "get": {
+                "description": "최근 메시지 순으로 대화방 목록과 마지막 메시지, 읽지 않은 메시지 수를 조회합니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "dm"
+                ],
+                "summary": "1:1 대화방 목록 조회 API",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "사용자 ID",
+                        "name": "userId",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.DirectConversationSummary"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/dm/message": {
+            "get": {
+                "description": "before 이전의 메시지를 최신순으로 조회합니다. before 가 없으면 가장 최근 메시지부터 조회합니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "dm"
+                ],
+                "summary": "1:1 대화 내역 조회 API",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "대화방 ID",
+                        "name": "conversationId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "사용자 ID",
+                        "name": "userId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "이 시간 이전 메시지 조회 (2006-01-02 15:04:05.000000)",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "최대 개수 (기본/최대 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.DirectMessage"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/dm/read": {
+            "put": {
+                "description": "지금까지 받은 메시지를 모두 읽은 것으로 기록합니다.",
+                "tags": [
+                    "dm"
+                ],
+                "summary": "1:1 대화 읽음 처리 API",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "대화방 ID",
+                        "name": "conversationId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "사용자 ID",
+                        "name": "userId",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/export/message": {
+            "get": {
+                "description": "피트그룹의 전체 채팅 내역을 JSON, CSV 또는 HTML 파일로 내려받습니다. 피트그룹 멤버만 요청할 수 있습니다.\nincludeDeleted 옵션은 fit leader 만 사용할 수 있습니다.",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "text/html"
+                ],
+                "tags": [
+                    "message"
+                ],
+                "summary": "채팅 내역 내보내기 API",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "피트그룹 ID",
+                        "name": "fitGroupId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "요청 사용자 ID",
+                        "name": "userId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "내보내기 형식 (json, csv, html). 기본값 json",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "삭제된 메시지 포함 여부 (fit leader 전용)",
+                        "name": "includeDeleted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    }
+                }
+            }
+        },
+        "/fit-mate/pending-events": {
+            "get": {
+                "description": "fit group 보다 먼저 도착해 보류한 fit mate 이벤트 수와 서버 시작 이후 보류, 재처리, 대체, 만료, 재처리 실패 누적 횟수를 조회",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fitmate"
+                ],
+                "summary": "보류한 fit mate 이벤트 현황 API",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PendingFitMateEventStats"
+                        }
+                    }
+                }
+            }
+        },
+        "/message": {
+            "delete": {
+                "description": "본인이 보낸 메시지를 삭제합니다. 삭제된 메시지는 조회 API 에서 제외됩니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "message"
+                ],
+                "summary": "채팅 메시지 삭제 API",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "삭제할 message UUID",
+                        "name": "messageId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "요청 사용자 ID",
+                        "name": "userId",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/moderation/audit": {
+            "get": {
+                "description": "fit leader 의 moderation 조치 기록을 최신순으로 조회합니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "moderation"
+                ],
+                "summary": "moderation 조치 기록 조회 API",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "피트그룹 ID",
+                        "name": "fitGroupId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "요청 사용자 ID (fit leader)",
+                        "name": "userId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "최대 개수 (기본 100, 최대 500)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.ModerationAuditLog"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/moderation/ban": {
+            "post": {
+                "description": "fit leader 가 멤버를 채팅방에서 내보내고 일정 시간 재입장을 막습니다. minutes 가 0 이면 해제할 때까지 유지됩니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "moderation"
+                ],
+                "summary": "멤버 채팅방 차단 API",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "피트그룹 ID",
+                        "name": "fitGroupId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "요청 사용자 ID (fit leader)",
+                        "name": "userId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "대상 사용자 ID",
+                        "name": "targetUserId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "차단 시간 (분)",
+                        "name": "minutes",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "사유",
+                        "name": "reason",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ChatRestriction"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "moderation"
+                ],
+                "summary": "멤버 채팅방 차단 해제 API",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "피트그룹 ID",
+                        "name": "fitGroupId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "요청 사용자 ID (fit leader)",
+                        "name": "userId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "대상 사용자 ID",
+                        "name": "targetUserId",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/moderation/message": {
+            "delete": {
+                "description": "fit leader 가 채팅방의 다른 멤버 메시지를 삭제합니다.",
+                "tags": [
+                    "moderation"
+                ],
+                "summary": "메시지 삭제 API (fit leader)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "삭제할 message UUID",
+                        "name": "messageId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "요청 사용자 ID (fit leader)",
+                        "name": "userId",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/moderation/mute": {
+            "post": {
+                "description": "fit leader 가 멤버의 채팅을 일정 시간 제한합니다. minutes 가 0 이면 해제할 때까지 유지됩니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "moderation"
+                ],
+                "summary": "멤버 뮤트 API",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "피트그룹 ID",
+                        "name": "fitGroupId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "요청 사용자 ID (fit leader)",
+                        "name": "userId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "대상 사용자 ID",
+                        "name": "targetUserId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "뮤트 시간 (분)",
+                        "name": "minutes",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "사유",
+                        "name": "reason",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ChatRestriction"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "moderation"
+                ],
+                "summary": "멤버 뮤트 해제 API",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "피트그룹 ID",
+                        "name": "fitGroupId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "요청 사용자 ID (fit leader)",
+                        "name": "userId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "대상 사용자 ID",
+                        "name": "targetUserId",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/moderation/pin": {
+            "post": {
+                "description": "fit leader 가 공지 등 메시지를 채팅방 상단에 고정합니다. fit group 당 최대 10개까지 고정할 수 있습니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pin"
+                ],
+                "summary": "메시지 고정 API",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "고정할 message UUID",
+                        "name": "messageId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "요청 사용자 ID (fit leader)",
+                        "name": "userId",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PinnedMessage"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "pin"
+                ],
+                "summary": "메시지 고정 해제 API",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "고정 해제할 message UUID",
+                        "name": "messageId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "요청 사용자 ID (fit leader)",
+                        "name": "userId",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/moderation/queue": {
+            "get": {
+                "description": "필터에 의해 flag 된 메시지 목록을 조회합니다. fit leader 만 조회할 수 있습니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "moderation"
+                ],
+                "summary": "moderation queue 조회 API",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "피트그룹 ID",
+                        "name": "fitGroupId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "요청 사용자 ID (fit leader)",
+                        "name": "userId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "PENDING, APPROVED, REMOVED. 기본값 PENDING",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.ModerationItem"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/moderation/restrictions": {
+            "get": {
+                "description": "현재 적용 중인 뮤트/차단 목록을 조회합니다. fit leader 만 조회할 수 있습니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "moderation"
+                ],
+                "summary": "채팅 제한 목록 조회 API",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "피트그룹 ID",
+                        "name": "fitGroupId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "요청 사용자 ID (fit leader)",
+                        "name": "userId",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.ChatRestriction"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/moderation/review": {
+            "put": {
+                "description": "fit leader 가 flag 된 메시지를 승인(APPROVED)하거나 삭제(REMOVED)합니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "moderation"
+                ],
+                "summary": "moderation queue 검토 API",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "moderation queue 항목 ID",
+                        "name": "moderationId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "요청 사용자 ID (fit leader)",
+                        "name": "userId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "APPROVED 또는 REMOVED",
+                        "name": "status",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ModerationItem"
+                        }
+                    }
+                }
+            }
+        },
+        "/reconciliation/run": {
+            "post": {
+                "description": "DB 의 fit group, fit mate, 사용자를 fit-group, auth 서비스에서 다시 조회한 정보로 맞추고 변경 내역을 반환",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reconciliation"
+                ],
+                "summary": "정합성 맞추기 즉시 실행 API",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReconciliationReport"
+                        }
+                    },
+                    "409": {
+                        "description": "이미 실행 중",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/reminder": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reminder"
+                ],
+                "summary": "리마인더 목록 조회 API",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "피트그룹 ID",
+                        "name": "fitGroupId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "요청 사용자 ID (fit leader)",
+                        "name": "userId",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Reminder"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "fit leader 가 채팅방에 핏봇이 보낼 정기 알림을 등록합니다. 시간은 fit group 시간대 기준입니다.\nrecurrence: DAILY(매일), WEEKLY(weekdays 요일마다, 0: 일요일), CYCLE_DEADLINE(인증 주기 마감 daysBefore 일 전, 인증 현황 포함)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reminder"
+                ],
+                "summary": "리마인더 등록 API",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "요청 사용자 ID (fit leader)",
+                        "name": "userId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "fitGroupId, recurrence, message, timeOfDay(HH:MM), weekdays, daysBefore",
+                        "name": "reminder",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Reminder"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Reminder"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "reminder"
+                ],
+                "summary": "리마인더 삭제 API",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "피트그룹 ID",
+                        "name": "fitGroupId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "요청 사용자 ID (fit leader)",
+                        "name": "userId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "리마인더 ID",
+                        "name": "reminderId",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/retention/policy": {
+            "get": {
+                "description": "fit group 별로 설정된 메시지 보관 기간 정책 목록을 조회",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "retention"
+                ],
+                "summary": "메시지 보관 기간 정책 조회 API",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.RetentionPolicy"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "fit group 의 메시지 보관 기간(일)을 설정. 0 이면 영구 보관. fit leader 만 설정 가능",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "retention"
+                ],
+                "summary": "메시지 보관 기간 정책 설정 API",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "피트그룹 ID",
+                        "name": "fitGroupId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "보관 기간 (일)",
+                        "name": "retentionDays",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "요청한 사용자 ID (fit leader)",
+                        "name": "userId",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.RetentionPolicy"
+                        }
+                    },
+                    "403": {
+                        "description": "fit leader 가 아님",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "fit group 별 정책을 삭제하여 전역 보관 기간을 따르도록 함. fit leader 만 삭제 가능",
+                "tags": [
+                    "retention"
+                ],
+                "summary": "메시지 보관 기간 정책 삭제 API",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "피트그룹 ID",
+                        "name": "fitGroupId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "요청한 사용자 ID (fit leader)",
+                        "name": "userId",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "403": {
+                        "description": "fit leader 가 아님",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/retrieve/fit-group": {
+            "get": {
+                "description": "userId 로 해당 사용자가 속해 있는 피트그룹들의 정보를 조희",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fitmate"
+                ],
+                "summary": "피트그룹 조회 API",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "사용자 ID, fitMateId 가 아님.",
+                        "name": "userId",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.FitGroup"
+                        }
+                    }
+                }
+            }
+        },
+        "/retrieve/message": {
+            "get": {
+                "description": "messageId 로 서버측 최신 채팅과 앱의 최신 채팅을 비교",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "message"
+                ],
+                "summary": "최신 채팅 내역을 확인하고 동기화 하기 위한 API",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "안드로이드 앱에서 생성된 message UUID",
+                        "name": "messageId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "피트그룹 채팅방 ID",
+                        "name": "fitGroupId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "사용자 ID, auth-server 의 userId, fit-mate-service 의 fitMateUserId",
+                        "name": "userId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "클라이언트측 메시지 생성 시간",
+                        "name": "messageTime",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "메시지 타입 (CHATTING or TICKET)",
+                        "name": "messageType",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.ChatMessage"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/retrieve/pin": {
+            "get": {
+                "description": "fit group 정보와 고정된 메시지 목록을 함께 조회합니다. fit group 멤버만 조회할 수 있습니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pin"
+                ],
+                "summary": "고정 메시지 조회 API",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "피트그룹 ID",
+                        "name": "fitGroupId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "요청 사용자 ID",
+                        "name": "userId",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.FitGroupPins"
+                        }
+                    }
+                }
+            }
+        },
+        "/retrieve/poll": {
+            "get": {
+                "description": "투표 메시지의 선택지와 현재 집계, 요청한 사용자가 선택한 선택지를 조회합니다.\n투표와 투표 종료는 채팅 웹소켓으로 {\"type\":\"POLL_VOTE\",\"pollId\":1,\"optionIds\":[2]}, {\"type\":\"POLL_CLOSE\",\"pollId\":1} 프레임을 보냅니다.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "poll"
+                ],
+                "summary": "투표 조회 API",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "투표 message UUID",
+                        "name": "messageId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "요청 사용자 ID",
+                        "name": "userId",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ChatPoll"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/chat": {
+            "get": {
+                "description": "사용자가 속한 모든 fit group 채팅방을 하나의 웹소켓으로 구독합니다.\n연결 직후 구독한 채팅방 목록을 {\"type\":\"SUBSCRIBED\",\"fitGroupIds\":[...]} 로 보내며, 이후 fit mate 가입/탈퇴에 따라 SUBSCRIBED/UNSUBSCRIBED 를 보냅니다.\n채팅방 프레임은 {\"type\":\"ROOM\",\"fitGroupId\":1,\"frame\":{...}} 형식이며 frame 은 /chat 웹소켓과 같습니다.\n보내는 프레임은 /chat 웹소켓과 같은 형식에 fitGroupId 를 반드시 포함해야 합니다. subprotocol 협상도 /chat 과 같습니다.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "chat"
+                ],
+                "summary": "websocket user chat",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "사용자 ID",
+                        "name": "userId",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "WebSocket 연결이 성공적으로 설정되었습니다.",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "잘못된 userId",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "fit group 조회 실패",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "model.ChatErrorCode": {
+            "type": "string",
+            "enum": [
+                "RATE_LIMITED",
+                "SLOW_MODE",
+                "DUPLICATE_MESSAGE",
+                "MESSAGE_REJECTED",
+                "MUTED",
+                "BANNED",
+                "NOT_ALLOWED",
+                "POLL_CLOSED",
+                "INVALID_VOTE",
+                "INVALID_MESSAGE",
+                "SAVE_FAILED"
+            ],
+            "x-enum-varnames": [
+                "ErrorRateLimited",
+                "ErrorSlowMode",
+                "ErrorDuplicateMessage",
+                "ErrorMessageRejected",
+                "ErrorMuted",
+                "ErrorBanned",
+                "ErrorNotAllowed",
+                "ErrorPollClosed",
+                "ErrorInvalidVote",
+                "ErrorInvalidMessage",
+                "ErrorSaveFailed"
+            ]
+        },
+        "model.ChatErrorFrame": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "$ref": "#/definitions/model.ChatErrorCode"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "messageId": {
+                    "type": "string"
+                },
+                "retryAfterMs": {
+                    "type": "integer"
+                },
+                "type": {
+                    "description": "항상 \"ERROR\"",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ChatMessage": {
+            "type": "object",
+            "properties": {
+                "deletedAt": {
+                    "description": "삭제된 메시지일 경우 삭제 시간",
+                    "type": "string"
+                },
+                "fitGroupId": {
+                    "type": "integer"
+                },
+                "fitMateId": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "messageId": {
+                    "type": "string"
+                },
+                "messageTime": {
+                    "type": "string"
+                },
+                "messageType": {
+                    "$ref": "#/definitions/model.MessageType"
+                },
+                "poll": {
+                    "description": "messageType 이 POLL 일 때만 사용",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.ChatPoll"
+                        }
+                    ]
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ChatPoll": {
+            "type": "object",
+            "properties": {
+                "anonymous": {
+                    "description": "true 면 누가 어디에 투표했는지 공개하지 않음",
+                    "type": "boolean"
+                },
+                "closedAt": {
+                    "description": "종료된 투표일 경우 종료 시간",
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "integer"
+                },
+                "deadline": {
+                    "description": "RFC3339, 없으면 직접 종료할 때까지 진행",
+                    "type": "string"
+                },
+                "fitGroupId": {
+                    "type": "integer"
+                },
+                "messageId": {
+                    "type": "string"
+                },
+                "multipleChoice": {
+                    "type": "boolean"
+                },
+                "myOptionIds": {
+                    "description": "조회한 사용자가 선택한 선택지",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PollOption"
+                    }
+                },
+                "pollId": {
+                    "type": "integer"
+                },
+                "question": {
+                    "type": "string"
+                },
+                "totalVoters": {
+                    "description": "한 번 이상 투표한 사용자 수",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ChatRestriction": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "fitGroupId": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "restrictionId": {
+                    "type": "integer"
+                },
+                "revokedAt": {
+                    "type": "string"
+                },
+                "revokedBy": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/model.RestrictionType"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ChatRoomSetting": {
+            "type": "object",
+            "properties": {
+                "fitGroupId": {
+                    "type": "integer"
+                },
+                "slowModeSeconds": {
+                    "description": "0 이면 슬로우 모드 해제",
+                    "type": "integer"
+                },
+                "timeZone": {
+                    "description": "IANA 시간대, 리마인더 실행 시간 기준",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "updatedBy": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.DirectConversation": {
+            "type": "object",
+            "properties": {
+                "conversationId": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "lastMessageAt": {
+                    "type": "string"
+                },
+                "userAId": {
+                    "type": "integer"
+                },
+                "userBId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.DirectConversationSummary": {
+            "type": "object",
+            "properties": {
+                "conversationId": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "lastMessage": {
+                    "$ref": "#/definitions/model.DirectMessage"
+                },
+                "lastMessageAt": {
+                    "type": "string"
+                },
+                "peerNickname": {
+                    "type": "string"
+                },
+                "peerUserId": {
+                    "type": "integer"
+                },
+                "unreadCount": {
+                    "type": "integer"
+                },
+                "userAId": {
+                    "type": "integer"
+                },
+                "userBId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.DirectMessage": {
+            "type": "object",
+            "properties": {
+                "conversationId": {
+                    "type": "integer"
+                },
+                "deletedAt": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "messageId": {
+                    "type": "string"
+                },
+                "messageTime": {
+                    "type": "string"
+                },
+                "recipientUserId": {
+                    "type": "integer"
+                },
+                "senderUserId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.FitGroup": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
                 "createdBy": {
                     "type": "string"
                 },
@@ -226,16 +1959,409 @@ const docTemplate = `{
                 }
             }
         },
+        "model.FitGroupPins": {
+            "type": "object",
+            "properties": {
+                "fitGroup": {
+                    "$ref": "#/definitions/model.FitGroup"
+                },
+                "maxPins": {
+                    "type": "integer"
+                },
+                "pins": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PinnedMessage"
+                    }
+                }
+            }
+        },
+        "model.FitGroupReconciliation": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "addedFitMateIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "fitGroupId": {
+                    "type": "integer"
+                },
+                "removedFitMateIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "model.LongPollResponse": {
+            "type": "object",
+            "properties": {
+                "cursor": {
+                    "type": "integer"
+                },
+                "frames": {
+                    "description": "채팅방 웹소켓(/chat)과 같은 형식의 프레임",
+                    "type": "array",
+                    "items": {}
+                },
+                "sessionId": {
+                    "type": "string"
+                }
+            }
+        },
         "model.MessageType": {
             "type": "string",
             "enum": [
                 "CHATTING",
-                "TICKET"
+                "TICKET",
+                "ANNOUNCEMENT",
+                "POLL",
+                "SYSTEM"
             ],
+            "x-enum-comments": {
+                "Announcement": "fit leader 만 보낼 수 있는 공지",
+                "Poll": "투표. ChatMessage.Poll 에 선택지 등을 담음",
+                "System": "투표 결과 등 서버가 보내는 메시지"
+            },
             "x-enum-varnames": [
                 "Chatting",
-                "Ticket"
+                "Ticket",
+                "Announcement",
+                "Poll",
+                "System"
+            ]
+        },
+        "model.ModerationAction": {
+            "type": "string",
+            "enum": [
+                "MUTE",
+                "UNMUTE",
+                "BAN",
+                "UNBAN",
+                "DELETE_MESSAGE",
+                "PIN_MESSAGE",
+                "UNPIN_MESSAGE",
+                "REVIEW_FLAG"
+            ],
+            "x-enum-varnames": [
+                "ActionMute",
+                "ActionUnmute",
+                "ActionBan",
+                "ActionUnban",
+                "ActionDeleteMessage",
+                "ActionPinMessage",
+                "ActionUnpinMessage",
+                "ActionReviewFlag"
+            ]
+        },
+        "model.ModerationAuditLog": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "$ref": "#/definitions/model.ModerationAction"
+                },
+                "actorUserId": {
+                    "type": "integer"
+                },
+                "auditId": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "fitGroupId": {
+                    "type": "integer"
+                },
+                "targetMessageId": {
+                    "type": "string"
+                },
+                "targetUserId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ModerationItem": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "fitGroupId": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "messageId": {
+                    "type": "string"
+                },
+                "moderationId": {
+                    "type": "integer"
+                },
+                "reasons": {
+                    "type": "string"
+                },
+                "reviewedAt": {
+                    "type": "string"
+                },
+                "reviewedBy": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/model.ModerationStatus"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ModerationStatus": {
+            "type": "string",
+            "enum": [
+                "PENDING",
+                "APPROVED",
+                "REMOVED"
+            ],
+            "x-enum-comments": {
+                "ModerationApproved": "문제 없음, 메시지 유지",
+                "ModerationRemoved": "메시지 삭제"
+            },
+            "x-enum-varnames": [
+                "ModerationPending",
+                "ModerationApproved",
+                "ModerationRemoved"
+            ]
+        },
+        "model.PendingFitMateEventStats": {
+            "type": "object",
+            "properties": {
+                "expired": {
+                    "description": "fit group 이 생성되지 않아 TTL 이 지나 버린 이벤트 수",
+                    "type": "integer"
+                },
+                "parked": {
+                    "description": "fit group 이 없어 보류한 이벤트 수",
+                    "type": "integer"
+                },
+                "pending": {
+                    "description": "현재 보류 중인 이벤트 수",
+                    "type": "integer"
+                },
+                "replayFailures": {
+                    "description": "다시 처리하다 실패한 횟수 (보류 상태로 남아 다음에 다시 시도)",
+                    "type": "integer"
+                },
+                "replayed": {
+                    "description": "fit group 생성 후 다시 처리한 이벤트 수",
+                    "type": "integer"
+                },
+                "superseded": {
+                    "description": "같은 fit group 의 새 이벤트로 대체되어 버린 이벤트 수",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.PinnedMessage": {
+            "type": "object",
+            "properties": {
+                "fitGroupId": {
+                    "type": "integer"
+                },
+                "message": {
+                    "description": "조회 시 고정된 메시지 내용",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.ChatMessage"
+                        }
+                    ]
+                },
+                "messageId": {
+                    "type": "string"
+                },
+                "pinnedAt": {
+                    "type": "string"
+                },
+                "pinnedBy": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.PollOption": {
+            "type": "object",
+            "properties": {
+                "optionId": {
+                    "type": "integer"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "voteCount": {
+                    "type": "integer"
+                },
+                "voterUserIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "model.ReconciliationReport": {
+            "type": "object",
+            "properties": {
+                "finishedAt": {
+                    "type": "string"
+                },
+                "fitGroupCount": {
+                    "description": "확인한 fit group 수",
+                    "type": "integer"
+                },
+                "fitGroups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FitGroupReconciliation"
+                    }
+                },
+                "startedAt": {
+                    "type": "string"
+                },
+                "userCount": {
+                    "description": "확인한 사용자 수",
+                    "type": "integer"
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.UserReconciliation"
+                    }
+                }
+            }
+        },
+        "model.Reminder": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "integer"
+                },
+                "daysBefore": {
+                    "description": "CYCLE_DEADLINE, 1 이면 주기의 마지막 날",
+                    "type": "integer"
+                },
+                "disabledAt": {
+                    "description": "실행할 수 없어(fit group 없음, 잘못된 설정) 비활성화된 시간과 사유. 비활성화된 리마인더는 실행하지 않음",
+                    "type": "string"
+                },
+                "disabledReason": {
+                    "type": "string"
+                },
+                "fitGroupId": {
+                    "type": "integer"
+                },
+                "lastRunAt": {
+                    "type": "string"
+                },
+                "message": {
+                    "description": "CYCLE_DEADLINE 은 비어 있으면 인증 현황만 보냄",
+                    "type": "string"
+                },
+                "nextRunAt": {
+                    "type": "string"
+                },
+                "recurrence": {
+                    "description": "DAILY, WEEKLY, CYCLE_DEADLINE",
+                    "type": "string"
+                },
+                "reminderId": {
+                    "type": "integer"
+                },
+                "timeOfDay": {
+                    "description": "HH:MM",
+                    "type": "string"
+                },
+                "weekdays": {
+                    "description": "WEEKLY, 0: 일요일 ~ 6: 토요일",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "model.RestrictionType": {
+            "type": "string",
+            "enum": [
+                "MUTE",
+                "BAN"
+            ],
+            "x-enum-comments": {
+                "RestrictionBan": "채팅방 입장 불가",
+                "RestrictionMute": "채팅방 입장은 가능하지만 메시지 전송 불가"
+            },
+            "x-enum-varnames": [
+                "RestrictionMute",
+                "RestrictionBan"
             ]
+        },
+        "model.RetentionPolicy": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "string"
+                },
+                "fitGroupId": {
+                    "type": "integer"
+                },
+                "retentionDays": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "updatedBy": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.SendFrameResponse": {
+            "type": "object",
+            "properties": {
+                "frames": {
+                    "type": "array",
+                    "items": {}
+                }
+            }
+        },
+        "model.UserReconciliation": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
+                }
+            }
         }
     }
 }`
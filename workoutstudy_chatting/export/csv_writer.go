@@ -0,0 +1,61 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"workoutstudy_chatting/model"
+)
+
+type csvWriter struct {
+	out io.Writer
+	w   *csv.Writer
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{out: w, w: csv.NewWriter(w)}
+}
+
+func (cw *csvWriter) ContentType() string   { return "text/csv; charset=utf-8" }
+func (cw *csvWriter) FileExtension() string { return "csv" }
+
+func (cw *csvWriter) Begin(fitGroup *model.FitGroup) error {
+	// 엑셀에서 한글이 깨지지 않도록 UTF-8 BOM 을 먼저 기록
+	if _, err := cw.out.Write([]byte("\xEF\xBB\xBF")); err != nil {
+		return err
+	}
+	return cw.w.Write([]string{"messageId", "messageTime", "userId", "senderNickname", "messageType", "message", "attachments", "deletedAt"})
+}
+
+func (cw *csvWriter) WriteEntry(entry model.ChatHistoryEntry) error {
+	deletedAt := ""
+	if entry.DeletedAt != nil {
+		deletedAt = entry.DeletedAt.Format(time.RFC3339)
+	}
+	return cw.w.Write([]string{
+		entry.ID,
+		entry.MessageTime.Format(time.RFC3339),
+		strconv.Itoa(entry.UserID),
+		escapeFormula(entry.SenderNickname),
+		string(entry.MessageType),
+		escapeFormula(entry.Message),
+		strings.Join(attachments(entry.Message), " "),
+		deletedAt,
+	})
+}
+
+// escapeFormula 는 사용자가 입력한 값이 스프레드시트에서 수식으로 실행되지 않도록
+// 수식 시작 문자로 시작하면 앞에 ' 를 붙입니다.
+func escapeFormula(cell string) string {
+	if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+		return "'" + cell
+	}
+	return cell
+}
+
+func (cw *csvWriter) End() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
@@ -0,0 +1,70 @@
+package export
+
+import (
+	"encoding/csv"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"workoutstudy_chatting/model"
+)
+
+func TestCSVWriter(t *testing.T) {
+	deletedAt := testMessageTime.Add(time.Hour)
+	deleted := historyEntry(2, "지운 메시지")
+	deleted.DeletedAt = &deletedAt
+	ticket := historyEntry(3, "오늘 인증 https://cdn.example.com/a.png https://example.com/b")
+	ticket.MessageType = model.Ticket
+
+	out := writeAll(t, model.ExportCSV, historyEntry(1, "쉼표, \"따옴표\"\n줄바꿈"), deleted, ticket)
+	if !strings.HasPrefix(out, "\xEF\xBB\xBF") {
+		t.Fatalf("output does not start with a UTF-8 BOM: %q", out)
+	}
+	records, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(out, "\xEF\xBB\xBF"))).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v\n%s", err, out)
+	}
+
+	want := [][]string{
+		{"messageId", "messageTime", "userId", "senderNickname", "messageType", "message", "attachments", "deletedAt"},
+		{"m1", "2024-05-01T09:31:00Z", "1", "user1", "CHATTING", "쉼표, \"따옴표\"\n줄바꿈", "", ""},
+		{"m2", "2024-05-01T09:32:00Z", "2", "user2", "CHATTING", "지운 메시지", "", "2024-05-01T10:30:00Z"},
+		{"m3", "2024-05-01T09:33:00Z", "3", "user3", "TICKET", "오늘 인증 https://cdn.example.com/a.png https://example.com/b", "https://cdn.example.com/a.png https://example.com/b", ""},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Fatalf("records = %q, want %q", records, want)
+	}
+}
+
+func TestCSVWriterEscapesFormulas(t *testing.T) {
+	tests := []struct {
+		name     string
+		nickname string
+		message  string
+		want     []string // senderNickname, message
+	}{
+		{name: "=", nickname: "user", message: `=HYPERLINK("http://evil.io","click")`, want: []string{"user", `'=HYPERLINK("http://evil.io","click")`}},
+		{name: "+", nickname: "user", message: "+1 오늘도 완료", want: []string{"user", "'+1 오늘도 완료"}},
+		{name: "-", nickname: "user", message: "-2+3", want: []string{"user", "'-2+3"}},
+		{name: "@", nickname: "@SUM(A1)", message: "안녕", want: []string{"'@SUM(A1)", "안녕"}},
+		{name: "탭", nickname: "user", message: "\t=1", want: []string{"user", "'\t=1"}},
+		{name: "중간의 = 는 그대로", nickname: "user", message: "1+1=2", want: []string{"user", "1+1=2"}},
+		{name: "빈 값", nickname: "", message: "", want: []string{"", ""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := historyEntry(1, tt.message)
+			entry.SenderNickname = tt.nickname
+			out := writeAll(t, model.ExportCSV, entry)
+			records, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(out, "\xEF\xBB\xBF"))).ReadAll()
+			if err != nil || len(records) != 2 {
+				t.Fatalf("ReadAll = %q, %v", records, err)
+			}
+			if got := []string{records[1][3], records[1][5]}; !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("cells = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package export
+
+import (
+	"bufio"
+	"html/template"
+	"io"
+	"time"
+	"workoutstudy_chatting/model"
+)
+
+// 외부 리소스 없이 파일 하나로 열람할 수 있도록 스타일을 인라인으로 포함
+var htmlTemplates = template.Must(template.New("header").Parse(`<!DOCTYPE html>
+<html lang="ko">
+<head>
+<meta charset="utf-8">
+<title>{{.FitGroupName}} 채팅 내역</title>
+<style>
+body{font-family:-apple-system,"Apple SD Gothic Neo","Malgun Gothic",sans-serif;background:#f4f5f7;margin:0;padding:24px;color:#222}
+h1{font-size:20px;margin:0 0 4px}
+.meta{color:#777;font-size:12px;margin-bottom:24px}
+.msg{background:#fff;border-radius:8px;padding:10px 14px;margin:0 0 8px;max-width:720px}
+.msg .sender{font-weight:600;font-size:13px}
+.msg .time{color:#999;font-size:11px;margin-left:6px}
+.msg .body{margin-top:4px;white-space:pre-wrap;word-break:break-word}
+.msg.ticket{border-left:4px solid #3b82f6}
+.msg.ticket .badge{display:inline-block;background:#3b82f6;color:#fff;font-size:11px;border-radius:4px;padding:1px 6px;margin-left:6px}
+.msg.deleted{opacity:.55}
+.msg.deleted .badge-deleted{display:inline-block;background:#ef4444;color:#fff;font-size:11px;border-radius:4px;padding:1px 6px;margin-left:6px}
+.attachments img{max-width:240px;border-radius:6px;margin-top:6px;display:block}
+.attachments a{font-size:12px}
+</style>
+</head>
+<body>
+<h1>{{.FitGroupName}}</h1>
+<div class="meta">fit group #{{.FitGroupID}} · 내보낸 시간 {{.ExportedAt}}</div>
+`))
+
+var htmlEntryTemplate = template.Must(template.New("entry").Parse(`<div class="msg{{if .Ticket}} ticket{{end}}{{if .Deleted}} deleted{{end}}">
+<span class="sender">{{.Sender}}</span><span class="time">{{.Time}}</span>{{if .Ticket}}<span class="badge">운동 인증</span>{{end}}{{if .Deleted}}<span class="badge-deleted">삭제됨</span>{{end}}
+<div class="body">{{.Message}}</div>
+{{if .Attachments}}<div class="attachments">{{range .Attachments}}{{if .Image}}<a href="{{.URL}}"><img src="{{.URL}}" alt="첨부 이미지"></a>{{else}}<a href="{{.URL}}">{{.URL}}</a>{{end}}{{end}}</div>{{end}}
+</div>
+`))
+
+type htmlWriter struct {
+	w *bufio.Writer
+}
+
+type htmlAttachment struct {
+	URL   string
+	Image bool
+}
+
+func newHTMLWriter(w io.Writer) *htmlWriter {
+	return &htmlWriter{w: bufio.NewWriter(w)}
+}
+
+func (hw *htmlWriter) ContentType() string   { return "text/html; charset=utf-8" }
+func (hw *htmlWriter) FileExtension() string { return "html" }
+
+func (hw *htmlWriter) Begin(fitGroup *model.FitGroup) error {
+	return htmlTemplates.Execute(hw.w, map[string]interface{}{
+		"FitGroupID":   fitGroup.ID,
+		"FitGroupName": fitGroup.FitGroupName,
+		"ExportedAt":   time.Now().Format("2006-01-02 15:04"),
+	})
+}
+
+func (hw *htmlWriter) WriteEntry(entry model.ChatHistoryEntry) error {
+	var files []htmlAttachment
+	for _, url := range attachments(entry.Message) {
+		files = append(files, htmlAttachment{URL: url, Image: isImage(url)})
+	}
+	sender := entry.SenderNickname
+	if sender == "" {
+		sender = "알 수 없음"
+	}
+	return htmlEntryTemplate.Execute(hw.w, map[string]interface{}{
+		"Sender":      sender,
+		"Time":        entry.MessageTime.Format("2006-01-02 15:04"),
+		"Message":     entry.Message,
+		"Ticket":      entry.MessageType == model.Ticket,
+		"Deleted":     entry.DeletedAt != nil,
+		"Attachments": files,
+	})
+}
+
+func (hw *htmlWriter) End() error {
+	if _, err := hw.w.WriteString("</body>\n</html>\n"); err != nil {
+		return err
+	}
+	return hw.w.Flush()
+}
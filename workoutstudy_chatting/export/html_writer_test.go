@@ -0,0 +1,58 @@
+package export
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"workoutstudy_chatting/model"
+)
+
+func TestHTMLWriterEscapesContent(t *testing.T) {
+	deletedAt := testMessageTime
+	script := historyEntry(1, `<script>alert("x")</script> & <b>굵게</b>`)
+	script.SenderNickname = `<img src=x onerror=alert(1)>`
+	ticket := historyEntry(2, `인증 https://cdn.example.com/a.png?v=1'onload='x https://example.com/doc`)
+	ticket.MessageType = model.Ticket
+	ticket.SenderNickname = ""
+	deleted := historyEntry(3, "지운 메시지")
+	deleted.DeletedAt = &deletedAt
+
+	out := writeAll(t, model.ExportHTML, script, ticket, deleted)
+
+	mustContain := []string{
+		"<title>아침 &lt;러닝&gt; &amp; 요가 채팅 내역</title>",
+		"fit group #12",
+		`&lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt; &amp; &lt;b&gt;굵게&lt;/b&gt;`,
+		`<span class="sender">&lt;img src=x onerror=alert(1)&gt;</span>`,
+		`<span class="sender">알 수 없음</span>`,
+		`<div class="msg ticket">`,
+		`<img src="https://cdn.example.com/a.png?v=1%27onload=%27x" alt="첨부 이미지">`,
+		`<a href="https://example.com/doc">https://example.com/doc</a>`,
+		`<div class="msg deleted">`,
+		"</body>\n</html>\n",
+	}
+	for _, want := range mustContain {
+		if !strings.Contains(out, want) {
+			t.Errorf("output does not contain %q", want)
+		}
+	}
+	for _, unsafe := range []string{"<script>", "<b>", "<img src=x", "'onload='"} {
+		if strings.Contains(out, unsafe) {
+			t.Errorf("output contains unescaped %q", unsafe)
+		}
+	}
+	if t.Failed() {
+		t.Log(out)
+	}
+}
+
+func TestHTMLWriterWithoutEntries(t *testing.T) {
+	out := writeAll(t, model.ExportHTML)
+	if strings.Contains(out, `class="msg`) || !strings.HasSuffix(out, "</body>\n</html>\n") {
+		t.Fatalf("empty export = %s", out)
+	}
+	if !strings.Contains(out, time.Now().Format("2006-01-02")) {
+		t.Fatalf("empty export has no export date: %s", out)
+	}
+}
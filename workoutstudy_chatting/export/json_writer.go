@@ -0,0 +1,65 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"time"
+	"workoutstudy_chatting/model"
+)
+
+type jsonWriter struct {
+	w     *bufio.Writer
+	count int
+}
+
+type jsonEntry struct {
+	model.ChatHistoryEntry
+	Attachments []string `json:"attachments,omitempty"`
+}
+
+func newJSONWriter(w io.Writer) *jsonWriter {
+	return &jsonWriter{w: bufio.NewWriter(w)}
+}
+
+func (jw *jsonWriter) ContentType() string   { return "application/json; charset=utf-8" }
+func (jw *jsonWriter) FileExtension() string { return "json" }
+
+func (jw *jsonWriter) Begin(fitGroup *model.FitGroup) error {
+	header, err := json.Marshal(struct {
+		FitGroupID   int       `json:"fitGroupId"`
+		FitGroupName string    `json:"fitGroupName"`
+		ExportedAt   time.Time `json:"exportedAt"`
+	}{fitGroup.ID, fitGroup.FitGroupName, time.Now()})
+	if err != nil {
+		return err
+	}
+	// 마지막 '}' 를 떼어내고 messages 배열을 이어서 기록
+	if _, err := jw.w.Write(header[:len(header)-1]); err != nil {
+		return err
+	}
+	_, err = jw.w.WriteString(`,"messages":[`)
+	return err
+}
+
+func (jw *jsonWriter) WriteEntry(entry model.ChatHistoryEntry) error {
+	data, err := json.Marshal(jsonEntry{ChatHistoryEntry: entry, Attachments: attachments(entry.Message)})
+	if err != nil {
+		return err
+	}
+	if jw.count > 0 {
+		if err := jw.w.WriteByte(','); err != nil {
+			return err
+		}
+	}
+	jw.count++
+	_, err = jw.w.Write(data)
+	return err
+}
+
+func (jw *jsonWriter) End() error {
+	if _, err := jw.w.WriteString("]}\n"); err != nil {
+		return err
+	}
+	return jw.w.Flush()
+}
@@ -0,0 +1,74 @@
+package export
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"workoutstudy_chatting/model"
+)
+
+func TestJSONWriterProducesValidJSON(t *testing.T) {
+	tests := []struct {
+		name            string
+		entries         []model.ChatHistoryEntry
+		wantIDs         []string
+		wantAttachments [][]string
+	}{
+		{name: "메시지 없음", wantIDs: []string{}, wantAttachments: [][]string{}},
+		{
+			name:            "메시지 1건",
+			entries:         []model.ChatHistoryEntry{historyEntry(1, `인증 "완료" https://cdn.example.com/a.png`)},
+			wantIDs:         []string{"m1"},
+			wantAttachments: [][]string{{"https://cdn.example.com/a.png"}},
+		},
+		{
+			name:            "메시지 여러 건",
+			entries:         []model.ChatHistoryEntry{historyEntry(1, "하나"), historyEntry(2, "둘\n줄바꿈"), historyEntry(3, "</script>")},
+			wantIDs:         []string{"m1", "m2", "m3"},
+			wantAttachments: [][]string{nil, nil, nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := writeAll(t, model.ExportJSON, tt.entries...)
+			var doc struct {
+				FitGroupID   int    `json:"fitGroupId"`
+				FitGroupName string `json:"fitGroupName"`
+				ExportedAt   string `json:"exportedAt"`
+				Messages     []struct {
+					ID             string   `json:"messageId"`
+					Message        string   `json:"message"`
+					MessageTime    string   `json:"messageTime"`
+					SenderNickname string   `json:"senderNickname"`
+					Attachments    []string `json:"attachments"`
+				} `json:"messages"`
+			}
+			if err := json.Unmarshal([]byte(out), &doc); err != nil {
+				t.Fatalf("invalid JSON: %v\n%s", err, out)
+			}
+			if doc.FitGroupID != testFitGroup.ID || doc.FitGroupName != testFitGroup.FitGroupName || doc.ExportedAt == "" {
+				t.Fatalf("header = %d %q %q", doc.FitGroupID, doc.FitGroupName, doc.ExportedAt)
+			}
+			if doc.Messages == nil {
+				t.Fatalf("messages is missing or null: %s", out)
+			}
+
+			ids := []string{}
+			attachments := [][]string{}
+			for i, msg := range doc.Messages {
+				ids = append(ids, msg.ID)
+				attachments = append(attachments, msg.Attachments)
+				want := tt.entries[i]
+				if msg.Message != want.Message || msg.SenderNickname != want.SenderNickname || msg.MessageTime != want.MessageTime.Format(time.RFC3339) {
+					t.Fatalf("message %d = %+v, want %+v", i, msg, want)
+				}
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) || !reflect.DeepEqual(attachments, tt.wantAttachments) {
+				t.Fatalf("messages = %v %v, want %v %v", ids, attachments, tt.wantIDs, tt.wantAttachments)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+	"workoutstudy_chatting/model"
+)
+
+// Writer 는 채팅 내역을 한 건씩 스트리밍으로 기록합니다.
+// Begin -> WriteEntry (0회 이상) -> End 순서로 호출해야 합니다.
+type Writer interface {
+	ContentType() string
+	FileExtension() string
+	Begin(fitGroup *model.FitGroup) error
+	WriteEntry(entry model.ChatHistoryEntry) error
+	End() error
+}
+
+// NewWriter 는 format 에 맞는 Writer 를 생성합니다.
+func NewWriter(format model.ExportFormat, w io.Writer) (Writer, error) {
+	switch format {
+	case model.ExportJSON:
+		return newJSONWriter(w), nil
+	case model.ExportCSV:
+		return newCSVWriter(w), nil
+	case model.ExportHTML:
+		return newHTMLWriter(w), nil
+	default:
+		return nil, fmt.Errorf("unsupported export format: %q", format)
+	}
+}
+
+// FileName 은 다운로드 파일 이름을 생성합니다. ex) fit-group-12-chat-20240501.csv
+func FileName(fitGroupID int, w Writer, now time.Time) string {
+	return fmt.Sprintf("fit-group-%d-chat-%s.%s", fitGroupID, now.Format("20060102"), w.FileExtension())
+}
+
+var urlPattern = regexp.MustCompile(`https?://[^\s<>"]+`)
+
+// attachments 는 메시지 본문에 포함된 URL 을 첨부파일로 간주하여 추출합니다.
+func attachments(message string) []string {
+	return urlPattern.FindAllString(message, -1)
+}
+
+func isImage(url string) bool {
+	lower := strings.ToLower(url)
+	if i := strings.IndexAny(lower, "?#"); i >= 0 {
+		lower = lower[:i]
+	}
+	for _, ext := range []string{".jpg", ".jpeg", ".png", ".gif", ".webp"} {
+		if strings.HasSuffix(lower, ext) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"workoutstudy_chatting/model"
+)
+
+var testFitGroup = &model.FitGroup{ID: 12, FitGroupName: "아침 <러닝> & 요가"}
+
+var testMessageTime = time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)
+
+func historyEntry(n int, message string) model.ChatHistoryEntry {
+	return model.ChatHistoryEntry{
+		ChatMessage: model.ChatMessage{
+			ID:          fmt.Sprintf("m%d", n),
+			UserID:      n,
+			FitGroupID:  testFitGroup.ID,
+			Message:     message,
+			MessageTime: testMessageTime.Add(time.Duration(n) * time.Minute),
+			MessageType: model.Chatting,
+		},
+		SenderNickname: fmt.Sprintf("user%d", n),
+	}
+}
+
+// writeAll 은 format 의 Writer 로 entries 를 Begin -> WriteEntry -> End 순서로 기록한 결과를 반환합니다.
+func writeAll(t *testing.T, format model.ExportFormat, entries ...model.ChatHistoryEntry) string {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := NewWriter(format, &buf)
+	if err != nil {
+		t.Fatalf("NewWriter(%s): %v", format, err)
+	}
+	if err := w.Begin(testFitGroup); err != nil {
+		t.Fatalf("Begin: %v", err)
+	}
+	for _, entry := range entries {
+		if err := w.WriteEntry(entry); err != nil {
+			t.Fatalf("WriteEntry: %v", err)
+		}
+	}
+	if err := w.End(); err != nil {
+		t.Fatalf("End: %v", err)
+	}
+	return buf.String()
+}
+
+func TestNewWriter(t *testing.T) {
+	tests := []struct {
+		format          model.ExportFormat
+		wantContentType string
+		wantFileName    string
+	}{
+		{model.ExportJSON, "application/json; charset=utf-8", "fit-group-12-chat-20240501.json"},
+		{model.ExportCSV, "text/csv; charset=utf-8", "fit-group-12-chat-20240501.csv"},
+		{model.ExportHTML, "text/html; charset=utf-8", "fit-group-12-chat-20240501.html"},
+	}
+	for _, tt := range tests {
+		w, err := NewWriter(tt.format, &bytes.Buffer{})
+		if err != nil {
+			t.Fatalf("NewWriter(%s): %v", tt.format, err)
+		}
+		if w.ContentType() != tt.wantContentType || FileName(12, w, testMessageTime) != tt.wantFileName {
+			t.Fatalf("%s writer = %q, %q", tt.format, w.ContentType(), FileName(12, w, testMessageTime))
+		}
+	}
+	if _, err := NewWriter("xml", &bytes.Buffer{}); err == nil {
+		t.Fatal("NewWriter(xml) succeeded, want unsupported format error")
+	}
+}
@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+	"workoutstudy_chatting/export"
+	"workoutstudy_chatting/model"
+	"workoutstudy_chatting/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ChatExportHandler struct {
+	ChatExportService service.ChatExportUseCase
+}
+
+func NewChatExportHandler(chatExportService service.ChatExportUseCase) *ChatExportHandler {
+	return &ChatExportHandler{ChatExportService: chatExportService}
+}
+
+// @Summary 채팅 내역 내보내기 API
+// @Description 피트그룹의 전체 채팅 내역을 JSON, CSV 또는 HTML 파일로 내려받습니다. 피트그룹 멤버만 요청할 수 있습니다.
+// @Description includeDeleted 옵션은 fit leader 만 사용할 수 있습니다.
+// @Tags message
+// @Produce  json
+// @Produce  text/csv
+// @Produce  text/html
+// @Param fitGroupId query int true "피트그룹 ID"
+// @Param userId query int true "요청 사용자 ID"
+// @Param format query string false "내보내기 형식 (json, csv, html). 기본값 json"
+// @Param includeDeleted query bool false "삭제된 메시지 포함 여부 (fit leader 전용)"
+// @Success 200 {file} file
+// @Router /export/message [get]
+func (h *ChatExportHandler) ExportChatHistory(c *gin.Context) {
+	fitGroupID, err := strconv.Atoi(c.Query("fitGroupId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 fit-group-id"})
+		return
+	}
+	userID, err := strconv.Atoi(c.Query("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 userId"})
+		return
+	}
+	includeDeleted, _ := strconv.ParseBool(c.DefaultQuery("includeDeleted", "false"))
+
+	opts := model.ChatExportOptions{
+		FitGroupID:     fitGroupID,
+		UserID:         userID,
+		Format:         model.ExportFormat(c.DefaultQuery("format", string(model.ExportJSON))),
+		IncludeDeleted: includeDeleted,
+	}
+
+	writer, err := export.NewWriter(opts.Format, c.Writer)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "지원하지 않는 내보내기 형식"})
+		return
+	}
+
+	fitGroup, err := h.ChatExportService.AuthorizeExport(opts)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrNotFitGroupMember), errors.Is(err, service.ErrNotFitLeader):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		default:
+			log.Printf("Error authorizing chat export: %v", err)
+			c.JSON(http.StatusNotFound, gin.H{"error": "피트그룹 조회 실패"})
+		}
+		return
+	}
+
+	c.Header("Content-Type", writer.ContentType())
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, export.FileName(fitGroupID, writer, time.Now())))
+	c.Status(http.StatusOK)
+
+	// 스트리밍 도중 에러가 나면 이미 헤더가 전송되었으므로 로그만 남김
+	if err := h.ChatExportService.ExportChatHistory(fitGroup, opts, writer); err != nil {
+		log.Printf("Error exporting chat history for fit group %d: %v", fitGroupID, err)
+	}
+}
@@ -0,0 +1,337 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+	"workoutstudy_chatting/model"
+	"workoutstudy_chatting/service"
+	"workoutstudy_chatting/util"
+	"workoutstudy_chatting/wire"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 웹소켓을 사용할 수 없는 네트워크를 위한 SSE, long-poll 수신과 REST 전송.
+// 모두 웹소켓과 같은 Room 에 Client 로 등록되고, 전송은 handleFrame 으로 같은 저장/브로드캐스트 경로를 사용합니다.
+const (
+	sseHeartbeatInterval = 25 * time.Second
+	sseQueueLimit        = 256
+	longPollTimeout      = 25 * time.Second
+	longPollQueueLimit   = 200
+	// 이 시간 동안 다음 요청이 없으면 long-poll 세션을 Room 에서 제거
+	longPollSessionTTL = time.Minute
+	// 사용자 + 채팅방 당 long-poll 세션 수 (여러 탭, 기기). 넘으면 가장 오래 조회하지 않은 세션을 만료
+	maxLongPollSessionsPerMember = 3
+	maxSendFrameBytes            = 64 << 10
+)
+
+// longPollSession 은 요청 사이에도 Room 에 등록되어 프레임을 쌓아두는 long-poll 수신자입니다.
+type longPollSession struct {
+	id       string
+	client   *Client
+	lastPoll time.Time
+}
+
+// longPollMember 는 long-poll 세션 수를 제한하는 단위(사용자 + 채팅방)입니다.
+type longPollMember struct {
+	userID     int
+	fitGroupID int
+}
+
+var (
+	longPollLock     sync.Mutex
+	longPollSessions = make(map[string]*longPollSession)
+	longPollMembers  = make(map[longPollMember][]*longPollSession)
+)
+
+// fallbackClient 는 Room 에 등록할 웹소켓이 아닌 Client 를 만듭니다. 잘못된 요청이거나 차단된 사용자면 응답을 쓰고 false 를 반환합니다.
+func (h *ChatHandler) fallbackClient(c *gin.Context, queueLimit int) (*Client, bool) {
+	fitGroupID, err := strconv.Atoi(c.Query("fitGroupId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 fit-group-id"})
+		return nil, false
+	}
+	userID, err := strconv.Atoi(c.Query("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 userId"})
+		return nil, false
+	}
+	if errors.Is(h.ModerationService.CheckRestriction(fitGroupID, userID), service.ErrBanned) {
+		c.JSON(http.StatusForbidden, gin.H{"error": service.ErrBanned.Error()})
+		return nil, false
+	}
+	return &Client{userID: userID, fitGroupID: fitGroupID, queue: newFrameQueue(queueLimit)}, true
+}
+
+// @Summary SSE chat stream
+// @Description 웹소켓을 사용할 수 없을 때 Server-Sent Events 로 채팅방 프레임을 받습니다.
+// @Description 각 이벤트의 data 는 채팅방 웹소켓(/chat)과 같은 형식의 JSON 이며, 연결 유지를 위해 주기적으로 주석(: ping)을 보냅니다.
+// @Description 메시지 전송은 POST /chat/message 를 사용합니다.
+// @Tags chat
+// @Produce text/event-stream
+// @Param fitGroupId query int true "피트그룹 ID"
+// @Param userId query int true "사용자 ID"
+// @Success 200 {string} string "event stream"
+// @Failure 400 {object} map[string]string "잘못된 요청"
+// @Failure 403 {object} map[string]string "차단된 사용자"
+// @Router /chat/sse [get]
+func (h *ChatHandler) StreamEvents(c *gin.Context) {
+	if !acceptConnection(c) {
+		return
+	}
+	defer activeConnections.done()
+
+	client, ok := h.fallbackClient(c, sseQueueLimit)
+	if !ok {
+		return
+	}
+	joinRoom(strconv.Itoa(client.fitGroupID), client)
+	defer leaveRoom(client)
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // 프록시 버퍼링 방지
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	ctx := c.Request.Context()
+	var cursor int64
+	for {
+		frames, closed := client.queue.wait(ctx, cursor, sseHeartbeatInterval)
+		if ctx.Err() != nil || closed {
+			return
+		}
+		if len(frames) == 0 {
+			if _, err := io.WriteString(c.Writer, ": ping\n\n"); err != nil {
+				return
+			}
+		}
+		for _, f := range frames {
+			data, err := json.Marshal(f.Frame)
+			if err != nil {
+				log.Printf("SSE 프레임 JSON 변환 실패: %v", err)
+				continue
+			}
+			if _, err := fmt.Fprintf(c.Writer, "id: %d\ndata: %s\n\n", f.Seq, data); err != nil {
+				return
+			}
+			cursor = f.Seq
+		}
+		c.Writer.Flush()
+	}
+}
+
+// @Summary long-poll chat
+// @Description 웹소켓과 SSE 를 모두 사용할 수 없을 때 long-poll 로 채팅방 프레임을 받습니다.
+// @Description sessionId 없이 요청하면 세션을 만들고 바로 응답합니다. 이후 응답의 sessionId, cursor 로 다시 요청하면 새 프레임이 생기거나 25초가 지날 때까지 기다립니다.
+// @Description 1분 동안 요청이 없거나 밀린 프레임이 너무 많으면 세션이 만료되어 410 을 응답하므로 메시지를 다시 조회한 뒤 새 세션을 만들어야 합니다.
+// @Description 같은 사용자와 피트그룹의 세션은 3개까지 유지하며, 새 세션을 만들면 가장 오래 조회하지 않은 세션이 만료됩니다.
+// @Tags chat
+// @Produce json
+// @Param fitGroupId query int true "피트그룹 ID"
+// @Param userId query int true "사용자 ID"
+// @Param sessionId query string false "long-poll 세션 ID"
+// @Param cursor query int false "마지막으로 받은 프레임 순번"
+// @Success 200 {object} model.LongPollResponse
+// @Failure 400 {object} map[string]string "잘못된 요청"
+// @Failure 403 {object} map[string]string "차단된 사용자"
+// @Failure 410 {object} map[string]string "만료된 세션"
+// @Router /chat/poll [get]
+func (h *ChatHandler) LongPoll(c *gin.Context) {
+	if !acceptConnection(c) {
+		return
+	}
+	defer activeConnections.done()
+
+	sessionID := c.Query("sessionId")
+	if sessionID == "" {
+		client, ok := h.fallbackClient(c, longPollQueueLimit)
+		if !ok {
+			return
+		}
+		session := &longPollSession{id: util.NewUUID(), client: client, lastPoll: time.Now()}
+		joinRoom(strconv.Itoa(client.fitGroupID), client)
+		for _, evicted := range addLongPollSession(session) {
+			removeLongPollSession(evicted)
+		}
+		c.JSON(http.StatusOK, model.LongPollResponse{SessionID: session.id, Cursor: client.queue.cursor(), Frames: []interface{}{}})
+		return
+	}
+
+	cursor, err := strconv.ParseInt(c.DefaultQuery("cursor", "0"), 10, 64)
+	if err != nil || cursor < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 cursor"})
+		return
+	}
+	longPollLock.Lock()
+	session := longPollSessions[sessionID]
+	if session != nil {
+		session.lastPoll = time.Now()
+	}
+	longPollLock.Unlock()
+	// 다른 사용자의 세션을 가로채지 못하도록 연결 정보도 확인
+	if session == nil || strconv.Itoa(session.client.userID) != c.Query("userId") || strconv.Itoa(session.client.fitGroupID) != c.Query("fitGroupId") {
+		c.JSON(http.StatusGone, gin.H{"error": "만료된 long-poll 세션입니다."})
+		return
+	}
+
+	frames, closed := session.client.queue.wait(c.Request.Context(), cursor, longPollTimeout)
+	if closed {
+		removeLongPollSession(session)
+		c.JSON(http.StatusGone, gin.H{"error": "만료된 long-poll 세션입니다."})
+		return
+	}
+	longPollLock.Lock()
+	session.lastPoll = time.Now()
+	longPollLock.Unlock()
+
+	resp := model.LongPollResponse{SessionID: session.id, Cursor: cursor, Frames: make([]interface{}, 0, len(frames))}
+	for _, f := range frames {
+		resp.Frames = append(resp.Frames, f.Frame)
+		resp.Cursor = f.Seq
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// addLongPollSession 은 세션을 등록하고, 사용자 + 채팅방 당 세션 수를 넘으면 가장 오래 조회하지 않은 세션부터 반환합니다.
+// 반환한 세션은 호출자가 removeLongPollSession 으로 제거합니다.
+func addLongPollSession(session *longPollSession) []*longPollSession {
+	member := longPollMember{userID: session.client.userID, fitGroupID: session.client.fitGroupID}
+	longPollLock.Lock()
+	defer longPollLock.Unlock()
+
+	longPollSessions[session.id] = session
+	sessions := append(longPollMembers[member], session)
+	var evicted []*longPollSession
+	for len(sessions) > maxLongPollSessionsPerMember {
+		oldest := 0
+		for i, s := range sessions {
+			if s.lastPoll.Before(sessions[oldest].lastPoll) {
+				oldest = i
+			}
+		}
+		evicted = append(evicted, sessions[oldest])
+		sessions = append(sessions[:oldest:oldest], sessions[oldest+1:]...)
+	}
+	longPollMembers[member] = sessions
+	return evicted
+}
+
+func removeLongPollSession(session *longPollSession) {
+	member := longPollMember{userID: session.client.userID, fitGroupID: session.client.fitGroupID}
+	longPollLock.Lock()
+	delete(longPollSessions, session.id)
+	var sessions []*longPollSession
+	for _, s := range longPollMembers[member] {
+		if s != session {
+			sessions = append(sessions, s)
+		}
+	}
+	if len(sessions) == 0 {
+		delete(longPollMembers, member)
+	} else {
+		longPollMembers[member] = sessions
+	}
+	longPollLock.Unlock()
+	leaveRoom(session.client)
+}
+
+// StartLongPollReaper 는 longPollSessionTTL 동안 요청이 없는 long-poll 세션을 주기적으로 제거합니다.
+func StartLongPollReaper(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			var expired []*longPollSession
+			longPollLock.Lock()
+			for _, session := range longPollSessions {
+				if now.Sub(session.lastPoll) > longPollSessionTTL {
+					expired = append(expired, session)
+				}
+			}
+			longPollLock.Unlock()
+			for _, session := range expired {
+				removeLongPollSession(session)
+			}
+		}
+	}
+}
+
+// @Summary send chat frame
+// @Description 웹소켓 없이 채팅 메시지나 투표 프레임을 보냅니다. 본문은 채팅방 웹소켓(/chat)으로 보내는 프레임과 같습니다.
+// @Description 저장과 전송 제한, 브로드캐스트는 웹소켓과 같은 경로로 처리되며, 거부되면 에러 프레임을 해당 상태 코드로 응답합니다.
+// @Tags chat
+// @Accept json
+// @Produce json
+// @Param fitGroupId query int true "피트그룹 ID"
+// @Param userId query int true "사용자 ID"
+// @Param frame body model.ChatMessage true "채팅 메시지 또는 투표 프레임"
+// @Success 200 {object} model.SendFrameResponse
+// @Failure 400 {object} model.ChatErrorFrame "잘못된 요청"
+// @Failure 403 {object} model.ChatErrorFrame "뮤트/차단/권한 없음"
+// @Failure 429 {object} model.ChatErrorFrame "전송 제한"
+// @Router /chat/message [post]
+func (h *ChatHandler) SendFrame(c *gin.Context) {
+	if !acceptConnection(c) {
+		return
+	}
+	defer activeConnections.done()
+
+	// 보낸 사람에게만 전달되는 프레임을 응답으로 돌려주기 위해 Room 에 등록하지 않은 Client 로 처리
+	client, ok := h.fallbackClient(c, 16)
+	if !ok {
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxSendFrameBytes))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.NewChatErrorFrame(model.ErrorInvalidMessage, "잘못된 메시지 형식입니다.", ""))
+		return
+	}
+
+	frame, err := wire.DecodeJSON(body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.NewChatErrorFrame(model.ErrorInvalidMessage, "잘못된 메시지 형식입니다.", ""))
+		return
+	}
+	h.handleFrame(client, client.fitGroupID, frame)
+	client.queue.close()
+
+	frames, _ := client.queue.wait(c.Request.Context(), 0, 0)
+	resp := model.SendFrameResponse{Frames: make([]interface{}, 0, len(frames))}
+	for _, f := range frames {
+		if errFrame, ok := f.Frame.(model.ChatErrorFrame); ok {
+			c.JSON(chatErrorStatus(errFrame.Code), errFrame)
+			return
+		}
+		resp.Frames = append(resp.Frames, f.Frame)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// chatErrorStatus 는 에러 프레임 코드에 맞는 HTTP 상태 코드를 반환합니다.
+func chatErrorStatus(code model.ChatErrorCode) int {
+	switch code {
+	case model.ErrorRateLimited, model.ErrorSlowMode, model.ErrorDuplicateMessage:
+		return http.StatusTooManyRequests
+	case model.ErrorMuted, model.ErrorBanned, model.ErrorNotAllowed:
+		return http.StatusForbidden
+	case model.ErrorPollClosed:
+		return http.StatusConflict
+	case model.ErrorSaveFailed:
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,268 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"workoutstudy_chatting/model"
+	"workoutstudy_chatting/persistence"
+	"workoutstudy_chatting/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestAddLongPollSessionEvictsLeastRecentlyPolled(t *testing.T) {
+	base := time.Now()
+	type session struct {
+		id         string
+		userID     int
+		fitGroupID int
+		polledAgo  time.Duration // base 기준 마지막 조회 시점
+	}
+	tests := []struct {
+		name        string
+		existing    []session
+		added       session
+		wantEvicted []string
+	}{
+		{
+			name:     "상한 이하면 유지",
+			existing: []session{{"a", 1, 1, 3 * time.Second}, {"b", 1, 1, 2 * time.Second}},
+			added:    session{"c", 1, 1, 0},
+		},
+		{
+			name:        "가장 오래 조회하지 않은 세션을 만료",
+			existing:    []session{{"a", 1, 1, time.Second}, {"b", 1, 1, 5 * time.Second}, {"c", 1, 1, 2 * time.Second}},
+			added:       session{"d", 1, 1, 0},
+			wantEvicted: []string{"b"},
+		},
+		{
+			name:        "먼저 만든 세션이라도 최근에 조회했으면 유지",
+			existing:    []session{{"a", 1, 1, 0}, {"b", 1, 1, 3 * time.Second}, {"c", 1, 1, 4 * time.Second}},
+			added:       session{"d", 1, 1, time.Second},
+			wantEvicted: []string{"c"},
+		},
+		{
+			name: "다른 사용자나 다른 채팅방 세션은 세지 않음",
+			existing: []session{
+				{"a", 1, 1, 9 * time.Second}, {"b", 1, 1, 8 * time.Second},
+				{"other-user", 2, 1, 10 * time.Second}, {"other-room", 1, 2, 10 * time.Second},
+			},
+			added: session{"c", 1, 1, 0},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 다른 테스트와 겹치지 않도록 테스트마다 사용자 ID 를 옮김
+			offset := 1000 * (i + 1)
+			newSession := func(s session) *longPollSession {
+				return &longPollSession{
+					id:       fmt.Sprintf("%d-%s", offset, s.id),
+					client:   &Client{userID: offset + s.userID, fitGroupID: offset + s.fitGroupID, queue: newFrameQueue(1)},
+					lastPoll: base.Add(-s.polledAgo),
+				}
+			}
+			var all []*longPollSession
+			for _, s := range tt.existing {
+				session := newSession(s)
+				all = append(all, session)
+				if evicted := addLongPollSession(session); len(evicted) != 0 {
+					t.Fatalf("evicted while adding existing sessions: %v", evicted)
+				}
+			}
+			added := newSession(tt.added)
+			all = append(all, added)
+			t.Cleanup(func() {
+				for _, s := range all {
+					removeLongPollSession(s)
+				}
+			})
+
+			var evicted []string
+			for _, s := range addLongPollSession(added) {
+				evicted = append(evicted, s.id)
+				removeLongPollSession(s)
+			}
+			var want []string
+			for _, id := range tt.wantEvicted {
+				want = append(want, fmt.Sprintf("%d-%s", offset, id))
+			}
+			if fmt.Sprint(evicted) != fmt.Sprint(want) {
+				t.Fatalf("evicted = %v, want %v", evicted, want)
+			}
+
+			member := longPollMember{userID: offset + 1, fitGroupID: offset + 1}
+			longPollLock.Lock()
+			remaining := len(longPollMembers[member])
+			_, addedKept := longPollSessions[added.id]
+			longPollLock.Unlock()
+			if remaining > maxLongPollSessionsPerMember || !addedKept {
+				t.Fatalf("member has %d sessions, added session kept = %v", remaining, addedKept)
+			}
+		})
+	}
+}
+
+// longPollServer 는 메모리 저장소의 채팅 제한 확인만 하는 ChatHandler 로 /chat/poll 을 처리합니다.
+func longPollServer() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	repos := persistence.NewMemoryRepositories(persistence.NewMemoryStore())
+	h := &ChatHandler{
+		ModerationService: service.NewChatModerationService(repos.ChatRestriction, repos.ModerationAudit, repos.Chat, repos.FitGroup, repos.FitMate, nil),
+	}
+	router := gin.New()
+	router.GET("/chat/poll", h.LongPoll)
+	return router
+}
+
+// poll 은 long-poll 요청을 보냅니다. 새 프레임이 없으면 wait 가 지나 빈 응답을 받습니다.
+func poll(t *testing.T, router *gin.Engine, query string, wait time.Duration) (int, model.LongPollResponse) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), wait)
+	defer cancel()
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/chat/poll?"+query, nil).WithContext(ctx))
+
+	var resp model.LongPollResponse
+	if rec.Code == http.StatusOK {
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("decode long-poll response %s: %v", rec.Body.String(), err)
+		}
+	}
+	return rec.Code, resp
+}
+
+func TestLongPollSessionCapExpiresLeastRecentlyPolled(t *testing.T) {
+	router := longPollServer()
+	const member = "fitGroupId=9101&userId=7"
+	open := func() string {
+		code, resp := poll(t, router, member, time.Second)
+		if code != http.StatusOK || resp.SessionID == "" {
+			t.Fatalf("open session = %d %+v", code, resp)
+		}
+		return resp.SessionID
+	}
+	pollSession := func(id string) int {
+		code, _ := poll(t, router, fmt.Sprintf("%s&sessionId=%s&cursor=0", member, id), 10*time.Millisecond)
+		return code
+	}
+
+	sessions := []string{open(), open(), open()}
+	t.Cleanup(func() {
+		longPollLock.Lock()
+		var all []*longPollSession
+		for _, s := range longPollSessions {
+			if s.client.fitGroupID == 9101 {
+				all = append(all, s)
+			}
+		}
+		longPollLock.Unlock()
+		for _, s := range all {
+			removeLongPollSession(s)
+		}
+	})
+
+	// 첫 세션을 조회하면 두 번째 세션이 가장 오래 조회하지 않은 세션이 됨
+	if code := pollSession(sessions[0]); code != http.StatusOK {
+		t.Fatalf("poll first session = %d", code)
+	}
+	sessions = append(sessions, open())
+
+	tests := []struct {
+		name  string
+		query string
+		want  int
+	}{
+		{name: "최근에 조회한 첫 세션", query: member + "&sessionId=" + sessions[0], want: http.StatusOK},
+		{name: "가장 오래 조회하지 않은 두 번째 세션은 만료", query: member + "&sessionId=" + sessions[1], want: http.StatusGone},
+		{name: "세 번째 세션", query: member + "&sessionId=" + sessions[2], want: http.StatusOK},
+		{name: "새 세션", query: member + "&sessionId=" + sessions[3], want: http.StatusOK},
+		{name: "다른 사용자는 세션을 쓸 수 없음", query: "fitGroupId=9101&userId=8&sessionId=" + sessions[0], want: http.StatusGone},
+		{name: "없는 세션", query: member + "&sessionId=unknown", want: http.StatusGone},
+		{name: "잘못된 cursor", query: member + "&sessionId=" + sessions[0] + "&cursor=-1", want: http.StatusBadRequest},
+		{name: "잘못된 userId 로 세션 생성", query: "fitGroupId=9101&userId=x", want: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code, _ := poll(t, router, tt.query, 10*time.Millisecond); code != tt.want {
+				t.Fatalf("GET /chat/poll?%s = %d, want %d", tt.query, code, tt.want)
+			}
+		})
+	}
+
+	longPollLock.Lock()
+	var ids []string
+	for _, s := range longPollMembers[longPollMember{userID: 7, fitGroupID: 9101}] {
+		ids = append(ids, s.id)
+	}
+	longPollLock.Unlock()
+	sort.Strings(ids)
+	want := []string{sessions[0], sessions[2], sessions[3]}
+	sort.Strings(want)
+	if !reflect.DeepEqual(ids, want) {
+		t.Fatalf("member sessions = %v, want %v", ids, want)
+	}
+}
+
+func TestLongPollReaperExpiresIdleSessions(t *testing.T) {
+	router := longPollServer()
+	const member = "fitGroupId=9102&userId=7"
+	var ids []string
+	for i := 0; i < 2; i++ {
+		code, resp := poll(t, router, member, time.Second)
+		if code != http.StatusOK {
+			t.Fatalf("open session = %d", code)
+		}
+		ids = append(ids, resp.SessionID)
+	}
+	idle, active := ids[0], ids[1]
+	t.Cleanup(func() {
+		longPollLock.Lock()
+		session := longPollSessions[active]
+		longPollLock.Unlock()
+		if session != nil {
+			removeLongPollSession(session)
+		}
+	})
+
+	longPollLock.Lock()
+	longPollSessions[idle].lastPoll = time.Now().Add(-longPollSessionTTL - time.Second)
+	longPollLock.Unlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		StartLongPollReaper(ctx, 5*time.Millisecond)
+	}()
+	deadline := time.Now().Add(time.Second)
+	for {
+		longPollLock.Lock()
+		_, exists := longPollSessions[idle]
+		longPollLock.Unlock()
+		if !exists {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("idle session was not reaped")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	cancel()
+	<-done
+
+	if code, _ := poll(t, router, member+"&sessionId="+idle, 10*time.Millisecond); code != http.StatusGone {
+		t.Fatalf("poll reaped session = %d, want 410", code)
+	}
+	if code, _ := poll(t, router, member+"&sessionId="+active, 10*time.Millisecond); code != http.StatusOK {
+		t.Fatalf("poll active session = %d, want 200", code)
+	}
+}
@@ -1,32 +1,44 @@
 package handler
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
+	"errors"
 	"log"
 	"net/http"
 	"strconv"
 	"sync"
-	"time"
+	"workoutstudy_chatting/client"
 	"workoutstudy_chatting/model"
+	"workoutstudy_chatting/ratelimit"
 	"workoutstudy_chatting/service"
 	"workoutstudy_chatting/util"
+	"workoutstudy_chatting/wire"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
 )
 
 type ChatHandler struct {
-	ChatService     service.ChatUseCase     // 인터페이스 사용
-	FitMateService  service.FitMateUseCase  // 인터페이스 사용
-	FitGroupService service.FitGroupUseCase // 인터페이스 사용
+	ChatService       service.ChatUseCase           // 인터페이스 사용
+	FitMateService    service.FitMateUseCase        // 인터페이스 사용
+	FitGroupService   service.FitGroupUseCase       // 인터페이스 사용
+	ModerationService service.ChatModerationUseCase // 뮤트/차단 확인
+	PollService       service.PollUseCase           // 투표 프레임 처리
+	CommandService    service.ChatCommandUseCase    // "/" 명령어 처리
+	connectionRate    ratelimit.Rate                // 웹소켓 연결 단위 프레임 제한
+	alarm             client.AlarmClient            // alarm-service 푸시 알림
 }
 
-func NewChatHandler(chatService service.ChatUseCase, fitMateService service.FitMateUseCase, fitGroupService service.FitGroupUseCase) *ChatHandler {
+func NewChatHandler(chatService service.ChatUseCase, fitMateService service.FitMateUseCase, fitGroupService service.FitGroupUseCase, moderationService service.ChatModerationUseCase, pollService service.PollUseCase, commandService service.ChatCommandUseCase, connectionRate ratelimit.Rate, alarm client.AlarmClient) *ChatHandler {
 	return &ChatHandler{
-		ChatService:     chatService,
-		FitMateService:  fitMateService,
-		FitGroupService: fitGroupService,
+		ChatService:       chatService,
+		FitMateService:    fitMateService,
+		FitGroupService:   fitGroupService,
+		ModerationService: moderationService,
+		PollService:       pollService,
+		CommandService:    commandService,
+		connectionRate:    connectionRate,
+		alarm:             alarm,
 	}
 }
 
@@ -36,61 +48,231 @@ var upgrader = websocket.Upgrader{
 	},
 }
 
+// chatUpgrader 는 채팅방 웹소켓에서 subprotocol(json.v1, msgpack.v1, protobuf.v1)을 협상합니다.
+// 요청하지 않은 클라이언트는 기존과 같이 JSON 텍스트 프레임을 사용합니다.
+var chatUpgrader = websocket.Upgrader{
+	CheckOrigin: func(r *http.Request) bool {
+		return true
+	},
+	Subprotocols: wire.Subprotocols,
+}
+
 type Client struct {
-	conn   *websocket.Conn
-	userID int
+	conn    *websocket.Conn
+	userID  int
+	writeMu sync.Mutex // gorilla websocket 은 동시 쓰기를 허용하지 않으므로 room 과 핸들러의 쓰기를 직렬화
+	codec   wire.Codec // 협상된 subprotocol. nil 이면 JSON
+
+	// 사용자 웹소켓(/user/chat)이 구독한 채팅방이면 설정됨. 쓰기는 fitGroupID 로 감싸 session 연결로 보냄
+	session    *userSession
+	fitGroupID int
+	// SSE, long-poll 처럼 웹소켓이 아닌 연결이면 설정됨. conn 대신 queue 에 쌓고 각 핸들러가 꺼내 전송
+	queue *frameQueue
+}
+
+func (c *Client) writeFrame(v interface{}) error {
+	if c.session != nil {
+		return c.session.writeFrame(model.NewUserSocketRoomFrame(c.fitGroupID, v))
+	}
+	if c.queue != nil {
+		return c.queue.push(v)
+	}
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	if c.codec == nil {
+		return c.conn.WriteJSON(v)
+	}
+	return writeEncoded(c.conn, c.codec, v)
+}
+
+// writeEncoded 는 협상된 subprotocol 로 프레임을 인코딩하여 보냅니다. 호출하는 쪽에서 쓰기를 직렬화해야 합니다.
+func writeEncoded(conn *websocket.Conn, codec wire.Codec, v interface{}) error {
+	data, err := codec.Encode(v)
+	if err != nil {
+		return err
+	}
+	messageType := websocket.TextMessage
+	if codec.Binary() {
+		messageType = websocket.BinaryMessage
+	}
+	return conn.WriteMessage(messageType, data)
+}
+
+// closeForRestart 는 서버 종료 시 재접속 안내를 보내고 연결을 닫습니다.
+func (c *Client) closeForRestart() {
+	switch {
+	case c.session != nil:
+		c.session.closeForRestart()
+	case c.queue != nil:
+		// 안내 프레임을 전달한 뒤 SSE, long-poll 응답이 끝나도록 queue 를 닫음
+		if err := c.queue.push(model.NewReconnectFrame(reconnectAfter.Milliseconds())); err != nil {
+			log.Printf("재접속 안내 전송 실패: %v", err)
+		}
+		c.queue.close()
+	default:
+		closeForRestart(c.conn, func(frame model.ReconnectFrame) error { return c.writeFrame(frame) })
+	}
+}
+
+// banned 는 차단된 사용자에게 에러 프레임을 보냅니다. 채팅방 웹소켓은 연결을 닫도록 false 를,
+// 사용자 웹소켓은 해당 채팅방 구독만 해지하고 true 를 반환합니다.
+func (c *Client) banned(messageID string) bool {
+	replyError(c, model.NewChatErrorFrame(model.ErrorBanned, "채팅방에서 차단되었습니다.", messageID))
+	if c.session == nil {
+		return false
+	}
+	if c.session.unsubscribe(c.fitGroupID) {
+		c.session.notify(model.FrameUnsubscribed, []int{c.fitGroupID})
+	}
+	return true
 }
 
 type Room struct {
-	clients       map[*websocket.Conn]int // 사용자 ID를 저장
+	clients       map[*Client]bool
 	broadcast     chan model.ChatMessage
-	register      chan Client
-	unregister    chan Client
+	events        chan interface{} // 모든 접속자에게 전달하는 프레임 (model.RoomEvent, 시스템 메시지)
+	kick          chan kickRequest
+	shutdown      chan struct{} // 서버 종료 시 모든 클라이언트에 재접속 안내 후 연결 종료
+	register      chan *Client
+	unregister    chan *Client
+	recipients    chan recipientsRequest // 웹훅을 보낼 사용자 조회. activeUsers 는 run 에서만 읽고 씀
+	done          chan struct{}          // run 이 종료되면 닫힘. 종료된 room 에 보내는 쪽이 영원히 대기하지 않도록 함
 	fitGroupIDStr string
 	activeUsers   map[int]bool // 현재 채팅방에 접속한 사용자 ID를 저장
 }
 
+type kickRequest struct {
+	userID int
+	reason string
+}
+
+type recipientsRequest struct {
+	senderID int
+	reply    chan []int
+}
+
 func NewRoom(fitGroupIDStr string) *Room {
 	return &Room{
 		broadcast:     make(chan model.ChatMessage),
-		register:      make(chan Client),
-		unregister:    make(chan Client),
-		clients:       make(map[*websocket.Conn]int),
+		events:        make(chan interface{}),
+		kick:          make(chan kickRequest),
+		shutdown:      make(chan struct{}),
+		register:      make(chan *Client),
+		unregister:    make(chan *Client),
+		recipients:    make(chan recipientsRequest),
+		done:          make(chan struct{}),
+		clients:       make(map[*Client]bool),
 		fitGroupIDStr: fitGroupIDStr,
 		activeUsers:   make(map[int]bool),
 	}
 }
 
 func (r *Room) run() {
+	defer close(r.done)
 	for {
 		select {
 		case client := <-r.register:
-			r.clients[client.conn] = client.userID
+			r.clients[client] = true
 			r.activeUsers[client.userID] = true
 		case client := <-r.unregister:
-			if userID, ok := r.clients[client.conn]; ok {
-				delete(r.clients, client.conn)
-				delete(r.activeUsers, userID)
-				client.conn.Close()
-				if len(r.clients) == 0 {
-					roomLock.Lock()
-					delete(rooms, r.fitGroupIDStr)
-					roomLock.Unlock()
-					return
-				}
+			if r.clients[client] {
+				r.removeClient(client)
 			}
 		case message := <-r.broadcast:
-			for conn, userID := range r.clients {
-				if userID != message.UserID {
-					err := conn.WriteJSON(message)
-					if err != nil {
+			for client := range r.clients {
+				if client.userID != message.UserID {
+					if err := client.writeFrame(message); err != nil {
 						log.Printf("error: %v", err)
-						conn.Close()
-						delete(r.clients, conn)
-						delete(r.activeUsers, userID)
+						r.removeClient(client)
 					}
 				}
 			}
+		case event := <-r.events:
+			for client := range r.clients {
+				if err := client.writeFrame(event); err != nil {
+					log.Printf("error: %v", err)
+					r.removeClient(client)
+				}
+			}
+		case <-r.shutdown:
+			for client := range r.clients {
+				client.closeForRestart()
+				r.removeClient(client)
+			}
+		case req := <-r.kick:
+			for client := range r.clients {
+				if client.userID == req.userID {
+					replyError(client, model.NewChatErrorFrame(model.ErrorBanned, req.reason, ""))
+					r.removeClient(client)
+				}
+			}
+		case req := <-r.recipients:
+			userIDs := make([]int, 0, len(r.activeUsers))
+			for id := range r.activeUsers {
+				if id != req.senderID {
+					userIDs = append(userIDs, id)
+				}
+			}
+			req.reply <- userIDs
+		}
+
+		if len(r.clients) == 0 {
+			roomLock.Lock()
+			delete(rooms, r.fitGroupIDStr)
+			roomLock.Unlock()
+			return
+		}
+	}
+}
+
+func (r *Room) removeClient(client *Client) {
+	// 사용자 웹소켓은 다른 채팅방 구독이 있으므로 연결은 유지하고 구독만 해지
+	if client.session != nil {
+		client.session.detach(client)
+	} else if client.queue != nil {
+		client.queue.close()
+	} else {
+		client.conn.Close()
+	}
+	delete(r.clients, client)
+	delete(r.activeUsers, client.userID)
+	// 같은 사용자가 다른 연결로 접속해 있으면 활성 상태 유지
+	for other := range r.clients {
+		if other.userID == client.userID {
+			r.activeUsers[client.userID] = true
+		}
+	}
+}
+
+// webhookRecipients 는 senderID 가 보낸 메시지의 웹훅을 보낼 접속자 ID 를 run 고루틴에서 조회합니다.
+// room 이 이미 종료되었으면 nil 을 반환합니다.
+func (r *Room) webhookRecipients(senderID int) []int {
+	req := recipientsRequest{senderID: senderID, reply: make(chan []int, 1)}
+	select {
+	case r.recipients <- req:
+		return <-req.reply
+	case <-r.done:
+		return nil
+	}
+}
+
+// joinRoom 은 채팅방을 찾거나 생성하여 client 를 등록합니다.
+// 등록 직전에 room 이 종료되었으면 새 room 으로 다시 시도합니다.
+func joinRoom(fitGroupIDStr string, client *Client) *Room {
+	for {
+		roomLock.Lock()
+		room, ok := rooms[fitGroupIDStr]
+		if !ok {
+			room = NewRoom(fitGroupIDStr)
+			rooms[fitGroupIDStr] = room
+			go room.run()
+		}
+		roomLock.Unlock()
+
+		select {
+		case room.register <- client:
+			return room
+		case <-room.done:
 		}
 	}
 }
@@ -104,6 +286,7 @@ var (
 // @Summary websocket chat
 // @Description 실시간 채팅 초기 연결 요청입니다.
 // @Description 첫 연결 요청 시 웹소켓 연결이 설정되며, 이후 채팅 메시지는 웹소켓을 통해 전송됩니다.
+// @Description Sec-WebSocket-Protocol 로 json.v1, msgpack.v1, protobuf.v1 중 하나를 요청할 수 있으며 요청하지 않으면 JSON 텍스트 프레임을 사용합니다. protobuf 스키마는 wire/proto/chat.proto 입니다.
 // @Tags chat
 // @Accept json
 // @Produce json
@@ -112,34 +295,45 @@ var (
 // @Success 101 {string} string "WebSocket 연결이 성공적으로 설정되었습니다."
 // @Router /chat [get]
 func (h *ChatHandler) Chat(c *gin.Context) {
-	fitGroupIDStr := c.Query("fitGroupId")
-
-	roomLock.Lock()
-	room, ok := rooms[fitGroupIDStr]
-	if !ok {
-		room = NewRoom(fitGroupIDStr)
-		rooms[fitGroupIDStr] = room
-		go room.run()
+	if !acceptConnection(c) {
+		return
 	}
-	roomLock.Unlock()
+	defer activeConnections.done()
 
-	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	fitGroupIDStr := c.Query("fitGroupId")
+	fitGroupID, err := strconv.Atoi(fitGroupIDStr)
 	if err != nil {
-		log.Println("Websocket upgrade failed:", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 fit-group-id"})
 		return
 	}
-	defer conn.Close()
 
 	// 클라이언트로부터 사용자 ID를 얻어오는 로직
 	userIDStr := c.Query("userId")
 	userID, err := strconv.Atoi(userIDStr)
 	if err != nil {
 		log.Printf("Invalid user ID: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 userId"})
+		return
+	}
+
+	// 차단된 사용자는 웹소켓 연결 전에 거부
+	if errors.Is(h.ModerationService.CheckRestriction(fitGroupID, userID), service.ErrBanned) {
+		c.JSON(http.StatusForbidden, gin.H{"error": service.ErrBanned.Error()})
 		return
 	}
 
-	client := Client{conn: conn, userID: userID}
-	room.register <- client
+	conn, err := chatUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		log.Println("Websocket upgrade failed:", err)
+		return
+	}
+	defer conn.Close()
+
+	codec := wire.ForSubprotocol(conn.Subprotocol())
+	client := &Client{conn: conn, userID: userID, codec: codec}
+	room := joinRoom(fitGroupIDStr, client)
+
+	connLimiter := ratelimit.NewTokenBucket(h.connectionRate)
 
 	for {
 		_, message, err := conn.ReadMessage()
@@ -148,60 +342,170 @@ func (h *ChatHandler) Chat(c *gin.Context) {
 			break
 		}
 
-		var chatMsg model.ChatMessage
-		if err := json.Unmarshal(message, &chatMsg); err != nil {
-			log.Printf("unmarshal error: %v", err)
+		// 연결 단위 제한은 파싱 전에 적용하여 잘못된 프레임 폭주도 막음
+		if ok, wait := connLimiter.Allow(); !ok {
+			frame := model.NewChatErrorFrame(model.ErrorRateLimited, "메시지를 너무 빠르게 보내고 있습니다.", "")
+			frame.RetryAfterMs = wait.Milliseconds()
+			if !replyError(client, frame) {
+				break
+			}
 			continue
 		}
 
-		room.broadcast <- chatMsg
-
-		err = h.ChatService.SaveChatMessage(chatMsg)
+		frame, err := codec.Decode(message)
 		if err != nil {
-			log.Printf("메시지 저장 실패: %v", err)
-			failMsg := model.ChatMessage{Message: "메시지 저장에 실패했습니다."}
-			failMsgJSON, _ := json.Marshal(failMsg)
-			if writeErr := conn.WriteMessage(websocket.TextMessage, failMsgJSON); writeErr != nil {
-				log.Printf("클라이언트에게 실패 메시지 전송 실패: %v", writeErr)
-				conn.Close()
-				return
+			log.Printf("unmarshal error: %v", err)
+			if !replyError(client, model.NewChatErrorFrame(model.ErrorInvalidMessage, "잘못된 메시지 형식입니다.", "")) {
+				break
 			}
 			continue
 		}
+		if !h.handleFrame(client, fitGroupID, frame) {
+			break
+		}
+	}
+	select {
+	case room.unregister <- client:
+	case <-room.done:
+	}
+}
 
-		// 현재 접속해 있지 않은 사용자에게 푸시 알림을 보냅니다.
-		roomLock.Lock()
-		for id := range room.activeUsers {
-			if id != chatMsg.UserID {
-				go sendWebhook(chatMsg, id)
+// handleFrame 은 클라이언트가 fit group 채팅방으로 보낸 프레임 하나를 처리합니다.
+// 채팅방 웹소켓(/chat), 사용자 웹소켓(/user/chat), REST 전송이 함께 사용하며, 연결을 닫아야 하면 false 를 반환합니다.
+func (h *ChatHandler) handleFrame(client *Client, fitGroupID int, frame wire.Inbound) bool {
+	// 채팅 메시지가 아닌 프레임(투표 등)
+	if frame.Poll != nil {
+		return h.handlePollFrame(client, fitGroupID, *frame.Poll)
+	}
+
+	chatMsg := *frame.Chat
+	// 다른 사용자/채팅방으로 위장하여 제한을 우회하지 못하도록 연결 정보로 덮어씀
+	chatMsg.UserID = client.userID
+	chatMsg.FitGroupID = fitGroupID
+
+	// "/" 로 시작하는 명령어는 저장/브로드캐스트하지 않고 명령어 응답만 보냄
+	reply, handled, err := h.CommandService.HandleCommand(chatMsg)
+	if handled {
+		if errors.Is(err, service.ErrBanned) {
+			return client.banned(chatMsg.ID)
+		}
+		if err != nil {
+			log.Printf("명령어 처리 실패: %v", err)
+			return replyError(client, model.NewChatErrorFrame(model.ErrorSaveFailed, "명령어 처리에 실패했습니다.", chatMsg.ID))
+		}
+		if reply != nil {
+			if err := client.writeFrame(reply); err != nil {
+				log.Printf("클라이언트에게 명령어 응답 전송 실패: %v", err)
+				return false
 			}
 		}
-		roomLock.Unlock()
+		return true
 	}
-	room.unregister <- client
-}
 
-func sendWebhook(chatMsg model.ChatMessage, userID int) {
-	webhookURL := "http://alarm-service:8080/chat/real-time-chat"
-	jsonData, err := json.Marshal(chatMsg)
+	// 전송 제한과 저장을 먼저 수행하고, 통과한 메시지만 브로드캐스트
+	saved, err := h.ChatService.SaveChatMessage(chatMsg)
 	if err != nil {
-		log.Printf("웹훅 요청을 위한 JSON 변환 실패: %v", err)
-		return
+		var limitErr *service.RateLimitError
+		var moderationErr *service.ModerationError
+		var frame model.ChatErrorFrame
+		if errors.As(err, &limitErr) {
+			frame = model.NewChatErrorFrame(limitErr.Code, rateLimitMessage(limitErr.Code), chatMsg.ID)
+			frame.RetryAfterMs = limitErr.RetryAfter.Milliseconds()
+		} else if errors.As(err, &moderationErr) {
+			frame = model.NewChatErrorFrame(model.ErrorMessageRejected, "부적절한 내용이 포함되어 메시지를 보낼 수 없습니다.", chatMsg.ID)
+		} else if errors.Is(err, service.ErrMuted) {
+			frame = model.NewChatErrorFrame(model.ErrorMuted, "fit leader 에 의해 채팅이 제한되었습니다.", chatMsg.ID)
+		} else if errors.Is(err, service.ErrInvalidPoll) {
+			frame = model.NewChatErrorFrame(model.ErrorInvalidMessage, "투표는 질문과 2~10개의 선택지가 필요합니다.", chatMsg.ID)
+		} else if errors.Is(err, service.ErrNotFitLeader) {
+			frame = model.NewChatErrorFrame(model.ErrorNotAllowed, "공지는 fit leader 만 보낼 수 있습니다.", chatMsg.ID)
+		} else if errors.Is(err, service.ErrBanned) {
+			return client.banned(chatMsg.ID)
+		} else {
+			log.Printf("메시지 저장 실패: %v", err)
+			frame = model.NewChatErrorFrame(model.ErrorSaveFailed, "메시지 저장에 실패했습니다.", chatMsg.ID)
+		}
+		return replyError(client, frame)
 	}
 
-	req, err := http.NewRequest("POST", webhookURL, bytes.NewBuffer(jsonData))
-	if err != nil {
-		log.Printf("웹훅 요청 생성 실패: %v", err)
-		return
+	// 금칙어 등이 가려지거나 투표가 생성된 경우 보낸 사람에게도 저장된 메시지를 알려 화면을 갱신하도록 함
+	if saved.Message != chatMsg.Message || saved.Poll != nil {
+		if err := client.writeFrame(saved); err != nil {
+			log.Printf("클라이언트에게 수정된 메시지 전송 실패: %v", err)
+		}
 	}
-	req.Header.Set("Content-Type", "application/json")
+	chatMsg = saved
 
-	client := &http.Client{Timeout: 10 * time.Second}
-	_, err = client.Do(req)
-	if err != nil {
+	room := findRoom(fitGroupID)
+	if room == nil {
+		return true
+	}
+	select {
+	case room.broadcast <- chatMsg:
+	case <-room.done:
+	}
+
+	// 현재 접속해 있지 않은 사용자에게 푸시 알림을 보냅니다. 공지는 높은 우선순위로 보냅니다.
+	priority := model.AlarmPriorityOf(chatMsg.MessageType)
+	for _, id := range room.webhookRecipients(chatMsg.UserID) {
+		id := id
+		goWebhook(func(ctx context.Context) { h.sendWebhook(ctx, chatMsg, id, priority) })
+	}
+	return true
+}
+
+// handlePollFrame 은 투표/투표 종료 프레임을 처리합니다. 집계는 서비스에서 채팅방 전체에 이벤트로 전달합니다.
+// 클라이언트에게 쓰기에 실패하면 false 를 반환합니다.
+func (h *ChatHandler) handlePollFrame(client *Client, fitGroupID int, frame model.PollFrame) bool {
+	var err error
+	if frame.Type == model.FramePollVote {
+		_, err = h.PollService.Vote(fitGroupID, client.userID, frame.PollID, frame.OptionIDs)
+	} else {
+		_, err = h.PollService.ClosePoll(fitGroupID, client.userID, frame.PollID)
+	}
+	if err == nil {
+		return true
+	}
+
+	var errFrame model.ChatErrorFrame
+	switch {
+	case errors.Is(err, service.ErrPollClosed):
+		errFrame = model.NewChatErrorFrame(model.ErrorPollClosed, "종료된 투표입니다.", "")
+	case errors.Is(err, service.ErrInvalidVote):
+		errFrame = model.NewChatErrorFrame(model.ErrorInvalidVote, "잘못된 투표입니다.", "")
+	case errors.Is(err, service.ErrNotFitLeader):
+		errFrame = model.NewChatErrorFrame(model.ErrorNotAllowed, "투표를 만든 사람이나 fit leader 만 종료할 수 있습니다.", "")
+	default:
+		log.Printf("투표 처리 실패: %v", err)
+		errFrame = model.NewChatErrorFrame(model.ErrorSaveFailed, "투표 처리에 실패했습니다.", "")
+	}
+	return replyError(client, errFrame)
+}
+
+// replyError 는 메시지를 보낸 클라이언트에게만 에러 프레임을 전송합니다. 전송에 실패하면 false 를 반환합니다.
+func replyError(client *Client, frame model.ChatErrorFrame) bool {
+	if err := client.writeFrame(frame); err != nil {
+		log.Printf("클라이언트에게 실패 메시지 전송 실패: %v", err)
+		return false
+	}
+	return true
+}
+
+func rateLimitMessage(code model.ChatErrorCode) string {
+	switch code {
+	case model.ErrorSlowMode:
+		return "슬로우 모드가 설정된 채팅방입니다. 잠시 후 다시 시도해주세요."
+	case model.ErrorDuplicateMessage:
+		return "같은 메시지를 반복해서 보낼 수 없습니다."
+	default:
+		return "메시지를 너무 빠르게 보내고 있습니다."
+	}
+}
+
+func (h *ChatHandler) sendWebhook(ctx context.Context, chatMsg model.ChatMessage, userID int, priority model.AlarmPriority) {
+	if err := h.alarm.SendChatAlarm(ctx, model.ChatAlarmRequest{ChatMessage: chatMsg, Priority: priority}); err != nil {
 		log.Printf("웹훅 요청 실패: %v", err)
 	}
-	// 응답을 무시하고 로그만 남김
 }
 
 // @Summary 최신 채팅 내역을 확인하고 동기화 하기 위한 API
@@ -259,3 +563,31 @@ func (h *ChatHandler) RetrieveMessages(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"messages": messages})
 	}
 }
+
+// @Summary 채팅 메시지 삭제 API
+// @Description 본인이 보낸 메시지를 삭제합니다. 삭제된 메시지는 조회 API 에서 제외됩니다.
+// @Tags message
+// @Produce  json
+// @Param messageId query string true "삭제할 message UUID"
+// @Param userId query int true "요청 사용자 ID"
+// @Success 204
+// @Router /message [delete]
+func (h *ChatHandler) DeleteMessage(c *gin.Context) {
+	messageID := c.Query("messageId")
+	userID, err := strconv.Atoi(c.Query("userId"))
+	if err != nil || messageID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 요청"})
+		return
+	}
+
+	if err := h.ChatService.DeleteChatMessage(messageID, userID); err != nil {
+		if errors.Is(err, service.ErrNotMessageOwner) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		log.Printf("Error deleting message %s: %v", messageID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "메시지 삭제 실패"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,231 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+	"workoutstudy_chatting/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ChatModerationHandler struct {
+	ChatModerationService service.ChatModerationUseCase
+}
+
+func NewChatModerationHandler(chatModerationService service.ChatModerationUseCase) *ChatModerationHandler {
+	return &ChatModerationHandler{ChatModerationService: chatModerationService}
+}
+
+// moderationTarget 은 fitGroupId, userId(fit leader), targetUserId 쿼리를 파싱합니다.
+func moderationTarget(c *gin.Context) (fitGroupID, leaderID, targetUserID int, ok bool) {
+	var err error
+	if fitGroupID, err = strconv.Atoi(c.Query("fitGroupId")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 fit-group-id"})
+		return
+	}
+	if leaderID, err = strconv.Atoi(c.Query("userId")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 userId"})
+		return
+	}
+	if targetUserID, err = strconv.Atoi(c.Query("targetUserId")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 targetUserId"})
+		return
+	}
+	return fitGroupID, leaderID, targetUserID, true
+}
+
+// restrictionDuration 은 minutes 쿼리를 기간으로 변환합니다. 없거나 0 이면 해제할 때까지 유지됩니다.
+func restrictionDuration(c *gin.Context) (time.Duration, bool) {
+	minutes, err := strconv.Atoi(c.DefaultQuery("minutes", "0"))
+	if err != nil || minutes < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 minutes"})
+		return 0, false
+	}
+	return time.Duration(minutes) * time.Minute, true
+}
+
+// @Summary 멤버 뮤트 API
+// @Description fit leader 가 멤버의 채팅을 일정 시간 제한합니다. minutes 가 0 이면 해제할 때까지 유지됩니다.
+// @Tags moderation
+// @Produce  json
+// @Param fitGroupId query int true "피트그룹 ID"
+// @Param userId query int true "요청 사용자 ID (fit leader)"
+// @Param targetUserId query int true "대상 사용자 ID"
+// @Param minutes query int false "뮤트 시간 (분)"
+// @Param reason query string false "사유"
+// @Success 200 {object} model.ChatRestriction
+// @Router /moderation/mute [post]
+func (h *ChatModerationHandler) MuteMember(c *gin.Context) {
+	fitGroupID, leaderID, targetUserID, ok := moderationTarget(c)
+	if !ok {
+		return
+	}
+	duration, ok := restrictionDuration(c)
+	if !ok {
+		return
+	}
+	restriction, err := h.ChatModerationService.MuteMember(fitGroupID, leaderID, targetUserID, duration, c.Query("reason"))
+	if err != nil {
+		respondChatModerationError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, restriction)
+}
+
+// @Summary 멤버 뮤트 해제 API
+// @Tags moderation
+// @Param fitGroupId query int true "피트그룹 ID"
+// @Param userId query int true "요청 사용자 ID (fit leader)"
+// @Param targetUserId query int true "대상 사용자 ID"
+// @Success 204
+// @Router /moderation/mute [delete]
+func (h *ChatModerationHandler) UnmuteMember(c *gin.Context) {
+	fitGroupID, leaderID, targetUserID, ok := moderationTarget(c)
+	if !ok {
+		return
+	}
+	if err := h.ChatModerationService.UnmuteMember(fitGroupID, leaderID, targetUserID); err != nil {
+		respondChatModerationError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary 멤버 채팅방 차단 API
+// @Description fit leader 가 멤버를 채팅방에서 내보내고 일정 시간 재입장을 막습니다. minutes 가 0 이면 해제할 때까지 유지됩니다.
+// @Tags moderation
+// @Produce  json
+// @Param fitGroupId query int true "피트그룹 ID"
+// @Param userId query int true "요청 사용자 ID (fit leader)"
+// @Param targetUserId query int true "대상 사용자 ID"
+// @Param minutes query int false "차단 시간 (분)"
+// @Param reason query string false "사유"
+// @Success 200 {object} model.ChatRestriction
+// @Router /moderation/ban [post]
+func (h *ChatModerationHandler) BanMember(c *gin.Context) {
+	fitGroupID, leaderID, targetUserID, ok := moderationTarget(c)
+	if !ok {
+		return
+	}
+	duration, ok := restrictionDuration(c)
+	if !ok {
+		return
+	}
+	restriction, err := h.ChatModerationService.BanMember(fitGroupID, leaderID, targetUserID, duration, c.Query("reason"))
+	if err != nil {
+		respondChatModerationError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, restriction)
+}
+
+// @Summary 멤버 채팅방 차단 해제 API
+// @Tags moderation
+// @Param fitGroupId query int true "피트그룹 ID"
+// @Param userId query int true "요청 사용자 ID (fit leader)"
+// @Param targetUserId query int true "대상 사용자 ID"
+// @Success 204
+// @Router /moderation/ban [delete]
+func (h *ChatModerationHandler) UnbanMember(c *gin.Context) {
+	fitGroupID, leaderID, targetUserID, ok := moderationTarget(c)
+	if !ok {
+		return
+	}
+	if err := h.ChatModerationService.UnbanMember(fitGroupID, leaderID, targetUserID); err != nil {
+		respondChatModerationError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary 메시지 삭제 API (fit leader)
+// @Description fit leader 가 채팅방의 다른 멤버 메시지를 삭제합니다.
+// @Tags moderation
+// @Param messageId query string true "삭제할 message UUID"
+// @Param userId query int true "요청 사용자 ID (fit leader)"
+// @Success 204
+// @Router /moderation/message [delete]
+func (h *ChatModerationHandler) DeleteMessage(c *gin.Context) {
+	messageID := c.Query("messageId")
+	leaderID, err := strconv.Atoi(c.Query("userId"))
+	if err != nil || messageID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 요청"})
+		return
+	}
+	if err := h.ChatModerationService.DeleteMessage(messageID, leaderID); err != nil {
+		respondChatModerationError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary 채팅 제한 목록 조회 API
+// @Description 현재 적용 중인 뮤트/차단 목록을 조회합니다. fit leader 만 조회할 수 있습니다.
+// @Tags moderation
+// @Produce  json
+// @Param fitGroupId query int true "피트그룹 ID"
+// @Param userId query int true "요청 사용자 ID (fit leader)"
+// @Success 200 {array} model.ChatRestriction
+// @Router /moderation/restrictions [get]
+func (h *ChatModerationHandler) GetActiveRestrictions(c *gin.Context) {
+	fitGroupID, err := strconv.Atoi(c.Query("fitGroupId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 fit-group-id"})
+		return
+	}
+	leaderID, err := strconv.Atoi(c.Query("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 userId"})
+		return
+	}
+	restrictions, err := h.ChatModerationService.GetActiveRestrictions(fitGroupID, leaderID)
+	if err != nil {
+		respondChatModerationError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, restrictions)
+}
+
+// @Summary moderation 조치 기록 조회 API
+// @Description fit leader 의 moderation 조치 기록을 최신순으로 조회합니다.
+// @Tags moderation
+// @Produce  json
+// @Param fitGroupId query int true "피트그룹 ID"
+// @Param userId query int true "요청 사용자 ID (fit leader)"
+// @Param limit query int false "최대 개수 (기본 100, 최대 500)"
+// @Success 200 {array} model.ModerationAuditLog
+// @Router /moderation/audit [get]
+func (h *ChatModerationHandler) GetAuditLogs(c *gin.Context) {
+	fitGroupID, err := strconv.Atoi(c.Query("fitGroupId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 fit-group-id"})
+		return
+	}
+	leaderID, err := strconv.Atoi(c.Query("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 userId"})
+		return
+	}
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "100"))
+	logs, err := h.ChatModerationService.GetAuditLogs(fitGroupID, leaderID, limit)
+	if err != nil {
+		respondChatModerationError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, logs)
+}
+
+func respondChatModerationError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrNotFitLeader):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrNotFitGroupMember), errors.Is(err, service.ErrInvalidTarget):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		log.Printf("Error handling chat moderation request: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "moderation 요청 처리 실패"})
+	}
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"workoutstudy_chatting/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ChatRoomSettingHandler struct {
+	ChatRoomSettingService service.ChatRoomSettingUseCase
+}
+
+func NewChatRoomSettingHandler(chatRoomSettingService service.ChatRoomSettingUseCase) *ChatRoomSettingHandler {
+	return &ChatRoomSettingHandler{ChatRoomSettingService: chatRoomSettingService}
+}
+
+// @Summary 채팅방 설정 조회 API
+// @Description 피트그룹 채팅방의 슬로우 모드 등 설정을 조회
+// @Tags chat
+// @Produce  json
+// @Param fitGroupId query int true "피트그룹 ID"
+// @Success 200 {object} model.ChatRoomSetting
+// @Router /chat/setting [get]
+func (h *ChatRoomSettingHandler) GetChatRoomSetting(c *gin.Context) {
+	fitGroupID, err := strconv.Atoi(c.Query("fitGroupId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 fit-group-id"})
+		return
+	}
+	setting, err := h.ChatRoomSettingService.GetChatRoomSetting(fitGroupID)
+	if err != nil {
+		log.Printf("Error retrieving chat room setting: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "채팅방 설정 조회 실패"})
+		return
+	}
+	c.JSON(http.StatusOK, setting)
+}
+
+// @Summary 슬로우 모드 설정 API
+// @Description fit leader 가 채팅방의 슬로우 모드(사용자별 메시지 전송 최소 간격, 초)를 설정. 0 이면 해제
+// @Tags chat
+// @Produce  json
+// @Param fitGroupId query int true "피트그룹 ID"
+// @Param userId query int true "요청 사용자 ID (fit leader)"
+// @Param seconds query int true "메시지 전송 최소 간격 (0 ~ 3600초)"
+// @Success 200 {object} model.ChatRoomSetting
+// @Router /chat/slow-mode [put]
+func (h *ChatRoomSettingHandler) SetSlowMode(c *gin.Context) {
+	fitGroupID, err := strconv.Atoi(c.Query("fitGroupId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 fit-group-id"})
+		return
+	}
+	userID, err := strconv.Atoi(c.Query("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 userId"})
+		return
+	}
+	seconds, err := strconv.Atoi(c.Query("seconds"))
+	if err != nil || seconds < 0 || seconds > service.MaxSlowModeSeconds {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 seconds"})
+		return
+	}
+
+	setting, err := h.ChatRoomSettingService.SetSlowMode(fitGroupID, userID, seconds)
+	if err != nil {
+		if errors.Is(err, service.ErrNotFitLeader) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		log.Printf("Error setting slow mode: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "슬로우 모드 설정 실패"})
+		return
+	}
+	c.JSON(http.StatusOK, setting)
+}
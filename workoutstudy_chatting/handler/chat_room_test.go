@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+)
+
+// queueClient 는 웹소켓 없이 room 에 등록할 수 있는 long-poll 형식의 클라이언트입니다.
+func queueClient(userID int) *Client {
+	return &Client{userID: userID, queue: newFrameQueue(16)}
+}
+
+func TestRoomWebhookRecipients(t *testing.T) {
+	tests := []struct {
+		name     string
+		userIDs  []int // 접속한 클라이언트의 사용자 ID. 같은 사용자가 여러 번 접속할 수 있음
+		leave    []int // userIDs 의 인덱스. 이 연결은 접속을 끊음
+		senderID int
+		want     []int
+	}{
+		{name: "보낸 사람 제외", userIDs: []int{1, 2, 3}, senderID: 1, want: []int{2, 3}},
+		{name: "같은 사용자의 여러 연결은 한 번", userIDs: []int{1, 2, 2}, senderID: 1, want: []int{2}},
+		{name: "나간 사용자 제외", userIDs: []int{1, 2, 3}, leave: []int{2}, senderID: 1, want: []int{2}},
+		{name: "다른 연결이 남아 있으면 유지", userIDs: []int{1, 2, 2}, leave: []int{1}, senderID: 1, want: []int{2}},
+		{name: "보낸 사람만 접속", userIDs: []int{1}, senderID: 1, want: []int{}},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roomID := fmt.Sprintf("recipients-%d", i)
+			clients := make([]*Client, len(tt.userIDs))
+			var room *Room
+			for j, userID := range tt.userIDs {
+				clients[j] = queueClient(userID)
+				room = joinRoom(roomID, clients[j])
+			}
+			for _, j := range tt.leave {
+				room.unregister <- clients[j]
+			}
+
+			got := room.webhookRecipients(tt.senderID)
+			sort.Ints(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("webhookRecipients(%d) = %v, want %v", tt.senderID, got, tt.want)
+			}
+
+			left := map[int]bool{}
+			for _, j := range tt.leave {
+				left[j] = true
+			}
+			for j, client := range clients {
+				if !left[j] {
+					room.unregister <- client
+				}
+			}
+			<-room.done
+			if room.webhookRecipients(tt.senderID) != nil {
+				t.Fatal("closed room returned recipients")
+			}
+		})
+	}
+}
+
+// TestRoomWebhookRecipientsConcurrent 는 접속, 종료와 웹훅 대상 조회가 동시에 일어나도 activeUsers 를 함께 쓰지 않는지 확인합니다. (-race)
+func TestRoomWebhookRecipientsConcurrent(t *testing.T) {
+	owner := queueClient(0)
+	room := joinRoom("recipients-concurrent", owner)
+
+	var wg sync.WaitGroup
+	for userID := 1; userID <= 20; userID++ {
+		wg.Add(2)
+		go func(userID int) {
+			defer wg.Done()
+			client := queueClient(userID)
+			joinRoom("recipients-concurrent", client)
+			room.unregister <- client
+		}(userID)
+		go func(userID int) {
+			defer wg.Done()
+			for _, id := range room.webhookRecipients(userID) {
+				if id == userID {
+					t.Errorf("sender %d is a recipient", userID)
+				}
+			}
+		}(userID)
+	}
+	wg.Wait()
+
+	if got := room.webhookRecipients(-1); !reflect.DeepEqual(got, []int{0}) {
+		t.Fatalf("webhookRecipients after all left = %v, want [0]", got)
+	}
+	room.unregister <- owner
+	<-room.done
+}
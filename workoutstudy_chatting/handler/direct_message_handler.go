@@ -0,0 +1,327 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+	"workoutstudy_chatting/client"
+	"workoutstudy_chatting/model"
+	"workoutstudy_chatting/ratelimit"
+	"workoutstudy_chatting/service"
+	"workoutstudy_chatting/util"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DirectMessageHandler struct {
+	DirectMessageService service.DirectMessageUseCase
+	connectionRate       ratelimit.Rate     // 웹소켓 연결 단위 프레임 제한
+	alarm                client.AlarmClient // alarm-service 푸시 알림
+}
+
+func NewDirectMessageHandler(directMessageService service.DirectMessageUseCase, connectionRate ratelimit.Rate, alarm client.AlarmClient) *DirectMessageHandler {
+	return &DirectMessageHandler{
+		DirectMessageService: directMessageService,
+		connectionRate:       connectionRate,
+		alarm:                alarm,
+	}
+}
+
+// directHub 는 1:1 대화방별 웹소켓 연결을 관리합니다.
+// 대화 참여자가 둘뿐이므로 Room 처럼 고루틴을 두지 않고 보내는 쪽에서 직접 전달합니다.
+type directHub struct {
+	mu      sync.Mutex
+	clients map[int]map[*Client]struct{} // key: conversationID
+}
+
+var dmHub = &directHub{clients: make(map[int]map[*Client]struct{})}
+
+func (h *directHub) join(conversationID int, client *Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.clients[conversationID] == nil {
+		h.clients[conversationID] = make(map[*Client]struct{})
+	}
+	h.clients[conversationID][client] = struct{}{}
+}
+
+func (h *directHub) leave(conversationID int, client *Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.clients[conversationID], client)
+	if len(h.clients[conversationID]) == 0 {
+		delete(h.clients, conversationID)
+	}
+}
+
+// all 은 모든 대화방 연결을 반환합니다. 서버 종료 시 연결을 닫는 데 사용합니다.
+func (h *directHub) all() []*Client {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var clients []*Client
+	for _, set := range h.clients {
+		for client := range set {
+			clients = append(clients, client)
+		}
+	}
+	return clients
+}
+
+// deliver 는 보낸 사람을 제외한 대화방 연결에 메시지를 전달하고, 상대방에게 전달되었는지 반환합니다.
+func (h *directHub) deliver(msg model.DirectMessage) bool {
+	h.mu.Lock()
+	var targets []*Client
+	for client := range h.clients[msg.ConversationID] {
+		if client.userID != msg.SenderUserID {
+			targets = append(targets, client)
+		}
+	}
+	h.mu.Unlock()
+
+	delivered := false
+	for _, client := range targets {
+		if err := client.writeFrame(msg); err != nil {
+			log.Printf("DM 전송 실패: %v", err)
+			continue
+		}
+		delivered = true
+	}
+	return delivered
+}
+
+// @Summary websocket direct message
+// @Description 1:1 대화 실시간 연결 요청입니다. 대화 참여자만 연결할 수 있습니다.
+// @Tags dm
+// @Param conversationId query int true "대화방 ID"
+// @Param userId query int true "사용자 ID"
+// @Success 101 {string} string "WebSocket 연결이 성공적으로 설정되었습니다."
+// @Router /dm [get]
+func (h *DirectMessageHandler) DirectChat(c *gin.Context) {
+	if !acceptConnection(c) {
+		return
+	}
+	defer activeConnections.done()
+
+	conversationID, err := strconv.Atoi(c.Query("conversationId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 conversationId"})
+		return
+	}
+	userID, err := strconv.Atoi(c.Query("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 userId"})
+		return
+	}
+
+	conversation, err := h.DirectMessageService.GetConversation(conversationID, userID)
+	if err != nil {
+		respondDirectMessageError(c, err)
+		return
+	}
+
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		log.Println("Websocket upgrade failed:", err)
+		return
+	}
+	defer conn.Close()
+
+	client := &Client{conn: conn, userID: userID}
+	dmHub.join(conversationID, client)
+	defer dmHub.leave(conversationID, client)
+
+	connLimiter := ratelimit.NewTokenBucket(h.connectionRate)
+
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			log.Printf("read error: %v", err)
+			break
+		}
+
+		if ok, wait := connLimiter.Allow(); !ok {
+			frame := model.NewChatErrorFrame(model.ErrorRateLimited, "메시지를 너무 빠르게 보내고 있습니다.", "")
+			frame.RetryAfterMs = wait.Milliseconds()
+			if !replyError(client, frame) {
+				break
+			}
+			continue
+		}
+
+		var dm model.DirectMessage
+		if err := json.Unmarshal(message, &dm); err != nil {
+			log.Printf("unmarshal error: %v", err)
+			if !replyError(client, model.NewChatErrorFrame(model.ErrorInvalidMessage, "잘못된 메시지 형식입니다.", "")) {
+				break
+			}
+			continue
+		}
+		dm.SenderUserID = userID
+
+		saved, err := h.DirectMessageService.SendDirectMessage(conversation, dm)
+		if err != nil {
+			var frame model.ChatErrorFrame
+			switch {
+			case errors.Is(err, service.ErrNoSharedFitGroup):
+				frame = model.NewChatErrorFrame(model.ErrorNotAllowed, "함께 하는 fit group 이 없어 메시지를 보낼 수 없습니다.", dm.ID)
+			case errors.Is(err, service.ErrEmptyMessage):
+				frame = model.NewChatErrorFrame(model.ErrorInvalidMessage, "빈 메시지는 보낼 수 없습니다.", dm.ID)
+			default:
+				log.Printf("DM 저장 실패: %v", err)
+				frame = model.NewChatErrorFrame(model.ErrorSaveFailed, "메시지 저장에 실패했습니다.", dm.ID)
+			}
+			if !replyError(client, frame) {
+				break
+			}
+			continue
+		}
+
+		// 상대방이 대화방에 접속해 있지 않으면 푸시 알림을 보냅니다.
+		if !dmHub.deliver(saved) {
+			goWebhook(func(ctx context.Context) { h.notifyRecipient(ctx, saved) })
+		}
+	}
+}
+
+func (h *DirectMessageHandler) notifyRecipient(ctx context.Context, msg model.DirectMessage) {
+	unread, err := h.DirectMessageService.CountUnread(msg.ConversationID, msg.RecipientUserID)
+	if err != nil {
+		log.Printf("DM 읽지 않은 메시지 수 조회 실패: %v", err)
+	}
+	err = h.alarm.SendDirectMessageAlarm(ctx, model.DirectMessageAlarmRequest{
+		DirectMessage: msg,
+		UnreadCount:   unread,
+		Priority:      model.AlarmPriorityNormal,
+	})
+	if err != nil {
+		log.Printf("웹훅 요청 실패: %v", err)
+	}
+}
+
+// @Summary 1:1 대화방 생성 API
+// @Description 상대방과의 대화방을 반환하고, 없으면 생성합니다. 활성 fit group 을 함께 하는 사용자와만 대화할 수 있습니다.
+// @Tags dm
+// @Produce  json
+// @Param userId query int true "사용자 ID"
+// @Param peerUserId query int true "상대방 사용자 ID"
+// @Success 200 {object} model.DirectConversation
+// @Router /dm/conversation [post]
+func (h *DirectMessageHandler) OpenConversation(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Query("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 userId"})
+		return
+	}
+	peerUserID, err := strconv.Atoi(c.Query("peerUserId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 peerUserId"})
+		return
+	}
+	conversation, err := h.DirectMessageService.OpenConversation(userID, peerUserID)
+	if err != nil {
+		respondDirectMessageError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, conversation)
+}
+
+// @Summary 1:1 대화방 목록 조회 API
+// @Description 최근 메시지 순으로 대화방 목록과 마지막 메시지, 읽지 않은 메시지 수를 조회합니다.
+// @Tags dm
+// @Produce  json
+// @Param userId query int true "사용자 ID"
+// @Success 200 {array} model.DirectConversationSummary
+// @Router /dm/conversations [get]
+func (h *DirectMessageHandler) GetConversations(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Query("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 userId"})
+		return
+	}
+	summaries, err := h.DirectMessageService.GetConversations(userID)
+	if err != nil {
+		respondDirectMessageError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, summaries)
+}
+
+// @Summary 1:1 대화 내역 조회 API
+// @Description before 이전의 메시지를 최신순으로 조회합니다. before 가 없으면 가장 최근 메시지부터 조회합니다.
+// @Tags dm
+// @Produce  json
+// @Param conversationId query int true "대화방 ID"
+// @Param userId query int true "사용자 ID"
+// @Param before query string false "이 시간 이전 메시지 조회 (2006-01-02 15:04:05.000000)"
+// @Param limit query int false "최대 개수 (기본/최대 100)"
+// @Success 200 {array} model.DirectMessage
+// @Router /dm/message [get]
+func (h *DirectMessageHandler) GetDirectMessages(c *gin.Context) {
+	conversationID, err := strconv.Atoi(c.Query("conversationId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 conversationId"})
+		return
+	}
+	userID, err := strconv.Atoi(c.Query("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 userId"})
+		return
+	}
+	before := time.Now().Add(24 * time.Hour) // 클라이언트 시간대 차이를 고려해 여유를 둠
+	if beforeStr := c.Query("before"); beforeStr != "" {
+		if before, err = util.ParseMessageTime(beforeStr); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "시간 파싱 실패"})
+			return
+		}
+	}
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "100"))
+
+	messages, err := h.DirectMessageService.GetDirectMessages(conversationID, userID, before, limit)
+	if err != nil {
+		respondDirectMessageError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, messages)
+}
+
+// @Summary 1:1 대화 읽음 처리 API
+// @Description 지금까지 받은 메시지를 모두 읽은 것으로 기록합니다.
+// @Tags dm
+// @Param conversationId query int true "대화방 ID"
+// @Param userId query int true "사용자 ID"
+// @Success 204
+// @Router /dm/read [put]
+func (h *DirectMessageHandler) MarkRead(c *gin.Context) {
+	conversationID, err := strconv.Atoi(c.Query("conversationId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 conversationId"})
+		return
+	}
+	userID, err := strconv.Atoi(c.Query("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 userId"})
+		return
+	}
+	if err := h.DirectMessageService.MarkRead(conversationID, userID); err != nil {
+		respondDirectMessageError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func respondDirectMessageError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrNoSharedFitGroup), errors.Is(err, service.ErrNotConversationParticipant):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrInvalidTarget):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		log.Printf("Error handling direct message request: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "DM 요청 처리 실패"})
+	}
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"log"
 	"net/http"
 	"strconv"
 	"workoutstudy_chatting/service" // 서비스 패키지 경로에 맞게 수정
@@ -44,3 +45,19 @@ func (h *fitMateHandler) RetrieveFitGroupByUserID(c *gin.Context) {
 
 	c.JSON(http.StatusOK, fitGroup)
 }
+
+// @Summary 보류한 fit mate 이벤트 현황 API
+// @Description fit group 보다 먼저 도착해 보류한 fit mate 이벤트 수와 서버 시작 이후 보류, 재처리, 대체, 만료, 재처리 실패 누적 횟수를 조회
+// @Tags fitmate
+// @Produce  json
+// @Success 200 {object} model.PendingFitMateEventStats
+// @Router /fit-mate/pending-events [get]
+func (h *fitMateHandler) GetPendingFitMateEventStats(c *gin.Context) {
+	stats, err := h.FitmateService.GetPendingFitMateEventStats()
+	if err != nil {
+		log.Printf("Error retrieving pending fit mate event stats: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal Server Error"})
+		return
+	}
+	c.JSON(http.StatusOK, stats)
+}
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	errQueueClosed = errors.New("frame queue closed")
+	errQueueFull   = errors.New("frame queue full")
+)
+
+// queuedFrame 은 frameQueue 에 쌓인 프레임과 순번입니다. 순번은 1 부터 증가합니다.
+type queuedFrame struct {
+	Seq   int64
+	Frame interface{}
+}
+
+// frameQueue 는 SSE, long-poll 연결로 보낼 프레임을 쌓아두는 버퍼입니다.
+// Room 고루틴이 push 하고 각 핸들러가 wait 로 꺼내 전송합니다. 가득 차면 push 가 실패하여 Room 이 느린 클라이언트를 제거합니다.
+type frameQueue struct {
+	mu      sync.Mutex
+	frames  []queuedFrame
+	lastSeq int64
+	limit   int
+	closed  bool
+	changed chan struct{} // 프레임이 추가되거나 닫히면 close 후 새 채널로 교체
+}
+
+func newFrameQueue(limit int) *frameQueue {
+	return &frameQueue{limit: limit, changed: make(chan struct{})}
+}
+
+func (q *frameQueue) push(frame interface{}) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return errQueueClosed
+	}
+	if len(q.frames) >= q.limit {
+		return errQueueFull
+	}
+	q.lastSeq++
+	q.frames = append(q.frames, queuedFrame{Seq: q.lastSeq, Frame: frame})
+	q.signal()
+	return nil
+}
+
+// close 는 더 이상 프레임을 받지 않도록 닫습니다. 이미 쌓인 프레임은 wait 로 계속 꺼낼 수 있습니다.
+func (q *frameQueue) close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if !q.closed {
+		q.closed = true
+		q.signal()
+	}
+}
+
+func (q *frameQueue) signal() {
+	close(q.changed)
+	q.changed = make(chan struct{})
+}
+
+/*
+wait
+1. after 이하의 프레임은 전달이 확인된 것으로 보고 버림
+2. after 이후 프레임이 있으면 바로 반환
+3. 없으면 프레임이 추가되거나, 닫히거나, timeout/ctx 가 끝날 때까지 대기
+closed 는 닫혔고 남은 프레임도 없는 경우 true
+*/
+func (q *frameQueue) wait(ctx context.Context, after int64, timeout time.Duration) (frames []queuedFrame, closed bool) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		q.mu.Lock()
+		i := 0
+		for i < len(q.frames) && q.frames[i].Seq <= after {
+			i++
+		}
+		q.frames = q.frames[i:]
+		if len(q.frames) > 0 {
+			frames = append([]queuedFrame(nil), q.frames...)
+			q.mu.Unlock()
+			return frames, false
+		}
+		if q.closed {
+			q.mu.Unlock()
+			return nil, true
+		}
+		changed := q.changed
+		q.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-timer.C:
+			return nil, false
+		case <-ctx.Done():
+			return nil, false
+		}
+	}
+}
+
+// cursor 는 마지막으로 쌓인 프레임의 순번입니다.
+func (q *frameQueue) cursor() int64 {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.lastSeq
+}
@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"workoutstudy_chatting/model"
+
+	"github.com/segmentio/kafka-go"
+)
+
+/*
+decodeEvent 는 fit-group, fit-mate, user-info-event 이벤트 값을 읽습니다. (model.EventSchemaVersion)
+- ID 만 담긴 v1 이벤트면 id 를 반환합니다. 상세 정보는 호출한 쪽에서 API 로 조회합니다.
+- 전체 상태를 담은 v2 이벤트면 fatEvent 에 읽고 full 을 true 로 반환합니다.
+형식이 잘못됐거나 지원하지 않는 버전이면 재시도하지 않는 오류를 반환합니다.
+*/
+func decodeEvent(msg kafka.Message, fatEvent interface{}) (id int, full bool, err error) {
+	value := bytes.TrimSpace(msg.Value)
+	if id, err := strconv.Atoi(string(value)); err == nil {
+		return id, false, nil
+	}
+
+	var header struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal(value, &header); err != nil {
+		return 0, false, permanent(fmt.Errorf("event is neither an ID nor a JSON object: %w", err))
+	}
+	if header.Version != model.EventSchemaVersion {
+		return 0, false, permanent(fmt.Errorf("unsupported event schema version %d", header.Version))
+	}
+	if err := json.Unmarshal(value, fatEvent); err != nil {
+		return 0, false, permanent(fmt.Errorf("decode v%d event: %w", header.Version, err))
+	}
+	return 0, true, nil
+}
+
+/*
+redelivered 는 재시도 토픽을 거쳤거나 dead-letter 토픽에서 다시 보낸 이벤트인지 확인합니다.
+이런 이벤트는 같은 key 의 더 최신 이벤트가 이미 반영된 뒤 늦게 도착할 수 있으므로,
+v2 이벤트라도 담긴 상태를 그대로 반영하지 않고 v1 처럼 API 로 최신 상태를 다시 조회합니다.
+*/
+func redelivered(msg kafka.Message) bool {
+	return EventHeader(msg, HeaderOriginalTopic) != "" || EventHeader(msg, HeaderRedrivenAt) != ""
+}
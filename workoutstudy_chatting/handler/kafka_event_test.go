@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"workoutstudy_chatting/client"
+	"workoutstudy_chatting/model"
+	"workoutstudy_chatting/service"
+
+	"github.com/segmentio/kafka-go"
+)
+
+func TestDecodeEvent(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         string
+		wantID        int
+		wantFull      bool
+		wantPermanent bool // 재시도하지 않는 오류
+		wantGroupID   int  // v2 이벤트에서 읽은 fitGroupId
+	}{
+		{name: "v1 ID", value: "42", wantID: 42},
+		{name: "v1 ID 앞뒤 공백", value: " 42\n", wantID: 42},
+		{name: "v2 이벤트", value: `{"version":2,"fitGroup":{"fitGroupId":3,"fitGroupName":"morning run"}}`, wantFull: true, wantGroupID: 3},
+		{name: "v2 이벤트 앞뒤 공백", value: "\n{\"version\":2,\"fitGroup\":{\"fitGroupId\":3}} ", wantFull: true, wantGroupID: 3},
+		{name: "version 이 없으면 지원하지 않는 버전", value: `{"fitGroup":{"fitGroupId":3}}`, wantPermanent: true},
+		{name: "지원하지 않는 버전", value: `{"version":3,"fitGroup":{"fitGroupId":3}}`, wantPermanent: true},
+		{name: "ID 도 JSON 도 아님", value: "fit-group-3", wantPermanent: true},
+		{name: "빈 값", value: "", wantPermanent: true},
+		{name: "v2 필드 타입 오류", value: `{"version":2,"fitGroup":{"fitGroupId":"3"}}`, wantPermanent: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var event model.FitGroupEvent
+			id, full, err := decodeEvent(kafka.Message{Value: []byte(tt.value)}, &event)
+			if tt.wantPermanent {
+				if err == nil || !isPermanent(err) {
+					t.Fatalf("decodeEvent err = %v, want permanent error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decodeEvent: %v", err)
+			}
+			if id != tt.wantID || full != tt.wantFull || event.FitGroup.FitGroupId != tt.wantGroupID {
+				t.Fatalf("decodeEvent = id %d full %v fitGroupId %d, want %d %v %d", id, full, event.FitGroup.FitGroupId, tt.wantID, tt.wantFull, tt.wantGroupID)
+			}
+		})
+	}
+}
+
+func TestRedelivered(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers []kafka.Header
+		want    bool
+	}{
+		{name: "헤더 없음"},
+		{name: "다른 헤더만 있음", headers: headers(HeaderEventID, "evt-1")},
+		{name: "재시도 토픽을 거침", headers: headers(HeaderOriginalTopic, "fit-group", HeaderRetryCount, "1"), want: true},
+		{name: "dead-letter 에서 다시 보냄", headers: headers(HeaderRedrivenAt, "2026-01-01T00:00:00Z"), want: true},
+		{name: "빈 헤더 값", headers: headers(HeaderOriginalTopic, "")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := redelivered(kafka.Message{Headers: tt.headers}); got != tt.want {
+				t.Fatalf("redelivered = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// fakeFitGroupClient 는 조회한 fit group ID 를 기록하고 fitGroup 을 반환하는 client.FitGroupClient 입니다.
+type fakeFitGroupClient struct {
+	fitGroup model.GetFitGroupDetailApiResponse
+	err      error
+	fetched  []int
+}
+
+var _ client.FitGroupClient = (*fakeFitGroupClient)(nil)
+
+func (c *fakeFitGroupClient) GetFitGroup(_ context.Context, fitGroupID int) (*model.GetFitGroupDetailApiResponse, error) {
+	c.fetched = append(c.fetched, fitGroupID)
+	if c.err != nil {
+		return nil, c.err
+	}
+	fitGroup := c.fitGroup
+	fitGroup.FitGroupId = fitGroupID
+	return &fitGroup, nil
+}
+
+func (c *fakeFitGroupClient) GetFitMates(_ context.Context, fitGroupID int) (*model.GetFitMatesApiResponse, error) {
+	return nil, client.ErrNotFound
+}
+
+// recordingFitGroupService 는 반영한 fit group 이벤트를 기록합니다. 나머지 메서드는 사용하지 않습니다.
+type recordingFitGroupService struct {
+	service.FitGroupUseCase
+	applied []model.GetFitGroupDetailApiResponse
+}
+
+func (s *recordingFitGroupService) HandleFitGroupEvent(apiResponse model.GetFitGroupDetailApiResponse) error {
+	s.applied = append(s.applied, apiResponse)
+	return nil
+}
+
+func TestFitGroupHandlerRefetchesRedeliveredEvents(t *testing.T) {
+	const v2Event = `{"version":2,"fitGroup":{"fitGroupId":3,"fitGroupName":"stale name"}}`
+	tests := []struct {
+		name        string
+		value       string
+		headers     []kafka.Header
+		clientErr   error
+		wantFetched []int
+		wantName    string // 반영한 fit group 이름. 빈 문자열이면 반영하지 않음
+		wantDLQ     bool
+	}{
+		{name: "v2 이벤트는 담긴 상태를 반영", value: v2Event, wantName: "stale name"},
+		{
+			name: "재시도 토픽을 거친 v2 이벤트는 다시 조회", value: v2Event,
+			headers:     headers(HeaderOriginalTopic, "fit-group", HeaderOriginalPartition, "0", HeaderOriginalOffset, "1", HeaderRetryCount, "1"),
+			wantFetched: []int{3}, wantName: "latest name",
+		},
+		{
+			name: "dead-letter 에서 다시 보낸 v2 이벤트는 다시 조회", value: v2Event,
+			headers:     headers(HeaderRedrivenAt, "2026-01-01T00:00:00Z"),
+			wantFetched: []int{3}, wantName: "latest name",
+		},
+		{name: "v1 이벤트는 조회", value: "3", wantFetched: []int{3}, wantName: "latest name"},
+		{name: "조회 결과가 404 면 재시도하지 않음", value: "3", clientErr: client.ErrNotFound, wantFetched: []int{3}, wantDLQ: true},
+		{name: "fitGroupId 가 없는 v2 이벤트", value: `{"version":2,"fitGroup":{}}`, wantDLQ: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fitGroups := &fakeFitGroupClient{fitGroup: model.GetFitGroupDetailApiResponse{FitGroupName: "latest name"}, err: tt.clientErr}
+			fgService := &recordingFitGroupService{}
+			publisher := &fakePublisher{}
+			events := make(chan MessageEvent, 1)
+			result := make(chan error, 1)
+			events <- MessageEvent{Topic: "fit-group", Result: result, Message: kafka.Message{Topic: "fit-group", Value: []byte(tt.value), Headers: tt.headers}}
+			close(events)
+
+			FitGroupHandler(context.Background(), events, NewEventRetrier(testKafkaConfig(), publisher, nil), fitGroups, fgService)
+
+			if err := <-result; err != nil {
+				t.Fatalf("result = %v", err)
+			}
+			if !reflect.DeepEqual(fitGroups.fetched, tt.wantFetched) {
+				t.Fatalf("fetched = %v, want %v", fitGroups.fetched, tt.wantFetched)
+			}
+			switch {
+			case tt.wantName == "" && len(fgService.applied) != 0:
+				t.Fatalf("applied %+v, want nothing", fgService.applied)
+			case tt.wantName != "" && (len(fgService.applied) != 1 || fgService.applied[0].FitGroupName != tt.wantName || fgService.applied[0].FitGroupId != 3):
+				t.Fatalf("applied %+v, want fit group 3 %q", fgService.applied, tt.wantName)
+			}
+			published := publisher.published()
+			if gotDLQ := len(published) == 1 && published[0].Topic == "chatting-dlq"; gotDLQ != tt.wantDLQ || len(published) > 1 {
+				t.Fatalf("published %d messages (dead-letter %v), want dead-letter %v", len(published), gotDLQ, tt.wantDLQ)
+			}
+		})
+	}
+}
+
+func TestUpstreamErrorIsPermanentOnlyFor4xx(t *testing.T) {
+	for err, want := range map[error]bool{
+		client.ErrNotFound:       true,
+		client.ErrBadRequest:     true,
+		client.ErrUnauthorized:   true,
+		client.ErrUnavailable:    false,
+		errors.New("dial error"): false,
+	} {
+		if got := isPermanent(upstreamError(err)); got != want {
+			t.Errorf("isPermanent(upstreamError(%v)) = %v, want %v", err, got, want)
+		}
+	}
+}
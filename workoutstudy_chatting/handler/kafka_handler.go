@@ -1,13 +1,15 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"log"
-	"net/http"
-	"strconv"
+	"sync"
 
+	"workoutstudy_chatting/client"
+	"workoutstudy_chatting/config"
 	"workoutstudy_chatting/model"
 	"workoutstudy_chatting/service"
 
@@ -17,46 +19,83 @@ import (
 type MessageEvent struct {
 	Message kafka.Message
 	Topic   string
+	Result  chan<- error // 처리를 마치면 결과를 보냄. nil 이면 보내지 않음
 }
 
-func HandleMessage(msgChan chan MessageEvent, fitMateService service.FitMateUseCase, fitGroupService service.FitGroupUseCase, userService service.UserUseCase) {
+// reply 는 컨슈머에 처리 결과를 알립니다. 오류가 없을 때만 오프셋이 커밋됩니다.
+func (e MessageEvent) reply(err error) {
+	if e.Result != nil {
+		e.Result <- err
+	}
+}
+
+// Deliver 는 컨슈머가 읽은 메시지를 msgChan 으로 보내고 토픽별 핸들러의 처리 결과를 기다리는 함수를 반환합니다.
+func Deliver(msgChan chan<- MessageEvent) func(topic string, m kafka.Message) error {
+	return func(topic string, m kafka.Message) error {
+		result := make(chan error, 1)
+		msgChan <- MessageEvent{Message: m, Topic: topic, Result: result}
+		return <-result
+	}
+}
+
+// HandleMessage 는 설정된 토픽 이름으로 이벤트를 토픽별 핸들러에 나눠 보냅니다.
+// 전체 상태를 담은 v2 이벤트는 바로 반영하고, ID 만 담긴 v1 이벤트는 fit-group, auth 서비스에서 상세 정보를 조회합니다.
+// 재시도 토픽이나 dead-letter 재전송으로 늦게 도착한 v2 이벤트는 오래된 상태일 수 있으므로 v1 처럼 조회합니다.
+// 처리에 실패한 이벤트는 retrier 의 토픽별 재시도 정책에 따라 재시도하거나 dead-letter 토픽으로 보냅니다.
+// ctx 가 취소되면(종료) 진행 중인 API 조회와 재시도 대기를 멈추고 처리 중인 이벤트를 커밋하지 않은 채로 마칩니다.
+func HandleMessage(ctx context.Context, msgChan chan MessageEvent, retrier *EventRetrier, topics config.KafkaTopics, fitGroupClient client.FitGroupClient, authClient client.AuthClient, fitMateService service.FitMateUseCase, fitGroupService service.FitGroupUseCase, userService service.UserUseCase) {
 	fitMateChannel := make(chan MessageEvent)
 	fitGroupChannel := make(chan MessageEvent)
 	userCreateEventChannel := make(chan MessageEvent)
 	userInfoEventChannel := make(chan MessageEvent)
 
-	// fitGroupEvents 채널 생성
-	fitGroupEvents := make(chan int, 1) // 비동기 이벤트 알림을 위해 채널 사용
-
-	go FitMateHandler(fitMateChannel, fitMateService, fitGroupEvents)
-	go FitGroupHandler(fitGroupChannel, fitGroupService, fitGroupEvents)
-	go UserCreateEventHandler(userCreateEventChannel, userService)
-	go UserInfoHandler(userInfoEventChannel, userService)
+	// 종료 시 처리 중인 이벤트를 마칠 때까지 기다리기 위해 토픽별 핸들러를 추적
+	var workers sync.WaitGroup
+	startWorker := func(run func()) {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			run()
+		}()
+	}
+	startWorker(func() { FitMateHandler(ctx, fitMateChannel, retrier, fitGroupClient, fitMateService) })
+	startWorker(func() { FitGroupHandler(ctx, fitGroupChannel, retrier, fitGroupClient, fitGroupService) })
+	startWorker(func() { UserCreateEventHandler(ctx, userCreateEventChannel, retrier, userService) })
+	startWorker(func() { UserInfoHandler(ctx, userInfoEventChannel, retrier, authClient, userService) })
 
 	for msgEvent := range msgChan {
 		msg := msgEvent.Message
 		topic := msgEvent.Topic
 
 		switch topic {
-		case "fit-mate":
-			log.Printf("fit-mate 이벤트 컨슘: %s", string(msg.Value))
+		case topics.FitMate:
+			log.Printf("%s 이벤트 컨슘: %s", topic, string(msg.Value))
 			fitMateChannel <- msgEvent
-		case "fit-group":
-			log.Printf("fit-group 이벤트 컨슘: %s", string(msg.Value))
+		case topics.FitGroup:
+			log.Printf("%s 이벤트 컨슘: %s", topic, string(msg.Value))
 			fitGroupChannel <- msgEvent
-		case "user-create-event":
-			log.Printf("user-create-event 이벤트 컨슘: %s", string(msg.Value))
+		case topics.UserCreate:
+			log.Printf("%s 이벤트 컨슘: %s", topic, string(msg.Value))
 			userCreateEventChannel <- msgEvent
-		case "user-info":
-			log.Printf("user-info-event 이벤트 컨슘: %s", string(msg.Value))
+		case topics.UserInfo:
+			log.Printf("%s 이벤트 컨슘: %s", topic, string(msg.Value))
 			userInfoEventChannel <- msgEvent
 		default:
 			log.Printf("No handler for topic %s\n", topic)
+			msgEvent.reply(nil)
 		}
 	}
+
+	// msgChan 이 닫히면(컨슈머 종료) 토픽별 핸들러가 남은 이벤트를 처리하고 끝날 때까지 대기
+	close(fitMateChannel)
+	close(fitGroupChannel)
+	close(userCreateEventChannel)
+	close(userInfoEventChannel)
+	workers.Wait()
+	log.Println("Kafka message handlers stopped")
 }
 
-// func FitMateHandler(c chan MessageEvent, fitMateService service.FitMateUseCase, fitGroupEvents chan int) {
+// func FitMateHandler(c chan MessageEvent, fitGroupURL string, fitMateService service.FitMateUseCase, fitGroupEvents chan int) {
 // 	for event := range c {
 // 		msg := event.Message
 // 		value, err := strconv.Atoi(string(msg.Value))
@@ -94,128 +133,149 @@ func HandleMessage(msgChan chan MessageEvent, fitMateService service.FitMateUseC
 // 	}
 // }
 
-func FitGroupHandler(c chan MessageEvent, fgService service.FitGroupUseCase, fitGroupEvents chan int) {
+func FitGroupHandler(ctx context.Context, c chan MessageEvent, retrier *EventRetrier, fitGroups client.FitGroupClient, fgService service.FitGroupUseCase) {
 	for event := range c {
-		msg := event.Message
-		value, err := strconv.Atoi(string(msg.Value))
-		if err != nil {
-			log.Printf("Error converting Kafka message to int: %v\n", err)
-			continue
-		}
-
-		handleFitGroupEvent(value, fgService, fitGroupEvents)
+		processEvent(ctx, retrier, event, func(ctx context.Context, msg kafka.Message) error {
+			var fitGroupEvent model.FitGroupEvent
+			value, full, err := decodeEvent(msg, &fitGroupEvent)
+			if err != nil {
+				return err
+			}
+			if full {
+				if fitGroupEvent.FitGroup.FitGroupId == 0 {
+					return permanent(fmt.Errorf("fit group event has no fitGroupId"))
+				}
+				if redelivered(msg) {
+					return handleFitGroupEvent(ctx, fitGroups, fitGroupEvent.FitGroup.FitGroupId, fgService)
+				}
+				return applyFitGroupEvent(fitGroupEvent.FitGroup, fgService)
+			}
+			return handleFitGroupEvent(ctx, fitGroups, value, fgService)
+		})
 	}
 }
 
-func handleFitGroupEvent(value int, fgService service.FitGroupUseCase, fitGroupEvents chan int) {
-	url := fmt.Sprintf("http://fit-group:8080/fit-group-service/groups/%d", value)
-	response, err := http.Get(url)
+// handleFitGroupEvent 는 ID 만 담긴 이벤트의 fit group 상세 정보를 조회해 반영합니다.
+func handleFitGroupEvent(ctx context.Context, fitGroups client.FitGroupClient, value int, fgService service.FitGroupUseCase) error {
+	fitGroup, err := fitGroups.GetFitGroup(ctx, value)
 	if err != nil {
-		log.Printf("Error sending GET request: %v\n", err)
-		return
-	}
-	defer response.Body.Close()
-
-	var apiResponse model.GetFitGroupDetailApiResponse
-	if err := json.NewDecoder(response.Body).Decode(&apiResponse); err != nil {
-		log.Printf("Error decoding API response: %v\n", err)
-		return
+		return upstreamError(err)
 	}
+	return applyFitGroupEvent(*fitGroup, fgService)
+}
 
-	if err := fgService.HandleFitGroupEvent(apiResponse, fitGroupEvents); err != nil {
-		log.Printf("Error handling fit group event: %v\n", err)
+func applyFitGroupEvent(fitGroup model.GetFitGroupDetailApiResponse, fgService service.FitGroupUseCase) error {
+	if err := fgService.HandleFitGroupEvent(fitGroup); err != nil {
+		return fmt.Errorf("handle fit group event: %w", err)
 	}
+	return nil
 }
 
-func UserCreateEventHandler(c chan MessageEvent, userService service.UserUseCase) {
+func UserCreateEventHandler(ctx context.Context, c chan MessageEvent, retrier *EventRetrier, userService service.UserUseCase) {
 	for event := range c {
-		msg := event.Message
-
-		var userCreateEvent model.UserCreateEvent
-
-		if err := json.Unmarshal(msg.Value, &userCreateEvent); err != nil {
-			log.Printf("Error unmarshalling message: %v\n", err)
-			continue
-		}
-
-		handleUserCreateEvent(userCreateEvent, userService)
+		processEvent(ctx, retrier, event, func(ctx context.Context, msg kafka.Message) error {
+			var userCreateEvent model.UserCreateEvent
+			if err := json.Unmarshal(msg.Value, &userCreateEvent); err != nil {
+				return permanent(fmt.Errorf("unmarshal message: %w", err))
+			}
+			return handleUserCreateEvent(userCreateEvent, userService)
+		})
 	}
 }
 
-func handleUserCreateEvent(userCreateEvent model.UserCreateEvent, userService service.UserUseCase) {
+func handleUserCreateEvent(userCreateEvent model.UserCreateEvent, userService service.UserUseCase) error {
 	if err := userService.HandleUserCreateEvent(&userCreateEvent); err != nil {
-		log.Printf("Error handling user creation process: %v\n", err)
+		return fmt.Errorf("handle user creation process: %w", err)
 	}
+	return nil
 }
 
-func UserInfoHandler(c chan MessageEvent, userService service.UserUseCase) {
+func UserInfoHandler(ctx context.Context, c chan MessageEvent, retrier *EventRetrier, auth client.AuthClient, userService service.UserUseCase) {
 	for event := range c {
-		msg := event.Message
-		value, err := strconv.Atoi(string(msg.Value))
-		if err != nil {
-			log.Printf("Error converting Kafka message to int: %v\n", err)
-			continue
-		}
-
-		handleUserInfoEvent(value, userService)
+		processEvent(ctx, retrier, event, func(ctx context.Context, msg kafka.Message) error {
+			var userInfoEvent model.UserInfoEvent
+			value, full, err := decodeEvent(msg, &userInfoEvent)
+			if err != nil {
+				return err
+			}
+			if full {
+				if userInfoEvent.User.UserID == 0 {
+					return permanent(fmt.Errorf("user info event has no userId"))
+				}
+				if redelivered(msg) {
+					return handleUserInfoEvent(ctx, auth, userInfoEvent.User.UserID, userService)
+				}
+				return applyUserInfoEvent(userInfoEvent.User, userService)
+			}
+			return handleUserInfoEvent(ctx, auth, value, userService)
+		})
 	}
 }
 
-func handleUserInfoEvent(value int, userService service.UserUseCase) {
-	url := fmt.Sprintf("http://auth-service:8080/user/user-info?userId=%d", value)
-	response, err := http.Get(url)
+// handleUserInfoEvent 는 ID 만 담긴 이벤트의 사용자 정보를 조회해 반영합니다.
+func handleUserInfoEvent(ctx context.Context, auth client.AuthClient, value int, userService service.UserUseCase) error {
+	userInfo, err := auth.GetUserInfo(ctx, value)
 	if err != nil {
-		log.Printf("Error sending GET request: %v\n", err)
-		return
-	}
-	defer response.Body.Close()
-
-	var apiResponse model.GetUserInfoApiResponse
-	if err := json.NewDecoder(response.Body).Decode(&apiResponse); err != nil {
-		log.Printf("Error decoding API response: %v\n", err)
-		return
+		return upstreamError(err)
 	}
+	return applyUserInfoEvent(*userInfo, userService)
+}
 
-	if err := userService.HandleUserInfoEvent(apiResponse); err != nil {
-		log.Printf("Error handling user info event: %v\n", err)
+func applyUserInfoEvent(userInfo model.GetUserInfoApiResponse, userService service.UserUseCase) error {
+	if err := userService.HandleUserInfoEvent(userInfo); err != nil {
+		return fmt.Errorf("handle user info event: %w", err)
 	}
+	return nil
 }
 
-func FitMateHandler(c chan MessageEvent, fitMateService service.FitMateUseCase, fitGroupEvents chan int) {
+func FitMateHandler(ctx context.Context, c chan MessageEvent, retrier *EventRetrier, fitGroups client.FitGroupClient, fitMateService service.FitMateUseCase) {
 	for event := range c {
-		msg := event.Message
-		value, err := strconv.Atoi(string(msg.Value))
-		if err != nil {
-			log.Printf("Error converting Kafka message to int: %v\n", err)
-			continue
-		}
-
-		handleFitMateEvent(value, fitMateService, fitGroupEvents)
+		processEvent(ctx, retrier, event, func(ctx context.Context, msg kafka.Message) error {
+			var fitMateEvent model.FitMateEvent
+			value, full, err := decodeEvent(msg, &fitMateEvent)
+			if err != nil {
+				return err
+			}
+			if full {
+				if fitMateEvent.FitMates.FitGroupId == 0 {
+					return permanent(fmt.Errorf("fit mate event has no fitGroupId"))
+				}
+				if redelivered(msg) {
+					return handleFitMateEvent(ctx, fitGroups, fitMateEvent.FitMates.FitGroupId, fitMateService)
+				}
+				return applyFitMateEvent(fitMateEvent.FitMates, fitMateService)
+			}
+			return handleFitMateEvent(ctx, fitGroups, value, fitMateService)
+		})
 	}
 }
 
-func handleFitMateEvent(value int, fitMateService service.FitMateUseCase, fitGroupEvents chan int) {
-	url := fmt.Sprintf("http://fit-group:8080/fit-group-service/mates/%d", value)
-	response, err := http.Get(url)
+// handleFitMateEvent 는 ID 만 담긴 이벤트의 fit mate 목록을 조회해 반영합니다.
+func handleFitMateEvent(ctx context.Context, fitGroups client.FitGroupClient, value int, fitMateService service.FitMateUseCase) error {
+	fitMates, err := fitGroups.GetFitMates(ctx, value)
 	if err != nil {
-		log.Printf("Error sending GET request: %v\n", err)
-		return
+		return upstreamError(err)
 	}
-	defer response.Body.Close()
+	return applyFitMateEvent(*fitMates, fitMateService)
+}
 
-	body, err := io.ReadAll(response.Body)
-	if err != nil {
-		log.Printf("Error reading response body: %v\n", err)
-		return
+func applyFitMateEvent(fitMates model.GetFitMatesApiResponse, fitMateService service.FitMateUseCase) error {
+	if err := fitMateService.HandleFitMateEvent(fitMates); err != nil {
+		return fmt.Errorf("handle fit mate event: %w", err)
 	}
+	return nil
+}
 
-	var apiResponse model.GetFitMatesApiResponse
-	if err := json.Unmarshal(body, &apiResponse); err != nil {
-		log.Printf("Error unmarshalling API response: %v\n", err)
-		return
+// upstreamError 는 API 오류 중 다시 조회해도 실패하는 4xx 응답은 재시도하지 않도록 표시합니다.
+// 연결 실패, 5xx, 회로 열림은 재시도 정책에 따라 다시 처리합니다.
+func upstreamError(err error) error {
+	if errors.Is(err, client.ErrNotFound) || errors.Is(err, client.ErrBadRequest) || errors.Is(err, client.ErrUnauthorized) {
+		return permanent(err)
 	}
+	return err
+}
 
-	if err := fitMateService.HandleFitMateEvent(apiResponse, fitGroupEvents); err != nil {
-		log.Printf("Error handling fit mate event: %v\n", err)
-	}
+// processEvent 는 재시도 정책에 따라 이벤트를 처리하고 결과를 컨슈머에 알립니다.
+func processEvent(ctx context.Context, retrier *EventRetrier, event MessageEvent, handle func(context.Context, kafka.Message) error) {
+	event.reply(retrier.Process(ctx, event, handle))
 }
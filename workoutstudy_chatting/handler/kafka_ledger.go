@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"workoutstudy_chatting/persistence"
+)
+
+// HeaderEventID 는 생산자가 붙이는 이벤트 ID 헤더입니다. 있으면 토픽 위치 대신 이벤트 ID 로 중복을 판단합니다.
+const HeaderEventID = "x-event-id"
+
+/*
+EventLedger 는 처리를 마친 이벤트를 기록해 Kafka 재전달(at-least-once) 시 이미 반영한 이벤트를 건너뜁니다.
+처리와 기록은 한 트랜잭션이 아니므로, 처리 후 기록 전에 종료되면 이벤트를 다시 처리합니다.
+이 경우에도 같은 상태가 되도록 repository 의 저장은 upsert 로 작성합니다.
+*/
+type EventLedger struct {
+	repo      persistence.ProcessedEventRepository
+	retention time.Duration
+}
+
+func NewEventLedger(repo persistence.ProcessedEventRepository, retention time.Duration) *EventLedger {
+	return &EventLedger{repo: repo, retention: retention}
+}
+
+// Processed 는 이벤트를 이미 처리했는지 확인합니다. 확인하지 못하면 처리하도록 false 를 반환합니다.
+func (l *EventLedger) Processed(event MessageEvent) bool {
+	processed, err := l.repo.IsEventProcessed(event.Topic, eventKey(event))
+	if err != nil {
+		log.Printf("Error checking processed %s event, processing it again: %v", event.Topic, err)
+		return false
+	}
+	return processed
+}
+
+// MarkProcessed 는 이벤트를 처리했다고 기록합니다.
+func (l *EventLedger) MarkProcessed(event MessageEvent) error {
+	if err := l.repo.MarkEventProcessed(event.Topic, eventKey(event)); err != nil {
+		return fmt.Errorf("mark event processed: %w", err)
+	}
+	return nil
+}
+
+// StartPruner 는 interval 마다 retention 보다 오래된 처리 기록을 지웁니다. ctx 가 취소되면 반환합니다.
+func (l *EventLedger) StartPruner(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		deleted, err := l.repo.DeleteProcessedEventsBefore(time.Now().Add(-l.retention))
+		if err != nil {
+			log.Printf("Processed event pruner failed: %v", err)
+		} else if deleted > 0 {
+			log.Printf("Pruned %d processed event records", deleted)
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Println("Processed event pruner stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// eventKey 는 처리 기록의 키입니다. 재시도 토픽을 거친 이벤트는 원래 토픽의 위치를 사용합니다.
+func eventKey(event MessageEvent) string {
+	m := event.Message
+	if id := EventHeader(m, HeaderEventID); id != "" {
+		return "id:" + id
+	}
+	if EventHeader(m, HeaderOriginalTopic) != "" {
+		return EventHeader(m, HeaderOriginalPartition) + "-" + EventHeader(m, HeaderOriginalOffset)
+	}
+	return fmt.Sprintf("%d-%d", m.Partition, m.Offset)
+}
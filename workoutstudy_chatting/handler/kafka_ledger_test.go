@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"workoutstudy_chatting/persistence"
+
+	"github.com/segmentio/kafka-go"
+)
+
+func TestLedgerSkipsProcessedEvents(t *testing.T) {
+	type delivery struct {
+		event    MessageEvent
+		fail     bool // handle 이 영구 오류로 실패
+		wantCall bool // handle 이 호출되어야 하는지
+	}
+	event := func(topic string, partition int, offset int64, kv ...string) MessageEvent {
+		return MessageEvent{Topic: topic, Message: kafka.Message{Topic: topic, Partition: partition, Offset: offset, Value: []byte("3"), Headers: headers(kv...)}}
+	}
+	// 재시도 토픽에서 읽은 이벤트는 DelayRetryEvents 가 원래 토픽 이벤트로 전달
+	retried := func(retryOffset int64) MessageEvent {
+		e := event("fit-group", 0, retryOffset, HeaderOriginalTopic, "fit-group", HeaderOriginalPartition, "2", HeaderOriginalOffset, "40", HeaderRetryCount, "1")
+		e.Message.Topic = "chatting-retry"
+		return e
+	}
+
+	tests := []struct {
+		name       string
+		deliveries []delivery
+	}{
+		{
+			name: "커밋 전 재전달된 이벤트는 건너뜀",
+			deliveries: []delivery{
+				{event: event("fit-group", 2, 40), wantCall: true},
+				{event: event("fit-group", 2, 40)},
+			},
+		},
+		{
+			name: "재시도 토픽을 거쳐 처리한 이벤트가 다시 전달되면 건너뜀",
+			deliveries: []delivery{
+				{event: retried(7), wantCall: true},
+				{event: retried(7)},
+				{event: event("fit-group", 2, 40)},
+			},
+		},
+		{
+			name: "dead-letter 에서 다시 보낸 이벤트는 이벤트 ID 로 건너뜀",
+			deliveries: []delivery{
+				{event: event("fit-group", 2, 40, HeaderEventID, "evt-1"), wantCall: true},
+				{event: event("fit-group", 0, 99, HeaderEventID, "evt-1", HeaderRedrivenAt, "2026-01-01T00:00:00Z")},
+			},
+		},
+		{
+			name: "실패한 이벤트는 기록하지 않으므로 다시 보내면 처리",
+			deliveries: []delivery{
+				{event: event("fit-group", 2, 40, HeaderEventID, "evt-1"), fail: true, wantCall: true},
+				{event: event("fit-group", 0, 99, HeaderEventID, "evt-1", HeaderRedrivenAt, "2026-01-01T00:00:00Z"), wantCall: true},
+			},
+		},
+		{
+			name: "다른 토픽의 같은 위치는 처리",
+			deliveries: []delivery{
+				{event: event("fit-group", 2, 40), wantCall: true},
+				{event: event("fit-mate", 2, 40), wantCall: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ledger := NewEventLedger(persistence.NewMemoryProcessedEventRepository(persistence.NewMemoryStore()), time.Hour)
+			retrier := NewEventRetrier(testKafkaConfig(), &fakePublisher{}, ledger)
+
+			for i, d := range tt.deliveries {
+				called := false
+				err := retrier.Process(context.Background(), d.event, func(context.Context, kafka.Message) error {
+					called = true
+					if d.fail {
+						return permanent(errors.New("bad event"))
+					}
+					return nil
+				})
+				if err != nil {
+					t.Fatalf("delivery %d: Process: %v", i, err)
+				}
+				if called != d.wantCall {
+					t.Fatalf("delivery %d: handle called = %v, want %v", i, called, d.wantCall)
+				}
+			}
+		})
+	}
+}
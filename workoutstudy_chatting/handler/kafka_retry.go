@@ -0,0 +1,235 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"workoutstudy_chatting/config"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// 재시도 토픽, dead-letter 토픽으로 보내는 이벤트의 헤더. 원래 이벤트의 Key, Value 는 그대로 보냅니다.
+const (
+	HeaderOriginalTopic     = "x-original-topic"
+	HeaderOriginalPartition = "x-original-partition"
+	HeaderOriginalOffset    = "x-original-offset"
+	HeaderAttempts          = "x-attempts"         // 지금까지 처리를 시도한 횟수
+	HeaderRetryCount        = "x-retry-count"      // 재시도 토픽을 거친 횟수
+	HeaderRetryNotBefore    = "x-retry-not-before" // 재시도 토픽 이벤트를 다시 처리할 시간 (RFC3339)
+	HeaderError             = "x-error"            // 마지막 처리 오류
+	HeaderFailedAt          = "x-failed-at"        // 마지막으로 실패한 시간 (RFC3339)
+	HeaderRedrivenAt        = "x-redriven-at"      // dead-letter 토픽에서 원래 토픽으로 다시 보낸 시간 (RFC3339)
+)
+
+var retryHeaders = map[string]bool{
+	HeaderOriginalTopic: true, HeaderOriginalPartition: true, HeaderOriginalOffset: true, HeaderAttempts: true,
+	HeaderRetryCount: true, HeaderRetryNotBefore: true, HeaderError: true, HeaderFailedAt: true, HeaderRedrivenAt: true,
+}
+
+// 재시도 토픽, dead-letter 토픽으로 보낼 때 기다리는 시간
+const publishTimeout = 10 * time.Second
+
+// EventPublisher 는 이벤트를 Kafka 로 보냅니다. 메시지마다 Topic 을 지정합니다.
+type EventPublisher interface {
+	Publish(ctx context.Context, msgs ...kafka.Message) error
+}
+
+var _ EventPublisher = (*config.KafkaProducer)(nil)
+
+// permanentError 는 다시 처리해도 실패하는 오류입니다. (이벤트 형식 오류 등)
+type permanentError struct {
+	err error
+}
+
+func (e permanentError) Error() string { return e.err.Error() }
+func (e permanentError) Unwrap() error { return e.err }
+
+// permanent 로 감싼 오류는 재시도하지 않고 바로 dead-letter 토픽으로 보냅니다.
+func permanent(err error) error {
+	return permanentError{err: err}
+}
+
+func isPermanent(err error) bool {
+	var p permanentError
+	return errors.As(err, &p)
+}
+
+// EventRetrier 는 토픽별 재시도 정책(config.RetryPolicy)에 따라 이벤트를 처리합니다.
+// ledger 가 있으면 이미 처리한 이벤트는 건너뛰고, 처리를 마친 이벤트를 기록합니다.
+type EventRetrier struct {
+	kafka     config.KafkaConfig
+	publisher EventPublisher
+	ledger    *EventLedger
+}
+
+func NewEventRetrier(kafkaConfig config.KafkaConfig, publisher EventPublisher, ledger *EventLedger) *EventRetrier {
+	return &EventRetrier{kafka: kafkaConfig, publisher: publisher, ledger: ledger}
+}
+
+// Process 는 handle 이 성공하거나, 실패한 이벤트를 재시도 토픽 또는 dead-letter 토픽으로 보낼 때까지 처리합니다.
+// handle 에는 ctx 를 넘기므로 종료 중에는 API 조회도 재시도 정책을 다 쓰기 전에 멈춥니다.
+// 실패한 이벤트를 보내지 못했거나 처리, 재시도 대기 중에 ctx 가 취소된(종료) 경우에만 오류를 반환합니다.
+// 이때 컨슈머는 오프셋을 커밋하지 않으므로 이벤트는 다시 전달됩니다.
+func (r *EventRetrier) Process(ctx context.Context, event MessageEvent, handle func(context.Context, kafka.Message) error) error {
+	if r.ledger != nil {
+		if r.ledger.Processed(event) {
+			log.Printf("Skipping %s event at partition %d offset %d: already processed", event.Topic, event.Message.Partition, event.Message.Offset)
+			return nil
+		}
+		apply := handle
+		handle = func(ctx context.Context, msg kafka.Message) error {
+			if err := apply(ctx, msg); err != nil {
+				return err
+			}
+			// 기록에 실패하면 재시도. 다시 처리해도 upsert 로 같은 상태가 됨
+			return r.ledger.MarkProcessed(event)
+		}
+	}
+
+	policy := r.kafka.RetryPolicyFor(event.Topic)
+	attempts := headerInt(event.Message, HeaderAttempts)
+	retryCount := headerInt(event.Message, HeaderRetryCount)
+
+	backoff := policy.Backoff
+	var err error
+	for attempt := 1; attempt <= policy.MaxAttempts; attempt++ {
+		attempts++
+		if err = handle(ctx, event.Message); err == nil {
+			if retryCount > 0 {
+				log.Printf("%s event succeeded after %d delayed retries", event.Topic, retryCount)
+			}
+			return nil
+		}
+		// 종료로 취소된 실패는 이벤트의 문제가 아니므로 재시도 토픽으로 보내지 않음
+		if ctx.Err() != nil {
+			return fmt.Errorf("%s event processing interrupted: %w", event.Topic, ctx.Err())
+		}
+		if isPermanent(err) {
+			log.Printf("Error handling %s event, not retrying: %v", event.Topic, err)
+			break
+		}
+		log.Printf("Error handling %s event (attempt %d/%d): %v", event.Topic, attempt, policy.MaxAttempts, err)
+		if attempt < policy.MaxAttempts {
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return fmt.Errorf("%s event retry interrupted: %w", event.Topic, ctx.Err())
+			}
+			if backoff *= 2; backoff > policy.MaxBackoff {
+				backoff = policy.MaxBackoff
+			}
+		}
+	}
+
+	if !isPermanent(err) && retryCount < policy.DelayedRetries {
+		notBefore := time.Now().Add(policy.RetryDelay << retryCount)
+		log.Printf("Sending %s event to retry topic %s (retry %d/%d at %s)", event.Topic, r.kafka.RetryTopic, retryCount+1, policy.DelayedRetries, notBefore.Format(time.RFC3339))
+		return r.publish(failedEvent(r.kafka.RetryTopic, event, err, attempts, retryCount+1, notBefore))
+	}
+	log.Printf("Sending %s event to dead-letter topic %s after %d attempts", event.Topic, r.kafka.DeadLetterTopic, attempts)
+	return r.publish(failedEvent(r.kafka.DeadLetterTopic, event, err, attempts, retryCount, time.Time{}))
+}
+
+/*
+DelayRetryEvents 는 컨슈머의 deliver 를 감싸 재시도 토픽 이벤트를 x-retry-not-before 까지 기다린 뒤 원래 토픽 이벤트로 전달합니다.
+토픽별 컨슈머 goroutine 에서 기다리므로 다른 토픽 이벤트 처리는 막지 않습니다.
+기다리는 중에 ctx 가 취소되면 오류를 반환합니다. 오프셋을 커밋하지 않으므로 다음 실행에서 다시 기다립니다.
+*/
+func (r *EventRetrier) DelayRetryEvents(ctx context.Context, deliver func(topic string, m kafka.Message) error) func(topic string, m kafka.Message) error {
+	return func(topic string, m kafka.Message) error {
+		if topic != r.kafka.RetryTopic {
+			return deliver(topic, m)
+		}
+
+		originalTopic := EventHeader(m, HeaderOriginalTopic)
+		if originalTopic == "" {
+			err := permanent(fmt.Errorf("missing %s header", HeaderOriginalTopic))
+			return r.publish(failedEvent(r.kafka.DeadLetterTopic, MessageEvent{Message: m, Topic: topic}, err, 0, 0, time.Time{}))
+		}
+
+		notBefore, _ := time.Parse(time.RFC3339Nano, EventHeader(m, HeaderRetryNotBefore))
+		if wait := time.Until(notBefore); wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			}
+		}
+		return deliver(originalTopic, m)
+	}
+}
+
+func (r *EventRetrier) publish(msg kafka.Message) error {
+	ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+	defer cancel()
+	if err := r.publisher.Publish(ctx, msg); err != nil {
+		return fmt.Errorf("publish to %s: %w", msg.Topic, err)
+	}
+	return nil
+}
+
+// failedEvent 는 실패한 이벤트를 topic 으로 보낼 메시지를 만듭니다. 원래 토픽과 위치는 처음 실패한 이벤트 기준입니다.
+func failedEvent(topic string, event MessageEvent, cause error, attempts, retryCount int, notBefore time.Time) kafka.Message {
+	m := event.Message
+	originalTopic, partition, offset := event.Topic, strconv.Itoa(m.Partition), strconv.FormatInt(m.Offset, 10)
+	if EventHeader(m, HeaderOriginalTopic) != "" {
+		partition, offset = EventHeader(m, HeaderOriginalPartition), EventHeader(m, HeaderOriginalOffset)
+	}
+
+	headers := append(withoutRetryHeaders(m.Headers),
+		kafka.Header{Key: HeaderOriginalTopic, Value: []byte(originalTopic)},
+		kafka.Header{Key: HeaderOriginalPartition, Value: []byte(partition)},
+		kafka.Header{Key: HeaderOriginalOffset, Value: []byte(offset)},
+		kafka.Header{Key: HeaderAttempts, Value: []byte(strconv.Itoa(attempts))},
+		kafka.Header{Key: HeaderRetryCount, Value: []byte(strconv.Itoa(retryCount))},
+		kafka.Header{Key: HeaderError, Value: []byte(cause.Error())},
+		kafka.Header{Key: HeaderFailedAt, Value: []byte(time.Now().UTC().Format(time.RFC3339Nano))},
+	)
+	if !notBefore.IsZero() {
+		headers = append(headers, kafka.Header{Key: HeaderRetryNotBefore, Value: []byte(notBefore.UTC().Format(time.RFC3339Nano))})
+	}
+	return kafka.Message{Topic: topic, Key: m.Key, Value: m.Value, Headers: headers}
+}
+
+// RedriveMessage 는 dead-letter 이벤트를 원래 토픽으로 다시 보낼 메시지로 바꿉니다. 재시도 정보는 지우고 처음부터 다시 처리합니다.
+func RedriveMessage(m kafka.Message) (kafka.Message, error) {
+	topic := EventHeader(m, HeaderOriginalTopic)
+	if topic == "" {
+		return kafka.Message{}, fmt.Errorf("dead-letter event at partition %d offset %d has no %s header", m.Partition, m.Offset, HeaderOriginalTopic)
+	}
+	headers := append(withoutRetryHeaders(m.Headers),
+		kafka.Header{Key: HeaderRedrivenAt, Value: []byte(time.Now().UTC().Format(time.RFC3339Nano))})
+	return kafka.Message{Topic: topic, Key: m.Key, Value: m.Value, Headers: headers}, nil
+}
+
+// EventHeader 는 key 헤더 값을 반환합니다. 없으면 빈 문자열입니다.
+func EventHeader(m kafka.Message, key string) string {
+	for _, h := range m.Headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+func headerInt(m kafka.Message, key string) int {
+	n, _ := strconv.Atoi(EventHeader(m, key))
+	return n
+}
+
+func withoutRetryHeaders(headers []kafka.Header) []kafka.Header {
+	kept := make([]kafka.Header, 0, len(headers))
+	for _, h := range headers {
+		if !retryHeaders[h.Key] {
+			kept = append(kept, h)
+		}
+	}
+	return kept
+}
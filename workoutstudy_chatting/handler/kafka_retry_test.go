@@ -0,0 +1,299 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"workoutstudy_chatting/config"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// fakePublisher 는 보낸 메시지를 기록하는 EventPublisher 입니다. err 가 있으면 보내지 않고 반환합니다.
+type fakePublisher struct {
+	mu   sync.Mutex
+	msgs []kafka.Message
+	err  error
+}
+
+func (p *fakePublisher) Publish(_ context.Context, msgs ...kafka.Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.err != nil {
+		return p.err
+	}
+	p.msgs = append(p.msgs, msgs...)
+	return nil
+}
+
+func (p *fakePublisher) published() []kafka.Message {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]kafka.Message(nil), p.msgs...)
+}
+
+func testKafkaConfig() config.KafkaConfig {
+	return config.KafkaConfig{
+		RetryTopic:      "chatting-retry",
+		DeadLetterTopic: "chatting-dlq",
+		Retry: config.RetryPolicy{
+			MaxAttempts:    3,
+			Backoff:        time.Millisecond,
+			MaxBackoff:     2 * time.Millisecond,
+			DelayedRetries: 2,
+			RetryDelay:     time.Minute,
+		},
+	}
+}
+
+func headers(kv ...string) []kafka.Header {
+	hs := make([]kafka.Header, 0, len(kv)/2)
+	for i := 0; i+1 < len(kv); i += 2 {
+		hs = append(hs, kafka.Header{Key: kv[i], Value: []byte(kv[i+1])})
+	}
+	return hs
+}
+
+// failing 은 처음 n 번 err 를 반환하고 그 뒤로 성공하는 handle 과 호출 횟수를 반환합니다. n 이 음수면 계속 실패합니다.
+func failing(n int, err error) (func(context.Context, kafka.Message) error, *int) {
+	calls := 0
+	return func(context.Context, kafka.Message) error {
+		calls++
+		if n < 0 || calls <= n {
+			return err
+		}
+		return nil
+	}, &calls
+}
+
+func TestProcessRoutesFailedEvents(t *testing.T) {
+	transient := errors.New("fit-group service unavailable")
+	tests := []struct {
+		name        string
+		headers     []kafka.Header // 처리할 이벤트의 헤더
+		failures    int            // handle 이 실패하는 횟수. 음수면 계속 실패
+		err         error
+		topicRetry  map[string]config.RetryPolicy
+		wantCalls   int
+		wantTopic   string // 보낸 토픽. 빈 문자열이면 보내지 않음
+		wantHeaders map[string]string
+	}{
+		{name: "성공하면 보내지 않음", wantCalls: 1},
+		{name: "MaxAttempts 안에 성공하면 보내지 않음", failures: 2, err: transient, wantCalls: 3},
+		{
+			name: "일시적 오류는 재시도 토픽", failures: -1, err: transient, wantCalls: 3, wantTopic: "chatting-retry",
+			wantHeaders: map[string]string{
+				HeaderOriginalTopic: "fit-group", HeaderOriginalPartition: "2", HeaderOriginalOffset: "40",
+				HeaderAttempts: "3", HeaderRetryCount: "1", HeaderError: transient.Error(),
+			},
+		},
+		{
+			name: "재시도 토픽을 거친 이벤트는 처음 위치를 유지하고 횟수를 누적",
+			headers: headers(
+				HeaderOriginalTopic, "fit-group", HeaderOriginalPartition, "0", HeaderOriginalOffset, "7",
+				HeaderAttempts, "3", HeaderRetryCount, "1", HeaderError, "old", HeaderRetryNotBefore, "2026-01-01T00:00:00Z",
+			),
+			failures: -1, err: transient, wantCalls: 3, wantTopic: "chatting-retry",
+			wantHeaders: map[string]string{
+				HeaderOriginalTopic: "fit-group", HeaderOriginalPartition: "0", HeaderOriginalOffset: "7",
+				HeaderAttempts: "6", HeaderRetryCount: "2", HeaderError: transient.Error(),
+			},
+		},
+		{
+			name:     "DelayedRetries 를 다 쓰면 dead-letter 토픽",
+			headers:  headers(HeaderOriginalTopic, "fit-group", HeaderOriginalPartition, "0", HeaderOriginalOffset, "7", HeaderAttempts, "6", HeaderRetryCount, "2"),
+			failures: -1, err: transient, wantCalls: 3, wantTopic: "chatting-dlq",
+			wantHeaders: map[string]string{
+				HeaderOriginalPartition: "0", HeaderOriginalOffset: "7", HeaderAttempts: "9", HeaderRetryCount: "2", HeaderRetryNotBefore: "",
+			},
+		},
+		{
+			name: "영구 오류는 재시도하지 않고 dead-letter 토픽", failures: -1, err: permanent(errors.New("bad event")), wantCalls: 1, wantTopic: "chatting-dlq",
+			wantHeaders: map[string]string{
+				HeaderOriginalTopic: "fit-group", HeaderAttempts: "1", HeaderRetryCount: "0", HeaderError: "bad event", HeaderRetryNotBefore: "",
+			},
+		},
+		{
+			name: "토픽별 정책 사용", failures: -1, err: transient, wantCalls: 1, wantTopic: "chatting-dlq",
+			topicRetry:  map[string]config.RetryPolicy{"fit-group": {MaxAttempts: 1}},
+			wantHeaders: map[string]string{HeaderAttempts: "1", HeaderRetryCount: "0"},
+		},
+		{
+			name: "다른 헤더는 그대로 전달", headers: headers(HeaderEventID, "evt-1", "trace-id", "abc"),
+			failures: -1, err: transient, wantCalls: 3, wantTopic: "chatting-retry",
+			wantHeaders: map[string]string{HeaderEventID: "evt-1", "trace-id": "abc"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kafkaConfig := testKafkaConfig()
+			kafkaConfig.TopicRetry = tt.topicRetry
+			publisher := &fakePublisher{}
+			retrier := NewEventRetrier(kafkaConfig, publisher, nil)
+			event := MessageEvent{
+				Topic:   "fit-group",
+				Message: kafka.Message{Topic: "fit-group", Partition: 2, Offset: 40, Key: []byte("3"), Value: []byte("3"), Headers: tt.headers},
+			}
+			handle, calls := failing(tt.failures, tt.err)
+
+			if err := retrier.Process(context.Background(), event, handle); err != nil {
+				t.Fatalf("Process: %v", err)
+			}
+			if *calls != tt.wantCalls {
+				t.Fatalf("handle calls = %d, want %d", *calls, tt.wantCalls)
+			}
+			published := publisher.published()
+			if tt.wantTopic == "" {
+				if len(published) != 0 {
+					t.Fatalf("published %d messages, want none", len(published))
+				}
+				return
+			}
+			if len(published) != 1 {
+				t.Fatalf("published %d messages, want 1", len(published))
+			}
+			m := published[0]
+			if m.Topic != tt.wantTopic || string(m.Key) != "3" || string(m.Value) != "3" {
+				t.Fatalf("published topic %q key %q value %q", m.Topic, m.Key, m.Value)
+			}
+			for key, want := range tt.wantHeaders {
+				if got := EventHeader(m, key); got != want {
+					t.Errorf("header %s = %q, want %q", key, got, want)
+				}
+			}
+			seen := map[string]bool{}
+			for _, h := range m.Headers {
+				if seen[h.Key] {
+					t.Errorf("header %s is duplicated", h.Key)
+				}
+				seen[h.Key] = true
+			}
+			if tt.wantTopic == "chatting-retry" {
+				notBefore, err := time.Parse(time.RFC3339Nano, EventHeader(m, HeaderRetryNotBefore))
+				if err != nil || time.Until(notBefore) < 30*time.Second {
+					t.Errorf("%s = %q, want about RetryDelay from now", HeaderRetryNotBefore, EventHeader(m, HeaderRetryNotBefore))
+				}
+			}
+		})
+	}
+}
+
+func TestProcessReturnsErrorWhenPublishFails(t *testing.T) {
+	retrier := NewEventRetrier(testKafkaConfig(), &fakePublisher{err: errors.New("broker down")}, nil)
+	handle, _ := failing(-1, permanent(errors.New("bad event")))
+
+	err := retrier.Process(context.Background(), MessageEvent{Topic: "fit-group"}, handle)
+	if err == nil {
+		t.Fatal("Process returned nil, want publish error so the offset is not committed")
+	}
+}
+
+func TestProcessStopsOnShutdown(t *testing.T) {
+	tests := []struct {
+		name   string
+		handle func(cancel context.CancelFunc) func(context.Context, kafka.Message) error
+	}{
+		{
+			name: "backoff 중 종료",
+			handle: func(cancel context.CancelFunc) func(context.Context, kafka.Message) error {
+				return func(context.Context, kafka.Message) error {
+					time.AfterFunc(20*time.Millisecond, cancel) // 실패를 반환한 뒤 backoff 를 기다리는 중에 종료
+					return errors.New("fit-group service unavailable")
+				}
+			},
+		},
+		{
+			name: "취소된 API 조회로 실패",
+			handle: func(cancel context.CancelFunc) func(context.Context, kafka.Message) error {
+				return func(ctx context.Context, _ kafka.Message) error {
+					cancel()
+					return ctx.Err()
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kafkaConfig := testKafkaConfig()
+			kafkaConfig.Retry.Backoff, kafkaConfig.Retry.MaxBackoff = time.Hour, time.Hour
+			publisher := &fakePublisher{}
+			retrier := NewEventRetrier(kafkaConfig, publisher, nil)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			done := make(chan error, 1)
+			go func() {
+				done <- retrier.Process(ctx, MessageEvent{Topic: "fit-group"}, tt.handle(cancel))
+			}()
+			select {
+			case err := <-done:
+				if !errors.Is(err, context.Canceled) {
+					t.Fatalf("Process err = %v, want context.Canceled", err)
+				}
+			case <-time.After(2 * time.Second):
+				t.Fatal("Process kept waiting for backoff after shutdown")
+			}
+			if published := publisher.published(); len(published) != 0 {
+				t.Fatalf("published %d messages on shutdown, want none", len(published))
+			}
+		})
+	}
+}
+
+func TestRedriveMessage(t *testing.T) {
+	tests := []struct {
+		name      string
+		headers   []kafka.Header
+		wantErr   bool
+		wantTopic string
+	}{
+		{
+			name: "원래 토픽으로 보내고 재시도 정보는 지움",
+			headers: headers(
+				HeaderOriginalTopic, "fit-mate", HeaderOriginalPartition, "1", HeaderOriginalOffset, "5",
+				HeaderAttempts, "9", HeaderRetryCount, "2", HeaderError, "boom", HeaderFailedAt, "2026-01-01T00:00:00Z",
+				HeaderEventID, "evt-1",
+			),
+			wantTopic: "fit-mate",
+		},
+		{name: "원래 토픽이 없으면 오류", headers: headers(HeaderEventID, "evt-1"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dead := kafka.Message{Topic: "chatting-dlq", Partition: 0, Offset: 11, Key: []byte("k"), Value: []byte("v"), Headers: tt.headers}
+			m, err := RedriveMessage(dead)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("RedriveMessage = %+v, want error", m)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RedriveMessage: %v", err)
+			}
+			if m.Topic != tt.wantTopic || string(m.Key) != "k" || string(m.Value) != "v" {
+				t.Fatalf("redriven topic %q key %q value %q", m.Topic, m.Key, m.Value)
+			}
+			for _, h := range m.Headers {
+				if retryHeaders[h.Key] && h.Key != HeaderRedrivenAt {
+					t.Errorf("retry header %s was kept", h.Key)
+				}
+			}
+			if EventHeader(m, HeaderEventID) != "evt-1" {
+				t.Errorf("%s header was dropped", HeaderEventID)
+			}
+			if _, err := time.Parse(time.RFC3339Nano, EventHeader(m, HeaderRedrivenAt)); err != nil {
+				t.Errorf("%s = %q: %v", HeaderRedrivenAt, EventHeader(m, HeaderRedrivenAt), err)
+			}
+			if !redelivered(m) {
+				t.Error("redriven event is not treated as redelivered")
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"workoutstudy_chatting/model"
+	"workoutstudy_chatting/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ModerationHandler struct {
+	ModerationService service.ModerationUseCase
+}
+
+func NewModerationHandler(moderationService service.ModerationUseCase) *ModerationHandler {
+	return &ModerationHandler{ModerationService: moderationService}
+}
+
+// @Summary moderation queue 조회 API
+// @Description 필터에 의해 flag 된 메시지 목록을 조회합니다. fit leader 만 조회할 수 있습니다.
+// @Tags moderation
+// @Produce  json
+// @Param fitGroupId query int true "피트그룹 ID"
+// @Param userId query int true "요청 사용자 ID (fit leader)"
+// @Param status query string false "PENDING, APPROVED, REMOVED. 기본값 PENDING"
+// @Success 200 {array} model.ModerationItem
+// @Router /moderation/queue [get]
+func (h *ModerationHandler) GetModerationQueue(c *gin.Context) {
+	fitGroupID, err := strconv.Atoi(c.Query("fitGroupId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 fit-group-id"})
+		return
+	}
+	userID, err := strconv.Atoi(c.Query("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 userId"})
+		return
+	}
+
+	items, err := h.ModerationService.GetModerationQueue(fitGroupID, userID, model.ModerationStatus(c.Query("status")))
+	if err != nil {
+		respondModerationError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, items)
+}
+
+// @Summary moderation queue 검토 API
+// @Description fit leader 가 flag 된 메시지를 승인(APPROVED)하거나 삭제(REMOVED)합니다.
+// @Tags moderation
+// @Produce  json
+// @Param moderationId query int true "moderation queue 항목 ID"
+// @Param userId query int true "요청 사용자 ID (fit leader)"
+// @Param status query string true "APPROVED 또는 REMOVED"
+// @Success 200 {object} model.ModerationItem
+// @Router /moderation/review [put]
+func (h *ModerationHandler) ReviewModerationItem(c *gin.Context) {
+	moderationID, err := strconv.Atoi(c.Query("moderationId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 moderationId"})
+		return
+	}
+	userID, err := strconv.Atoi(c.Query("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 userId"})
+		return
+	}
+	status := model.ModerationStatus(c.Query("status"))
+	if status != model.ModerationApproved && status != model.ModerationRemoved {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 status"})
+		return
+	}
+
+	item, err := h.ModerationService.ReviewModerationItem(moderationID, userID, status)
+	if err != nil {
+		respondModerationError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, item)
+}
+
+func respondModerationError(c *gin.Context, err error) {
+	if errors.Is(err, service.ErrNotFitLeader) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	log.Printf("Error handling moderation request: %v", err)
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "moderation 요청 처리 실패"})
+}
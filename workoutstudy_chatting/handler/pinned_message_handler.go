@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"workoutstudy_chatting/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PinnedMessageHandler struct {
+	PinnedMessageService service.PinnedMessageUseCase
+}
+
+func NewPinnedMessageHandler(pinnedMessageService service.PinnedMessageUseCase) *PinnedMessageHandler {
+	return &PinnedMessageHandler{PinnedMessageService: pinnedMessageService}
+}
+
+// @Summary 메시지 고정 API
+// @Description fit leader 가 공지 등 메시지를 채팅방 상단에 고정합니다. fit group 당 최대 10개까지 고정할 수 있습니다.
+// @Tags pin
+// @Produce  json
+// @Param messageId query string true "고정할 message UUID"
+// @Param userId query int true "요청 사용자 ID (fit leader)"
+// @Success 200 {object} model.PinnedMessage
+// @Router /moderation/pin [post]
+func (h *PinnedMessageHandler) PinMessage(c *gin.Context) {
+	messageID := c.Query("messageId")
+	leaderID, err := strconv.Atoi(c.Query("userId"))
+	if err != nil || messageID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 요청"})
+		return
+	}
+	pin, err := h.PinnedMessageService.PinMessage(messageID, leaderID)
+	if err != nil {
+		respondPinError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, pin)
+}
+
+// @Summary 메시지 고정 해제 API
+// @Tags pin
+// @Param messageId query string true "고정 해제할 message UUID"
+// @Param userId query int true "요청 사용자 ID (fit leader)"
+// @Success 204
+// @Router /moderation/pin [delete]
+func (h *PinnedMessageHandler) UnpinMessage(c *gin.Context) {
+	messageID := c.Query("messageId")
+	leaderID, err := strconv.Atoi(c.Query("userId"))
+	if err != nil || messageID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 요청"})
+		return
+	}
+	if err := h.PinnedMessageService.UnpinMessage(messageID, leaderID); err != nil {
+		respondPinError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary 고정 메시지 조회 API
+// @Description fit group 정보와 고정된 메시지 목록을 함께 조회합니다. fit group 멤버만 조회할 수 있습니다.
+// @Tags pin
+// @Produce  json
+// @Param fitGroupId query int true "피트그룹 ID"
+// @Param userId query int true "요청 사용자 ID"
+// @Success 200 {object} model.FitGroupPins
+// @Router /retrieve/pin [get]
+func (h *PinnedMessageHandler) GetPinnedMessages(c *gin.Context) {
+	fitGroupID, err := strconv.Atoi(c.Query("fitGroupId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 fit-group-id"})
+		return
+	}
+	userID, err := strconv.Atoi(c.Query("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 userId"})
+		return
+	}
+	pins, err := h.PinnedMessageService.GetPinnedMessages(fitGroupID, userID)
+	if err != nil {
+		respondPinError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, pins)
+}
+
+func respondPinError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrNotFitLeader), errors.Is(err, service.ErrNotFitGroupMember):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrInvalidTarget):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrNotPinned):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrPinLimitExceeded):
+		c.JSON(http.StatusConflict, gin.H{"error": "고정 메시지는 최대 " + strconv.Itoa(service.MaxPinnedMessages) + "개까지 가능합니다."})
+	default:
+		log.Printf("Error handling pinned message request: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "고정 메시지 요청 처리 실패"})
+	}
+}
@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"workoutstudy_chatting/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PollHandler struct {
+	PollService service.PollUseCase
+}
+
+func NewPollHandler(pollService service.PollUseCase) *PollHandler {
+	return &PollHandler{PollService: pollService}
+}
+
+// @Summary 투표 조회 API
+// @Description 투표 메시지의 선택지와 현재 집계, 요청한 사용자가 선택한 선택지를 조회합니다.
+// @Description 투표와 투표 종료는 채팅 웹소켓으로 {"type":"POLL_VOTE","pollId":1,"optionIds":[2]}, {"type":"POLL_CLOSE","pollId":1} 프레임을 보냅니다.
+// @Tags poll
+// @Produce  json
+// @Param messageId query string true "투표 message UUID"
+// @Param userId query int true "요청 사용자 ID"
+// @Success 200 {object} model.ChatPoll
+// @Router /retrieve/poll [get]
+func (h *PollHandler) GetPoll(c *gin.Context) {
+	messageID := c.Query("messageId")
+	userID, err := strconv.Atoi(c.Query("userId"))
+	if err != nil || messageID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 요청"})
+		return
+	}
+	poll, err := h.PollService.GetPoll(messageID, userID)
+	if err != nil {
+		if errors.Is(err, service.ErrNotFitGroupMember) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		log.Printf("Error retrieving poll for message %s: %v", messageID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "투표 조회 실패"})
+		return
+	}
+	c.JSON(http.StatusOK, poll)
+}
@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"workoutstudy_chatting/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReconciliationHandler struct {
+	ReconciliationService service.ReconciliationUseCase
+}
+
+func NewReconciliationHandler(reconciliationService service.ReconciliationUseCase) *ReconciliationHandler {
+	return &ReconciliationHandler{ReconciliationService: reconciliationService}
+}
+
+// @Summary 정합성 맞추기 즉시 실행 API
+// @Description DB 의 fit group, fit mate, 사용자를 fit-group, auth 서비스에서 다시 조회한 정보로 맞추고 변경 내역을 반환
+// @Tags reconciliation
+// @Produce  json
+// @Success 200 {object} model.ReconciliationReport
+// @Failure 409 {object} map[string]string "이미 실행 중"
+// @Router /reconciliation/run [post]
+func (h *ReconciliationHandler) RunReconciliation(c *gin.Context) {
+	report, err := h.ReconciliationService.RunReconciliation(c.Request.Context())
+	if errors.Is(err, service.ErrReconciliationRunning) {
+		c.JSON(http.StatusConflict, gin.H{"error": "정합성 맞추기가 이미 실행 중"})
+		return
+	}
+	if err != nil {
+		log.Printf("Error running reconciliation: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "정합성 맞추기 실행 실패", "report": report})
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
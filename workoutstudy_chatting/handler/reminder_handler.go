@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"workoutstudy_chatting/model"
+	"workoutstudy_chatting/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReminderHandler struct {
+	ReminderService service.ReminderUseCase
+}
+
+func NewReminderHandler(reminderService service.ReminderUseCase) *ReminderHandler {
+	return &ReminderHandler{ReminderService: reminderService}
+}
+
+// @Summary 리마인더 등록 API
+// @Description fit leader 가 채팅방에 핏봇이 보낼 정기 알림을 등록합니다. 시간은 fit group 시간대 기준입니다.
+// @Description recurrence: DAILY(매일), WEEKLY(weekdays 요일마다, 0: 일요일), CYCLE_DEADLINE(인증 주기 마감 daysBefore 일 전, 인증 현황 포함)
+// @Tags reminder
+// @Accept  json
+// @Produce  json
+// @Param userId query int true "요청 사용자 ID (fit leader)"
+// @Param reminder body model.Reminder true "fitGroupId, recurrence, message, timeOfDay(HH:MM), weekdays, daysBefore"
+// @Success 200 {object} model.Reminder
+// @Router /reminder [post]
+func (h *ReminderHandler) CreateReminder(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Query("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 userId"})
+		return
+	}
+	var reminder model.Reminder
+	if err := c.ShouldBindJSON(&reminder); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 요청"})
+		return
+	}
+	saved, err := h.ReminderService.CreateReminder(userID, reminder)
+	if err != nil {
+		respondReminderError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, saved)
+}
+
+// @Summary 리마인더 목록 조회 API
+// @Tags reminder
+// @Produce  json
+// @Param fitGroupId query int true "피트그룹 ID"
+// @Param userId query int true "요청 사용자 ID (fit leader)"
+// @Success 200 {array} model.Reminder
+// @Router /reminder [get]
+func (h *ReminderHandler) GetReminders(c *gin.Context) {
+	fitGroupID, err := strconv.Atoi(c.Query("fitGroupId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 fit-group-id"})
+		return
+	}
+	userID, err := strconv.Atoi(c.Query("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 userId"})
+		return
+	}
+	reminders, err := h.ReminderService.GetReminders(fitGroupID, userID)
+	if err != nil {
+		respondReminderError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, reminders)
+}
+
+// @Summary 리마인더 삭제 API
+// @Tags reminder
+// @Param fitGroupId query int true "피트그룹 ID"
+// @Param userId query int true "요청 사용자 ID (fit leader)"
+// @Param reminderId query int true "리마인더 ID"
+// @Success 204
+// @Router /reminder [delete]
+func (h *ReminderHandler) DeleteReminder(c *gin.Context) {
+	fitGroupID, err := strconv.Atoi(c.Query("fitGroupId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 fit-group-id"})
+		return
+	}
+	userID, err := strconv.Atoi(c.Query("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 userId"})
+		return
+	}
+	reminderID, err := strconv.Atoi(c.Query("reminderId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 reminderId"})
+		return
+	}
+	if err := h.ReminderService.DeleteReminder(fitGroupID, userID, reminderID); err != nil {
+		respondReminderError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary 채팅방 시간대 설정 API
+// @Description fit leader 가 리마인더 실행 기준 시간대를 설정합니다. 등록된 리마인더의 다음 실행 시간도 다시 계산됩니다.
+// @Tags chat
+// @Produce  json
+// @Param fitGroupId query int true "피트그룹 ID"
+// @Param userId query int true "요청 사용자 ID (fit leader)"
+// @Param timeZone query string true "IANA 시간대 (예: Asia/Seoul)"
+// @Success 200 {object} model.ChatRoomSetting
+// @Router /chat/time-zone [put]
+func (h *ReminderHandler) SetTimeZone(c *gin.Context) {
+	fitGroupID, err := strconv.Atoi(c.Query("fitGroupId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 fit-group-id"})
+		return
+	}
+	userID, err := strconv.Atoi(c.Query("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 userId"})
+		return
+	}
+	setting, err := h.ReminderService.SetTimeZone(fitGroupID, userID, c.Query("timeZone"))
+	if err != nil {
+		respondReminderError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, setting)
+}
+
+func respondReminderError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrNotFitLeader):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrInvalidReminder), errors.Is(err, service.ErrInvalidTimeZone), errors.Is(err, service.ErrTooManyReminders):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrReminderNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		log.Printf("Error handling reminder request: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "리마인더 요청 처리 실패"})
+	}
+}
@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+	"workoutstudy_chatting/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RetentionHandler struct {
+	RetentionService service.RetentionUseCase
+}
+
+func NewRetentionHandler(retentionService service.RetentionUseCase) *RetentionHandler {
+	return &RetentionHandler{RetentionService: retentionService}
+}
+
+// @Summary 메시지 보관 기간 정책 조회 API
+// @Description fit group 별로 설정된 메시지 보관 기간 정책 목록을 조회
+// @Tags retention
+// @Produce  json
+// @Success 200 {array} model.RetentionPolicy
+// @Router /retention/policy [get]
+func (h *RetentionHandler) GetRetentionPolicies(c *gin.Context) {
+	policies, err := h.RetentionService.GetRetentionPolicies()
+	if err != nil {
+		log.Printf("Error retrieving retention policies: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "보관 정책 조회 실패"})
+		return
+	}
+	c.JSON(http.StatusOK, policies)
+}
+
+// @Summary 메시지 보관 기간 정책 설정 API
+// @Description fit group 의 메시지 보관 기간(일)을 설정. 0 이면 영구 보관. fit leader 만 설정 가능
+// @Tags retention
+// @Produce  json
+// @Param fitGroupId query int true "피트그룹 ID"
+// @Param retentionDays query int true "보관 기간 (일)"
+// @Param userId query int true "요청한 사용자 ID (fit leader)"
+// @Success 200 {object} model.RetentionPolicy
+// @Failure 403 {object} map[string]string "fit leader 가 아님"
+// @Router /retention/policy [put]
+func (h *RetentionHandler) SetRetentionPolicy(c *gin.Context) {
+	fitGroupID, err := strconv.Atoi(c.Query("fitGroupId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 fit-group-id"})
+		return
+	}
+	retentionDays, err := strconv.Atoi(c.Query("retentionDays"))
+	if err != nil || retentionDays < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 retentionDays"})
+		return
+	}
+	userID, err := strconv.Atoi(c.Query("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 userId"})
+		return
+	}
+
+	policy, err := h.RetentionService.SetRetentionPolicy(fitGroupID, retentionDays, userID)
+	if err != nil {
+		respondRetentionError(c, err, "보관 정책 저장 실패")
+		return
+	}
+	c.JSON(http.StatusOK, policy)
+}
+
+// @Summary 메시지 보관 기간 정책 삭제 API
+// @Description fit group 별 정책을 삭제하여 전역 보관 기간을 따르도록 함. fit leader 만 삭제 가능
+// @Tags retention
+// @Param fitGroupId query int true "피트그룹 ID"
+// @Param userId query int true "요청한 사용자 ID (fit leader)"
+// @Success 204
+// @Failure 403 {object} map[string]string "fit leader 가 아님"
+// @Router /retention/policy [delete]
+func (h *RetentionHandler) DeleteRetentionPolicy(c *gin.Context) {
+	fitGroupID, err := strconv.Atoi(c.Query("fitGroupId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 fit-group-id"})
+		return
+	}
+	userID, err := strconv.Atoi(c.Query("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 userId"})
+		return
+	}
+	if err := h.RetentionService.DeleteRetentionPolicy(fitGroupID, userID); err != nil {
+		respondRetentionError(c, err, "보관 정책 삭제 실패")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary 아카이브 메시지 복원 API
+// @Description 특정 피트그룹의 아카이브된 메시지를 날짜 구간(양 끝 포함)으로 복원. fit leader 만 복원 가능
+// @Description 삭제된 메시지는 삭제된 상태로 복원하며, 복원한 메시지는 보관 기간이 지나도 다시 아카이브하지 않음
+// @Tags retention
+// @Produce  json
+// @Param fitGroupId query int true "피트그룹 ID"
+// @Param userId query int true "요청한 사용자 ID (fit leader)"
+// @Param start query string true "복원 시작 날짜 (yyyy-MM-dd)"
+// @Param end query string true "복원 종료 날짜 (yyyy-MM-dd)"
+// @Success 200 {object} map[string]int
+// @Failure 403 {object} map[string]string "fit leader 가 아님"
+// @Failure 503 {object} map[string]string "아카이브 저장소가 설정되지 않음"
+// @Router /archive/restore [post]
+func (h *RetentionHandler) RestoreArchive(c *gin.Context) {
+	fitGroupID, err := strconv.Atoi(c.Query("fitGroupId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 fit-group-id"})
+		return
+	}
+	userID, err := strconv.Atoi(c.Query("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 userId"})
+		return
+	}
+	start, err := time.Parse("2006-01-02", c.Query("start"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 start 날짜"})
+		return
+	}
+	end, err := time.Parse("2006-01-02", c.Query("end"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 end 날짜"})
+		return
+	}
+	// end 날짜의 메시지까지 포함
+	end = end.AddDate(0, 0, 1).Add(-time.Nanosecond)
+
+	restored, err := h.RetentionService.RestoreArchive(fitGroupID, userID, start, end)
+	if errors.Is(err, service.ErrNotFitLeader) || errors.Is(err, service.ErrArchiveNotConfigured) {
+		respondRetentionError(c, err, "아카이브 복원 실패")
+		return
+	}
+	if err != nil {
+		log.Printf("Error restoring archive: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "아카이브 복원 실패", "restored": restored})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"restored": restored})
+}
+
+func respondRetentionError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrNotFitLeader):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrArchiveNotConfigured):
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+	default:
+		log.Printf("Error handling retention request: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"strconv"
+	"workoutstudy_chatting/model"
+	"workoutstudy_chatting/service"
+)
+
+// roomNotifier 는 service.RoomNotifier 구현체로, 현재 인스턴스에 열려 있는 Room 에 이벤트를 전달합니다.
+// 접속자가 없는 채팅방은 Room 이 없으므로 이벤트를 버립니다.
+type roomNotifier struct{}
+
+var _ service.RoomNotifier = (*roomNotifier)(nil)
+
+func NewRoomNotifier() service.RoomNotifier {
+	return &roomNotifier{}
+}
+
+func (n *roomNotifier) Publish(fitGroupID int, event model.RoomEvent) {
+	room := findRoom(fitGroupID)
+	if room == nil {
+		return
+	}
+	select {
+	case room.events <- event:
+	case <-room.done:
+	}
+}
+
+func (n *roomNotifier) Post(fitGroupID int, msg model.ChatMessage) {
+	room := findRoom(fitGroupID)
+	if room == nil {
+		return
+	}
+	select {
+	case room.events <- msg:
+	case <-room.done:
+	}
+}
+
+func (n *roomNotifier) Kick(fitGroupID, userID int, reason string) {
+	room := findRoom(fitGroupID)
+	if room == nil {
+		return
+	}
+	select {
+	case room.kick <- kickRequest{userID: userID, reason: reason}:
+	case <-room.done:
+	}
+}
+
+func findRoom(fitGroupID int) *Room {
+	roomLock.Lock()
+	defer roomLock.Unlock()
+	return rooms[strconv.Itoa(fitGroupID)]
+}
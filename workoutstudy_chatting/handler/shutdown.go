@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+	"workoutstudy_chatting/model"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// 종료 시 클라이언트에게 안내하는 재접속 대기 시간. 새 인스턴스가 뜰 시간을 고려
+const reconnectAfter = 3 * time.Second
+
+// inflight 는 종료 시 기다려야 하는 작업 수를 셉니다.
+// sync.WaitGroup 과 달리 종료가 시작된 뒤의 새 작업을 거부(tryAdd)할 수 있습니다.
+type inflight struct {
+	mu       sync.Mutex
+	n        int
+	draining bool
+	idle     chan struct{}
+}
+
+// tryAdd 는 종료가 시작되지 않았으면 작업을 추가하고 true 를 반환합니다.
+func (t *inflight) tryAdd() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.draining {
+		return false
+	}
+	t.n++
+	return true
+}
+
+// add 는 종료 중에도 작업을 추가합니다. 처리 중인 메시지가 보내는 웹훅처럼 이미 시작된 작업의 후속 작업에 사용합니다.
+func (t *inflight) add() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.n++
+}
+
+func (t *inflight) done() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.n--
+	if t.n == 0 && t.idle != nil {
+		close(t.idle)
+		t.idle = nil
+	}
+}
+
+// drain 은 새 작업을 거부하도록 표시합니다.
+func (t *inflight) drain() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.draining = true
+}
+
+// wait 는 진행 중인 작업이 모두 끝나거나 ctx 가 끝날 때까지 기다립니다.
+func (t *inflight) wait(ctx context.Context) error {
+	t.mu.Lock()
+	if t.n == 0 {
+		t.mu.Unlock()
+		return nil
+	}
+	if t.idle == nil {
+		t.idle = make(chan struct{})
+	}
+	idle := t.idle
+	t.mu.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+var (
+	activeConnections inflight // 실시간 연결(웹소켓, SSE, long-poll 대기)과 REST 전송 처리
+	pendingWebhooks   inflight // 전송 중인 alarm-service 웹훅
+)
+
+// webhookCtx 는 웹훅 전송에 넘기는 context 입니다. 종료 대기 시간이 지나면 취소해 재시도 중인 전송을 멈춥니다.
+var webhookCtx, cancelWebhooks = context.WithCancel(context.Background())
+
+// acceptConnection 은 새 실시간 연결을 받을 수 있으면 true 를 반환합니다. true 면 연결이 끝날 때 activeConnections.done() 을 호출해야 합니다.
+// 종료 중이면 다른 인스턴스로 재시도하도록 503 을 응답합니다.
+func acceptConnection(c *gin.Context) bool {
+	if activeConnections.tryAdd() {
+		return true
+	}
+	c.Header("Retry-After", strconv.Itoa(int(reconnectAfter.Seconds())))
+	c.JSON(http.StatusServiceUnavailable, gin.H{"error": "서버를 재시작하고 있습니다. 잠시 후 다시 연결해주세요."})
+	return false
+}
+
+// goWebhook 은 종료 시 전송이 끝날 때까지 기다릴 수 있도록 웹훅을 추적하며 비동기로 보냅니다.
+// send 에 넘기는 ctx 는 Shutdown 의 대기 시간이 지나면 취소됩니다.
+func goWebhook(send func(ctx context.Context)) {
+	pendingWebhooks.add()
+	go func() {
+		defer pendingWebhooks.done()
+		send(webhookCtx)
+	}()
+}
+
+// closeForRestart 는 재접속 안내 후 웹소켓 close 프레임(1012 Service Restart)을 보내고 연결을 닫습니다.
+func closeForRestart(conn *websocket.Conn, writeReconnect func(model.ReconnectFrame) error) {
+	if err := writeReconnect(model.NewReconnectFrame(reconnectAfter.Milliseconds())); err != nil {
+		log.Printf("재접속 안내 전송 실패: %v", err)
+	}
+	deadline := time.Now().Add(time.Second)
+	if err := conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseServiceRestart, "server restarting"), deadline); err != nil {
+		log.Printf("close 프레임 전송 실패: %v", err)
+	}
+	conn.Close()
+}
+
+/*
+Shutdown 은 실시간 연결을 정리합니다. HTTP 서버와 Kafka 컨슈머를 닫기 전에 호출합니다.
+1. 새 웹소켓 업그레이드, SSE, long-poll, REST 전송 거부
+2. 모든 채팅방, 사용자 웹소켓, 1:1 대화 연결에 재접속 안내와 close 프레임 전송 (SSE, long-poll 은 안내 후 종료)
+3. 처리 중이던 메시지 저장이 끝나 연결 핸들러가 모두 반환될 때까지 대기
+4. 전송 중인 웹훅 대기 (ctx 가 끝나면 남은 웹훅 전송을 취소)
+*/
+func Shutdown(ctx context.Context) error {
+	activeConnections.drain()
+
+	roomLock.Lock()
+	openRooms := make([]*Room, 0, len(rooms))
+	for _, room := range rooms {
+		openRooms = append(openRooms, room)
+	}
+	roomLock.Unlock()
+	for _, room := range openRooms {
+		select {
+		case room.shutdown <- struct{}{}:
+		case <-room.done:
+		}
+	}
+	// 구독한 채팅방이 없는 사용자 웹소켓도 닫음
+	for _, session := range allSessions() {
+		session.closeForRestart()
+	}
+	for _, client := range dmHub.all() {
+		client.closeForRestart()
+	}
+	log.Printf("Sent reconnect hints to open rooms: %d", len(openRooms))
+
+	if err := activeConnections.wait(ctx); err != nil {
+		cancelWebhooks()
+		return err
+	}
+	if err := pendingWebhooks.wait(ctx); err != nil {
+		cancelWebhooks()
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,323 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"workoutstudy_chatting/model"
+	"workoutstudy_chatting/ratelimit"
+	"workoutstudy_chatting/service"
+	"workoutstudy_chatting/wire"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// userSession 은 사용자 단위 웹소켓 연결입니다. 사용자가 속한 모든 fit group 채팅방 Room 에
+// 채팅방별 Client 로 등록되며, Room 이 보내는 프레임은 fitGroupId 로 감싸 하나의 연결로 전달됩니다.
+type userSession struct {
+	conn    *websocket.Conn
+	userID  int
+	writeMu sync.Mutex
+	codec   wire.Codec
+
+	subMu         sync.Mutex // 구독 추가/해지/종료를 직렬화
+	mu            sync.Mutex // subscriptions, closed 보호. Room 고루틴에서도 잠그므로 Room 으로 보내는 동안 잡지 않음
+	subscriptions map[int]*Client
+	closed        bool
+	restartOnce   sync.Once
+}
+
+func (s *userSession) writeFrame(v interface{}) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	return writeEncoded(s.conn, s.codec, v)
+}
+
+// client 는 구독 중인 채팅방의 Client 를 반환합니다. 구독하지 않은 채팅방이면 nil 입니다.
+func (s *userSession) client(fitGroupID int) *Client {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.subscriptions[fitGroupID]
+}
+
+// subscribe 는 채팅방 Room 에 등록합니다. 새로 구독했으면 true 를 반환합니다.
+func (s *userSession) subscribe(fitGroupID int) bool {
+	s.subMu.Lock()
+	defer s.subMu.Unlock()
+
+	s.mu.Lock()
+	if s.closed || s.subscriptions[fitGroupID] != nil {
+		s.mu.Unlock()
+		return false
+	}
+	client := &Client{conn: s.conn, userID: s.userID, session: s, fitGroupID: fitGroupID}
+	s.subscriptions[fitGroupID] = client
+	s.mu.Unlock()
+
+	joinRoom(strconv.Itoa(fitGroupID), client)
+	return true
+}
+
+// unsubscribe 는 채팅방 Room 에서 등록을 해지합니다. 구독 중이었으면 true 를 반환합니다.
+func (s *userSession) unsubscribe(fitGroupID int) bool {
+	s.subMu.Lock()
+	defer s.subMu.Unlock()
+
+	s.mu.Lock()
+	client := s.subscriptions[fitGroupID]
+	delete(s.subscriptions, fitGroupID)
+	s.mu.Unlock()
+	if client == nil {
+		return false
+	}
+	leaveRoom(client)
+	return true
+}
+
+// detach 는 Room 이 client 를 제거했을 때(차단, 전송 실패) 구독 목록에서 지우고 구독 해지를 알립니다. Room 고루틴에서 호출됩니다.
+func (s *userSession) detach(client *Client) {
+	s.mu.Lock()
+	removed := s.subscriptions[client.fitGroupID] == client
+	if removed {
+		delete(s.subscriptions, client.fitGroupID)
+	}
+	closed := s.closed
+	s.mu.Unlock()
+	if removed && !closed {
+		s.notify(model.FrameUnsubscribed, []int{client.fitGroupID})
+	}
+}
+
+// closeForRestart 는 서버 종료 시 재접속 안내를 한 번만 보내고 연결을 닫습니다. 구독 해지는 연결 핸들러가 반환하며 처리합니다.
+func (s *userSession) closeForRestart() {
+	s.restartOnce.Do(func() {
+		s.mu.Lock()
+		s.closed = true
+		s.mu.Unlock()
+		closeForRestart(s.conn, func(frame model.ReconnectFrame) error { return s.writeFrame(frame) })
+	})
+}
+
+// close 는 모든 구독을 해지합니다. 이후 subscribe 는 무시됩니다.
+func (s *userSession) close() {
+	s.subMu.Lock()
+	defer s.subMu.Unlock()
+
+	s.mu.Lock()
+	s.closed = true
+	clients := make([]*Client, 0, len(s.subscriptions))
+	for _, client := range s.subscriptions {
+		clients = append(clients, client)
+	}
+	s.subscriptions = make(map[int]*Client)
+	s.mu.Unlock()
+
+	for _, client := range clients {
+		leaveRoom(client)
+	}
+}
+
+func (s *userSession) notify(frameType string, fitGroupIDs []int) {
+	if err := s.writeFrame(model.SubscriptionFrame{Type: frameType, FitGroupIDs: fitGroupIDs}); err != nil {
+		log.Printf("사용자 웹소켓 구독 알림 전송 실패: %v", err)
+	}
+}
+
+// leaveRoom 은 client 가 등록된 Room 에서 등록을 해지합니다. Room 이 이미 종료되었으면 무시합니다.
+func leaveRoom(client *Client) {
+	room := findRoom(client.fitGroupID)
+	if room == nil {
+		return
+	}
+	select {
+	case room.unregister <- client:
+	case <-room.done:
+	}
+}
+
+// 사용자별 사용자 웹소켓 연결. 같은 사용자가 여러 기기에서 접속할 수 있음
+var (
+	userSessionLock sync.Mutex
+	userSessions    = make(map[int]map[*userSession]bool)
+)
+
+func addUserSession(session *userSession) {
+	userSessionLock.Lock()
+	defer userSessionLock.Unlock()
+	if userSessions[session.userID] == nil {
+		userSessions[session.userID] = make(map[*userSession]bool)
+	}
+	userSessions[session.userID][session] = true
+}
+
+func removeUserSession(session *userSession) {
+	userSessionLock.Lock()
+	defer userSessionLock.Unlock()
+	delete(userSessions[session.userID], session)
+	if len(userSessions[session.userID]) == 0 {
+		delete(userSessions, session.userID)
+	}
+}
+
+func sessionsOf(userID int) []*userSession {
+	userSessionLock.Lock()
+	defer userSessionLock.Unlock()
+	sessions := make([]*userSession, 0, len(userSessions[userID]))
+	for session := range userSessions[userID] {
+		sessions = append(sessions, session)
+	}
+	return sessions
+}
+
+func allSessions() []*userSession {
+	userSessionLock.Lock()
+	defer userSessionLock.Unlock()
+	var sessions []*userSession
+	for _, set := range userSessions {
+		for session := range set {
+			sessions = append(sessions, session)
+		}
+	}
+	return sessions
+}
+
+// membershipNotifier 는 service.MembershipNotifier 구현체로, 현재 인스턴스에 열려 있는 사용자 웹소켓의 구독을 갱신합니다.
+type membershipNotifier struct {
+	moderation service.ChatModerationUseCase
+}
+
+var _ service.MembershipNotifier = (*membershipNotifier)(nil)
+
+func NewMembershipNotifier(moderation service.ChatModerationUseCase) service.MembershipNotifier {
+	return &membershipNotifier{moderation: moderation}
+}
+
+func (n *membershipNotifier) MemberJoined(fitGroupID, userID int) {
+	// 차단된 채팅방에 다시 가입한 경우에도 채팅방 웹소켓과 같이 구독하지 않음
+	if errors.Is(n.moderation.CheckRestriction(fitGroupID, userID), service.ErrBanned) {
+		return
+	}
+	for _, session := range sessionsOf(userID) {
+		if session.subscribe(fitGroupID) {
+			session.notify(model.FrameSubscribed, []int{fitGroupID})
+		}
+	}
+}
+
+func (n *membershipNotifier) MemberLeft(fitGroupID, userID int) {
+	for _, session := range sessionsOf(userID) {
+		if session.unsubscribe(fitGroupID) {
+			session.notify(model.FrameUnsubscribed, []int{fitGroupID})
+		}
+	}
+}
+
+func (n *membershipNotifier) FitGroupRemoved(fitGroupID int) {
+	for _, session := range allSessions() {
+		if session.unsubscribe(fitGroupID) {
+			session.notify(model.FrameUnsubscribed, []int{fitGroupID})
+		}
+	}
+}
+
+// @Summary websocket user chat
+// @Description 사용자가 속한 모든 fit group 채팅방을 하나의 웹소켓으로 구독합니다.
+// @Description 연결 직후 구독한 채팅방 목록을 {"type":"SUBSCRIBED","fitGroupIds":[...]} 로 보내며, 이후 fit mate 가입/탈퇴에 따라 SUBSCRIBED/UNSUBSCRIBED 를 보냅니다.
+// @Description 채팅방 프레임은 {"type":"ROOM","fitGroupId":1,"frame":{...}} 형식이며 frame 은 /chat 웹소켓과 같습니다.
+// @Description 보내는 프레임은 /chat 웹소켓과 같은 형식에 fitGroupId 를 반드시 포함해야 합니다. subprotocol 협상도 /chat 과 같습니다.
+// @Tags chat
+// @Accept json
+// @Produce json
+// @Param userId query int true "사용자 ID"
+// @Success 101 {string} string "WebSocket 연결이 성공적으로 설정되었습니다."
+// @Failure 400 {object} map[string]string "잘못된 userId"
+// @Failure 500 {object} map[string]string "fit group 조회 실패"
+// @Router /user/chat [get]
+func (h *ChatHandler) UserChat(c *gin.Context) {
+	if !acceptConnection(c) {
+		return
+	}
+	defer activeConnections.done()
+
+	userID, err := strconv.Atoi(c.Query("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 userId"})
+		return
+	}
+
+	fitGroups, err := h.FitMateService.GetFitGroupsByUserID(userID)
+	if err != nil {
+		log.Printf("Error retrieving fit groups for user %d: %v", userID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "fit group 조회 실패"})
+		return
+	}
+
+	conn, err := chatUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		log.Println("Websocket upgrade failed:", err)
+		return
+	}
+	defer conn.Close()
+
+	session := &userSession{conn: conn, userID: userID, codec: wire.ForSubprotocol(conn.Subprotocol()), subscriptions: make(map[int]*Client)}
+	// 구독 전에 등록하여 연결 중 들어온 가입 이벤트도 반영
+	addUserSession(session)
+	defer func() {
+		removeUserSession(session)
+		session.close()
+	}()
+
+	subscribed := make([]int, 0, len(fitGroups))
+	for _, fitGroup := range fitGroups {
+		if errors.Is(h.ModerationService.CheckRestriction(fitGroup.ID, userID), service.ErrBanned) {
+			continue
+		}
+		session.subscribe(fitGroup.ID)
+		subscribed = append(subscribed, fitGroup.ID)
+	}
+	sort.Ints(subscribed)
+	session.notify(model.FrameSubscribed, subscribed)
+
+	connLimiter := ratelimit.NewTokenBucket(h.connectionRate)
+
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			log.Printf("read error: %v", err)
+			break
+		}
+
+		// 연결 단위 제한은 파싱 전에 적용하여 잘못된 프레임 폭주도 막음
+		if ok, wait := connLimiter.Allow(); !ok {
+			errFrame := model.NewChatErrorFrame(model.ErrorRateLimited, "메시지를 너무 빠르게 보내고 있습니다.", "")
+			errFrame.RetryAfterMs = wait.Milliseconds()
+			if err := session.writeFrame(errFrame); err != nil {
+				break
+			}
+			continue
+		}
+
+		frame, err := session.codec.Decode(message)
+		if err != nil {
+			if err := session.writeFrame(model.NewChatErrorFrame(model.ErrorInvalidMessage, "잘못된 메시지 형식입니다.", "")); err != nil {
+				break
+			}
+			continue
+		}
+		client := session.client(frame.FitGroupID)
+		if client == nil {
+			if err := session.writeFrame(model.NewChatErrorFrame(model.ErrorNotAllowed, "구독하지 않은 채팅방입니다.", "")); err != nil {
+				break
+			}
+			continue
+		}
+
+		if !h.handleFrame(client, frame.FitGroupID, frame) {
+			break
+		}
+	}
+}
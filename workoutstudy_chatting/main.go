@@ -2,12 +2,20 @@ package main
 
 import (
 	"context"
+	"errors"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
+	_ "time/tzdata" // fit group 시간대 계산을 위해 컨테이너에 tzdata 가 없어도 동작하도록 포함
+	"workoutstudy_chatting/archive"
+	"workoutstudy_chatting/client"
+	"workoutstudy_chatting/command"
 	"workoutstudy_chatting/config"
 	"workoutstudy_chatting/handler"
+	"workoutstudy_chatting/moderation"
 	"workoutstudy_chatting/persistence"
 	"workoutstudy_chatting/service"
 
@@ -19,44 +27,322 @@ import (
 )
 
 func main() {
-	DB := persistence.InitializeDB()
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+	log.Printf("Config loaded: %+v", cfg) // Secret 값은 마스킹되어 출력됨
 
-	chatService := service.NewChatService(persistence.NewChatRepository(DB))
-	fitMateService := service.NewFitMateService(persistence.NewPostgresFitMateRepository(DB), make(chan int))
-	fitGroupService := service.NewFitGroupService(persistence.NewFitGroupRepository(DB), make(chan int))
-	userService := service.NewUserService(persistence.NewUserRepository(DB))
+	// workoutstudy_chatting migrate up|down [N]|status
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrateCommand(cfg.Database, os.Args[2:]); err != nil {
+			log.Fatalf("migrate: %v", err)
+		}
+		return
+	}
+	// workoutstudy_chatting dlq redrive [N]
+	if len(os.Args) > 1 && os.Args[1] == "dlq" {
+		if err := runDLQCommand(cfg.Kafka, os.Args[2:]); err != nil {
+			log.Fatalf("dlq: %v", err)
+		}
+		return
+	}
 
-	chatHandler := handler.NewChatHandler(chatService, fitMateService, fitGroupService)
+	var DB *persistence.SQLDB
+	var repos persistence.Repositories
+	switch cfg.Database.Driver {
+	case config.DriverMemory:
+		repos = newMemoryRepositories(cfg.Database.SeedFile)
+	default:
+		DB = persistence.InitializeDB(cfg.Database)
+		repos = persistence.NewSQLRepositories(DB)
+	}
+
+	chatRepository := repos.Chat
+	fitMateRepository := repos.FitMate
+	fitGroupRepository := repos.FitGroup
+	chatRoomSettingService := service.NewChatRoomSettingService(repos.ChatRoomSetting, fitGroupRepository)
+	rateLimitConfig := service.NewRateLimitConfig(cfg.RateLimit)
+	moderationRepository := repos.Moderation
+	moderationConfig, err := moderation.NewConfig(cfg.Moderation)
+	if err != nil {
+		log.Fatalf("Failed to load moderation config: %v", err)
+	}
+	moderationPipeline := moderation.NewDefaultPipeline(moderationConfig)
+	moderationAuditRepository := repos.ModerationAudit
+	roomNotifier := handler.NewRoomNotifier()
+	chatModerationService := service.NewChatModerationService(
+		repos.ChatRestriction, moderationAuditRepository, chatRepository, fitGroupRepository, fitMateRepository, roomNotifier)
+	pollService := service.NewPollService(repos.Poll, chatRepository, fitGroupRepository, fitMateRepository, roomNotifier)
+	// 뮤트/차단 및 공지 권한 확인 -> 전송 제한 -> moderation 필터 -> 투표 생성 및 저장 순서로 처리
+	chatService := service.NewRestrictedChatService(
+		service.NewRateLimitedChatService(
+			service.NewModeratedChatService(
+				service.NewPollChatService(service.NewChatService(chatRepository, roomNotifier), pollService, chatRepository),
+				moderationPipeline, moderationRepository),
+			chatRoomSettingService, rateLimitConfig),
+		chatModerationService)
+	pinnedMessageService := service.NewPinnedMessageService(repos.PinnedMessage, chatRepository, fitGroupRepository, fitMateRepository, moderationAuditRepository, roomNotifier)
+	reminderService := service.NewReminderService(repos.Reminder, chatRepository, fitGroupRepository, chatRoomSettingService, roomNotifier)
+	directMessageService := service.NewDirectMessageService(repos.DirectMessage, fitMateRepository)
+	moderationService := service.NewModerationService(moderationRepository, chatRepository, fitGroupRepository, moderationAuditRepository, roomNotifier)
+	membershipNotifier := handler.NewMembershipNotifier(chatModerationService)
+	// fit group 보다 먼저 도착한 fit mate 이벤트는 보류했다가 fit group 이 생성되면 다시 처리
+	fitMateService := service.NewFitMateService(fitMateRepository, repos.PendingFitMateEvent, cfg.Kafka.PendingEventTTL, membershipNotifier)
+	fitGroupService := service.NewFitGroupService(fitGroupRepository, fitMateService, membershipNotifier)
+	userService := service.NewUserService(repos.User)
+
+	// 아카이브 저장소가 설정되지 않으면 아카이브 작업과 복원을 하지 않음. fit group 별 정책은 message_retention_policy 테이블에서 관리
+	var archiveStorage archive.Storage
+	if cfg.Retention.ArchiveDir != "" {
+		localStorage, err := archive.NewLocalStorage(cfg.Retention.ArchiveDir)
+		if err != nil {
+			log.Fatalf("Failed to initialize archive storage: %v", err)
+		}
+		archiveStorage = localStorage
+	}
+	retentionService := service.NewRetentionService(chatRepository, repos.Retention, fitGroupRepository, repos.Poll, archiveStorage, cfg.Retention.DefaultDays)
+	chatExportService := service.NewChatExportService(chatRepository, fitGroupRepository, fitMateRepository)
+
+	// 명령어는 commandRouter.Register 로 추가하며 ChatHandler 는 수정하지 않아도 됨
+	commandRouter := command.NewRouter()
+	commandRouter.MustRegister(service.NewFitGroupCommands(chatRepository, fitGroupRepository, chatRoomSettingService, pinnedMessageService).Commands()...)
+	chatCommandService := service.NewChatCommandService(commandRouter, chatRepository, chatModerationService, roomNotifier)
+
+	// fit-group, auth, alarm 서비스 호출 (시간 제한, 재시도, 서비스별 circuit breaker)
+	fitGroupClient := client.NewFitGroupClient(cfg.Services.FitGroup, cfg.Clients)
+	authClient := client.NewAuthClient(cfg.Services.Auth, cfg.Clients)
+	alarmClient := client.NewAlarmClient(cfg.Services.Alarm, cfg.Clients)
+	// 놓친 Kafka 이벤트로 어긋난 fit group, fit mate, 사용자를 다른 서비스 정보로 맞춤
+	reconciliationService := service.NewReconciliationService(fitGroupRepository, repos.User, fitGroupService, fitMateService, userService, fitGroupClient, authClient)
+
+	// workoutstudy_chatting reconcile
+	if len(os.Args) > 1 && os.Args[1] == "reconcile" {
+		err := runReconcileCommand(reconciliationService, os.Args[2:])
+		if DB != nil {
+			DB.Close()
+		}
+		if err != nil {
+			log.Fatalf("reconcile: %v", err)
+		}
+		return
+	}
+
+	// workoutstudy_chatting retention run
+	if len(os.Args) > 1 && os.Args[1] == "retention" {
+		err := runRetentionCommand(retentionService, os.Args[2:])
+		if DB != nil {
+			DB.Close()
+		}
+		if err != nil {
+			log.Fatalf("retention: %v", err)
+		}
+		return
+	}
+
+	chatHandler := handler.NewChatHandler(chatService, fitMateService, fitGroupService, chatModerationService, pollService, chatCommandService, rateLimitConfig.Connection, alarmClient)
 	fitMateHandler := handler.NewFitMateHandler(fitMateService)
+	retentionHandler := handler.NewRetentionHandler(retentionService)
+	chatExportHandler := handler.NewChatExportHandler(chatExportService)
+	chatRoomSettingHandler := handler.NewChatRoomSettingHandler(chatRoomSettingService)
+	moderationHandler := handler.NewModerationHandler(moderationService)
+	chatModerationHandler := handler.NewChatModerationHandler(chatModerationService)
+	pinnedMessageHandler := handler.NewPinnedMessageHandler(pinnedMessageService)
+	pollHandler := handler.NewPollHandler(pollService)
+	reminderHandler := handler.NewReminderHandler(reminderService)
+	directMessageHandler := handler.NewDirectMessageHandler(directMessageService, rateLimitConfig.Connection, alarmClient)
+	reconciliationHandler := handler.NewReconciliationHandler(reconciliationService)
 
 	r := gin.Default()
 	r.Static("/docs", "./docs")
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, ginSwagger.URL("/docs/doc.json")))
 
 	r.GET("/chat", chatHandler.Chat)
+	r.GET("/user/chat", chatHandler.UserChat)
+	r.GET("/chat/sse", chatHandler.StreamEvents)
+	r.GET("/chat/poll", chatHandler.LongPoll)
+	r.POST("/chat/message", chatHandler.SendFrame)
+	r.GET("/dm", directMessageHandler.DirectChat)
+	r.POST("/dm/conversation", directMessageHandler.OpenConversation)
+	r.GET("/dm/conversations", directMessageHandler.GetConversations)
+	r.GET("/dm/message", directMessageHandler.GetDirectMessages)
+	r.PUT("/dm/read", directMessageHandler.MarkRead)
+	r.GET("/chat/setting", chatRoomSettingHandler.GetChatRoomSetting)
+	r.PUT("/chat/slow-mode", chatRoomSettingHandler.SetSlowMode)
+	r.PUT("/chat/time-zone", reminderHandler.SetTimeZone)
+	r.POST("/reminder", reminderHandler.CreateReminder)
+	r.GET("/reminder", reminderHandler.GetReminders)
+	r.DELETE("/reminder", reminderHandler.DeleteReminder)
 	r.GET("/retrieve/fit-group", fitMateHandler.RetrieveFitGroupByUserID)
+	r.GET("/fit-mate/pending-events", fitMateHandler.GetPendingFitMateEventStats)
 	r.GET("/retrieve/message", chatHandler.RetrieveMessages)
+	r.DELETE("/message", chatHandler.DeleteMessage)
+	r.GET("/export/message", chatExportHandler.ExportChatHistory)
+	r.GET("/moderation/queue", moderationHandler.GetModerationQueue)
+	r.PUT("/moderation/review", moderationHandler.ReviewModerationItem)
+	r.POST("/moderation/mute", chatModerationHandler.MuteMember)
+	r.DELETE("/moderation/mute", chatModerationHandler.UnmuteMember)
+	r.POST("/moderation/ban", chatModerationHandler.BanMember)
+	r.DELETE("/moderation/ban", chatModerationHandler.UnbanMember)
+	r.DELETE("/moderation/message", chatModerationHandler.DeleteMessage)
+	r.POST("/moderation/pin", pinnedMessageHandler.PinMessage)
+	r.DELETE("/moderation/pin", pinnedMessageHandler.UnpinMessage)
+	r.GET("/retrieve/pin", pinnedMessageHandler.GetPinnedMessages)
+	r.GET("/retrieve/poll", pollHandler.GetPoll)
+	r.GET("/moderation/restrictions", chatModerationHandler.GetActiveRestrictions)
+	r.GET("/moderation/audit", chatModerationHandler.GetAuditLogs)
+	r.GET("/retention/policy", retentionHandler.GetRetentionPolicies)
+	r.PUT("/retention/policy", retentionHandler.SetRetentionPolicy)
+	r.DELETE("/retention/policy", retentionHandler.DeleteRetentionPolicy)
+	r.POST("/archive/restore", retentionHandler.RestoreArchive)
+	r.POST("/reconciliation/run", reconciliationHandler.RunReconciliation)
 
-	msgChan := make(chan handler.MessageEvent)
+	ctx, cancel := context.WithCancel(context.Background())
 
-	kafkaConsumer := config.NewKafkaConsumer([]string{"kafka-1:9092" /*, "kafka-2:9093", "kafka-3:9094"*/}, "chatting-service", []string{"fit-mate", "fit-group", "user-create-event", "user-info-event"})
+	var kafkaConsumer *config.KafkaConsumer
+	var kafkaProducer *config.KafkaProducer
+	var eventLedger *handler.EventLedger
+	consumerDone := make(chan struct{})
+	handlerDone := make(chan struct{})
+	if cfg.Kafka.Enabled {
+		msgChan := make(chan handler.MessageEvent)
 
-	ctx, cancel := context.WithCancel(context.Background())
-	log.Println("Context created for Kafka consumer")
+		// 처리에 실패한 이벤트는 재시도 토픽이나 dead-letter 토픽으로 보냄
+		kafkaProducer = config.NewKafkaProducer(cfg.Kafka.Brokers)
+		// 재전달된 이벤트 중 이미 처리한 이벤트는 건너뜀
+		eventLedger = handler.NewEventLedger(repos.ProcessedEvent, cfg.Kafka.ProcessedEventRetention)
+		retrier := handler.NewEventRetrier(cfg.Kafka, kafkaProducer, eventLedger)
+
+		kafkaConsumer = config.NewKafkaConsumer(cfg.Kafka.Brokers, cfg.Kafka.GroupID, cfg.Kafka.ConsumedTopics(), cfg.Kafka.CommitInterval, cfg.Kafka.CommitBatchSize)
+		log.Println("Context created for Kafka consumer")
+
+		go func() {
+			// 토픽별 핸들러가 처리를 마친 이벤트의 오프셋만 커밋
+			kafkaConsumer.Consume(ctx, retrier.DelayRetryEvents(ctx, handler.Deliver(msgChan)))
+			close(msgChan)
+			close(consumerDone)
+		}()
+
+		go func() {
+			handler.HandleMessage(ctx, msgChan, retrier, cfg.Kafka.Topics, fitGroupClient, authClient, fitMateService, fitGroupService, userService)
+			close(handlerDone)
+		}()
+	} else {
+		log.Println("Kafka consumer disabled (kafka.enabled=false)")
+		close(consumerDone)
+		close(handlerDone)
+	}
 
-	go kafkaConsumer.Consume(ctx, msgChan)
+	// 주기 작업은 ctx 취소 후 현재 실행을 마치고 반환하므로 DB 를 닫기 전에 기다림
+	var jobs sync.WaitGroup
+	runJob := func(job func()) {
+		jobs.Add(1)
+		go func() {
+			defer jobs.Done()
+			job()
+		}()
+	}
+	if archiveStorage != nil {
+		runJob(func() { retentionService.StartRetentionJob(ctx, cfg.Retention.Interval) })
+	} else {
+		log.Println("Retention job disabled: retention.archiveDir is not set")
+	}
+	runJob(func() { pollService.StartPollCloser(ctx, cfg.Jobs.PollCloserInterval) })
+	runJob(func() { reminderService.StartScheduler(ctx, cfg.Jobs.ReminderInterval) })
+	runJob(func() { handler.StartLongPollReaper(ctx, cfg.Jobs.LongPollReaperInterval) })
+	if eventLedger != nil {
+		runJob(func() { eventLedger.StartPruner(ctx, cfg.Kafka.ProcessedEventPruneInterval) })
+		runJob(func() { fitMateService.StartPendingFitMateEventReplayer(ctx, cfg.Kafka.PendingEventReplayInterval) })
+	}
+	if cfg.Reconciliation.Enabled {
+		runJob(func() { reconciliationService.StartReconciliationJob(ctx, cfg.Reconciliation.Interval) })
+	}
+
+	srv := &http.Server{Addr: cfg.HTTP.Addr, Handler: r}
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("HTTP server failed: %v", err)
+		}
+	}()
+	log.Println("Kafka Consumer and Handlers started")
 
-	go handler.HandleMessage(msgChan, fitMateService, fitGroupService, userService)
 	// Graceful shutdown
 	sigs := make(chan os.Signal, 1)
 	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	sig := <-sigs
+	log.Printf("Received signal: %s, initiating shutdown", sig)
+
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), cfg.HTTP.ShutdownTimeout)
+	defer cancelShutdown()
+
+	// 1. 새 연결을 거부하고, 실시간 연결에 재접속 안내 후 처리 중인 메시지 저장과 웹훅 전송을 기다림
+	if err := handler.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Timed out draining connections: %v", err)
+	}
 
+	// 2. Kafka 읽기와 주기 작업을 멈추고, 이미 읽은 이벤트 처리와 오프셋 커밋을 마친 뒤 Reader 를 닫음
+	cancel()
+	if err := waitDone(shutdownCtx, consumerDone, handlerDone); err != nil {
+		log.Printf("Timed out waiting for Kafka handlers: %v", err)
+	}
+	if kafkaConsumer != nil {
+		if err := kafkaConsumer.Close(); err != nil {
+			log.Printf("Error closing Kafka consumer: %v", err)
+		}
+	}
+	if kafkaProducer != nil {
+		if err := kafkaProducer.Close(); err != nil {
+			log.Printf("Error closing Kafka producer: %v", err)
+		}
+	}
+	jobsDone := make(chan struct{})
 	go func() {
-		sig := <-sigs
-		log.Printf("Received signal: %s, initiating shutdown", sig)
-		cancel()
+		jobs.Wait()
+		close(jobsDone)
 	}()
+	if err := waitDone(shutdownCtx, jobsDone); err != nil {
+		log.Printf("Timed out waiting for background jobs: %v", err)
+	}
 
-	log.Println("Kafka Consumer and Handlers started")
-	r.Run(":8888")
+	// 3. HTTP 서버 종료
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Printf("HTTP server shutdown error: %v", err)
+	}
+
+	// 4. DB 연결 풀 종료 (memory 저장소는 닫을 연결이 없음)
+	if DB != nil {
+		if err := DB.Close(); err != nil {
+			log.Printf("Error closing DB: %v", err)
+		}
+	}
+	log.Println("Shutdown complete")
+}
+
+// newMemoryRepositories 는 DB 없이 실행하기 위한 메모리 repository 를 만들고 seedFile 이 있으면 초기 데이터를 넣습니다.
+func newMemoryRepositories(seedFile string) persistence.Repositories {
+	store := persistence.NewMemoryStore()
+	if seedFile != "" {
+		seed, err := persistence.LoadMemorySeed(seedFile)
+		if err != nil {
+			log.Fatalf("Failed to load seed data: %v", err)
+		}
+		if err := store.Seed(seed); err != nil {
+			log.Fatalf("Failed to seed memory store: %v", err)
+		}
+		log.Printf("Memory store seeded from %s (%d users, %d fit groups, %d fit mates)", seedFile, len(seed.Users), len(seed.FitGroups), len(seed.FitMates))
+	}
+	log.Println("Using in-memory repositories; data is lost on restart")
+	return persistence.NewMemoryRepositories(store)
+}
+
+// waitDone 은 모든 채널이 닫히거나 ctx 가 끝날 때까지 기다립니다.
+func waitDone(ctx context.Context, chans ...<-chan struct{}) error {
+	for _, ch := range chans {
+		select {
+		case <-ch:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
 }
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+	"workoutstudy_chatting/config"
+	"workoutstudy_chatting/persistence"
+)
+
+const migrateUsage = `usage: workoutstudy_chatting migrate <command>
+
+commands:
+  up        적용되지 않은 마이그레이션을 모두 적용
+  down [N]  최근 적용된 마이그레이션 N개(기본 1)를 되돌림
+  status    마이그레이션별 적용 여부 출력`
+
+// runMigrateCommand 는 migrate 서브커맨드를 실행합니다. 서버는 시작하지 않습니다.
+func runMigrateCommand(cfg config.DatabaseConfig, args []string) error {
+	if cfg.Driver == config.DriverMemory {
+		return fmt.Errorf("migrations are not supported by the %s driver", config.DriverMemory)
+	}
+	if len(args) == 0 {
+		return fmt.Errorf("missing command\n%s", migrateUsage)
+	}
+
+	steps := 1
+	switch args[0] {
+	case "up", "status":
+		if len(args) > 1 {
+			return fmt.Errorf("unexpected arguments %v\n%s", args[1:], migrateUsage)
+		}
+	case "down":
+		if len(args) > 2 {
+			return fmt.Errorf("unexpected arguments %v\n%s", args[2:], migrateUsage)
+		}
+		if len(args) == 2 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n <= 0 {
+				return fmt.Errorf("down step must be a positive integer: %q", args[1])
+			}
+			steps = n
+		}
+	default:
+		return fmt.Errorf("unknown command %q\n%s", args[0], migrateUsage)
+	}
+
+	db, err := persistence.OpenDB(cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	migrator, err := persistence.NewMigrator(db)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d migrations applied\n", len(applied))
+	case "down":
+		reverted, err := migrator.Down(ctx, steps)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d migrations reverted\n", len(reverted))
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+		for _, s := range statuses {
+			appliedAt := "pending"
+			if s.AppliedAt != nil {
+				appliedAt = s.AppliedAt.Format(time.RFC3339)
+			}
+			if s.Unknown {
+				appliedAt += " (not in this binary)"
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\n", s.Version, s.Name, appliedAt)
+		}
+		return w.Flush()
+	}
+	return nil
+}
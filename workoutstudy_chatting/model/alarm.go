@@ -0,0 +1,30 @@
+package model
+
+// AlarmPriority 는 alarm-service 웹훅으로 전달하는 푸시 알림 우선순위입니다.
+type AlarmPriority string
+
+const (
+	AlarmPriorityNormal AlarmPriority = "NORMAL"
+	AlarmPriorityHigh   AlarmPriority = "HIGH" // fit leader 공지
+)
+
+// AlarmPriorityOf 는 메시지 타입에 맞는 알림 우선순위를 반환합니다.
+func AlarmPriorityOf(messageType MessageType) AlarmPriority {
+	if messageType == Announcement {
+		return AlarmPriorityHigh
+	}
+	return AlarmPriorityNormal
+}
+
+// ChatAlarmRequest 는 채팅 메시지 알림 웹훅(/chat/real-time-chat) 요청 본문입니다. 기존 메시지 필드에 알림 우선순위를 추가합니다.
+type ChatAlarmRequest struct {
+	ChatMessage
+	Priority AlarmPriority `json:"priority"`
+}
+
+// DirectMessageAlarmRequest 는 1:1 메시지 알림 웹훅(/chat/direct-message) 요청 본문입니다.
+type DirectMessageAlarmRequest struct {
+	DirectMessage
+	UnreadCount int           `json:"unreadCount"`
+	Priority    AlarmPriority `json:"priority"`
+}
@@ -0,0 +1,12 @@
+package model
+
+// FrameCommandReply 는 명령어를 입력한 사용자에게만 보내는 응답 프레임의 type 입니다.
+const FrameCommandReply = "COMMAND_REPLY"
+
+// CommandReply 는 "/" 로 시작하는 채팅 명령어의 비공개 응답입니다. 공개 응답은 핏봇 SYSTEM 메시지로 채팅방에 전송됩니다.
+type CommandReply struct {
+	Type      string `json:"type"` // 항상 "COMMAND_REPLY"
+	Command   string `json:"command"`
+	Message   string `json:"message"`
+	MessageID string `json:"messageId,omitempty"` // 명령어를 입력한 메시지 ID
+}
@@ -0,0 +1,31 @@
+package model
+
+// ChatErrorCode 는 웹소켓으로 전송되는 에러 프레임의 코드입니다.
+type ChatErrorCode string
+
+const (
+	ErrorRateLimited      ChatErrorCode = "RATE_LIMITED"
+	ErrorSlowMode         ChatErrorCode = "SLOW_MODE"
+	ErrorDuplicateMessage ChatErrorCode = "DUPLICATE_MESSAGE"
+	ErrorMessageRejected  ChatErrorCode = "MESSAGE_REJECTED"
+	ErrorMuted            ChatErrorCode = "MUTED"
+	ErrorBanned           ChatErrorCode = "BANNED"
+	ErrorNotAllowed       ChatErrorCode = "NOT_ALLOWED"
+	ErrorPollClosed       ChatErrorCode = "POLL_CLOSED"
+	ErrorInvalidVote      ChatErrorCode = "INVALID_VOTE"
+	ErrorInvalidMessage   ChatErrorCode = "INVALID_MESSAGE"
+	ErrorSaveFailed       ChatErrorCode = "SAVE_FAILED"
+)
+
+// ChatErrorFrame 은 클라이언트가 보낸 프레임이 거부되었을 때 해당 클라이언트에게만 전송하는 응답입니다.
+type ChatErrorFrame struct {
+	Type         string        `json:"type"` // 항상 "ERROR"
+	Code         ChatErrorCode `json:"code"`
+	Message      string        `json:"message"`
+	MessageID    string        `json:"messageId,omitempty"`
+	RetryAfterMs int64         `json:"retryAfterMs,omitempty"`
+}
+
+func NewChatErrorFrame(code ChatErrorCode, message, messageID string) ChatErrorFrame {
+	return ChatErrorFrame{Type: "ERROR", Code: code, Message: message, MessageID: messageID}
+}
@@ -0,0 +1,24 @@
+package model
+
+// ExportFormat은 채팅 내역 내보내기 형식입니다.
+type ExportFormat string
+
+const (
+	ExportJSON ExportFormat = "json"
+	ExportCSV  ExportFormat = "csv"
+	ExportHTML ExportFormat = "html"
+)
+
+// ChatHistoryEntry는 내보내기용 채팅 메시지로, 보낸 사람의 닉네임을 함께 가집니다.
+type ChatHistoryEntry struct {
+	ChatMessage
+	SenderNickname string `json:"senderNickname"`
+}
+
+// ChatExportOptions는 채팅 내역 내보내기 요청 옵션입니다.
+type ChatExportOptions struct {
+	FitGroupID     int
+	UserID         int
+	Format         ExportFormat
+	IncludeDeleted bool // fit leader 만 사용 가능
+}
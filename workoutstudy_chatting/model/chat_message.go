@@ -10,8 +10,11 @@ type MessageType string
 
 // 가능한 MessageType 값을 상수로 정의합니다.
 const (
-	Chatting MessageType = "CHATTING"
-	Ticket   MessageType = "TICKET"
+	Chatting     MessageType = "CHATTING"
+	Ticket       MessageType = "TICKET"
+	Announcement MessageType = "ANNOUNCEMENT" // fit leader 만 보낼 수 있는 공지
+	Poll         MessageType = "POLL"         // 투표. ChatMessage.Poll 에 선택지 등을 담음
+	System       MessageType = "SYSTEM"       // 투표 결과 등 서버가 보내는 메시지
 )
 
 // ChatMessage는 채팅 메시지를 나타내는 구조체입니다.
@@ -23,6 +26,8 @@ type ChatMessage struct {
 	Message     string      `json:"message"`
 	MessageTime time.Time   `json:"messageTime"`
 	MessageType MessageType `json:"messageType"`
+	DeletedAt   *time.Time  `json:"deletedAt,omitempty"` // 삭제된 메시지일 경우 삭제 시간
+	Poll        *ChatPoll   `json:"poll,omitempty"`      // messageType 이 POLL 일 때만 사용
 }
 
 func (cm *ChatMessage) UnmarshalJSON(data []byte) error {
@@ -0,0 +1,51 @@
+package model
+
+import "time"
+
+// RestrictionType 은 fit leader 가 멤버에게 부여하는 채팅 제한 종류입니다.
+type RestrictionType string
+
+const (
+	RestrictionMute RestrictionType = "MUTE" // 채팅방 입장은 가능하지만 메시지 전송 불가
+	RestrictionBan  RestrictionType = "BAN"  // 채팅방 입장 불가
+)
+
+// ChatRestriction 은 채팅 제한 내역입니다. ExpiresAt 이 nil 이면 해제할 때까지 유지됩니다.
+type ChatRestriction struct {
+	ID         int             `json:"restrictionId"`
+	FitGroupID int             `json:"fitGroupId"`
+	UserID     int             `json:"userId"`
+	Type       RestrictionType `json:"type"`
+	Reason     string          `json:"reason"`
+	ExpiresAt  *time.Time      `json:"expiresAt,omitempty"`
+	CreatedAt  time.Time       `json:"createdAt"`
+	CreatedBy  string          `json:"createdBy"`
+	RevokedAt  *time.Time      `json:"revokedAt,omitempty"`
+	RevokedBy  string          `json:"revokedBy,omitempty"`
+}
+
+// ModerationAction 은 moderation audit 에 기록되는 조치 종류입니다.
+type ModerationAction string
+
+const (
+	ActionMute          ModerationAction = "MUTE"
+	ActionUnmute        ModerationAction = "UNMUTE"
+	ActionBan           ModerationAction = "BAN"
+	ActionUnban         ModerationAction = "UNBAN"
+	ActionDeleteMessage ModerationAction = "DELETE_MESSAGE"
+	ActionPinMessage    ModerationAction = "PIN_MESSAGE"
+	ActionUnpinMessage  ModerationAction = "UNPIN_MESSAGE"
+	ActionReviewFlag    ModerationAction = "REVIEW_FLAG"
+)
+
+// ModerationAuditLog 는 fit leader 의 moderation 조치 기록입니다.
+type ModerationAuditLog struct {
+	ID              int              `json:"auditId"`
+	FitGroupID      int              `json:"fitGroupId"`
+	ActorUserID     int              `json:"actorUserId"`
+	Action          ModerationAction `json:"action"`
+	TargetUserID    int              `json:"targetUserId,omitempty"`
+	TargetMessageID string           `json:"targetMessageId,omitempty"`
+	Detail          string           `json:"detail,omitempty"`
+	CreatedAt       time.Time        `json:"createdAt"`
+}
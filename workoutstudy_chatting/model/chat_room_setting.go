@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+// DefaultTimeZone 은 시간대를 설정하지 않은 fit group 의 시간대입니다.
+const DefaultTimeZone = "Asia/Seoul"
+
+// ChatRoomSetting 은 fit group 채팅방 별 설정입니다.
+type ChatRoomSetting struct {
+	FitGroupID      int       `json:"fitGroupId"`
+	SlowModeSeconds int       `json:"slowModeSeconds"` // 0 이면 슬로우 모드 해제
+	TimeZone        string    `json:"timeZone"`        // IANA 시간대, 리마인더 실행 시간 기준
+	UpdatedAt       time.Time `json:"updatedAt"`
+	UpdatedBy       string    `json:"updatedBy"`
+}
@@ -0,0 +1,72 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// DirectConversation 은 fit mate 두 명 사이의 1:1 대화방입니다.
+// 같은 두 사용자 사이에는 대화방이 하나만 존재하도록 UserAID < UserBID 로 저장합니다.
+type DirectConversation struct {
+	ID            int        `json:"conversationId"`
+	UserAID       int        `json:"userAId"`
+	UserBID       int        `json:"userBId"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	LastMessageAt *time.Time `json:"lastMessageAt,omitempty"`
+}
+
+// HasParticipant 는 사용자가 대화 참여자인지 확인합니다.
+func (c *DirectConversation) HasParticipant(userID int) bool {
+	return c.UserAID == userID || c.UserBID == userID
+}
+
+// PeerOf 는 userID 의 대화 상대 ID 를 반환합니다.
+func (c *DirectConversation) PeerOf(userID int) int {
+	if c.UserAID == userID {
+		return c.UserBID
+	}
+	return c.UserAID
+}
+
+// DirectMessage 는 1:1 대화 메시지입니다.
+type DirectMessage struct {
+	ID              string     `json:"messageId"`
+	ConversationID  int        `json:"conversationId"`
+	SenderUserID    int        `json:"senderUserId"`
+	RecipientUserID int        `json:"recipientUserId"`
+	Message         string     `json:"message"`
+	MessageTime     time.Time  `json:"messageTime"`
+	DeletedAt       *time.Time `json:"deletedAt,omitempty"`
+}
+
+// UnmarshalJSON 은 ChatMessage 와 같이 클라이언트의 시간 형식을 파싱합니다.
+func (dm *DirectMessage) UnmarshalJSON(data []byte) error {
+	type Alias DirectMessage
+	tmp := struct {
+		MessageTime string `json:"messageTime"`
+		*Alias
+	}{
+		Alias: (*Alias)(dm),
+	}
+
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return err
+	}
+
+	t, err := time.Parse("2006-01-02T15:04:05.999999999", tmp.MessageTime)
+	if err != nil {
+		return err
+	}
+
+	dm.MessageTime = t
+	return nil
+}
+
+// DirectConversationSummary 는 대화 목록 조회 응답으로, 상대방 정보와 마지막 메시지, 읽지 않은 메시지 수를 포함합니다.
+type DirectConversationSummary struct {
+	DirectConversation
+	PeerUserID   int            `json:"peerUserId"`
+	PeerNickname string         `json:"peerNickname"`
+	LastMessage  *DirectMessage `json:"lastMessage,omitempty"`
+	UnreadCount  int            `json:"unreadCount"`
+}
@@ -0,0 +1,15 @@
+package model
+
+// LongPollResponse 는 long-poll 응답입니다. 다음 요청에 sessionId 와 cursor 를 그대로 보내면
+// cursor 이후 프레임만 받으며, 그 이전 프레임은 전달된 것으로 보고 서버에서 버립니다.
+type LongPollResponse struct {
+	SessionID string        `json:"sessionId"`
+	Cursor    int64         `json:"cursor"`
+	Frames    []interface{} `json:"frames"` // 채팅방 웹소켓(/chat)과 같은 형식의 프레임
+}
+
+// SendFrameResponse 는 REST 전송 응답입니다. 웹소켓이었다면 보낸 사람에게만 전달되었을 프레임
+// (가려진 메시지, 생성된 투표, 명령어 응답 등)을 담습니다.
+type SendFrameResponse struct {
+	Frames []interface{} `json:"frames"`
+}
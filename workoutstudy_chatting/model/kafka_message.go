@@ -23,3 +23,33 @@ type UserCreateEvent struct {
 // user-info-event
 // zero payload 용 이벤트 토픽
 // kafka message 의 value 에 key:value 형태가 아닌 단순 숫자타입 데이터(user_id) 하나만 전송
+
+/*
+fit-group, fit-mate, user-info-event 이벤트 스키마
+- v1: value 에 ID 숫자 하나만 전송 (fit-group, fit-mate 는 fit group ID, user-info-event 는 user ID). 상세 정보는 fit-group, auth 서비스 API 로 조회
+- v2: value 에 version 과 API 응답과 같은 형식의 전체 상태를 JSON 으로 전송. API 를 호출하지 않고 바로 반영
+생산자가 v2 로 옮기는 동안 두 형식을 모두 받습니다.
+v2 이벤트라도 재시도 토픽이나 dead-letter 재전송으로 늦게 도착한 이벤트는 더 최신 이벤트가 이미 반영됐을 수 있으므로 v1 처럼 API 로 조회합니다.
+*/
+const EventSchemaVersion = 2
+
+// fit-group v2
+// { "version": 2, "fitGroup": { GET /fit-group-service/groups/{id} 응답 } }
+type FitGroupEvent struct {
+	Version  int                          `json:"version"`
+	FitGroup GetFitGroupDetailApiResponse `json:"fitGroup"`
+}
+
+// fit-mate v2
+// { "version": 2, "fitMates": { GET /fit-group-service/mates/{id} 응답 } }
+type FitMateEvent struct {
+	Version  int                    `json:"version"`
+	FitMates GetFitMatesApiResponse `json:"fitMates"`
+}
+
+// user-info-event v2
+// { "version": 2, "user": { GET /user/user-info 응답 } }
+type UserInfoEvent struct {
+	Version int                    `json:"version"`
+	User    GetUserInfoApiResponse `json:"user"`
+}
@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+// ModerationStatus 는 moderation queue 항목의 검토 상태입니다.
+type ModerationStatus string
+
+const (
+	ModerationPending  ModerationStatus = "PENDING"
+	ModerationApproved ModerationStatus = "APPROVED" // 문제 없음, 메시지 유지
+	ModerationRemoved  ModerationStatus = "REMOVED"  // 메시지 삭제
+)
+
+// ModerationItem 은 필터에 의해 flag 된 메시지로, fit leader 가 검토합니다.
+type ModerationItem struct {
+	ID         int              `json:"moderationId"`
+	MessageID  string           `json:"messageId"`
+	FitGroupID int              `json:"fitGroupId"`
+	UserID     int              `json:"userId"`
+	Message    string           `json:"message"`
+	Reasons    string           `json:"reasons"`
+	Status     ModerationStatus `json:"status"`
+	CreatedAt  time.Time        `json:"createdAt"`
+	ReviewedAt *time.Time       `json:"reviewedAt,omitempty"`
+	ReviewedBy string           `json:"reviewedBy,omitempty"`
+}
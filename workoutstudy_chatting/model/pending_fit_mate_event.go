@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+// PendingFitMateEvent 는 fit group 보다 먼저 도착해 보류한 fit mate 이벤트입니다. fit group 별로 가장 최근 이벤트만 보관합니다.
+type PendingFitMateEvent struct {
+	FitGroupID int
+	FitMates   GetFitMatesApiResponse
+	ReceivedAt time.Time
+	ExpiresAt  time.Time
+}
+
+// PendingFitMateEventStats 는 보류한 fit mate 이벤트 현황입니다. Pending 외에는 서버 시작 이후 누적값입니다.
+type PendingFitMateEventStats struct {
+	Pending        int   `json:"pending"`        // 현재 보류 중인 이벤트 수
+	Parked         int64 `json:"parked"`         // fit group 이 없어 보류한 이벤트 수
+	Replayed       int64 `json:"replayed"`       // fit group 생성 후 다시 처리한 이벤트 수
+	Superseded     int64 `json:"superseded"`     // 같은 fit group 의 새 이벤트로 대체되어 버린 이벤트 수
+	Expired        int64 `json:"expired"`        // fit group 이 생성되지 않아 TTL 이 지나 버린 이벤트 수
+	ReplayFailures int64 `json:"replayFailures"` // 다시 처리하다 실패한 횟수 (보류 상태로 남아 다음에 다시 시도)
+}
@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+// PinnedMessage 는 채팅방에 고정된 메시지입니다.
+type PinnedMessage struct {
+	FitGroupID int          `json:"fitGroupId"`
+	MessageID  string       `json:"messageId"`
+	PinnedBy   int          `json:"pinnedBy"`
+	PinnedAt   time.Time    `json:"pinnedAt"`
+	Message    *ChatMessage `json:"message,omitempty"` // 조회 시 고정된 메시지 내용
+}
+
+// FitGroupPins 는 fit group 정보와 고정 메시지 목록을 함께 내려주는 응답입니다.
+type FitGroupPins struct {
+	FitGroup *FitGroup       `json:"fitGroup"`
+	Pins     []PinnedMessage `json:"pins"`
+	MaxPins  int             `json:"maxPins"`
+}
@@ -0,0 +1,48 @@
+package model
+
+import "time"
+
+// ChatPoll 은 채팅방 투표입니다. 투표 메시지(messageType POLL)와 1:1 로 연결됩니다.
+// 생성 시 클라이언트는 question 대신 메시지 본문(message)을 질문으로 사용하고 options 의 text 만 채웁니다.
+type ChatPoll struct {
+	ID             int          `json:"pollId"`
+	MessageID      string       `json:"messageId"`
+	FitGroupID     int          `json:"fitGroupId"`
+	CreatedBy      int          `json:"createdBy"`
+	Question       string       `json:"question"`
+	Options        []PollOption `json:"options"`
+	MultipleChoice bool         `json:"multipleChoice"`
+	Anonymous      bool         `json:"anonymous"`          // true 면 누가 어디에 투표했는지 공개하지 않음
+	Deadline       *time.Time   `json:"deadline,omitempty"` // RFC3339, 없으면 직접 종료할 때까지 진행
+	ClosedAt       *time.Time   `json:"closedAt,omitempty"` // 종료된 투표일 경우 종료 시간
+	CreatedAt      time.Time    `json:"createdAt"`
+	TotalVoters    int          `json:"totalVoters"`           // 한 번 이상 투표한 사용자 수
+	MyOptionIDs    []int        `json:"myOptionIds,omitempty"` // 조회한 사용자가 선택한 선택지
+}
+
+// IsClosed 는 투표가 종료되었거나 마감 시간이 지났는지 확인합니다.
+func (p *ChatPoll) IsClosed(now time.Time) bool {
+	return p.ClosedAt != nil || (p.Deadline != nil && !now.Before(*p.Deadline))
+}
+
+// PollOption 은 투표 선택지와 집계입니다. 익명 투표는 VoterUserIDs 를 채우지 않습니다.
+type PollOption struct {
+	ID           int    `json:"optionId"`
+	Text         string `json:"text"`
+	VoteCount    int    `json:"voteCount"`
+	VoterUserIDs []int  `json:"voterUserIds,omitempty"`
+}
+
+// 채팅 웹소켓으로 받는 채팅 메시지 외의 프레임 종류
+const (
+	FramePollVote  = "POLL_VOTE"
+	FramePollClose = "POLL_CLOSE"
+)
+
+// PollFrame 은 투표(POLL_VOTE) 또는 투표 종료(POLL_CLOSE) 요청 프레임입니다.
+// POLL_VOTE 의 optionIds 가 비어 있으면 투표를 취소합니다.
+type PollFrame struct {
+	Type      string `json:"type"`
+	PollID    int    `json:"pollId"`
+	OptionIDs []int  `json:"optionIds,omitempty"`
+}
@@ -0,0 +1,37 @@
+package model
+
+import "time"
+
+// 정합성 맞추기에서 수행한 조치 (FitGroupReconciliation, UserReconciliation 의 Action)
+const (
+	ReconcileUpdated  = "UPDATED"   // 다른 서비스 응답으로 DB 를 수정함
+	ReconcileDeleted  = "DELETED"   // fit-group 서비스에서 삭제(state=true)된 fit group 을 DB 에서 삭제함
+	ReconcileNotFound = "NOT_FOUND" // 다른 서비스에 없음. DB 는 그대로 둠
+	ReconcileFailed   = "FAILED"
+)
+
+// ReconciliationReport 는 fit-group, auth 서비스와 DB 의 정합성 맞추기 1회 실행 결과입니다.
+// FitGroups, Users 에는 바뀌었거나 확인하지 못한 항목만 담습니다.
+type ReconciliationReport struct {
+	StartedAt     time.Time                `json:"startedAt"`
+	FinishedAt    time.Time                `json:"finishedAt"`
+	FitGroupCount int                      `json:"fitGroupCount"` // 확인한 fit group 수
+	UserCount     int                      `json:"userCount"`     // 확인한 사용자 수
+	FitGroups     []FitGroupReconciliation `json:"fitGroups"`
+	Users         []UserReconciliation     `json:"users"`
+}
+
+// FitGroupReconciliation 은 fit group 하나의 변경 내역입니다. fit group 정보는 그대로이고 fit mate 만 바뀌었으면 Action 이 비어 있습니다.
+type FitGroupReconciliation struct {
+	FitGroupID        int    `json:"fitGroupId"`
+	Action            string `json:"action,omitempty"`
+	AddedFitMateIDs   []int  `json:"addedFitMateIds,omitempty"`
+	RemovedFitMateIDs []int  `json:"removedFitMateIds,omitempty"`
+	Error             string `json:"error,omitempty"`
+}
+
+type UserReconciliation struct {
+	UserID int    `json:"userId"`
+	Action string `json:"action"`
+	Error  string `json:"error,omitempty"`
+}
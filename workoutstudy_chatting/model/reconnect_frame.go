@@ -0,0 +1,14 @@
+package model
+
+// FrameReconnect 는 서버 종료(배포 등) 직전에 모든 실시간 연결로 보내는 프레임의 type 입니다.
+const FrameReconnect = "RECONNECT"
+
+// ReconnectFrame 은 연결을 닫기 전에 보내는 재접속 안내입니다. 클라이언트는 RetryAfterMs 뒤에 다시 연결합니다.
+type ReconnectFrame struct {
+	Type         string `json:"type"` // 항상 "RECONNECT"
+	RetryAfterMs int64  `json:"retryAfterMs"`
+}
+
+func NewReconnectFrame(retryAfterMs int64) ReconnectFrame {
+	return ReconnectFrame{Type: FrameReconnect, RetryAfterMs: retryAfterMs}
+}
@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+// SystemBotUserID 는 리마인더, 투표 결과 등 서버가 보내는 메시지의 보낸 사람(핏봇)입니다.
+const SystemBotUserID = 0
+
+// Reminder 는 fit leader 가 등록한 채팅방 정기 알림입니다. 실행 시간은 fit group 시간대 기준입니다.
+type Reminder struct {
+	ID         int        `json:"reminderId"`
+	FitGroupID int        `json:"fitGroupId"`
+	Recurrence string     `json:"recurrence"`           // DAILY, WEEKLY, CYCLE_DEADLINE
+	Message    string     `json:"message"`              // CYCLE_DEADLINE 은 비어 있으면 인증 현황만 보냄
+	TimeOfDay  string     `json:"timeOfDay"`            // HH:MM
+	Weekdays   []int      `json:"weekdays,omitempty"`   // WEEKLY, 0: 일요일 ~ 6: 토요일
+	DaysBefore int        `json:"daysBefore,omitempty"` // CYCLE_DEADLINE, 1 이면 주기의 마지막 날
+	NextRunAt  time.Time  `json:"nextRunAt"`
+	LastRunAt  *time.Time `json:"lastRunAt,omitempty"`
+	CreatedBy  int        `json:"createdBy"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	// 실행할 수 없어(fit group 없음, 잘못된 설정) 비활성화된 시간과 사유. 비활성화된 리마인더는 실행하지 않음
+	DisabledAt     *time.Time `json:"disabledAt,omitempty"`
+	DisabledReason string     `json:"disabledReason,omitempty"`
+}
+
+// CertificationProgress 는 현재 인증 주기 동안 fit mate 의 운동 인증(TICKET) 횟수입니다.
+type CertificationProgress struct {
+	UserID   int    `json:"userId"`
+	Nickname string `json:"nickname"`
+	Count    int    `json:"count"`
+}
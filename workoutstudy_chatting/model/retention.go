@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+// RetentionPolicy는 fit group 별 채팅 메시지 보관 기간을 나타내는 구조체입니다.
+// RetentionDays 가 0 이면 해당 fit group 의 메시지는 아카이브하지 않고 영구 보관합니다.
+type RetentionPolicy struct {
+	FitGroupID    int       `json:"fitGroupId"`
+	RetentionDays int       `json:"retentionDays"`
+	CreatedAt     time.Time `json:"createdAt"`
+	CreatedBy     string    `json:"createdBy"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+	UpdatedBy     string    `json:"updatedBy"`
+}
+
+// RetentionReport는 보관 기간 정책 1회 실행 결과입니다.
+type RetentionReport struct {
+	StartedAt        time.Time `json:"startedAt"`
+	FinishedAt       time.Time `json:"finishedAt"`
+	FitGroupCount    int       `json:"fitGroupCount"`
+	ArchivedMessages int       `json:"archivedMessages"`
+	ArchiveObjects   []string  `json:"archiveObjects"`
+}
@@ -0,0 +1,26 @@
+package model
+
+// RoomEventType 은 채팅 메시지 외에 채팅방으로 전송되는 이벤트 종류입니다.
+type RoomEventType string
+
+const (
+	EventMessageDeleted  RoomEventType = "MESSAGE_DELETED"
+	EventMessagePinned   RoomEventType = "MESSAGE_PINNED"
+	EventMessageUnpinned RoomEventType = "MESSAGE_UNPINNED"
+	EventMemberMuted     RoomEventType = "MEMBER_MUTED"
+	EventMemberUnmuted   RoomEventType = "MEMBER_UNMUTED"
+	EventMemberBanned    RoomEventType = "MEMBER_BANNED"
+	EventPollUpdated     RoomEventType = "POLL_UPDATED"
+	EventPollClosed      RoomEventType = "POLL_CLOSED"
+)
+
+// RoomEvent 는 채팅방 전체에 전송되는 이벤트 프레임입니다.
+// 클라이언트는 type 필드로 일반 채팅 메시지(messageType)와 구분합니다.
+type RoomEvent struct {
+	Type         RoomEventType `json:"type"`
+	FitGroupID   int           `json:"fitGroupId"`
+	MessageID    string        `json:"messageId,omitempty"`
+	TargetUserID int           `json:"targetUserId,omitempty"`
+	ActorUserID  int           `json:"actorUserId,omitempty"`
+	Payload      interface{}   `json:"payload,omitempty"`
+}
@@ -0,0 +1,26 @@
+package model
+
+// 사용자 웹소켓(/user/chat)으로 전송되는 프레임 종류
+const (
+	FrameRoom         = "ROOM"         // fit group 채팅방 프레임
+	FrameSubscribed   = "SUBSCRIBED"   // 채팅방 구독 추가
+	FrameUnsubscribed = "UNSUBSCRIBED" // 채팅방 구독 해지 (탈퇴, 차단, fit group 삭제)
+)
+
+// UserSocketRoomFrame 은 사용자 웹소켓으로 전달되는 채팅방 프레임입니다.
+// Frame 은 채팅방 웹소켓(/chat)과 같은 형식(채팅 메시지, RoomEvent, 에러, 명령어 응답 등)입니다.
+type UserSocketRoomFrame struct {
+	Type       string      `json:"type"` // 항상 "ROOM"
+	FitGroupID int         `json:"fitGroupId"`
+	Frame      interface{} `json:"frame"`
+}
+
+func NewUserSocketRoomFrame(fitGroupID int, frame interface{}) UserSocketRoomFrame {
+	return UserSocketRoomFrame{Type: FrameRoom, FitGroupID: fitGroupID, Frame: frame}
+}
+
+// SubscriptionFrame 은 사용자 웹소켓의 채팅방 구독 변경 알림입니다. 연결 직후 구독한 전체 목록을 SUBSCRIBED 로 보냅니다.
+type SubscriptionFrame struct {
+	Type        string `json:"type"`
+	FitGroupIDs []int  `json:"fitGroupIds"`
+}
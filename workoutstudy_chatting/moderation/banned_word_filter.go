@@ -0,0 +1,115 @@
+package moderation
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"unicode/utf8"
+	"workoutstudy_chatting/model"
+)
+
+//go:embed banned_words.txt
+var defaultBannedWords string
+
+// DefaultBannedWords 는 기본 금칙어 목록입니다.
+func DefaultBannedWords() []string {
+	return parseWordList(defaultBannedWords)
+}
+
+// LoadBannedWords 는 한 줄에 하나씩 금칙어가 적힌 파일을 읽습니다. '#' 으로 시작하는 줄은 주석입니다.
+func LoadBannedWords(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading banned word list %s: %w", path, err)
+	}
+	return parseWordList(string(data)), nil
+}
+
+func parseWordList(data string) []string {
+	var words []string
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		words = append(words, line)
+	}
+	return words
+}
+
+// BannedWordFilter 는 정규화된 문자열에서 금칙어를 찾아 가리거나(Mask) 거부(Reject)합니다.
+type BannedWordFilter struct {
+	words  [][]rune
+	action Action
+}
+
+func NewBannedWordFilter(words []string, action Action) *BannedWordFilter {
+	f := &BannedWordFilter{action: action}
+	for _, w := range words {
+		if n := normalizeWord(w); len(n) > 0 {
+			f.words = append(f.words, n)
+		}
+	}
+	// 긴 금칙어부터 검사하여 겹치는 경우 더 넓게 가림
+	sort.Slice(f.words, func(i, j int) bool { return len(f.words[i]) > len(f.words[j]) })
+	return f
+}
+
+func (f *BannedWordFilter) Name() string { return "banned-word" }
+
+func (f *BannedWordFilter) Check(msg model.ChatMessage) Verdict {
+	if len(f.words) == 0 {
+		return Verdict{Action: Allow}
+	}
+	text := normalize(msg.Message)
+
+	type span struct{ start, end int }
+	var spans []span
+	for _, word := range f.words {
+		for i := 0; i+len(word) <= len(text.runes); i++ {
+			if runesEqual(text.runes[i:i+len(word)], word) {
+				spans = append(spans, span{text.start[i], text.end[i+len(word)-1]})
+			}
+		}
+	}
+	if len(spans) == 0 {
+		return Verdict{Action: Allow}
+	}
+
+	reason := fmt.Sprintf("%d banned word(s)", len(spans))
+	if f.action != Mask {
+		return Verdict{Action: f.action, Reason: reason}
+	}
+
+	masked := []byte(msg.Message)
+	hidden := make([]bool, len(masked))
+	for _, sp := range spans {
+		for i := sp.start; i < sp.end; i++ {
+			hidden[i] = true
+		}
+	}
+	var b strings.Builder
+	for i := 0; i < len(masked); {
+		_, size := utf8.DecodeRune(masked[i:])
+		if hidden[i] && masked[i] != ' ' {
+			b.WriteByte('*')
+		} else {
+			b.Write(masked[i : i+size])
+		}
+		i += size
+	}
+	return Verdict{Action: Mask, Reason: reason, Text: b.String()}
+}
+
+func runesEqual(a, b []rune) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
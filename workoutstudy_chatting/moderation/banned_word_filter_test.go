@@ -0,0 +1,75 @@
+package moderation
+
+import (
+	"reflect"
+	"testing"
+
+	"workoutstudy_chatting/model"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		want      string
+		wantStart []int
+		wantEnd   []int
+	}{
+		{name: "공백, 숫자, 특수문자 제거", input: "시 1 발!", want: "시발", wantStart: []int{0, 6}, wantEnd: []int{3, 9}},
+		{name: "전각 영문은 반각 소문자로", input: "ＳＰａｍ", want: "spam", wantStart: []int{0, 3, 6, 9}, wantEnd: []int{3, 6, 9, 12}},
+		{name: "분리 입력한 자모는 음절로 조합", input: "ㅅㅣ발", want: "시발", wantStart: []int{0, 6}, wantEnd: []int{6, 9}},
+		{name: "호환 자모는 초성 자모로", input: "ㅅㅂ", want: "ᄉᄇ", wantStart: []int{0, 3}, wantEnd: []int{3, 6}},
+		{name: "글자가 없으면 빈 결과", input: "123 !?", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := normalize(tt.input)
+			if string(got.runes) != tt.want {
+				t.Fatalf("normalize(%q) = %q, want %q", tt.input, string(got.runes), tt.want)
+			}
+			if len(tt.want) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(got.start, tt.wantStart) || !reflect.DeepEqual(got.end, tt.wantEnd) {
+				t.Fatalf("offsets = %v-%v, want %v-%v", got.start, got.end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestBannedWordFilterCheck(t *testing.T) {
+	words := []string{"시발", "ㅅㅂ", "spam"}
+	tests := []struct {
+		name   string
+		action Action
+		input  string
+		want   Verdict
+	}{
+		{name: "금칙어 없음", action: Mask, input: "시바견 귀여워", want: Verdict{Action: Allow}},
+		{name: "금칙어만 가림", action: Mask, input: "아 시발 진짜", want: Verdict{Action: Mask, Reason: "1 banned word(s)", Text: "아 ** 진짜"}},
+		{name: "끼워 넣은 숫자도 가리고 공백은 유지", action: Mask, input: "시 1 발", want: Verdict{Action: Mask, Reason: "1 banned word(s)", Text: "* * *"}},
+		{name: "분리 입력한 자모", action: Mask, input: "ㅅㅣ발 뭐야", want: Verdict{Action: Mask, Reason: "1 banned word(s)", Text: "*** 뭐야"}},
+		{name: "호환 자모 금칙어", action: Mask, input: "ㅅ.ㅂ", want: Verdict{Action: Mask, Reason: "1 banned word(s)", Text: "***"}},
+		{name: "전각 대문자", action: Mask, input: "ＳＰＡＭ 메일", want: Verdict{Action: Mask, Reason: "1 banned word(s)", Text: "**** 메일"}},
+		{name: "여러 번 나오면 모두 가림", action: Mask, input: "시발 spam 시발", want: Verdict{Action: Mask, Reason: "3 banned word(s)", Text: "** **** **"}},
+		{name: "Reject 는 가린 문장 없이 거부", action: Reject, input: "시발", want: Verdict{Action: Reject, Reason: "1 banned word(s)"}},
+		{name: "Flag 는 원문 그대로 기록", action: Flag, input: "spam", want: Verdict{Action: Flag, Reason: "1 banned word(s)"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewBannedWordFilter(words, tt.action)
+			if got := f.Check(model.ChatMessage{Message: tt.input}); got != tt.want {
+				t.Fatalf("Check(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBannedWordFilterWithoutWords(t *testing.T) {
+	f := NewBannedWordFilter([]string{"", " ", "123"}, Reject)
+	if got := f.Check(model.ChatMessage{Message: "아무 말"}); got.Action != Allow {
+		t.Fatalf("Check = %+v, want Allow when no word survives normalization", got)
+	}
+}
@@ -0,0 +1,64 @@
+package moderation
+
+import (
+	"fmt"
+
+	"workoutstudy_chatting/config"
+)
+
+// Config 는 기본 필터 체인 설정입니다.
+type Config struct {
+	BannedWords        []string
+	BannedWordAction   Action
+	AllowedLinkDomains []string
+	LinkAction         Action
+	Spam               SpamConfig
+}
+
+// NewConfig 는 서비스 설정의 moderation 항목으로 필터 체인 설정을 만듭니다.
+// 금칙어 파일이 지정되지 않으면 내장 금칙어 목록을 사용합니다.
+func NewConfig(cfg config.ModerationConfig) (Config, error) {
+	words := DefaultBannedWords()
+	if cfg.BannedWordsFile != "" {
+		var err error
+		if words, err = LoadBannedWords(cfg.BannedWordsFile); err != nil {
+			return Config{}, err
+		}
+	}
+
+	actions := make(map[string]Action, 3)
+	for name, value := range map[string]string{
+		"bannedWordAction": cfg.BannedWordAction,
+		"linkAction":       cfg.LinkAction,
+		"spam.action":      cfg.Spam.Action,
+	} {
+		action, ok := ParseAction(value)
+		if !ok {
+			return Config{}, fmt.Errorf("invalid moderation.%s %q", name, value)
+		}
+		actions[name] = action
+	}
+
+	return Config{
+		BannedWords:        words,
+		BannedWordAction:   actions["bannedWordAction"],
+		AllowedLinkDomains: cfg.AllowedLinkDomains,
+		LinkAction:         actions["linkAction"],
+		Spam: SpamConfig{
+			MaxLength:        cfg.Spam.MaxLength,
+			MaxRepeatedRunes: cfg.Spam.MaxRepeatedRunes,
+			MaxLinks:         cfg.Spam.MaxLinks,
+			Action:           actions["spam.action"],
+		},
+	}, nil
+}
+
+// NewDefaultPipeline 은 스팸 -> 금칙어 -> 링크 순서의 기본 파이프라인을 생성합니다.
+// 사용자 정의 규칙은 반환된 Pipeline 의 Use 로 추가합니다.
+func NewDefaultPipeline(config Config) *Pipeline {
+	return NewPipeline(
+		NewSpamFilter(config.Spam),
+		NewBannedWordFilter(config.BannedWords, config.BannedWordAction),
+		NewLinkFilter(config.AllowedLinkDomains, config.LinkAction),
+	)
+}
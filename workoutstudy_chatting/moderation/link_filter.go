@@ -0,0 +1,71 @@
+package moderation
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"workoutstudy_chatting/model"
+)
+
+var linkPattern = regexp.MustCompile(`(?i)\b(?:https?://|www\.)[^\s<>"]+`)
+
+// LinkFilter 는 허용 도메인 목록에 없는 링크에 action 을 적용합니다.
+// AllowedDomains 가 비어 있으면 모든 링크에 action 을 적용하고, action 이 Allow 이면 링크를 검사하지 않습니다.
+type LinkFilter struct {
+	allowedDomains []string
+	action         Action
+}
+
+func NewLinkFilter(allowedDomains []string, action Action) *LinkFilter {
+	domains := make([]string, 0, len(allowedDomains))
+	for _, d := range allowedDomains {
+		if d = strings.ToLower(strings.TrimSpace(d)); d != "" {
+			domains = append(domains, strings.TrimPrefix(d, "."))
+		}
+	}
+	return &LinkFilter{allowedDomains: domains, action: action}
+}
+
+func (f *LinkFilter) Name() string { return "link" }
+
+func (f *LinkFilter) Check(msg model.ChatMessage) Verdict {
+	if f.action == Allow {
+		return Verdict{Action: Allow}
+	}
+
+	var blocked []string
+	masked := linkPattern.ReplaceAllStringFunc(msg.Message, func(link string) string {
+		if f.allowed(link) {
+			return link
+		}
+		blocked = append(blocked, link)
+		return "[차단된 링크]"
+	})
+	if len(blocked) == 0 {
+		return Verdict{Action: Allow}
+	}
+
+	v := Verdict{Action: f.action, Reason: fmt.Sprintf("disallowed link(s): %s", strings.Join(blocked, ", "))}
+	if f.action == Mask {
+		v.Text = masked
+	}
+	return v
+}
+
+func (f *LinkFilter) allowed(link string) bool {
+	if !strings.Contains(link, "://") {
+		link = "http://" + link
+	}
+	u, err := url.Parse(link)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, d := range f.allowedDomains {
+		if host == d || strings.HasSuffix(host, "."+d) {
+			return true
+		}
+	}
+	return false
+}
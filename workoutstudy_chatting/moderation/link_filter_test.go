@@ -0,0 +1,55 @@
+package moderation
+
+import (
+	"testing"
+
+	"workoutstudy_chatting/model"
+)
+
+func TestLinkFilterCheck(t *testing.T) {
+	allowed := []string{"example.com", " .Docs.Example.org "}
+	tests := []struct {
+		name    string
+		allowed []string
+		action  Action
+		input   string
+		want    Verdict
+	}{
+		{name: "링크 없음", allowed: allowed, action: Mask, input: "오늘 운동 인증", want: Verdict{Action: Allow}},
+		{name: "허용 도메인", allowed: allowed, action: Mask, input: "https://example.com/run 참고", want: Verdict{Action: Allow}},
+		{name: "허용 도메인의 하위 도메인", allowed: allowed, action: Mask, input: "http://m.example.com", want: Verdict{Action: Allow}},
+		{name: "scheme 없는 www 링크", allowed: allowed, action: Mask, input: "www.example.com/a?b=c", want: Verdict{Action: Allow}},
+		{name: "대소문자 무시", allowed: allowed, action: Mask, input: "HTTPS://EXAMPLE.COM", want: Verdict{Action: Allow}},
+		{name: "앞의 점과 공백을 지운 허용 도메인", allowed: allowed, action: Mask, input: "https://docs.example.org/x", want: Verdict{Action: Allow}},
+		{
+			name: "이름이 허용 도메인으로 끝나는 다른 도메인", allowed: allowed, action: Mask, input: "여기 https://evil-example.com 봐",
+			want: Verdict{Action: Mask, Reason: "disallowed link(s): https://evil-example.com", Text: "여기 [차단된 링크] 봐"},
+		},
+		{
+			name: "scheme 없는 www 링크도 차단", allowed: allowed, action: Mask, input: "www.evil-example.com",
+			want: Verdict{Action: Mask, Reason: "disallowed link(s): www.evil-example.com", Text: "[차단된 링크]"},
+		},
+		{
+			name: "허용 도메인을 하위 도메인으로 쓴 링크", allowed: allowed, action: Mask, input: "http://example.com.evil.io/login",
+			want: Verdict{Action: Mask, Reason: "disallowed link(s): http://example.com.evil.io/login", Text: "[차단된 링크]"},
+		},
+		{
+			name: "허용 링크는 두고 나머지만 가림", allowed: allowed, action: Mask, input: "https://example.com http://a.io http://b.io",
+			want: Verdict{Action: Mask, Reason: "disallowed link(s): http://a.io, http://b.io", Text: "https://example.com [차단된 링크] [차단된 링크]"},
+		},
+		{
+			name: "허용 목록이 비어 있으면 모든 링크", action: Reject, input: "https://example.com",
+			want: Verdict{Action: Reject, Reason: "disallowed link(s): https://example.com"},
+		},
+		{name: "Allow 이면 검사하지 않음", action: Allow, input: "https://evil.io", want: Verdict{Action: Allow}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewLinkFilter(tt.allowed, tt.action)
+			if got := f.Check(model.ChatMessage{Message: tt.input}); got != tt.want {
+				t.Fatalf("Check(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package moderation
+
+import (
+	"strings"
+	"workoutstudy_chatting/model"
+)
+
+// Action 은 필터의 판정 결과입니다. 값이 클수록 강한 조치입니다.
+type Action int
+
+const (
+	Allow  Action = iota // 그대로 통과
+	Mask                 // 문제 부분을 가린 뒤 통과
+	Flag                 // 통과시키되 moderation queue 에 기록하여 fit leader 가 검토
+	Reject               // 저장 및 브로드캐스트하지 않고 거부
+)
+
+func (a Action) String() string {
+	switch a {
+	case Mask:
+		return "MASK"
+	case Flag:
+		return "FLAG"
+	case Reject:
+		return "REJECT"
+	default:
+		return "ALLOW"
+	}
+}
+
+// ParseAction 은 설정 문자열(allow, mask, flag, reject)을 Action 으로 변환합니다.
+func ParseAction(s string) (Action, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "allow":
+		return Allow, true
+	case "mask":
+		return Mask, true
+	case "flag":
+		return Flag, true
+	case "reject":
+		return Reject, true
+	}
+	return Allow, false
+}
+
+// Verdict 는 필터 하나의 판정입니다. Action 이 Mask 이면 Text 에 가려진 메시지가 들어 있습니다.
+type Verdict struct {
+	Action Action
+	Reason string
+	Text   string
+}
+
+// Filter 는 메시지 검사 규칙입니다. 사용자 정의 규칙은 이 인터페이스를 구현하거나 NewHook 으로 등록합니다.
+type Filter interface {
+	Name() string
+	Check(msg model.ChatMessage) Verdict
+}
+
+type hook struct {
+	name string
+	fn   func(msg model.ChatMessage) Verdict
+}
+
+// NewHook 은 함수를 Filter 로 감쌉니다.
+func NewHook(name string, fn func(msg model.ChatMessage) Verdict) Filter {
+	return &hook{name: name, fn: fn}
+}
+
+func (h *hook) Name() string                        { return h.name }
+func (h *hook) Check(msg model.ChatMessage) Verdict { return h.fn(msg) }
+
+// Result 는 파이프라인 전체 실행 결과입니다.
+type Result struct {
+	Action  Action
+	Message model.ChatMessage // Mask 가 적용된 최종 메시지
+	Reasons []string          // "필터이름: 사유" 형식
+}
+
+// Pipeline 은 등록된 순서대로 필터를 실행합니다.
+// Mask 결과는 다음 필터의 입력이 되고, Reject 가 나오면 즉시 중단합니다.
+type Pipeline struct {
+	filters []Filter
+}
+
+func NewPipeline(filters ...Filter) *Pipeline {
+	return &Pipeline{filters: filters}
+}
+
+// Use 는 파이프라인 끝에 필터를 추가합니다. 서비스 시작 전에만 호출해야 합니다.
+func (p *Pipeline) Use(filter Filter) {
+	p.filters = append(p.filters, filter)
+}
+
+func (p *Pipeline) Run(msg model.ChatMessage) Result {
+	result := Result{Action: Allow, Message: msg}
+	for _, f := range p.filters {
+		v := f.Check(result.Message)
+		if v.Action == Allow {
+			continue
+		}
+		result.Reasons = append(result.Reasons, f.Name()+": "+v.Reason)
+		if v.Action == Mask && v.Text != "" {
+			result.Message.Message = v.Text
+		}
+		if v.Action > result.Action {
+			result.Action = v.Action
+		}
+		if v.Action == Reject {
+			break
+		}
+	}
+	return result
+}
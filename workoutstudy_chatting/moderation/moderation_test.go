@@ -0,0 +1,95 @@
+package moderation
+
+import (
+	"reflect"
+	"testing"
+
+	"workoutstudy_chatting/model"
+)
+
+// verdictHook 은 호출된 필터 이름을 calls 에 기록하고 verdict 를 반환하는 필터를 만듭니다.
+func verdictHook(name string, verdict Verdict, calls *[]string) Filter {
+	return NewHook(name, func(msg model.ChatMessage) Verdict {
+		*calls = append(*calls, name+"("+msg.Message+")")
+		return verdict
+	})
+}
+
+func TestPipelineRun(t *testing.T) {
+	tests := []struct {
+		name        string
+		verdicts    []Verdict // 필터 a, b, c 의 판정
+		wantAction  Action
+		wantMessage string
+		wantReasons []string
+		wantCalls   []string
+	}{
+		{
+			name:        "모두 통과",
+			verdicts:    []Verdict{{Action: Allow}, {Action: Allow}, {Action: Allow}},
+			wantAction:  Allow,
+			wantMessage: "hi",
+			wantCalls:   []string{"a(hi)", "b(hi)", "c(hi)"},
+		},
+		{
+			name:        "가린 메시지가 다음 필터의 입력",
+			verdicts:    []Verdict{{Action: Mask, Reason: "r1", Text: "h*"}, {Action: Allow}, {Action: Mask, Reason: "r3", Text: "**"}},
+			wantAction:  Mask,
+			wantMessage: "**",
+			wantReasons: []string{"a: r1", "c: r3"},
+			wantCalls:   []string{"a(hi)", "b(h*)", "c(h*)"},
+		},
+		{
+			name:        "가장 강한 조치를 유지",
+			verdicts:    []Verdict{{Action: Flag, Reason: "r1"}, {Action: Mask, Reason: "r2", Text: "h*"}, {Action: Allow}},
+			wantAction:  Flag,
+			wantMessage: "h*",
+			wantReasons: []string{"a: r1", "b: r2"},
+			wantCalls:   []string{"a(hi)", "b(hi)", "c(h*)"},
+		},
+		{
+			name:        "Reject 이면 남은 필터를 실행하지 않음",
+			verdicts:    []Verdict{{Action: Mask, Reason: "r1", Text: "h*"}, {Action: Reject, Reason: "r2"}, {Action: Flag, Reason: "r3"}},
+			wantAction:  Reject,
+			wantMessage: "h*",
+			wantReasons: []string{"a: r1", "b: r2"},
+			wantCalls:   []string{"a(hi)", "b(h*)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			p := NewPipeline(verdictHook("a", tt.verdicts[0], &calls), verdictHook("b", tt.verdicts[1], &calls))
+			p.Use(verdictHook("c", tt.verdicts[2], &calls))
+
+			result := p.Run(model.ChatMessage{ID: "m1", Message: "hi"})
+			if result.Action != tt.wantAction || result.Message.Message != tt.wantMessage || result.Message.ID != "m1" {
+				t.Fatalf("Run = %+v, want %v %q", result, tt.wantAction, tt.wantMessage)
+			}
+			if !reflect.DeepEqual(result.Reasons, tt.wantReasons) {
+				t.Fatalf("Reasons = %v, want %v", result.Reasons, tt.wantReasons)
+			}
+			if !reflect.DeepEqual(calls, tt.wantCalls) {
+				t.Fatalf("calls = %v, want %v", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestDefaultPipelineRejectsBeforeLinkCheck(t *testing.T) {
+	p := NewDefaultPipeline(Config{
+		BannedWords:      []string{"시발"},
+		BannedWordAction: Reject,
+		LinkAction:       Mask,
+		Spam:             SpamConfig{MaxLength: 100},
+	})
+
+	result := p.Run(model.ChatMessage{Message: "시발 https://evil.io"})
+	if result.Action != Reject || !reflect.DeepEqual(result.Reasons, []string{"banned-word: 1 banned word(s)"}) {
+		t.Fatalf("Run = %+v, want rejected by banned-word only", result)
+	}
+	if result.Message.Message != "시발 https://evil.io" {
+		t.Fatalf("rejected message was changed to %q", result.Message.Message)
+	}
+}
@@ -0,0 +1,49 @@
+package moderation
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// normalizedText 는 비교용으로 정규화된 문자열과, 각 문자가 원문 어디에서 왔는지를 함께 가집니다.
+type normalizedText struct {
+	runes []rune
+	start []int // 원문 byte offset (포함)
+	end   []int // 원문 byte offset (미포함)
+}
+
+// normalize 는 금칙어 비교를 위해 문자열을 정규화합니다.
+//   - NFKC: 전각/반각, 호환 자모(ㅅ -> ᄉ) 통일, 분리 입력된 한글 자모를 음절로 조합
+//   - 소문자 변환
+//   - 글자가 아닌 문자(공백, 숫자, 특수문자)를 제거하여 "시 1 발" 같은 우회 입력도 탐지
+func normalize(s string) normalizedText {
+	var out normalizedText
+	var it norm.Iter
+	it.InitString(norm.NFKC, s)
+
+	offset := 0
+	for !it.Done() {
+		segStart := offset
+		seg := it.Next()
+		// Iter 는 원문 segment 를 소비한 만큼 Pos 를 이동시킴
+		offset = it.Pos()
+		for len(seg) > 0 {
+			r, size := utf8.DecodeRune(seg)
+			seg = seg[size:]
+			r = unicode.ToLower(r)
+			if !unicode.IsLetter(r) {
+				continue
+			}
+			out.runes = append(out.runes, r)
+			out.start = append(out.start, segStart)
+			out.end = append(out.end, offset)
+		}
+	}
+	return out
+}
+
+func normalizeWord(s string) []rune {
+	return normalize(s).runes
+}
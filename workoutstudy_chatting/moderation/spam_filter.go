@@ -0,0 +1,56 @@
+package moderation
+
+import (
+	"fmt"
+	"unicode/utf8"
+	"workoutstudy_chatting/model"
+)
+
+// SpamConfig 는 스팸 휴리스틱 기준입니다. 0 이면 해당 검사를 하지 않습니다.
+type SpamConfig struct {
+	MaxLength        int    // 최대 글자 수. 초과 시 항상 Reject
+	MaxRepeatedRunes int    // 같은 글자 연속 반복 허용 횟수 (ㅋㅋㅋ 는 흔하므로 넉넉하게)
+	MaxLinks         int    // 메시지 하나에 포함 가능한 링크 수
+	Action           Action // 반복/링크 기준 초과 시 조치
+}
+
+type SpamFilter struct {
+	config SpamConfig
+}
+
+func NewSpamFilter(config SpamConfig) *SpamFilter {
+	return &SpamFilter{config: config}
+}
+
+func (f *SpamFilter) Name() string { return "spam" }
+
+func (f *SpamFilter) Check(msg model.ChatMessage) Verdict {
+	if f.config.MaxLength > 0 {
+		if n := utf8.RuneCountInString(msg.Message); n > f.config.MaxLength {
+			return Verdict{Action: Reject, Reason: fmt.Sprintf("message too long (%d > %d)", n, f.config.MaxLength)}
+		}
+	}
+
+	if f.config.MaxRepeatedRunes > 0 {
+		var prev rune
+		run := 0
+		for _, r := range msg.Message {
+			if r == prev {
+				run++
+			} else {
+				prev, run = r, 1
+			}
+			if run > f.config.MaxRepeatedRunes {
+				return Verdict{Action: f.config.Action, Reason: fmt.Sprintf("character %q repeated more than %d times", r, f.config.MaxRepeatedRunes)}
+			}
+		}
+	}
+
+	if f.config.MaxLinks > 0 {
+		if n := len(linkPattern.FindAllStringIndex(msg.Message, -1)); n > f.config.MaxLinks {
+			return Verdict{Action: f.config.Action, Reason: fmt.Sprintf("too many links (%d > %d)", n, f.config.MaxLinks)}
+		}
+	}
+
+	return Verdict{Action: Allow}
+}
@@ -2,7 +2,10 @@ package persistence
 
 import (
 	"database/sql"
+	"fmt"
 	"log"
+	"strconv"
+	"strings"
 	"time"
 	"workoutstudy_chatting/model"
 )
@@ -12,24 +15,32 @@ type ChatRepository interface {
 	RetrieveMessages(fitGroupID int, since time.Time) ([]model.ChatMessage, error)
 	SaveMessage(msg model.ChatMessage) error
 	RetrieveMessagesInRange(fitGroupID int, start, end time.Time) ([]model.ChatMessage, error)
+	GetFitGroupIDsWithMessages() ([]int, error)
+	RetrieveMessagesBefore(fitGroupID int, cutoff time.Time, limit int) ([]model.ChatMessage, error)
+	DeleteMessages(messageIDs []string) (int64, error)
+	RestoreMessages(messages []model.ChatMessage) (int, error)
+	GetMessageByID(messageID string) (*model.ChatMessage, error)
+	SoftDeleteMessage(messageID string, deletedBy string) error
+	StreamMessageHistory(fitGroupID int, includeDeleted bool, fn func(model.ChatHistoryEntry) error) error
+	CountCertifications(fitGroupID int, start, end time.Time) ([]model.CertificationProgress, error)
 }
 
 type ChatRepositoryImpl struct {
-	DB *sql.DB
+	DB *SQLDB
 }
 
 // 훈기 tip : 인터페이스에 정의된 함수 중 구현안된거 체크
 // var _ ChatRepository = &ChatRepositoryImpl{}
 var _ ChatRepository = (*ChatRepositoryImpl)(nil)
 
-func NewChatRepository(db *sql.DB) ChatRepository {
+func NewChatRepository(db *SQLDB) ChatRepository {
 	return &ChatRepositoryImpl{DB: db}
 }
 
 // TODO : 서비스 레이어에서 이 함수를 사용해서 messageId 만 비교하도록 수정
 func (repo *ChatRepositoryImpl) RetrieveMessage(fitGroupID int) (int, error) {
 	query := `
-	SELECT message_id FROM message WHERE fit_group_id = $1 ORDER BY message_time DESC LIMIT 1
+	SELECT message_id FROM message WHERE fit_group_id = $1 AND deleted_at IS NULL ORDER BY message_time DESC LIMIT 1
 	`
 	log.Printf("Repository layer: Executing query for fitGroupID: %d", fitGroupID)
 	var messageID int
@@ -44,7 +55,7 @@ func (repo *ChatRepositoryImpl) RetrieveMessages(fitGroupID int, since time.Time
 	query := `
     SELECT message_id, user_id, fit_group_id, message, message_time, message_type
     FROM message
-    WHERE fit_group_id = $1 AND message_time > $2
+    WHERE fit_group_id = $1 AND message_time > $2 AND deleted_at IS NULL
     ORDER BY message_time DESC
     `
 	log.Printf("Repository layer: Executing query for fitGroupID: %d, since: %v", fitGroupID, since)
@@ -58,7 +69,7 @@ func (repo *ChatRepositoryImpl) RetrieveMessages(fitGroupID int, since time.Time
 	var messages []model.ChatMessage
 	for rows.Next() {
 		var msg model.ChatMessage
-		if err := rows.Scan(&msg.ID, &msg.FitGroupID, &msg.FitMateID, &msg.Message, &msg.MessageTime, &msg.MessageType); err != nil {
+		if err := rows.Scan(&msg.ID, &msg.UserID, &msg.FitGroupID, &msg.Message, &msg.MessageTime, &msg.MessageType); err != nil {
 			return nil, err
 		}
 		messages = append(messages, msg)
@@ -74,7 +85,7 @@ func (repo *ChatRepositoryImpl) RetrieveMessagesInRange(fitGroupID int, start, e
 	query := `
     SELECT message_id, user_id, fit_group_id, message, message_time, message_type
     FROM message
-    WHERE fit_group_id = $1 AND message_time >= $2 AND message_time <= $3
+    WHERE fit_group_id = $1 AND message_time >= $2 AND message_time <= $3 AND deleted_at IS NULL
     ORDER BY message_time ASC
     `
 	log.Printf("Repository layer: Executing range query for fitGroupID: %d, start: %v, end: %v", fitGroupID, start, end)
@@ -88,7 +99,7 @@ func (repo *ChatRepositoryImpl) RetrieveMessagesInRange(fitGroupID int, start, e
 	var messages []model.ChatMessage
 	for rows.Next() {
 		var msg model.ChatMessage
-		if err := rows.Scan(&msg.ID, &msg.FitGroupID, &msg.FitMateID, &msg.Message, &msg.MessageTime, &msg.MessageType); err != nil {
+		if err := rows.Scan(&msg.ID, &msg.UserID, &msg.FitGroupID, &msg.Message, &msg.MessageTime, &msg.MessageType); err != nil {
 			return nil, err
 		}
 		messages = append(messages, msg)
@@ -104,8 +115,244 @@ func (repo *ChatRepositoryImpl) SaveMessage(msg model.ChatMessage) error {
 	log.Printf("chat repository 에서 메시지 저장 시작: %v", msg)
 	query := `
     INSERT INTO message (message_id, user_id, fit_group_id, message, message_time, message_type, created_at, created_by, updated_at, updated_by)
-	VALUES ($1, $2, $3, $4, $5, $6, NOW(), $7, NOW(), $7)
+	VALUES ($1::uuid, $2, $3, $4, $5, $6, NOW(), $7, NOW(), $7)
     `
-	_, err := repo.DB.Exec(query, msg.ID, msg.FitGroupID, msg.UserID, msg.Message, msg.MessageTime, msg.MessageType, time.Now(), msg.UserID, time.Now(), msg.UserID)
+	_, err := repo.DB.Exec(query, msg.ID, msg.UserID, msg.FitGroupID, msg.Message, msg.MessageTime, msg.MessageType, strconv.Itoa(msg.UserID))
 	return err
 }
+
+func (repo *ChatRepositoryImpl) GetFitGroupIDsWithMessages() ([]int, error) {
+	query := `SELECT DISTINCT fit_group_id FROM message ORDER BY fit_group_id`
+	rows, err := repo.DB.Query(query)
+	if err != nil {
+		log.Printf("Repository layer: Error retrieving fit group IDs with messages: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var fitGroupIDs []int
+	for rows.Next() {
+		var fitGroupID int
+		if err := rows.Scan(&fitGroupID); err != nil {
+			return nil, err
+		}
+		fitGroupIDs = append(fitGroupIDs, fitGroupID)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return fitGroupIDs, nil
+}
+
+/*
+RetrieveMessagesBefore 는 cutoff 이전의 메시지를 삭제된 메시지를 포함해 오래된 순으로 최대 limit 개 조회합니다.
+다음 메시지는 삭제하면 ON DELETE CASCADE 로 채팅방 상태가 사라지므로 제외합니다.
+- 아카이브에서 복원한 메시지
+- 고정된 메시지, 진행 중인 투표 메시지, 검토 대기 중인 moderation queue 메시지
+*/
+func (repo *ChatRepositoryImpl) RetrieveMessagesBefore(fitGroupID int, cutoff time.Time, limit int) ([]model.ChatMessage, error) {
+	query := `
+    SELECT message_id, user_id, fit_group_id, message, message_time, message_type, deleted_at
+    FROM message m
+    WHERE fit_group_id = $1 AND message_time < $2 AND restored_at IS NULL
+      AND NOT EXISTS (SELECT 1 FROM pinned_message p WHERE p.message_id = m.message_id)
+      AND NOT EXISTS (SELECT 1 FROM poll WHERE poll.message_id = m.message_id AND poll.closed_at IS NULL)
+      AND NOT EXISTS (SELECT 1 FROM moderation_queue q WHERE q.message_id = m.message_id AND q.status = 'PENDING')
+    ORDER BY message_time ASC
+    LIMIT $3
+    `
+	rows, err := repo.DB.Query(query, fitGroupID, cutoff, limit)
+	if err != nil {
+		log.Printf("Repository layer: Error retrieving messages before %v: %v", cutoff, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []model.ChatMessage
+	for rows.Next() {
+		var msg model.ChatMessage
+		var deletedAt sql.NullTime
+		if err := rows.Scan(&msg.ID, &msg.UserID, &msg.FitGroupID, &msg.Message, &msg.MessageTime, &msg.MessageType, &deletedAt); err != nil {
+			return nil, err
+		}
+		if deletedAt.Valid {
+			msg.DeletedAt = &deletedAt.Time
+		}
+		messages = append(messages, msg)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+func (repo *ChatRepositoryImpl) DeleteMessages(messageIDs []string) (int64, error) {
+	if len(messageIDs) == 0 {
+		return 0, nil
+	}
+	// 배열 파라미터는 SQLite 에서 사용할 수 없으므로 ID 마다 자리표시자를 만듦
+	placeholders := make([]string, len(messageIDs))
+	args := make([]interface{}, len(messageIDs))
+	for i, messageID := range messageIDs {
+		placeholders[i] = fmt.Sprintf("$%d::uuid", i+1)
+		args[i] = messageID
+	}
+	query := `DELETE FROM message WHERE message_id IN (` + strings.Join(placeholders, ", ") + `)`
+	result, err := repo.DB.Exec(query, args...)
+	if err != nil {
+		log.Printf("Repository layer: Error deleting messages: %v", err)
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// RestoreMessages 는 아카이브된 메시지를 삭제 여부(deleted_at)와 함께 다시 저장합니다. 이미 존재하는 message_id 는 건너뜁니다.
+// 복원한 메시지는 restored_at 을 기록해 보관 기간 정책이 다시 아카이브하지 않도록 합니다.
+func (repo *ChatRepositoryImpl) RestoreMessages(messages []model.ChatMessage) (int, error) {
+	tx, err := repo.DB.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`
+    INSERT INTO message (message_id, user_id, fit_group_id, message, message_time, message_type, deleted_at, restored_at, created_at, created_by, updated_at, updated_by)
+    VALUES ($1::uuid, $2, $3, $4, $5, $6, $7, NOW(), NOW(), 'archive-restore', NOW(), 'archive-restore')
+    ON CONFLICT (message_id) DO NOTHING
+    `)
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+
+	restored := 0
+	for _, msg := range messages {
+		result, err := stmt.Exec(msg.ID, msg.UserID, msg.FitGroupID, msg.Message, msg.MessageTime, msg.MessageType, msg.DeletedAt)
+		if err != nil {
+			log.Printf("Repository layer: Error restoring message %s: %v", msg.ID, err)
+			return 0, err
+		}
+		n, err := result.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		restored += int(n)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return restored, nil
+}
+
+func (repo *ChatRepositoryImpl) GetMessageByID(messageID string) (*model.ChatMessage, error) {
+	query := `
+    SELECT message_id, user_id, fit_group_id, message, message_time, message_type, deleted_at
+    FROM message
+    WHERE message_id = $1::uuid
+    `
+	var msg model.ChatMessage
+	var deletedAt sql.NullTime
+	err := repo.DB.QueryRow(query, messageID).Scan(&msg.ID, &msg.UserID, &msg.FitGroupID, &msg.Message, &msg.MessageTime, &msg.MessageType, &deletedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Printf("Repository layer: No message found for ID: %s", messageID)
+			return nil, fmt.Errorf("no message found for ID: %s", messageID)
+		}
+		log.Printf("Repository layer: Error querying message by ID: %v", err)
+		return nil, err
+	}
+	if deletedAt.Valid {
+		msg.DeletedAt = &deletedAt.Time
+	}
+	return &msg, nil
+}
+
+// SoftDeleteMessage 는 메시지를 실제로 지우지 않고 deleted_at 을 기록합니다.
+func (repo *ChatRepositoryImpl) SoftDeleteMessage(messageID string, deletedBy string) error {
+	query := `
+    UPDATE message SET deleted_at = NOW(), deleted_by = $2, updated_at = NOW(), updated_by = $2
+    WHERE message_id = $1::uuid AND deleted_at IS NULL
+    `
+	result, err := repo.DB.Exec(query, messageID, deletedBy)
+	if err != nil {
+		log.Printf("Repository layer: Error deleting message %s: %v", messageID, err)
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("no message found for ID: %s", messageID)
+	}
+	return nil
+}
+
+// StreamMessageHistory 는 fit group 의 전체 채팅 내역을 오래된 순으로 조회하며 한 건씩 fn 에 전달합니다.
+// 전체 결과를 메모리에 올리지 않으므로 내보내기처럼 큰 조회에 사용합니다.
+func (repo *ChatRepositoryImpl) StreamMessageHistory(fitGroupID int, includeDeleted bool, fn func(model.ChatHistoryEntry) error) error {
+	query := `
+    SELECT m.message_id, m.user_id, m.fit_group_id, m.message, m.message_time, m.message_type, m.deleted_at, COALESCE(u.nickname, '')
+    FROM message m
+    LEFT JOIN "user" u ON u.id = m.user_id
+    WHERE m.fit_group_id = $1 AND ($2 OR m.deleted_at IS NULL)
+    ORDER BY m.message_time ASC
+    `
+	rows, err := repo.DB.Query(query, fitGroupID, includeDeleted)
+	if err != nil {
+		log.Printf("Repository layer: Error streaming message history: %v", err)
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var entry model.ChatHistoryEntry
+		var deletedAt sql.NullTime
+		if err := rows.Scan(&entry.ID, &entry.UserID, &entry.FitGroupID, &entry.Message, &entry.MessageTime, &entry.MessageType, &deletedAt, &entry.SenderNickname); err != nil {
+			return err
+		}
+		if deletedAt.Valid {
+			entry.DeletedAt = &deletedAt.Time
+		}
+		if err := fn(entry); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// CountCertifications 는 기간 동안 fit mate 별 운동 인증(TICKET) 메시지 수를 조회합니다. 인증하지 않은 fit mate 도 0 으로 포함합니다.
+// message_time 은 클라이언트 시간이므로 서버에서 기록한 created_at 으로 기간을 비교합니다.
+func (repo *ChatRepositoryImpl) CountCertifications(fitGroupID int, start, end time.Time) ([]model.CertificationProgress, error) {
+	query := `
+	SELECT fm.user_id, COALESCE(u.nickname, ''), COUNT(m.message_id)
+	FROM fit_mate fm
+	LEFT JOIN "user" u ON u.id = fm.user_id
+	LEFT JOIN message m ON m.user_id = fm.user_id AND m.fit_group_id = fm.fit_group_id
+		AND m.message_type = 'TICKET' AND m.deleted_at IS NULL
+		AND m.created_at >= $2 AND m.created_at < $3
+	WHERE fm.fit_group_id = $1 AND fm.state = false
+	GROUP BY fm.user_id, u.nickname
+	ORDER BY fm.user_id
+	`
+	rows, err := repo.DB.Query(query, fitGroupID, start, end)
+	if err != nil {
+		log.Printf("Repository layer: Error counting certifications: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var progress []model.CertificationProgress
+	for rows.Next() {
+		var p model.CertificationProgress
+		if err := rows.Scan(&p.UserID, &p.Nickname, &p.Count); err != nil {
+			return nil, err
+		}
+		progress = append(progress, p)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return progress, nil
+}
@@ -0,0 +1,91 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+	"workoutstudy_chatting/model"
+)
+
+// recordingConnector 는 실행한 쿼리와 인자만 기록하는 database/sql 드라이버입니다. DB 없이 repository 가 넘기는 인자를 확인합니다.
+type recordingConnector struct {
+	query string
+	args  []driver.Value
+}
+
+func (c *recordingConnector) Connect(context.Context) (driver.Conn, error) {
+	return recordingConn{c}, nil
+}
+func (c *recordingConnector) Driver() driver.Driver { return nil }
+
+type recordingConn struct{ c *recordingConnector }
+
+func (recordingConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (recordingConn) Close() error                        { return nil }
+func (recordingConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+func (conn recordingConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	conn.c.query = query
+	conn.c.args = nil
+	for _, arg := range args {
+		conn.c.args = append(conn.c.args, arg.Value)
+	}
+	return driver.RowsAffected(1), nil
+}
+
+var placeholder = regexp.MustCompile(`\$(\d+)`)
+
+// maxPlaceholder 는 쿼리에서 가장 큰 $n 의 n 입니다. Postgres 는 인자 수가 이와 다르면 쿼리를 거부합니다.
+func maxPlaceholder(query string) int {
+	max := 0
+	for _, m := range placeholder.FindAllStringSubmatch(query, -1) {
+		if n, _ := strconv.Atoi(m[1]); n > max {
+			max = n
+		}
+	}
+	return max
+}
+
+func TestSaveMessageArgs(t *testing.T) {
+	messageTime := time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		msg  model.ChatMessage
+		want []driver.Value // message_id, user_id, fit_group_id, message, message_time, message_type, created_by
+	}{
+		{
+			name: "user_id 와 fit_group_id 순서",
+			msg:  model.ChatMessage{ID: "m1", UserID: 7, FitGroupID: 3, Message: "hi", MessageTime: messageTime, MessageType: model.Chatting},
+			want: []driver.Value{"m1", int64(7), int64(3), "hi", messageTime, "CHATTING", "7"},
+		},
+		{
+			name: "시스템 메시지",
+			msg:  model.ChatMessage{ID: "m2", UserID: 0, FitGroupID: 12, Message: "투표 결과", MessageTime: messageTime, MessageType: model.System},
+			want: []driver.Value{"m2", int64(0), int64(12), "투표 결과", messageTime, "SYSTEM", "0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			connector := &recordingConnector{}
+			db := sql.OpenDB(connector)
+			defer db.Close()
+
+			if err := NewChatRepository(NewSQLDB(db, Postgres)).SaveMessage(tt.msg); err != nil {
+				t.Fatalf("SaveMessage: %v", err)
+			}
+			if n := maxPlaceholder(connector.query); n != len(connector.args) {
+				t.Fatalf("query uses %d placeholders but got %d args", n, len(connector.args))
+			}
+			if !reflect.DeepEqual(connector.args, tt.want) {
+				t.Fatalf("args = %#v, want %#v", connector.args, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package persistence
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"workoutstudy_chatting/model"
+)
+
+type ChatRestrictionRepository interface {
+	SaveRestriction(restriction *model.ChatRestriction) (*model.ChatRestriction, error)
+	GetActiveRestrictions(fitGroupID, userID int) ([]model.ChatRestriction, error)
+	GetActiveRestrictionsByFitGroup(fitGroupID int) ([]model.ChatRestriction, error)
+	RevokeRestrictions(fitGroupID, userID int, restrictionType model.RestrictionType, revokedBy string) (int64, error)
+}
+
+type ChatRestrictionRepositoryImpl struct {
+	DB *SQLDB
+}
+
+var _ ChatRestrictionRepository = (*ChatRestrictionRepositoryImpl)(nil)
+
+func NewChatRestrictionRepository(db *SQLDB) ChatRestrictionRepository {
+	return &ChatRestrictionRepositoryImpl{DB: db}
+}
+
+const chatRestrictionColumns = `id, fit_group_id, user_id, type, reason, expires_at, created_at, COALESCE(created_by, ''), revoked_at, COALESCE(revoked_by, '')`
+
+func (repo *ChatRestrictionRepositoryImpl) SaveRestriction(restriction *model.ChatRestriction) (*model.ChatRestriction, error) {
+	query := `
+	INSERT INTO chat_restriction (fit_group_id, user_id, type, reason, expires_at, created_at, created_by)
+	VALUES ($1, $2, $3, $4, $5, NOW(), $6)
+	RETURNING id, created_at
+	`
+	err := repo.DB.QueryRow(query, restriction.FitGroupID, restriction.UserID, restriction.Type, restriction.Reason, restriction.ExpiresAt, restriction.CreatedBy).
+		Scan(&restriction.ID, &restriction.CreatedAt)
+	if err != nil {
+		log.Printf("Repository layer: Error saving chat restriction: %v", err)
+		return nil, fmt.Errorf("error saving chat restriction: %w", err)
+	}
+	return restriction, nil
+}
+
+// GetActiveRestrictions 는 해제되지 않았고 만료되지 않은 제한만 조회합니다.
+func (repo *ChatRestrictionRepositoryImpl) GetActiveRestrictions(fitGroupID, userID int) ([]model.ChatRestriction, error) {
+	query := `SELECT ` + chatRestrictionColumns + `
+	FROM chat_restriction
+	WHERE fit_group_id = $1 AND user_id = $2 AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > NOW())
+	ORDER BY created_at DESC
+	`
+	return repo.queryRestrictions(query, fitGroupID, userID)
+}
+
+func (repo *ChatRestrictionRepositoryImpl) GetActiveRestrictionsByFitGroup(fitGroupID int) ([]model.ChatRestriction, error) {
+	query := `SELECT ` + chatRestrictionColumns + `
+	FROM chat_restriction
+	WHERE fit_group_id = $1 AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > NOW())
+	ORDER BY created_at DESC
+	`
+	return repo.queryRestrictions(query, fitGroupID)
+}
+
+func (repo *ChatRestrictionRepositoryImpl) RevokeRestrictions(fitGroupID, userID int, restrictionType model.RestrictionType, revokedBy string) (int64, error) {
+	query := `
+	UPDATE chat_restriction SET revoked_at = NOW(), revoked_by = $4
+	WHERE fit_group_id = $1 AND user_id = $2 AND type = $3 AND revoked_at IS NULL
+	`
+	result, err := repo.DB.Exec(query, fitGroupID, userID, restrictionType, revokedBy)
+	if err != nil {
+		log.Printf("Repository layer: Error revoking chat restriction: %v", err)
+		return 0, fmt.Errorf("error revoking chat restriction: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+func (repo *ChatRestrictionRepositoryImpl) queryRestrictions(query string, args ...interface{}) ([]model.ChatRestriction, error) {
+	rows, err := repo.DB.Query(query, args...)
+	if err != nil {
+		log.Printf("Repository layer: Error retrieving chat restrictions: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var restrictions []model.ChatRestriction
+	for rows.Next() {
+		var r model.ChatRestriction
+		var expiresAt, revokedAt sql.NullTime
+		if err := rows.Scan(&r.ID, &r.FitGroupID, &r.UserID, &r.Type, &r.Reason, &expiresAt, &r.CreatedAt, &r.CreatedBy, &revokedAt, &r.RevokedBy); err != nil {
+			return nil, err
+		}
+		if expiresAt.Valid {
+			r.ExpiresAt = &expiresAt.Time
+		}
+		if revokedAt.Valid {
+			r.RevokedAt = &revokedAt.Time
+		}
+		restrictions = append(restrictions, r)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return restrictions, nil
+}
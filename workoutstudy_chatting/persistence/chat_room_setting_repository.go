@@ -0,0 +1,74 @@
+package persistence
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"workoutstudy_chatting/model"
+)
+
+type ChatRoomSettingRepository interface {
+	GetChatRoomSetting(fitGroupID int) (*model.ChatRoomSetting, error)
+	SaveSlowMode(fitGroupID, slowModeSeconds int, updatedBy string) (*model.ChatRoomSetting, error)
+	SaveTimeZone(fitGroupID int, timeZone string, updatedBy string) (*model.ChatRoomSetting, error)
+}
+
+type ChatRoomSettingRepositoryImpl struct {
+	DB *SQLDB
+}
+
+var _ ChatRoomSettingRepository = (*ChatRoomSettingRepositoryImpl)(nil)
+
+func NewChatRoomSettingRepository(db *SQLDB) ChatRoomSettingRepository {
+	return &ChatRoomSettingRepositoryImpl{DB: db}
+}
+
+// GetChatRoomSetting 은 설정이 없으면 기본값(슬로우 모드 해제, 기본 시간대)을 반환합니다.
+func (repo *ChatRoomSettingRepositoryImpl) GetChatRoomSetting(fitGroupID int) (*model.ChatRoomSetting, error) {
+	query := `SELECT fit_group_id, slow_mode_seconds, time_zone, updated_at, COALESCE(updated_by, '') FROM chat_room_setting WHERE fit_group_id = $1`
+
+	setting := model.ChatRoomSetting{FitGroupID: fitGroupID, TimeZone: model.DefaultTimeZone}
+	err := repo.DB.QueryRow(query, fitGroupID).Scan(&setting.FitGroupID, &setting.SlowModeSeconds, &setting.TimeZone, &setting.UpdatedAt, &setting.UpdatedBy)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return &setting, nil
+		}
+		log.Printf("Repository layer: Error querying chat room setting for fitGroupID %d: %v", fitGroupID, err)
+		return nil, err
+	}
+	return &setting, nil
+}
+
+func (repo *ChatRoomSettingRepositoryImpl) SaveSlowMode(fitGroupID, slowModeSeconds int, updatedBy string) (*model.ChatRoomSetting, error) {
+	query := `
+	INSERT INTO chat_room_setting (fit_group_id, slow_mode_seconds, created_at, created_by, updated_at, updated_by)
+	VALUES ($1, $2, NOW(), $3, NOW(), $3)
+	ON CONFLICT (fit_group_id) DO UPDATE
+	SET slow_mode_seconds = EXCLUDED.slow_mode_seconds, updated_at = NOW(), updated_by = EXCLUDED.updated_by
+	RETURNING fit_group_id, slow_mode_seconds, time_zone, updated_at, updated_by
+	`
+	var setting model.ChatRoomSetting
+	err := repo.DB.QueryRow(query, fitGroupID, slowModeSeconds, updatedBy).Scan(&setting.FitGroupID, &setting.SlowModeSeconds, &setting.TimeZone, &setting.UpdatedAt, &setting.UpdatedBy)
+	if err != nil {
+		log.Printf("Repository layer: Error saving slow mode: %v", err)
+		return nil, fmt.Errorf("error saving slow mode: %w", err)
+	}
+	return &setting, nil
+}
+
+func (repo *ChatRoomSettingRepositoryImpl) SaveTimeZone(fitGroupID int, timeZone string, updatedBy string) (*model.ChatRoomSetting, error) {
+	query := `
+	INSERT INTO chat_room_setting (fit_group_id, time_zone, created_at, created_by, updated_at, updated_by)
+	VALUES ($1, $2, NOW(), $3, NOW(), $3)
+	ON CONFLICT (fit_group_id) DO UPDATE
+	SET time_zone = EXCLUDED.time_zone, updated_at = NOW(), updated_by = EXCLUDED.updated_by
+	RETURNING fit_group_id, slow_mode_seconds, time_zone, updated_at, updated_by
+	`
+	var setting model.ChatRoomSetting
+	err := repo.DB.QueryRow(query, fitGroupID, timeZone, updatedBy).Scan(&setting.FitGroupID, &setting.SlowModeSeconds, &setting.TimeZone, &setting.UpdatedAt, &setting.UpdatedBy)
+	if err != nil {
+		log.Printf("Repository layer: Error saving time zone: %v", err)
+		return nil, fmt.Errorf("error saving time zone: %w", err)
+	}
+	return &setting, nil
+}
@@ -1,96 +1,77 @@
 package persistence
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
+	"workoutstudy_chatting/config"
 
 	_ "github.com/lib/pq"
 )
 
-const (
-	host     = "postgresql-chatting"
-	port     = 5432
-	user     = "chatting"
-	password = "chatting"
-	dbname   = "chatting-db"
-)
-
-var DB *sql.DB
-
-func InitializeDB() *sql.DB {
-	connStr := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=disable", host, port, user, password, dbname)
+var DB *SQLDB
+
+// OpenDB 는 database.driver 에 맞는 연결 풀을 설정하고 연결을 확인합니다. 스키마는 변경하지 않습니다.
+func OpenDB(cfg config.DatabaseConfig) (*SQLDB, error) {
+	var driverName, dsn string
+	var dialect Dialect
+	switch cfg.Driver {
+	case config.DriverPostgres:
+		driverName, dsn, dialect = "postgres", cfg.DSN(), Postgres
+	case config.DriverSQLite:
+		driverName, dsn, dialect = "sqlite", sqliteDSN(cfg.Path), SQLite
+	default:
+		return nil, fmt.Errorf("database driver %q does not use a DB connection", cfg.Driver)
+	}
 
-	var err error
-	DB, err = sql.Open("postgres", connStr)
+	db, err := sql.Open(driverName, dsn)
 	if err != nil {
-		log.Fatalf("Failed to open a DB connection: %v", err)
+		return nil, fmt.Errorf("open DB connection: %w", err)
 	}
 
 	// 데이터베이스 연결 풀 설정
-	DB.SetMaxOpenConns(25)
-	DB.SetMaxIdleConns(10)
-	DB.SetConnMaxLifetime(0)
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(0)
 
 	// 데이터베이스 연결 테스트
-	if err := DB.Ping(); err != nil {
-		log.Fatalf("Failed to ping database: %v", err)
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("ping database: %w", err)
 	}
+	return NewSQLDB(db, dialect), nil
+}
 
-	fmt.Println("Database connection pool initialized successfully")
-
-	createTables := []string{
-		`CREATE TABLE IF NOT EXISTS "user" (
-			id INTEGER PRIMARY KEY,
-			nickname VARCHAR(10) NOT NULL,
-			state BOOLEAN DEFAULT false NOT NULL,
-			created_at TIMESTAMP(6) WITH TIME ZONE NOT NULL,
-			updated_at TIMESTAMP(6) WITH TIME ZONE NOT NULL
-		)`,
-		`CREATE TABLE IF NOT EXISTS fit_group (
-			id INTEGER PRIMARY KEY,
-			fit_leader_user_id INTEGER REFERENCES "user"(id) NOT NULL,
-			fit_group_name VARCHAR(30),
-			category INTEGER NOT NULL,
-			cycle INTEGER NOT NULL,
-			frequency INTEGER NOT NULL,
-			present_fit_mate_count INTEGER NOT NULL,
-			max_fit_mate INTEGER NOT NULL,
-			state BOOLEAN DEFAULT false NOT NULL,
-			created_at TIMESTAMP(6) WITH TIME ZONE NOT NULL,
-			created_by VARCHAR(30),
-			updated_at TIMESTAMP(6) WITH TIME ZONE NOT NULL,
-			updated_by VARCHAR(30)
-		)`,
-		`CREATE TABLE IF NOT EXISTS fit_mate (
-			id INTEGER PRIMARY KEY,
-			user_id INTEGER REFERENCES "user"(id) NOT NULL,
-			fit_group_id INTEGER REFERENCES fit_group(id) NOT NULL,
-			state BOOLEAN DEFAULT false NOT NULL,
-			created_at TIMESTAMP(6) WITH TIME ZONE NOT NULL,
-			created_by VARCHAR(30),
-			updated_at TIMESTAMP(6) WITH TIME ZONE NOT NULL,
-			updated_by VARCHAR(30)
-		)`,
-		`CREATE TABLE IF NOT EXISTS message (
-			message_id UUID PRIMARY KEY,
-			user_id INTEGER REFERENCES "user"(id) NOT NULL,
-			fit_group_id INTEGER REFERENCES fit_group(id) NOT NULL,
-			message TEXT NOT NULL,
-			message_time TIMESTAMP(6),
-			message_type VARCHAR(8) CHECK (message_type IN ('CHATTING', 'TICKET')),
-			created_at TIMESTAMP(6) WITH TIME ZONE NOT NULL,
-			created_by VARCHAR(30),
-			updated_at TIMESTAMP(6) WITH TIME ZONE NOT NULL,
-			updated_by VARCHAR(30)
-		)`,
+// InitializeDB 는 DB 에 연결하고, cfg.AutoMigrate 이면 적용되지 않은 마이그레이션을 적용합니다.
+// 여러 레플리카가 동시에 시작해도 Postgres 마이그레이션은 advisory lock 으로 한 번만 실행됩니다.
+func InitializeDB(cfg config.DatabaseConfig) *SQLDB {
+	var err error
+	DB, err = OpenDB(cfg)
+	if err != nil {
+		log.Fatalf("Failed to connect database: %v", err)
 	}
 
-	for _, query := range createTables {
-		if _, err := DB.Exec(query); err != nil {
-			log.Fatalf("Failed to execute query: %v, error: %v", query, err)
-		}
+	if cfg.Driver == config.DriverSQLite {
+		fmt.Printf("Database connection pool initialized successfully (sqlite %s)\n", cfg.Path)
+	} else {
+		fmt.Printf("Database connection pool initialized successfully (%s:%d/%s)\n", cfg.Host, cfg.Port, cfg.Name)
+	}
+
+	if !cfg.AutoMigrate {
+		log.Println("Skipping schema migrations (autoMigrate disabled)")
+		return DB
+	}
+
+	migrator, err := NewMigrator(DB)
+	if err != nil {
+		log.Fatalf("Failed to load migrations: %v", err)
+	}
+	applied, err := migrator.Up(context.Background())
+	if err != nil {
+		log.Fatalf("Failed to migrate database: %v", err)
 	}
+	log.Printf("Applied %d migrations", len(applied))
 
 	fmt.Println("Database initialized successfully")
 
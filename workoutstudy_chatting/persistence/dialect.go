@@ -0,0 +1,163 @@
+package persistence
+
+import (
+	"database/sql"
+	"regexp"
+	"sync"
+	"time"
+)
+
+/*
+Dialect 는 repository 의 SQL 을 저장소별 문법으로 바꿉니다.
+repository 는 Postgres 문법으로 한 벌의 쿼리만 작성하고, SQLite 에서 다르게 동작하는 부분만 Rebind/Arg 로 변환합니다.
+- $n 자리표시자, RETURNING, EXISTS, ON CONFLICT 는 SQLite 3.35 이상에서 그대로 동작합니다.
+- $n::uuid 는 SQLite 에 등록한 uuid() 함수로 바꿉니다. Postgres 와 같은 형식으로 정규화하고 잘못된 값은 거부합니다.
+- ::text 캐스트와 FOR UPDATE 는 제거합니다. SQLite 트랜잭션은 BEGIN IMMEDIATE 로 시작하므로 쓰기 잠금을 먼저 잡습니다.
+- 시간 인자는 문자열 비교로 정렬되도록 고정 길이 UTC 문자열로 저장합니다.
+LATERAL, 배열 파라미터, '-infinity' 처럼 변환할 수 없는 문법은 쿼리에서 사용하지 않습니다.
+*/
+type Dialect interface {
+	Name() string
+	Rebind(query string) string
+	Arg(v interface{}) interface{}
+}
+
+// 저장소 종류 (config.Driver* 와 같은 값, 마이그레이션 디렉터리 이름)
+const (
+	DialectPostgres = "postgres"
+	DialectSQLite   = "sqlite"
+)
+
+type postgresDialect struct{}
+
+func (postgresDialect) Name() string                  { return DialectPostgres }
+func (postgresDialect) Rebind(query string) string    { return query }
+func (postgresDialect) Arg(v interface{}) interface{} { return v }
+
+// Postgres 는 repository 쿼리를 그대로 실행합니다.
+var Postgres Dialect = postgresDialect{}
+
+// sqliteTimeFormat 은 SQLite 에 저장하는 시간 형식입니다. TIMESTAMP 컬럼은 조회 시 UTC time.Time 으로 읽힙니다.
+const sqliteTimeFormat = "2006-01-02 15:04:05.000000"
+
+var (
+	sqliteUUIDCast = regexp.MustCompile(`\$(\d+)::uuid\b`)
+	sqliteTextCast = regexp.MustCompile(`::text\b`)
+	sqliteForLock  = regexp.MustCompile(`\s+FOR UPDATE\b`)
+)
+
+type sqliteDialect struct {
+	queries sync.Map // 원본 쿼리 -> 변환한 쿼리
+}
+
+func (*sqliteDialect) Name() string { return DialectSQLite }
+
+func (d *sqliteDialect) Rebind(query string) string {
+	if rebound, ok := d.queries.Load(query); ok {
+		return rebound.(string)
+	}
+	rebound := sqliteUUIDCast.ReplaceAllString(query, "uuid($$$1)")
+	rebound = sqliteTextCast.ReplaceAllString(rebound, "")
+	rebound = sqliteForLock.ReplaceAllString(rebound, "")
+	d.queries.Store(query, rebound)
+	return rebound
+}
+
+func (*sqliteDialect) Arg(v interface{}) interface{} {
+	switch t := v.(type) {
+	case time.Time:
+		return formatSQLiteTime(t)
+	case *time.Time:
+		if t == nil {
+			return nil
+		}
+		return formatSQLiteTime(*t)
+	}
+	return v
+}
+
+// formatSQLiteTime 은 Postgres TIMESTAMP(6) 와 같이 마이크로초 단위로 반올림합니다.
+func formatSQLiteTime(t time.Time) string {
+	return t.UTC().Round(time.Microsecond).Format(sqliteTimeFormat)
+}
+
+// SQLite 는 repository 쿼리를 SQLite 문법으로 바꿔 실행합니다.
+var SQLite Dialect = &sqliteDialect{}
+
+// SQLDB 는 쿼리와 인자를 Dialect 에 맞게 바꿔 실행하는 *sql.DB 입니다.
+// Query, QueryRow, Exec, Begin 만 변환하므로 repository 는 이 메서드만 사용합니다.
+type SQLDB struct {
+	*sql.DB
+	Dialect Dialect
+}
+
+func NewSQLDB(db *sql.DB, dialect Dialect) *SQLDB {
+	return &SQLDB{DB: db, Dialect: dialect}
+}
+
+func (db *SQLDB) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return db.DB.Query(db.Dialect.Rebind(query), bindArgs(db.Dialect, args)...)
+}
+
+func (db *SQLDB) QueryRow(query string, args ...interface{}) *sql.Row {
+	return db.DB.QueryRow(db.Dialect.Rebind(query), bindArgs(db.Dialect, args)...)
+}
+
+func (db *SQLDB) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return db.DB.Exec(db.Dialect.Rebind(query), bindArgs(db.Dialect, args)...)
+}
+
+func (db *SQLDB) Begin() (*SQLTx, error) {
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	return &SQLTx{Tx: tx, dialect: db.Dialect}, nil
+}
+
+// SQLTx 는 SQLDB.Begin 으로 시작한 트랜잭션입니다.
+type SQLTx struct {
+	*sql.Tx
+	dialect Dialect
+}
+
+func (tx *SQLTx) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return tx.Tx.Query(tx.dialect.Rebind(query), bindArgs(tx.dialect, args)...)
+}
+
+func (tx *SQLTx) QueryRow(query string, args ...interface{}) *sql.Row {
+	return tx.Tx.QueryRow(tx.dialect.Rebind(query), bindArgs(tx.dialect, args)...)
+}
+
+func (tx *SQLTx) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return tx.Tx.Exec(tx.dialect.Rebind(query), bindArgs(tx.dialect, args)...)
+}
+
+func (tx *SQLTx) Prepare(query string) (*SQLStmt, error) {
+	stmt, err := tx.Tx.Prepare(tx.dialect.Rebind(query))
+	if err != nil {
+		return nil, err
+	}
+	return &SQLStmt{Stmt: stmt, dialect: tx.dialect}, nil
+}
+
+// SQLStmt 는 SQLTx.Prepare 로 준비한 문장입니다. 실행할 때 인자만 변환합니다.
+type SQLStmt struct {
+	*sql.Stmt
+	dialect Dialect
+}
+
+func (stmt *SQLStmt) Exec(args ...interface{}) (sql.Result, error) {
+	return stmt.Stmt.Exec(bindArgs(stmt.dialect, args)...)
+}
+
+func bindArgs(dialect Dialect, args []interface{}) []interface{} {
+	if len(args) == 0 {
+		return args
+	}
+	bound := make([]interface{}, len(args))
+	for i, arg := range args {
+		bound[i] = dialect.Arg(arg)
+	}
+	return bound
+}
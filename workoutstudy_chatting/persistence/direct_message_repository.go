@@ -0,0 +1,232 @@
+package persistence
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+	"workoutstudy_chatting/model"
+)
+
+type DirectMessageRepository interface {
+	GetOrCreateConversation(userID, peerUserID int) (*model.DirectConversation, error)
+	GetConversationByID(conversationID int) (*model.DirectConversation, error)
+	GetConversationSummaries(userID int) ([]model.DirectConversationSummary, error)
+	SaveDirectMessage(msg model.DirectMessage) error
+	RetrieveDirectMessages(conversationID int, before time.Time, limit int) ([]model.DirectMessage, error)
+	MarkRead(conversationID, userID int) error
+	CountUnread(conversationID, userID int) (int, error)
+}
+
+type DirectMessageRepositoryImpl struct {
+	DB *SQLDB
+}
+
+var _ DirectMessageRepository = (*DirectMessageRepositoryImpl)(nil)
+
+func NewDirectMessageRepository(db *SQLDB) DirectMessageRepository {
+	return &DirectMessageRepositoryImpl{DB: db}
+}
+
+// GetOrCreateConversation 은 두 사용자 사이의 대화방을 조회하고, 없으면 생성합니다.
+func (repo *DirectMessageRepositoryImpl) GetOrCreateConversation(userID, peerUserID int) (*model.DirectConversation, error) {
+	userA, userB := userID, peerUserID
+	if userA > userB {
+		userA, userB = userB, userA
+	}
+	// DO UPDATE 로 이미 있는 대화방도 RETURNING 에서 조회되도록 함
+	query := `
+	INSERT INTO direct_conversation (user_a_id, user_b_id, created_at)
+	VALUES ($1, $2, NOW())
+	ON CONFLICT (user_a_id, user_b_id) DO UPDATE SET user_a_id = EXCLUDED.user_a_id
+	RETURNING id, user_a_id, user_b_id, created_at, last_message_at
+	`
+	conversation, err := scanDirectConversation(repo.DB.QueryRow(query, userA, userB))
+	if err != nil {
+		log.Printf("Repository layer: Error creating direct conversation: %v", err)
+		return nil, fmt.Errorf("error creating direct conversation: %w", err)
+	}
+	return conversation, nil
+}
+
+func (repo *DirectMessageRepositoryImpl) GetConversationByID(conversationID int) (*model.DirectConversation, error) {
+	query := `
+	SELECT id, user_a_id, user_b_id, created_at, last_message_at
+	FROM direct_conversation
+	WHERE id = $1
+	`
+	conversation, err := scanDirectConversation(repo.DB.QueryRow(query, conversationID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Printf("Repository layer: No direct conversation found for ID: %d", conversationID)
+			return nil, fmt.Errorf("no direct conversation found for ID: %d", conversationID)
+		}
+		log.Printf("Repository layer: Error querying direct conversation: %v", err)
+		return nil, err
+	}
+	return conversation, nil
+}
+
+// GetConversationSummaries 는 사용자의 대화방을 최근 메시지 순으로 조회합니다.
+// 읽지 않은 메시지 수는 direct_read_state 의 마지막으로 읽은 시간 이후 상대방이 보낸 메시지 수입니다.
+// message_time 은 클라이언트 시간이므로 서버에서 기록한 created_at 으로 비교합니다.
+func (repo *DirectMessageRepositoryImpl) GetConversationSummaries(userID int) ([]model.DirectConversationSummary, error) {
+	query := `
+	SELECT c.id, c.user_a_id, c.user_b_id, c.created_at, c.last_message_at,
+		COALESCE(u.nickname, ''),
+		lm.message_id, lm.sender_user_id, lm.message, lm.message_time,
+		(SELECT COUNT(*) FROM direct_message dm
+			WHERE dm.conversation_id = c.id AND dm.sender_user_id <> $1 AND dm.deleted_at IS NULL
+			AND (rs.last_read_at IS NULL OR dm.created_at > rs.last_read_at))
+	FROM direct_conversation c
+	LEFT JOIN "user" u ON u.id = CASE WHEN c.user_a_id = $1 THEN c.user_b_id ELSE c.user_a_id END
+	LEFT JOIN direct_read_state rs ON rs.conversation_id = c.id AND rs.user_id = $1
+	LEFT JOIN direct_message lm ON lm.message_id = (
+		SELECT message_id
+		FROM direct_message
+		WHERE conversation_id = c.id AND deleted_at IS NULL
+		ORDER BY message_time DESC
+		LIMIT 1
+	)
+	WHERE c.user_a_id = $1 OR c.user_b_id = $1
+	ORDER BY c.last_message_at DESC NULLS LAST, c.id DESC
+	`
+	rows, err := repo.DB.Query(query, userID)
+	if err != nil {
+		log.Printf("Repository layer: Error retrieving direct conversations: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var summaries []model.DirectConversationSummary
+	for rows.Next() {
+		var s model.DirectConversationSummary
+		var lastMessageAt sql.NullTime
+		var lastMessageID, lastMessage sql.NullString
+		var lastSender sql.NullInt64
+		var lastMessageTime sql.NullTime
+		if err := rows.Scan(&s.ID, &s.UserAID, &s.UserBID, &s.CreatedAt, &lastMessageAt, &s.PeerNickname,
+			&lastMessageID, &lastSender, &lastMessage, &lastMessageTime, &s.UnreadCount); err != nil {
+			return nil, err
+		}
+		if lastMessageAt.Valid {
+			s.LastMessageAt = &lastMessageAt.Time
+		}
+		s.PeerUserID = s.PeerOf(userID)
+		if lastMessageID.Valid {
+			recipient := s.PeerUserID
+			if int(lastSender.Int64) == s.PeerUserID {
+				recipient = userID
+			}
+			s.LastMessage = &model.DirectMessage{
+				ID:              lastMessageID.String,
+				ConversationID:  s.ID,
+				SenderUserID:    int(lastSender.Int64),
+				RecipientUserID: recipient,
+				Message:         lastMessage.String,
+				MessageTime:     lastMessageTime.Time,
+			}
+		}
+		summaries = append(summaries, s)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return summaries, nil
+}
+
+// SaveDirectMessage 는 메시지를 저장하고 대화방의 마지막 메시지 시간을 갱신합니다.
+func (repo *DirectMessageRepositoryImpl) SaveDirectMessage(msg model.DirectMessage) error {
+	tx, err := repo.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	insert := `
+	INSERT INTO direct_message (message_id, conversation_id, sender_user_id, message, message_time, created_at)
+	VALUES ($1::uuid, $2, $3, $4, $5, NOW())
+	`
+	if _, err := tx.Exec(insert, msg.ID, msg.ConversationID, msg.SenderUserID, msg.Message, msg.MessageTime); err != nil {
+		log.Printf("Repository layer: Error saving direct message: %v", err)
+		return fmt.Errorf("error saving direct message: %w", err)
+	}
+	update := `
+	UPDATE direct_conversation SET last_message_at = NOW()
+	WHERE id = $1
+	`
+	if _, err := tx.Exec(update, msg.ConversationID); err != nil {
+		log.Printf("Repository layer: Error updating direct conversation: %v", err)
+		return fmt.Errorf("error updating direct conversation: %w", err)
+	}
+	return tx.Commit()
+}
+
+// RetrieveDirectMessages 는 before 이전 메시지를 최신순으로 최대 limit 개 조회합니다.
+func (repo *DirectMessageRepositoryImpl) RetrieveDirectMessages(conversationID int, before time.Time, limit int) ([]model.DirectMessage, error) {
+	query := `
+	SELECT message_id, conversation_id, sender_user_id, message, message_time
+	FROM direct_message
+	WHERE conversation_id = $1 AND message_time < $2 AND deleted_at IS NULL
+	ORDER BY message_time DESC
+	LIMIT $3
+	`
+	rows, err := repo.DB.Query(query, conversationID, before, limit)
+	if err != nil {
+		log.Printf("Repository layer: Error retrieving direct messages: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []model.DirectMessage
+	for rows.Next() {
+		var msg model.DirectMessage
+		if err := rows.Scan(&msg.ID, &msg.ConversationID, &msg.SenderUserID, &msg.Message, &msg.MessageTime); err != nil {
+			return nil, err
+		}
+		messages = append(messages, msg)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// MarkRead 는 지금까지 받은 메시지를 모두 읽은 것으로 기록합니다.
+func (repo *DirectMessageRepositoryImpl) MarkRead(conversationID, userID int) error {
+	query := `
+	INSERT INTO direct_read_state (conversation_id, user_id, last_read_at)
+	VALUES ($1, $2, NOW())
+	ON CONFLICT (conversation_id, user_id) DO UPDATE SET last_read_at = NOW()
+	`
+	if _, err := repo.DB.Exec(query, conversationID, userID); err != nil {
+		log.Printf("Repository layer: Error marking direct conversation read: %v", err)
+		return fmt.Errorf("error marking direct conversation read: %w", err)
+	}
+	return nil
+}
+
+func (repo *DirectMessageRepositoryImpl) CountUnread(conversationID, userID int) (int, error) {
+	query := `
+	SELECT COUNT(*)
+	FROM direct_message dm
+	LEFT JOIN direct_read_state rs ON rs.conversation_id = dm.conversation_id AND rs.user_id = $2
+	WHERE dm.conversation_id = $1 AND dm.sender_user_id <> $2 AND dm.deleted_at IS NULL
+		AND (rs.last_read_at IS NULL OR dm.created_at > rs.last_read_at)
+	`
+	var count int
+	err := repo.DB.QueryRow(query, conversationID, userID).Scan(&count)
+	return count, err
+}
+
+func scanDirectConversation(row rowScanner) (*model.DirectConversation, error) {
+	var c model.DirectConversation
+	var lastMessageAt sql.NullTime
+	if err := row.Scan(&c.ID, &c.UserAID, &c.UserBID, &c.CreatedAt, &lastMessageAt); err != nil {
+		return nil, err
+	}
+	if lastMessageAt.Valid {
+		c.LastMessageAt = &lastMessageAt.Time
+	}
+	return &c, nil
+}
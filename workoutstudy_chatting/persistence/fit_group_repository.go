@@ -9,6 +9,7 @@ import (
 
 type FitGroupRepository interface {
 	GetFitGroupByID(id int) (*model.FitGroup, error)
+	GetFitGroupIDs() ([]int, error)
 	GetFitMatesByFitGroupId(id int) ([]int, error)
 	SaveFitGroup(fitGroup *model.FitGroup) (*model.FitGroup, error)
 	DeleteFitGroup(fitGroupID int) error
@@ -16,26 +17,26 @@ type FitGroupRepository interface {
 }
 
 type FitGroupRepositoryImpl struct {
-	DB *sql.DB
+	DB *SQLDB
 }
 
 // 훈기 tip : 인터페이스 메소드 슬라이스 중 구현안되거 있으면 에러 띄워줌
 var _ FitGroupRepository = &FitGroupRepositoryImpl{}
 
-func NewFitGroupRepository(db *sql.DB) FitGroupRepository {
+func NewFitGroupRepository(db *SQLDB) FitGroupRepository {
 	return &FitGroupRepositoryImpl{DB: db}
 }
 
 func (repo *FitGroupRepositoryImpl) GetFitGroupByID(id int) (*model.FitGroup, error) {
-	query := `SELECT id, fit_group_name, max_fit_mate, created_at, created_by, updated_at, updated_by FROM fit_group WHERE id = $1`
+	query := `SELECT id, fit_leader_user_id, fit_group_name, category, cycle, frequency, present_fit_mate_count, max_fit_mate, state, created_at, created_by, updated_at, updated_by FROM fit_group WHERE id = $1`
 
 	log.Printf("Repository layer: Executing query for FitGroupID: %d", id)
 	fitGroup := model.FitGroup{}
-	err := repo.DB.QueryRow(query, id).Scan(&fitGroup.ID, &fitGroup.FitGroupName, &fitGroup.MaxFitMate, &fitGroup.CreatedAt, &fitGroup.CreatedBy, &fitGroup.UpdatedAt, &fitGroup.UpdatedBy)
+	err := repo.DB.QueryRow(query, id).Scan(&fitGroup.ID, &fitGroup.FitLeaderUserID, &fitGroup.FitGroupName, &fitGroup.Category, &fitGroup.Cycle, &fitGroup.Frequency, &fitGroup.PresentFitMateCount, &fitGroup.MaxFitMate, &fitGroup.State, &fitGroup.CreatedAt, &fitGroup.CreatedBy, &fitGroup.UpdatedAt, &fitGroup.UpdatedBy)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			log.Printf("Repository layer: No fit_group found for ID: %v", id)
-			return nil, fmt.Errorf("no fit_group found for ID: %d", id)
+			return nil, fmt.Errorf("no fit_group found for ID: %d: %w", id, sql.ErrNoRows)
 		}
 		log.Printf("Repository layer: Error querying fit_group by ID: %v", err)
 		return nil, err
@@ -44,6 +45,29 @@ func (repo *FitGroupRepositoryImpl) GetFitGroupByID(id int) (*model.FitGroup, er
 	return &fitGroup, nil
 }
 
+// GetFitGroupIDs 는 DB 에 있는 모든 fit group ID 를 오름차순으로 조회합니다.
+func (repo *FitGroupRepositoryImpl) GetFitGroupIDs() ([]int, error) {
+	rows, err := repo.DB.Query(`SELECT id FROM fit_group ORDER BY id`)
+	if err != nil {
+		log.Printf("Repository layer: Error retrieving fit group IDs: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var fitGroupIDs []int
+	for rows.Next() {
+		var fitGroupID int
+		if err := rows.Scan(&fitGroupID); err != nil {
+			return nil, err
+		}
+		fitGroupIDs = append(fitGroupIDs, fitGroupID)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return fitGroupIDs, nil
+}
+
 func (repo *FitGroupRepositoryImpl) GetFitMatesByFitGroupId(id int) ([]int, error) {
 	// SQL 쿼리 정의
 	query := `
@@ -81,19 +105,24 @@ func (repo *FitGroupRepositoryImpl) GetFitMatesByFitGroupId(id int) ([]int, erro
 }
 
 func (repo *FitGroupRepositoryImpl) SaveFitGroup(fitGroup *model.FitGroup) (*model.FitGroup, error) {
+	// id 는 fit-group-service 의 fit group ID 를 그대로 사용. 이미 있으면 생성 정보는 두고 나머지를 덮어씀
 	query := `
-		INSERT INTO fit_group (fit_group_name, max_fit_mate, created_at, created_by, updated_at, updated_by)
-		VALUES ($1, $2, $3, $4, $5, $6)
-		RETURNING id
+		INSERT INTO fit_group (id, fit_leader_user_id, fit_group_name, category, cycle, frequency, present_fit_mate_count, max_fit_mate, state, created_at, created_by, updated_at, updated_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW(), $10, NOW(), $10)
+		ON CONFLICT (id) DO UPDATE SET
+			fit_leader_user_id = EXCLUDED.fit_leader_user_id, fit_group_name = EXCLUDED.fit_group_name, category = EXCLUDED.category,
+			cycle = EXCLUDED.cycle, frequency = EXCLUDED.frequency, present_fit_mate_count = EXCLUDED.present_fit_mate_count,
+			max_fit_mate = EXCLUDED.max_fit_mate, state = EXCLUDED.state, updated_at = EXCLUDED.updated_at, updated_by = EXCLUDED.updated_by
+		RETURNING id, created_at, updated_at
 	`
 
-	var id int
-	err := repo.DB.QueryRow(query, fitGroup.FitGroupName, fitGroup.MaxFitMate, fitGroup.CreatedAt, fitGroup.CreatedBy, fitGroup.UpdatedAt, fitGroup.UpdatedBy).Scan(&id)
+	err := repo.DB.QueryRow(query, fitGroup.ID, fitGroup.FitLeaderUserID, fitGroup.FitGroupName, fitGroup.Category, fitGroup.Cycle, fitGroup.Frequency, fitGroup.PresentFitMateCount, fitGroup.MaxFitMate, fitGroup.State, fitGroup.CreatedBy).
+		Scan(&fitGroup.ID, &fitGroup.CreatedAt, &fitGroup.UpdatedAt)
 	if err != nil {
 		return nil, err
 	}
 
-	fitGroup.ID = id
+	fitGroup.UpdatedBy = fitGroup.CreatedBy
 	return fitGroup, nil
 }
 
@@ -111,10 +140,10 @@ func (repo *FitGroupRepositoryImpl) DeleteFitGroup(fitGroupID int) error {
 func (repo *FitGroupRepositoryImpl) UpdateFitGroup(fitGroup *model.FitGroup) error {
 	query := `
 		UPDATE fit_group
-		SET fit_group_name = $1, category = $2, cycle = $3, frequency = $4, present_fit_mate_count = $5, max_fit_mate = $6, state = $7, created_at = $8, created_by = $9, updated_at = NOW(), updated_by = $11
-		WHERE id = $12
+		SET fit_leader_user_id = $1, fit_group_name = $2, category = $3, cycle = $4, frequency = $5, present_fit_mate_count = $6, max_fit_mate = $7, state = $8, updated_at = NOW(), updated_by = $9
+		WHERE id = $10
 	`
-	_, err := repo.DB.Exec(query, fitGroup.FitGroupName, fitGroup.Category, fitGroup.Cycle, fitGroup.Frequency, fitGroup.PresentFitMateCount, fitGroup.MaxFitMate, fitGroup.State, fitGroup.CreatedAt, fitGroup.CreatedBy, fitGroup.UpdatedBy, fitGroup.ID)
+	_, err := repo.DB.Exec(query, fitGroup.FitLeaderUserID, fitGroup.FitGroupName, fitGroup.Category, fitGroup.Cycle, fitGroup.Frequency, fitGroup.PresentFitMateCount, fitGroup.MaxFitMate, fitGroup.State, fitGroup.UpdatedBy, fitGroup.ID)
 	if err != nil {
 		return err
 	}
@@ -1,7 +1,6 @@
 package persistence
 
 import (
-	"database/sql"
 	"fmt"
 	"log"
 	"workoutstudy_chatting/model" // 모델 패키지 경로에 맞게 수정
@@ -16,14 +15,16 @@ type FitMateRepository interface {
 	UpdateFitMate(fitMate *model.FitMate) (*model.FitMate, error)
 	GetFitMatesIdsByFitGroupId(fitGroupId int) ([]int, error)
 	CheckFitGroupExists(fitGroupID int) (bool, error)
+	CheckFitMateExists(userID, fitGroupID int) (bool, error)
+	CheckSharedActiveFitGroup(userID, otherUserID int) (bool, error)
 }
 
 type PostgresFitMateRepository struct {
-	DB *sql.DB
+	DB *SQLDB
 }
 
 // NewPostgresFitMateRepository 생성자 함수는 PostgresFitMateRepository의 새 인스턴스를 반환합니다.
-func NewPostgresFitMateRepository(db *sql.DB) *PostgresFitMateRepository {
+func NewPostgresFitMateRepository(db *SQLDB) *PostgresFitMateRepository {
 	return &PostgresFitMateRepository{DB: db}
 }
 
@@ -63,23 +64,46 @@ func (repo *PostgresFitMateRepository) GetFitGroupsByUserID(userID int) ([]model
 
 func (repo *PostgresFitMateRepository) GetFitMateByID(fitMateID string) (*model.FitMate, error) {
 	query := `
-	SELECT id, fit_group_id, state, created_at, created_by, updated_at, updated_by
+	SELECT id, user_id, fit_group_id, state, created_at, created_by, updated_at, updated_by
 	FROM fit_mate
 	WHERE id = $1
 	`
 	var fm model.FitMate
-	err := repo.DB.QueryRow(query, fitMateID).Scan(&fm.ID, &fm.FitGroupID, &fm.State, &fm.CreatedAt, &fm.CreatedBy, &fm.UpdatedAt, &fm.UpdatedBy)
+	err := repo.DB.QueryRow(query, fitMateID).Scan(&fm.ID, &fm.UserID, &fm.FitGroupID, &fm.State, &fm.CreatedAt, &fm.CreatedBy, &fm.UpdatedAt, &fm.UpdatedBy)
 	if err != nil {
 		return nil, err
 	}
 	return &fm, nil
 }
+
+// SaveFitMate 는 fit mate 와 fit_group_mate 연결을 한 트랜잭션으로 저장합니다.
+// 같은 ID 의 fit mate 가 있으면 생성 정보는 두고 나머지를 덮어쓰고, 연결도 저장한 fit group 으로 맞춥니다.
 func (repo *PostgresFitMateRepository) SaveFitMate(fitMate *model.FitMate) (*model.FitMate, error) {
-	query := `INSERT INTO fit_mate (id, state, created_at, created_by, updated_at, updated_by) VALUES ($1, $2, $3, $4, NOW(), $5, NOW(), $5) RETURNING id`
-	err := repo.DB.QueryRow(query, fitMate.ID, fitMate.State, fitMate.CreatedBy).Scan(&fitMate.ID)
+	tx, err := repo.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `
+	INSERT INTO fit_mate (id, user_id, fit_group_id, state, created_at, created_by, updated_at, updated_by) VALUES ($1, $2, $3, $4, NOW(), $5, NOW(), $5)
+	ON CONFLICT (id) DO UPDATE SET user_id = EXCLUDED.user_id, fit_group_id = EXCLUDED.fit_group_id, state = EXCLUDED.state, updated_at = EXCLUDED.updated_at, updated_by = EXCLUDED.updated_by
+	RETURNING id`
+	err = tx.QueryRow(query, fitMate.ID, fitMate.UserID, fitMate.FitGroupID, fitMate.State, fitMate.CreatedBy).Scan(&fitMate.ID)
 	if err != nil {
 		return nil, err
 	}
+
+	// fit mate 삭제 시에는 FK(ON DELETE CASCADE)로 연결도 함께 삭제됨
+	if _, err := tx.Exec(`DELETE FROM fit_group_mate WHERE fit_mate_id = $1 AND fit_group_id <> $2`, fitMate.ID, fitMate.FitGroupID); err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(`INSERT INTO fit_group_mate (fit_group_id, fit_mate_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`, fitMate.FitGroupID, fitMate.ID); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
 	return fitMate, nil
 }
 
@@ -108,7 +132,7 @@ func (repo *PostgresFitMateRepository) DeleteFitMate(id int) ([]int, error) {
 }
 
 func (repo *PostgresFitMateRepository) UpdateFitMate(fitMate *model.FitMate) (*model.FitMate, error) {
-	query := `UPDATE fit_mate SET state = $3, updated_at = NOW(), updated_by = $5 WHERE id = $1 RETURNING id`
+	query := `UPDATE fit_mate SET state = $2, updated_at = NOW(), updated_by = $3 WHERE id = $1 RETURNING id`
 	err := repo.DB.QueryRow(query, fitMate.ID, fitMate.State, fitMate.UpdatedBy).Scan(&fitMate.ID)
 	if err != nil {
 		return nil, err
@@ -146,3 +170,36 @@ func (repo *PostgresFitMateRepository) CheckFitGroupExists(fitGroupID int) (bool
 	err := repo.DB.QueryRow(query, fitGroupID).Scan(&exists)
 	return exists, err
 }
+
+// CheckFitMateExists 는 사용자가 해당 fit group 의 fit mate 인지 확인합니다.
+func (repo *PostgresFitMateRepository) CheckFitMateExists(userID, fitGroupID int) (bool, error) {
+	query := "SELECT EXISTS(SELECT 1 FROM fit_mate WHERE user_id = $1 AND fit_group_id = $2)"
+	var exists bool
+	err := repo.DB.QueryRow(query, userID, fitGroupID).Scan(&exists)
+	return exists, err
+}
+
+// CheckSharedActiveFitGroup 은 두 사용자가 활성 상태인 fit group 을 하나 이상 함께 속해 있는지 확인합니다.
+// fit leader 는 fit_mate 에 없을 수 있으므로 fit_leader_user_id 도 멤버로 봅니다.
+func (repo *PostgresFitMateRepository) CheckSharedActiveFitGroup(userID, otherUserID int) (bool, error) {
+	query := `
+	WITH members AS (
+		SELECT fm.user_id, fm.fit_group_id
+		FROM fit_mate fm
+		WHERE fm.state = false
+		UNION
+		SELECT fg.fit_leader_user_id, fg.id
+		FROM fit_group fg
+	)
+	SELECT EXISTS(
+		SELECT 1
+		FROM members a
+		JOIN members b ON a.fit_group_id = b.fit_group_id
+		JOIN fit_group fg ON fg.id = a.fit_group_id
+		WHERE a.user_id = $1 AND b.user_id = $2 AND fg.state = false
+	)
+	`
+	var exists bool
+	err := repo.DB.QueryRow(query, userID, otherUserID).Scan(&exists)
+	return exists, err
+}
@@ -0,0 +1,322 @@
+package persistence
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+	"workoutstudy_chatting/model"
+)
+
+type MemoryChatRepository struct {
+	store *MemoryStore
+}
+
+var _ ChatRepository = (*MemoryChatRepository)(nil)
+
+func NewMemoryChatRepository(store *MemoryStore) ChatRepository {
+	return &MemoryChatRepository{store: store}
+}
+
+var messageTypes = map[model.MessageType]bool{
+	model.Chatting: true, model.Ticket: true, model.Announcement: true, model.Poll: true, model.System: true,
+}
+
+// RetrieveMessage 는 Postgres 구현과 같이 UUID 인 message_id 를 int 로 읽을 수 없어 메시지가 있으면 오류를 반환합니다.
+func (repo *MemoryChatRepository) RetrieveMessage(fitGroupID int) (int, error) {
+	s := repo.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rows := s.sortedMessagesLocked(func(m *memoryMessage) bool {
+		return m.FitGroupID == fitGroupID && m.DeletedAt == nil
+	}, true)
+	if len(rows) == 0 {
+		return 0, sql.ErrNoRows
+	}
+	return 0, fmt.Errorf("sql: Scan error on column index 0, name %q: converting driver.Value type string (%q) to a int: invalid syntax", "message_id", rows[0].ID)
+}
+
+func (repo *MemoryChatRepository) RetrieveMessages(fitGroupID int, since time.Time) ([]model.ChatMessage, error) {
+	since = since.Round(time.Microsecond)
+	s := repo.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return chatMessages(s.sortedMessagesLocked(func(m *memoryMessage) bool {
+		return m.FitGroupID == fitGroupID && m.MessageTime.After(since) && m.DeletedAt == nil
+	}, true), false), nil
+}
+
+func (repo *MemoryChatRepository) RetrieveMessagesInRange(fitGroupID int, start, end time.Time) ([]model.ChatMessage, error) {
+	start, end = start.Round(time.Microsecond), end.Round(time.Microsecond)
+	s := repo.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return chatMessages(s.sortedMessagesLocked(func(m *memoryMessage) bool {
+		return m.FitGroupID == fitGroupID && !m.MessageTime.Before(start) && !m.MessageTime.After(end) && m.DeletedAt == nil
+	}, false), false), nil
+}
+
+func (repo *MemoryChatRepository) SaveMessage(msg model.ChatMessage) error {
+	s := repo.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	row, err := s.newMessageRowLocked(msg)
+	if err != nil {
+		return err
+	}
+	if _, exists := s.messages[row.ID]; exists {
+		return errDuplicateKey("message_pkey")
+	}
+	s.messages[row.ID] = row
+	return nil
+}
+
+// newMessageRowLocked 는 message 테이블의 제약을 확인하고 저장할 행을 만듭니다.
+func (s *MemoryStore) newMessageRowLocked(msg model.ChatMessage) (*memoryMessage, error) {
+	id, err := canonicalUUID(msg.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !messageTypes[msg.MessageType] {
+		return nil, fmt.Errorf("new row for relation %q violates check constraint %q", "message", "message_message_type_check")
+	}
+	if _, ok := s.users[msg.UserID]; !ok {
+		return nil, errForeignKey("message", "message_user_id_fkey")
+	}
+	if _, ok := s.fitGroups[msg.FitGroupID]; !ok {
+		return nil, errForeignKey("message", "message_fit_group_id_fkey")
+	}
+
+	// 저장되는 컬럼만 남김
+	return &memoryMessage{
+		ChatMessage: model.ChatMessage{
+			ID:          id,
+			UserID:      msg.UserID,
+			FitGroupID:  msg.FitGroupID,
+			Message:     msg.Message,
+			MessageTime: msg.MessageTime.Round(time.Microsecond),
+			MessageType: msg.MessageType,
+		},
+		CreatedAt: memoryNow(),
+	}, nil
+}
+
+func (repo *MemoryChatRepository) GetFitGroupIDsWithMessages() ([]int, error) {
+	s := repo.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	seen := make(map[int]bool)
+	var fitGroupIDs []int
+	for _, m := range s.messages {
+		if !seen[m.FitGroupID] {
+			seen[m.FitGroupID] = true
+			fitGroupIDs = append(fitGroupIDs, m.FitGroupID)
+		}
+	}
+	sort.Ints(fitGroupIDs)
+	return fitGroupIDs, nil
+}
+
+// RetrieveMessagesBefore 는 cutoff 이전의 메시지를 삭제된 메시지를 포함해 오래된 순으로 최대 limit 개 조회합니다.
+// 아카이브에서 복원한 메시지와 고정된 메시지, 진행 중인 투표 메시지, 검토 대기 중인 moderation queue 메시지는 제외합니다.
+func (repo *MemoryChatRepository) RetrieveMessagesBefore(fitGroupID int, cutoff time.Time, limit int) ([]model.ChatMessage, error) {
+	cutoff = cutoff.Round(time.Microsecond)
+	s := repo.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	held := make(map[string]bool)
+	for key := range s.pins {
+		held[key.messageID] = true
+	}
+	for _, p := range s.polls {
+		if p.poll.ClosedAt == nil {
+			held[p.poll.MessageID] = true
+		}
+	}
+	for _, item := range s.moderationItems {
+		if item.Status == model.ModerationPending {
+			held[item.MessageID] = true
+		}
+	}
+	rows := s.sortedMessagesLocked(func(m *memoryMessage) bool {
+		return m.FitGroupID == fitGroupID && m.MessageTime.Before(cutoff) && m.RestoredAt == nil && !held[m.ID]
+	}, false)
+	if len(rows) > limit {
+		rows = rows[:limit]
+	}
+	return chatMessages(rows, true), nil
+}
+
+func (repo *MemoryChatRepository) DeleteMessages(messageIDs []string) (int64, error) {
+	if len(messageIDs) == 0 {
+		return 0, nil
+	}
+	ids := make([]string, 0, len(messageIDs))
+	for _, messageID := range messageIDs {
+		id, err := canonicalUUID(messageID)
+		if err != nil {
+			return 0, err
+		}
+		ids = append(ids, id)
+	}
+
+	s := repo.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var deleted int64
+	for _, id := range ids {
+		if _, ok := s.messages[id]; ok {
+			s.deleteMessageLocked(id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// RestoreMessages 는 아카이브된 메시지를 삭제 여부(deleted_at)와 함께 다시 저장합니다. 이미 존재하는 message_id 는 건너뜁니다.
+// 복원한 메시지는 restored_at 을 기록해 보관 기간 정책이 다시 아카이브하지 않도록 합니다.
+// 하나라도 제약을 위반하면 아무것도 저장하지 않습니다.
+func (repo *MemoryChatRepository) RestoreMessages(messages []model.ChatMessage) (int, error) {
+	s := repo.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := memoryNow()
+	rows := make(map[string]*memoryMessage)
+	var order []string
+	for _, msg := range messages {
+		row, err := s.newMessageRowLocked(msg)
+		if err != nil {
+			return 0, err
+		}
+		if msg.DeletedAt != nil {
+			deletedAt := msg.DeletedAt.Round(time.Microsecond)
+			row.DeletedAt = &deletedAt
+		}
+		row.RestoredAt = &now
+		if _, exists := s.messages[row.ID]; exists {
+			continue
+		}
+		if _, exists := rows[row.ID]; exists {
+			continue
+		}
+		rows[row.ID] = row
+		order = append(order, row.ID)
+	}
+	for _, id := range order {
+		s.messages[id] = rows[id]
+	}
+	return len(order), nil
+}
+
+func (repo *MemoryChatRepository) GetMessageByID(messageID string) (*model.ChatMessage, error) {
+	id, err := canonicalUUID(messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	s := repo.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	row, ok := s.messages[id]
+	if !ok {
+		return nil, fmt.Errorf("no message found for ID: %s", messageID)
+	}
+	msg := chatMessages([]*memoryMessage{row}, true)[0]
+	return &msg, nil
+}
+
+// SoftDeleteMessage 는 메시지를 실제로 지우지 않고 deleted_at 을 기록합니다.
+func (repo *MemoryChatRepository) SoftDeleteMessage(messageID string, deletedBy string) error {
+	id, err := canonicalUUID(messageID)
+	if err != nil {
+		return err
+	}
+
+	s := repo.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	row, ok := s.messages[id]
+	if !ok || row.DeletedAt != nil {
+		return fmt.Errorf("no message found for ID: %s", messageID)
+	}
+	now := memoryNow()
+	row.DeletedAt = &now
+	row.DeletedBy = deletedBy
+	return nil
+}
+
+// StreamMessageHistory 는 fit group 의 전체 채팅 내역을 오래된 순으로 한 건씩 fn 에 전달합니다.
+// fn 이 느려도 다른 요청을 막지 않도록 조회 시점의 내역을 복사한 뒤 잠금 없이 전달합니다.
+func (repo *MemoryChatRepository) StreamMessageHistory(fitGroupID int, includeDeleted bool, fn func(model.ChatHistoryEntry) error) error {
+	s := repo.store
+	s.mu.RLock()
+	rows := s.sortedMessagesLocked(func(m *memoryMessage) bool {
+		return m.FitGroupID == fitGroupID && (includeDeleted || m.DeletedAt == nil)
+	}, false)
+	entries := make([]model.ChatHistoryEntry, 0, len(rows))
+	for i, msg := range chatMessages(rows, true) {
+		entries = append(entries, model.ChatHistoryEntry{ChatMessage: msg, SenderNickname: s.users[rows[i].UserID].Nickname})
+	}
+	s.mu.RUnlock()
+
+	for _, entry := range entries {
+		if err := fn(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CountCertifications 는 기간 동안 fit mate 별 운동 인증(TICKET) 메시지 수를 조회합니다. 인증하지 않은 fit mate 도 0 으로 포함합니다.
+func (repo *MemoryChatRepository) CountCertifications(fitGroupID int, start, end time.Time) ([]model.CertificationProgress, error) {
+	start, end = start.Round(time.Microsecond), end.Round(time.Microsecond)
+	s := repo.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	counts := make(map[int]int)
+	for _, fm := range s.fitMates {
+		if fm.FitGroupID != fitGroupID || fm.State {
+			continue
+		}
+		counts[fm.UserID] += 0
+		for _, m := range s.messages {
+			if m.UserID == fm.UserID && m.FitGroupID == fitGroupID && m.MessageType == model.Ticket && m.DeletedAt == nil &&
+				!m.CreatedAt.Before(start) && m.CreatedAt.Before(end) {
+				counts[fm.UserID]++
+			}
+		}
+	}
+
+	var progress []model.CertificationProgress
+	for userID, count := range counts {
+		progress = append(progress, model.CertificationProgress{UserID: userID, Nickname: s.users[userID].Nickname, Count: count})
+	}
+	sort.Slice(progress, func(i, j int) bool { return progress[i].UserID < progress[j].UserID })
+	return progress, nil
+}
+
+// chatMessages 는 조회한 행을 복사합니다. withDeletedAt 이 false 면 Postgres 조회처럼 deleted_at 을 채우지 않습니다.
+func chatMessages(rows []*memoryMessage, withDeletedAt bool) []model.ChatMessage {
+	var messages []model.ChatMessage
+	for _, row := range rows {
+		msg := row.ChatMessage
+		msg.DeletedAt = nil
+		if withDeletedAt && row.DeletedAt != nil {
+			deletedAt := *row.DeletedAt
+			msg.DeletedAt = &deletedAt
+		}
+		messages = append(messages, msg)
+	}
+	return messages
+}
@@ -0,0 +1,98 @@
+package persistence
+
+import (
+	"fmt"
+	"sort"
+	"time"
+	"workoutstudy_chatting/model"
+)
+
+type MemoryChatRestrictionRepository struct {
+	store *MemoryStore
+}
+
+var _ ChatRestrictionRepository = (*MemoryChatRestrictionRepository)(nil)
+
+func NewMemoryChatRestrictionRepository(store *MemoryStore) ChatRestrictionRepository {
+	return &MemoryChatRestrictionRepository{store: store}
+}
+
+func (repo *MemoryChatRestrictionRepository) SaveRestriction(restriction *model.ChatRestriction) (*model.ChatRestriction, error) {
+	s := repo.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if restriction.Type != model.RestrictionMute && restriction.Type != model.RestrictionBan {
+		return nil, fmt.Errorf("error saving chat restriction: new row for relation %q violates check constraint %q", "chat_restriction", "chat_restriction_type_check")
+	}
+	if _, ok := s.fitGroups[restriction.FitGroupID]; !ok {
+		return nil, fmt.Errorf("error saving chat restriction: %w", errForeignKey("chat_restriction", "chat_restriction_fit_group_id_fkey"))
+	}
+
+	restriction.ID = s.nextID("chat_restriction")
+	restriction.CreatedAt = memoryNow()
+	stored := *restriction
+	if restriction.ExpiresAt != nil {
+		expiresAt := restriction.ExpiresAt.Round(time.Microsecond)
+		stored.ExpiresAt = &expiresAt
+	}
+	stored.RevokedAt = nil
+	stored.RevokedBy = ""
+	s.restrictions[stored.ID] = stored
+	return restriction, nil
+}
+
+// GetActiveRestrictions 는 해제되지 않았고 만료되지 않은 제한만 조회합니다.
+func (repo *MemoryChatRestrictionRepository) GetActiveRestrictions(fitGroupID, userID int) ([]model.ChatRestriction, error) {
+	return repo.activeRestrictions(func(r model.ChatRestriction) bool {
+		return r.FitGroupID == fitGroupID && r.UserID == userID
+	}), nil
+}
+
+func (repo *MemoryChatRestrictionRepository) GetActiveRestrictionsByFitGroup(fitGroupID int) ([]model.ChatRestriction, error) {
+	return repo.activeRestrictions(func(r model.ChatRestriction) bool {
+		return r.FitGroupID == fitGroupID
+	}), nil
+}
+
+func (repo *MemoryChatRestrictionRepository) RevokeRestrictions(fitGroupID, userID int, restrictionType model.RestrictionType, revokedBy string) (int64, error) {
+	s := repo.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := memoryNow()
+	var revoked int64
+	for id, r := range s.restrictions {
+		if r.FitGroupID == fitGroupID && r.UserID == userID && r.Type == restrictionType && r.RevokedAt == nil {
+			revokedAt := now
+			r.RevokedAt = &revokedAt
+			r.RevokedBy = revokedBy
+			s.restrictions[id] = r
+			revoked++
+		}
+	}
+	return revoked, nil
+}
+
+// activeRestrictions 는 조건에 맞는 유효한 제한을 최근에 부여된 순서로 반환합니다.
+func (repo *MemoryChatRestrictionRepository) activeRestrictions(match func(r model.ChatRestriction) bool) []model.ChatRestriction {
+	s := repo.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := time.Now()
+	var restrictions []model.ChatRestriction
+	for _, r := range s.restrictions {
+		if match(r) && r.RevokedAt == nil && (r.ExpiresAt == nil || r.ExpiresAt.After(now)) {
+			restrictions = append(restrictions, r)
+		}
+	}
+	sort.Slice(restrictions, func(i, j int) bool {
+		a, b := restrictions[i], restrictions[j]
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.After(b.CreatedAt)
+		}
+		return a.ID > b.ID
+	})
+	return restrictions
+}
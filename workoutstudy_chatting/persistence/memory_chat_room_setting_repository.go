@@ -0,0 +1,72 @@
+package persistence
+
+import (
+	"fmt"
+	"workoutstudy_chatting/model"
+)
+
+type MemoryChatRoomSettingRepository struct {
+	store *MemoryStore
+}
+
+var _ ChatRoomSettingRepository = (*MemoryChatRoomSettingRepository)(nil)
+
+func NewMemoryChatRoomSettingRepository(store *MemoryStore) ChatRoomSettingRepository {
+	return &MemoryChatRoomSettingRepository{store: store}
+}
+
+// GetChatRoomSetting 은 설정이 없으면 기본값(슬로우 모드 해제, 기본 시간대)을 반환합니다.
+func (repo *MemoryChatRoomSettingRepository) GetChatRoomSetting(fitGroupID int) (*model.ChatRoomSetting, error) {
+	s := repo.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	setting, ok := s.roomSettings[fitGroupID]
+	if !ok {
+		setting = model.ChatRoomSetting{FitGroupID: fitGroupID, TimeZone: model.DefaultTimeZone}
+	}
+	return &setting, nil
+}
+
+func (repo *MemoryChatRoomSettingRepository) SaveSlowMode(fitGroupID, slowModeSeconds int, updatedBy string) (*model.ChatRoomSetting, error) {
+	if slowModeSeconds < 0 {
+		return nil, fmt.Errorf("error saving slow mode: new row for relation %q violates check constraint %q", "chat_room_setting", "chat_room_setting_slow_mode_seconds_check")
+	}
+	setting, err := repo.upsert(fitGroupID, updatedBy, func(setting *model.ChatRoomSetting) {
+		setting.SlowModeSeconds = slowModeSeconds
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error saving slow mode: %w", err)
+	}
+	return setting, nil
+}
+
+func (repo *MemoryChatRoomSettingRepository) SaveTimeZone(fitGroupID int, timeZone string, updatedBy string) (*model.ChatRoomSetting, error) {
+	setting, err := repo.upsert(fitGroupID, updatedBy, func(setting *model.ChatRoomSetting) {
+		setting.TimeZone = timeZone
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error saving time zone: %w", err)
+	}
+	return setting, nil
+}
+
+// upsert 는 ON CONFLICT (fit_group_id) DO UPDATE 와 같이 설정이 없으면 기본값으로 만든 뒤 update 를 적용합니다.
+func (repo *MemoryChatRoomSettingRepository) upsert(fitGroupID int, updatedBy string, update func(setting *model.ChatRoomSetting)) (*model.ChatRoomSetting, error) {
+	s := repo.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.fitGroups[fitGroupID]; !ok {
+		return nil, errForeignKey("chat_room_setting", "chat_room_setting_fit_group_id_fkey")
+	}
+	setting, ok := s.roomSettings[fitGroupID]
+	if !ok {
+		setting = model.ChatRoomSetting{FitGroupID: fitGroupID, TimeZone: model.DefaultTimeZone}
+	}
+	update(&setting)
+	setting.UpdatedAt = memoryNow()
+	setting.UpdatedBy = updatedBy
+	s.roomSettings[fitGroupID] = setting
+	return &setting, nil
+}
@@ -0,0 +1,228 @@
+package persistence
+
+import (
+	"fmt"
+	"sort"
+	"time"
+	"workoutstudy_chatting/model"
+)
+
+type MemoryDirectMessageRepository struct {
+	store *MemoryStore
+}
+
+var _ DirectMessageRepository = (*MemoryDirectMessageRepository)(nil)
+
+func NewMemoryDirectMessageRepository(store *MemoryStore) DirectMessageRepository {
+	return &MemoryDirectMessageRepository{store: store}
+}
+
+// GetOrCreateConversation 은 두 사용자 사이의 대화방을 조회하고, 없으면 생성합니다.
+func (repo *MemoryDirectMessageRepository) GetOrCreateConversation(userID, peerUserID int) (*model.DirectConversation, error) {
+	userA, userB := userID, peerUserID
+	if userA > userB {
+		userA, userB = userB, userA
+	}
+
+	s := repo.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, c := range s.conversations {
+		if c.UserAID == userA && c.UserBID == userB {
+			return copyConversation(c), nil
+		}
+	}
+	if userA == userB {
+		return nil, fmt.Errorf("error creating direct conversation: new row for relation %q violates check constraint %q", "direct_conversation", "direct_conversation_check")
+	}
+	if _, ok := s.users[userA]; !ok {
+		return nil, fmt.Errorf("error creating direct conversation: %w", errForeignKey("direct_conversation", "direct_conversation_user_a_id_fkey"))
+	}
+	if _, ok := s.users[userB]; !ok {
+		return nil, fmt.Errorf("error creating direct conversation: %w", errForeignKey("direct_conversation", "direct_conversation_user_b_id_fkey"))
+	}
+
+	c := model.DirectConversation{ID: s.nextID("direct_conversation"), UserAID: userA, UserBID: userB, CreatedAt: memoryNow()}
+	s.conversations[c.ID] = c
+	return copyConversation(c), nil
+}
+
+func (repo *MemoryDirectMessageRepository) GetConversationByID(conversationID int) (*model.DirectConversation, error) {
+	s := repo.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	c, ok := s.conversations[conversationID]
+	if !ok {
+		return nil, fmt.Errorf("no direct conversation found for ID: %d", conversationID)
+	}
+	return copyConversation(c), nil
+}
+
+// GetConversationSummaries 는 사용자의 대화방을 최근 메시지 순으로 조회합니다.
+// 읽지 않은 메시지 수는 마지막으로 읽은 시간 이후 상대방이 보낸 메시지 수입니다.
+func (repo *MemoryDirectMessageRepository) GetConversationSummaries(userID int) ([]model.DirectConversationSummary, error) {
+	s := repo.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var summaries []model.DirectConversationSummary
+	for _, c := range s.conversations {
+		if !c.HasParticipant(userID) {
+			continue
+		}
+		summary := model.DirectConversationSummary{DirectConversation: *copyConversation(c)}
+		summary.PeerUserID = c.PeerOf(userID)
+		summary.PeerNickname = s.users[summary.PeerUserID].Nickname
+		summary.UnreadCount = s.countUnreadLocked(c.ID, userID)
+
+		var last *memoryDirectMessage
+		for _, dm := range s.directMessages {
+			if dm.ConversationID == c.ID && dm.DeletedAt == nil && (last == nil || dm.MessageTime.After(last.MessageTime)) {
+				last = dm
+			}
+		}
+		if last != nil {
+			recipient := summary.PeerUserID
+			if last.SenderUserID == summary.PeerUserID {
+				recipient = userID
+			}
+			summary.LastMessage = &model.DirectMessage{
+				ID:              last.ID,
+				ConversationID:  c.ID,
+				SenderUserID:    last.SenderUserID,
+				RecipientUserID: recipient,
+				Message:         last.Message,
+				MessageTime:     last.MessageTime,
+			}
+		}
+		summaries = append(summaries, summary)
+	}
+
+	// last_message_at DESC NULLS LAST, id DESC
+	sort.Slice(summaries, func(i, j int) bool {
+		a, b := summaries[i].LastMessageAt, summaries[j].LastMessageAt
+		switch {
+		case a != nil && b != nil && !a.Equal(*b):
+			return a.After(*b)
+		case a != nil && b == nil:
+			return true
+		case a == nil && b != nil:
+			return false
+		}
+		return summaries[i].ID > summaries[j].ID
+	})
+	return summaries, nil
+}
+
+// SaveDirectMessage 는 메시지를 저장하고 대화방의 마지막 메시지 시간을 갱신합니다.
+func (repo *MemoryDirectMessageRepository) SaveDirectMessage(msg model.DirectMessage) error {
+	id, err := canonicalUUID(msg.ID)
+	if err != nil {
+		return fmt.Errorf("error saving direct message: %w", err)
+	}
+
+	s := repo.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.directMessages[id]; exists {
+		return fmt.Errorf("error saving direct message: %w", errDuplicateKey("direct_message_pkey"))
+	}
+	c, ok := s.conversations[msg.ConversationID]
+	if !ok {
+		return fmt.Errorf("error saving direct message: %w", errForeignKey("direct_message", "direct_message_conversation_id_fkey"))
+	}
+	if _, ok := s.users[msg.SenderUserID]; !ok {
+		return fmt.Errorf("error saving direct message: %w", errForeignKey("direct_message", "direct_message_sender_user_id_fkey"))
+	}
+
+	now := memoryNow()
+	s.directMessages[id] = &memoryDirectMessage{
+		DirectMessage: model.DirectMessage{
+			ID:             id,
+			ConversationID: msg.ConversationID,
+			SenderUserID:   msg.SenderUserID,
+			Message:        msg.Message,
+			MessageTime:    msg.MessageTime.Round(time.Microsecond),
+		},
+		CreatedAt: now,
+	}
+	lastMessageAt := now
+	c.LastMessageAt = &lastMessageAt
+	s.conversations[c.ID] = c
+	return nil
+}
+
+// RetrieveDirectMessages 는 before 이전 메시지를 최신순으로 최대 limit 개 조회합니다.
+func (repo *MemoryDirectMessageRepository) RetrieveDirectMessages(conversationID int, before time.Time, limit int) ([]model.DirectMessage, error) {
+	before = before.Round(time.Microsecond)
+	s := repo.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var messages []model.DirectMessage
+	for _, dm := range s.directMessages {
+		if dm.ConversationID == conversationID && dm.MessageTime.Before(before) && dm.DeletedAt == nil {
+			messages = append(messages, model.DirectMessage{
+				ID:             dm.ID,
+				ConversationID: dm.ConversationID,
+				SenderUserID:   dm.SenderUserID,
+				Message:        dm.Message,
+				MessageTime:    dm.MessageTime,
+			})
+		}
+	}
+	sort.Slice(messages, func(i, j int) bool {
+		if !messages[i].MessageTime.Equal(messages[j].MessageTime) {
+			return messages[i].MessageTime.After(messages[j].MessageTime)
+		}
+		return messages[i].ID > messages[j].ID
+	})
+	if len(messages) > limit {
+		messages = messages[:limit]
+	}
+	return messages, nil
+}
+
+// MarkRead 는 지금까지 받은 메시지를 모두 읽은 것으로 기록합니다.
+func (repo *MemoryDirectMessageRepository) MarkRead(conversationID, userID int) error {
+	s := repo.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.conversations[conversationID]; !ok {
+		return fmt.Errorf("error marking direct conversation read: %w", errForeignKey("direct_read_state", "direct_read_state_conversation_id_fkey"))
+	}
+	s.readStates[readStateKey{conversationID: conversationID, userID: userID}] = memoryNow()
+	return nil
+}
+
+func (repo *MemoryDirectMessageRepository) CountUnread(conversationID, userID int) (int, error) {
+	s := repo.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.countUnreadLocked(conversationID, userID), nil
+}
+
+func (s *MemoryStore) countUnreadLocked(conversationID, userID int) int {
+	lastReadAt, read := s.readStates[readStateKey{conversationID: conversationID, userID: userID}]
+	count := 0
+	for _, dm := range s.directMessages {
+		if dm.ConversationID == conversationID && dm.SenderUserID != userID && dm.DeletedAt == nil &&
+			(!read || dm.CreatedAt.After(lastReadAt)) {
+			count++
+		}
+	}
+	return count
+}
+
+func copyConversation(c model.DirectConversation) *model.DirectConversation {
+	if c.LastMessageAt != nil {
+		lastMessageAt := *c.LastMessageAt
+		c.LastMessageAt = &lastMessageAt
+	}
+	return &c
+}
@@ -0,0 +1,132 @@
+package persistence
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"workoutstudy_chatting/model"
+)
+
+type MemoryFitGroupRepository struct {
+	store *MemoryStore
+}
+
+var _ FitGroupRepository = (*MemoryFitGroupRepository)(nil)
+
+func NewMemoryFitGroupRepository(store *MemoryStore) FitGroupRepository {
+	return &MemoryFitGroupRepository{store: store}
+}
+
+func (repo *MemoryFitGroupRepository) GetFitGroupByID(id int) (*model.FitGroup, error) {
+	s := repo.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	fitGroup, ok := s.fitGroups[id]
+	if !ok {
+		return nil, fmt.Errorf("no fit_group found for ID: %d: %w", id, sql.ErrNoRows)
+	}
+	return &fitGroup, nil
+}
+
+func (repo *MemoryFitGroupRepository) GetFitGroupIDs() ([]int, error) {
+	s := repo.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	fitGroupIDs := make([]int, 0, len(s.fitGroups))
+	for id := range s.fitGroups {
+		fitGroupIDs = append(fitGroupIDs, id)
+	}
+	sort.Ints(fitGroupIDs)
+	return fitGroupIDs, nil
+}
+
+func (repo *MemoryFitGroupRepository) GetFitMatesByFitGroupId(id int) ([]int, error) {
+	s := repo.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return fitGroupMateIDsLocked(s, id), nil
+}
+
+func (repo *MemoryFitGroupRepository) SaveFitGroup(fitGroup *model.FitGroup) (*model.FitGroup, error) {
+	s := repo.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.users[fitGroup.FitLeaderUserID]; !ok {
+		return nil, errForeignKey("fit_group", "fit_group_fit_leader_user_id_fkey")
+	}
+
+	now := memoryNow()
+	stored := *fitGroup
+	stored.CreatedAt = now
+	stored.UpdatedAt = now
+	stored.UpdatedBy = fitGroup.CreatedBy
+	// 이미 있으면 생성 정보는 유지 (ON CONFLICT DO UPDATE)
+	if existing, exists := s.fitGroups[fitGroup.ID]; exists {
+		stored.CreatedAt = existing.CreatedAt
+		stored.CreatedBy = existing.CreatedBy
+	}
+	s.fitGroups[fitGroup.ID] = stored
+
+	fitGroup.CreatedAt = stored.CreatedAt
+	fitGroup.UpdatedAt = stored.UpdatedAt
+	fitGroup.UpdatedBy = stored.UpdatedBy
+	return fitGroup, nil
+}
+
+func (repo *MemoryFitGroupRepository) DeleteFitGroup(fitGroupID int) error {
+	s := repo.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.fitGroups[fitGroupID]; !ok {
+		return nil
+	}
+	if err := s.fitGroupReferencedLocked(fitGroupID); err != nil {
+		return err
+	}
+	s.deleteFitGroupLocked(fitGroupID)
+	return nil
+}
+
+func (repo *MemoryFitGroupRepository) UpdateFitGroup(fitGroup *model.FitGroup) error {
+	s := repo.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.fitGroups[fitGroup.ID]
+	if !ok {
+		return nil
+	}
+	if _, ok := s.users[fitGroup.FitLeaderUserID]; !ok {
+		return errForeignKey("fit_group", "fit_group_fit_leader_user_id_fkey")
+	}
+
+	stored.FitLeaderUserID = fitGroup.FitLeaderUserID
+	stored.FitGroupName = fitGroup.FitGroupName
+	stored.Category = fitGroup.Category
+	stored.Cycle = fitGroup.Cycle
+	stored.Frequency = fitGroup.Frequency
+	stored.PresentFitMateCount = fitGroup.PresentFitMateCount
+	stored.MaxFitMate = fitGroup.MaxFitMate
+	stored.State = fitGroup.State
+	stored.UpdatedAt = memoryNow()
+	stored.UpdatedBy = fitGroup.UpdatedBy
+	s.fitGroups[fitGroup.ID] = stored
+	return nil
+}
+
+// fitGroupMateIDsLocked 는 fit_group_mate 에 연결된 fit mate ID 를 오름차순으로 반환합니다.
+func fitGroupMateIDsLocked(s *MemoryStore, fitGroupID int) []int {
+	var fitMateIds []int
+	for key := range s.fitGroupMates {
+		if key.fitGroupID == fitGroupID {
+			fitMateIds = append(fitMateIds, key.fitMateID)
+		}
+	}
+	sort.Ints(fitMateIds)
+	return fitMateIds
+}
@@ -0,0 +1,191 @@
+package persistence
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strconv"
+	"workoutstudy_chatting/model"
+)
+
+type MemoryFitMateRepository struct {
+	store *MemoryStore
+}
+
+var _ FitMateRepository = (*MemoryFitMateRepository)(nil)
+
+func NewMemoryFitMateRepository(store *MemoryStore) FitMateRepository {
+	return &MemoryFitMateRepository{store: store}
+}
+
+// GetFitGroupsByUserID 는 Postgres 조회와 같이 fit_leader_user_id, state 를 채우지 않습니다.
+func (repo *MemoryFitMateRepository) GetFitGroupsByUserID(userID int) ([]model.FitGroup, error) {
+	s := repo.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var fitMates []model.FitMate
+	for _, fm := range s.fitMates {
+		if fm.UserID == userID {
+			fitMates = append(fitMates, fm)
+		}
+	}
+	sort.Slice(fitMates, func(i, j int) bool { return fitMates[i].ID < fitMates[j].ID })
+
+	var fitGroups []model.FitGroup
+	for _, fm := range fitMates {
+		fg := s.fitGroups[fm.FitGroupID]
+		fitGroups = append(fitGroups, model.FitGroup{
+			ID:                  fg.ID,
+			FitGroupName:        fg.FitGroupName,
+			Category:            fg.Category,
+			Cycle:               fg.Cycle,
+			Frequency:           fg.Frequency,
+			PresentFitMateCount: fg.PresentFitMateCount,
+			MaxFitMate:          fg.MaxFitMate,
+			CreatedAt:           fg.CreatedAt,
+			CreatedBy:           fg.CreatedBy,
+			UpdatedAt:           fg.UpdatedAt,
+			UpdatedBy:           fg.UpdatedBy,
+		})
+	}
+	return fitGroups, nil
+}
+
+func (repo *MemoryFitMateRepository) GetFitMateByID(fitMateID string) (*model.FitMate, error) {
+	id, err := strconv.Atoi(fitMateID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid input syntax for type integer: %q", fitMateID)
+	}
+
+	s := repo.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	fm, ok := s.fitMates[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return &fm, nil
+}
+
+// SaveFitMate 는 fit mate 와 fit_group_mate 연결을 함께 저장합니다. 같은 ID 의 fit mate 가 있으면 덮어씁니다.
+func (repo *MemoryFitMateRepository) SaveFitMate(fitMate *model.FitMate) (*model.FitMate, error) {
+	s := repo.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.users[fitMate.UserID]; !ok {
+		return nil, errForeignKey("fit_mate", "fit_mate_user_id_fkey")
+	}
+	if _, ok := s.fitGroups[fitMate.FitGroupID]; !ok {
+		return nil, errForeignKey("fit_mate", "fit_mate_fit_group_id_fkey")
+	}
+
+	now := memoryNow()
+	stored := *fitMate
+	stored.CreatedAt = now
+	stored.UpdatedAt = now
+	stored.UpdatedBy = fitMate.CreatedBy
+	// 이미 있으면 생성 정보는 유지하고 연결을 저장한 fit group 으로 맞춤 (ON CONFLICT DO UPDATE)
+	if existing, exists := s.fitMates[fitMate.ID]; exists {
+		stored.CreatedAt = existing.CreatedAt
+		stored.CreatedBy = existing.CreatedBy
+		if existing.FitGroupID != fitMate.FitGroupID {
+			delete(s.fitGroupMates, fitGroupMateKey{fitGroupID: existing.FitGroupID, fitMateID: fitMate.ID})
+		}
+	}
+	s.fitMates[fitMate.ID] = stored
+	s.fitGroupMates[fitGroupMateKey{fitGroupID: fitMate.FitGroupID, fitMateID: fitMate.ID}] = struct{}{}
+	return fitMate, nil
+}
+
+func (repo *MemoryFitMateRepository) DeleteFitMate(id int) ([]int, error) {
+	s := repo.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.fitMates[id]; !ok {
+		return nil, nil
+	}
+	delete(s.fitMates, id)
+	for key := range s.fitGroupMates {
+		if key.fitMateID == id {
+			delete(s.fitGroupMates, key)
+		}
+	}
+	return []int{id}, nil
+}
+
+func (repo *MemoryFitMateRepository) UpdateFitMate(fitMate *model.FitMate) (*model.FitMate, error) {
+	s := repo.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.fitMates[fitMate.ID]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	stored.State = fitMate.State
+	stored.UpdatedAt = memoryNow()
+	stored.UpdatedBy = fitMate.UpdatedBy
+	s.fitMates[fitMate.ID] = stored
+	return fitMate, nil
+}
+
+func (repo *MemoryFitMateRepository) GetFitMatesIdsByFitGroupId(fitGroupId int) ([]int, error) {
+	s := repo.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return fitGroupMateIDsLocked(s, fitGroupId), nil
+}
+
+func (repo *MemoryFitMateRepository) CheckFitGroupExists(fitGroupID int) (bool, error) {
+	s := repo.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, ok := s.fitGroups[fitGroupID]
+	return ok, nil
+}
+
+// CheckFitMateExists 는 사용자가 해당 fit group 의 fit mate 인지 확인합니다.
+func (repo *MemoryFitMateRepository) CheckFitMateExists(userID, fitGroupID int) (bool, error) {
+	s := repo.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, fm := range s.fitMates {
+		if fm.UserID == userID && fm.FitGroupID == fitGroupID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// CheckSharedActiveFitGroup 은 두 사용자가 활성 상태인 fit group 을 하나 이상 함께 속해 있는지 확인합니다.
+// fit leader 도 멤버로 봅니다.
+func (repo *MemoryFitMateRepository) CheckSharedActiveFitGroup(userID, otherUserID int) (bool, error) {
+	s := repo.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	isMember := func(uID int, fg model.FitGroup) bool {
+		if fg.FitLeaderUserID == uID {
+			return true
+		}
+		for _, fm := range s.fitMates {
+			if fm.UserID == uID && fm.FitGroupID == fg.ID && !fm.State {
+				return true
+			}
+		}
+		return false
+	}
+	for _, fg := range s.fitGroups {
+		if !fg.State && isMember(userID, fg) && isMember(otherUserID, fg) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
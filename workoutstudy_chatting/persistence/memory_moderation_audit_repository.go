@@ -0,0 +1,54 @@
+package persistence
+
+import (
+	"fmt"
+	"workoutstudy_chatting/model"
+)
+
+type MemoryModerationAuditRepository struct {
+	store *MemoryStore
+}
+
+var _ ModerationAuditRepository = (*MemoryModerationAuditRepository)(nil)
+
+func NewMemoryModerationAuditRepository(store *MemoryStore) ModerationAuditRepository {
+	return &MemoryModerationAuditRepository{store: store}
+}
+
+func (repo *MemoryModerationAuditRepository) SaveAuditLog(auditLog *model.ModerationAuditLog) error {
+	targetMessageID := auditLog.TargetMessageID
+	if targetMessageID != "" {
+		id, err := canonicalUUID(targetMessageID)
+		if err != nil {
+			return fmt.Errorf("error saving moderation audit log: %w", err)
+		}
+		targetMessageID = id
+	}
+
+	s := repo.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	auditLog.ID = s.nextID("moderation_audit")
+	auditLog.CreatedAt = memoryNow()
+	stored := *auditLog
+	stored.TargetMessageID = targetMessageID
+	s.auditLogs = append(s.auditLogs, stored)
+	return nil
+}
+
+// GetAuditLogs 는 최신 기록부터 최대 limit 개를 조회합니다.
+func (repo *MemoryModerationAuditRepository) GetAuditLogs(fitGroupID int, limit int) ([]model.ModerationAuditLog, error) {
+	s := repo.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// auditLogs 는 저장 순서(created_at 순)이므로 뒤에서부터 읽음
+	var logs []model.ModerationAuditLog
+	for i := len(s.auditLogs) - 1; i >= 0 && len(logs) < limit; i-- {
+		if s.auditLogs[i].FitGroupID == fitGroupID {
+			logs = append(logs, s.auditLogs[i])
+		}
+	}
+	return logs, nil
+}
@@ -0,0 +1,112 @@
+package persistence
+
+import (
+	"fmt"
+	"sort"
+	"workoutstudy_chatting/model"
+)
+
+type MemoryModerationRepository struct {
+	store *MemoryStore
+}
+
+var _ ModerationRepository = (*MemoryModerationRepository)(nil)
+
+func NewMemoryModerationRepository(store *MemoryStore) ModerationRepository {
+	return &MemoryModerationRepository{store: store}
+}
+
+var moderationStatuses = map[model.ModerationStatus]bool{
+	model.ModerationPending: true, model.ModerationApproved: true, model.ModerationRemoved: true,
+}
+
+func errModerationStatusCheck() error {
+	return fmt.Errorf("new row for relation %q violates check constraint %q", "moderation_queue", "moderation_queue_status_check")
+}
+
+func (repo *MemoryModerationRepository) SaveModerationItem(item *model.ModerationItem) (*model.ModerationItem, error) {
+	if item.Status == "" {
+		item.Status = model.ModerationPending
+	}
+	if !moderationStatuses[item.Status] {
+		return nil, fmt.Errorf("error saving moderation item: %w", errModerationStatusCheck())
+	}
+	messageID, err := canonicalUUID(item.MessageID)
+	if err != nil {
+		return nil, fmt.Errorf("error saving moderation item: %w", err)
+	}
+
+	s := repo.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.messages[messageID]; !ok {
+		return nil, fmt.Errorf("error saving moderation item: %w", errForeignKey("moderation_queue", "moderation_queue_message_id_fkey"))
+	}
+	if _, ok := s.fitGroups[item.FitGroupID]; !ok {
+		return nil, fmt.Errorf("error saving moderation item: %w", errForeignKey("moderation_queue", "moderation_queue_fit_group_id_fkey"))
+	}
+
+	item.ID = s.nextID("moderation_queue")
+	item.CreatedAt = memoryNow()
+	stored := *item
+	stored.MessageID = messageID
+	stored.ReviewedAt = nil
+	stored.ReviewedBy = ""
+	s.moderationItems[item.ID] = stored
+	return item, nil
+}
+
+func (repo *MemoryModerationRepository) GetModerationItemByID(id int) (*model.ModerationItem, error) {
+	s := repo.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	item, ok := s.moderationItems[id]
+	if !ok {
+		return nil, fmt.Errorf("no moderation item found for ID: %d", id)
+	}
+	return &item, nil
+}
+
+func (repo *MemoryModerationRepository) GetModerationItems(fitGroupID int, status model.ModerationStatus) ([]model.ModerationItem, error) {
+	s := repo.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var items []model.ModerationItem
+	for _, item := range s.moderationItems {
+		if item.FitGroupID == fitGroupID && item.Status == status {
+			items = append(items, item)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		return a.ID < b.ID
+	})
+	return items, nil
+}
+
+func (repo *MemoryModerationRepository) UpdateModerationStatus(id int, status model.ModerationStatus, reviewedBy string) error {
+	if !moderationStatuses[status] {
+		return fmt.Errorf("error updating moderation item: %w", errModerationStatusCheck())
+	}
+
+	s := repo.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item, ok := s.moderationItems[id]
+	if !ok {
+		return nil
+	}
+	reviewedAt := memoryNow()
+	item.Status = status
+	item.ReviewedAt = &reviewedAt
+	item.ReviewedBy = reviewedBy
+	s.moderationItems[id] = item
+	return nil
+}
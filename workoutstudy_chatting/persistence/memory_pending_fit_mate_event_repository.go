@@ -0,0 +1,114 @@
+package persistence
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+	"workoutstudy_chatting/model"
+)
+
+type MemoryPendingFitMateEventRepository struct {
+	store *MemoryStore
+}
+
+var _ PendingFitMateEventRepository = (*MemoryPendingFitMateEventRepository)(nil)
+
+func NewMemoryPendingFitMateEventRepository(store *MemoryStore) PendingFitMateEventRepository {
+	return &MemoryPendingFitMateEventRepository{store: store}
+}
+
+func (repo *MemoryPendingFitMateEventRepository) SavePendingFitMateEvent(event *model.PendingFitMateEvent) error {
+	payload, err := json.Marshal(event.FitMates)
+	if err != nil {
+		return fmt.Errorf("error encoding pending fit mate event: %w", err)
+	}
+
+	s := repo.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pendingFitMateEvents[event.FitGroupID] = memoryPendingFitMateEvent{
+		payload:    string(payload),
+		receivedAt: event.ReceivedAt.Round(time.Microsecond),
+		expiresAt:  event.ExpiresAt.Round(time.Microsecond),
+	}
+	return nil
+}
+
+func (repo *MemoryPendingFitMateEventRepository) GetPendingFitMateEvent(fitGroupID int) (*model.PendingFitMateEvent, error) {
+	s := repo.store
+	s.mu.RLock()
+	row, ok := s.pendingFitMateEvents[fitGroupID]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, nil
+	}
+
+	event := model.PendingFitMateEvent{FitGroupID: fitGroupID, ReceivedAt: row.receivedAt, ExpiresAt: row.expiresAt}
+	if err := json.Unmarshal([]byte(row.payload), &event.FitMates); err != nil {
+		return nil, fmt.Errorf("error decoding pending fit mate event for fit group %d: %w", fitGroupID, err)
+	}
+	return &event, nil
+}
+
+func (repo *MemoryPendingFitMateEventRepository) GetPendingFitMateEventGroupIDs() ([]int, error) {
+	s := repo.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	fitGroupIDs := make([]int, 0, len(s.pendingFitMateEvents))
+	for id := range s.pendingFitMateEvents {
+		fitGroupIDs = append(fitGroupIDs, id)
+	}
+	sort.Slice(fitGroupIDs, func(i, j int) bool {
+		a, b := s.pendingFitMateEvents[fitGroupIDs[i]], s.pendingFitMateEvents[fitGroupIDs[j]]
+		if !a.receivedAt.Equal(b.receivedAt) {
+			return a.receivedAt.Before(b.receivedAt)
+		}
+		return fitGroupIDs[i] < fitGroupIDs[j]
+	})
+	return fitGroupIDs, nil
+}
+
+func (repo *MemoryPendingFitMateEventRepository) DeletePendingFitMateEvent(event *model.PendingFitMateEvent) (bool, error) {
+	payload, err := json.Marshal(event.FitMates)
+	if err != nil {
+		return false, fmt.Errorf("error encoding pending fit mate event: %w", err)
+	}
+
+	s := repo.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	row, ok := s.pendingFitMateEvents[event.FitGroupID]
+	if !ok || row.payload != string(payload) {
+		return false, nil
+	}
+	delete(s.pendingFitMateEvents, event.FitGroupID)
+	return true, nil
+}
+
+func (repo *MemoryPendingFitMateEventRepository) DeleteExpiredPendingFitMateEvents(now time.Time) (int64, error) {
+	s := repo.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now = now.Round(time.Microsecond)
+	var deleted int64
+	for fitGroupID, row := range s.pendingFitMateEvents {
+		if !row.expiresAt.After(now) {
+			delete(s.pendingFitMateEvents, fitGroupID)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (repo *MemoryPendingFitMateEventRepository) CountPendingFitMateEvents() (int, error) {
+	s := repo.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.pendingFitMateEvents), nil
+}
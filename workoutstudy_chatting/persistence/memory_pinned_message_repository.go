@@ -0,0 +1,95 @@
+package persistence
+
+import (
+	"fmt"
+	"sort"
+	"workoutstudy_chatting/model"
+)
+
+type MemoryPinnedMessageRepository struct {
+	store *MemoryStore
+}
+
+var _ PinnedMessageRepository = (*MemoryPinnedMessageRepository)(nil)
+
+func NewMemoryPinnedMessageRepository(store *MemoryStore) PinnedMessageRepository {
+	return &MemoryPinnedMessageRepository{store: store}
+}
+
+// PinMessage 는 이미 고정된 메시지를 다시 고정하면 고정 시간과 고정한 사용자를 갱신합니다.
+func (repo *MemoryPinnedMessageRepository) PinMessage(pin *model.PinnedMessage) (*model.PinnedMessage, error) {
+	messageID, err := canonicalUUID(pin.MessageID)
+	if err != nil {
+		return nil, fmt.Errorf("error pinning message: %w", err)
+	}
+
+	s := repo.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.fitGroups[pin.FitGroupID]; !ok {
+		return nil, fmt.Errorf("error pinning message: %w", errForeignKey("pinned_message", "pinned_message_fit_group_id_fkey"))
+	}
+	if _, ok := s.messages[messageID]; !ok {
+		return nil, fmt.Errorf("error pinning message: %w", errForeignKey("pinned_message", "pinned_message_message_id_fkey"))
+	}
+
+	pin.PinnedAt = memoryNow()
+	s.pins[pinKey{fitGroupID: pin.FitGroupID, messageID: messageID}] = model.PinnedMessage{
+		FitGroupID: pin.FitGroupID,
+		MessageID:  messageID,
+		PinnedBy:   pin.PinnedBy,
+		PinnedAt:   pin.PinnedAt,
+	}
+	return pin, nil
+}
+
+// UnpinMessage 는 고정을 해제하고, 고정되어 있지 않았으면 false 를 반환합니다.
+func (repo *MemoryPinnedMessageRepository) UnpinMessage(fitGroupID int, messageID string) (bool, error) {
+	id, err := canonicalUUID(messageID)
+	if err != nil {
+		return false, fmt.Errorf("error unpinning message: %w", err)
+	}
+
+	s := repo.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := pinKey{fitGroupID: fitGroupID, messageID: id}
+	if _, ok := s.pins[key]; !ok {
+		return false, nil
+	}
+	delete(s.pins, key)
+	return true, nil
+}
+
+// GetPinnedMessages 는 최근에 고정된 순서로 메시지 내용과 함께 조회합니다. 삭제된 메시지는 제외합니다.
+func (repo *MemoryPinnedMessageRepository) GetPinnedMessages(fitGroupID int) ([]model.PinnedMessage, error) {
+	s := repo.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var pins []model.PinnedMessage
+	for key, p := range s.pins {
+		m, ok := s.messages[key.messageID]
+		if key.fitGroupID != fitGroupID || !ok || m.DeletedAt != nil {
+			continue
+		}
+		p.Message = &model.ChatMessage{
+			ID:          p.MessageID,
+			UserID:      m.UserID,
+			FitGroupID:  p.FitGroupID,
+			Message:     m.Message,
+			MessageTime: m.MessageTime,
+			MessageType: m.MessageType,
+		}
+		pins = append(pins, p)
+	}
+	sort.Slice(pins, func(i, j int) bool {
+		if !pins[i].PinnedAt.Equal(pins[j].PinnedAt) {
+			return pins[i].PinnedAt.After(pins[j].PinnedAt)
+		}
+		return pins[i].MessageID < pins[j].MessageID
+	})
+	return pins, nil
+}
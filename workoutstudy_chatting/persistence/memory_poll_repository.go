@@ -0,0 +1,293 @@
+package persistence
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+	"workoutstudy_chatting/model"
+)
+
+type MemoryPollRepository struct {
+	store *MemoryStore
+}
+
+var _ PollRepository = (*MemoryPollRepository)(nil)
+
+func NewMemoryPollRepository(store *MemoryStore) PollRepository {
+	return &MemoryPollRepository{store: store}
+}
+
+// CreatePoll 은 투표와 선택지를 함께 저장합니다. 투표 메시지는 먼저 저장되어 있어야 합니다.
+func (repo *MemoryPollRepository) CreatePoll(poll *model.ChatPoll) (*model.ChatPoll, error) {
+	messageID, err := canonicalUUID(poll.MessageID)
+	if err != nil {
+		return nil, fmt.Errorf("error creating poll: %w", err)
+	}
+
+	s := repo.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, p := range s.polls {
+		if p.poll.MessageID == messageID {
+			return nil, fmt.Errorf("error creating poll: %w", errDuplicateKey("poll_message_id_key"))
+		}
+	}
+	if _, ok := s.messages[messageID]; !ok {
+		return nil, fmt.Errorf("error creating poll: %w", errForeignKey("poll", "poll_message_id_fkey"))
+	}
+	if _, ok := s.fitGroups[poll.FitGroupID]; !ok {
+		return nil, fmt.Errorf("error creating poll: %w", errForeignKey("poll", "poll_fit_group_id_fkey"))
+	}
+
+	poll.ID = s.nextID("poll")
+	poll.CreatedAt = memoryNow()
+	stored := &memoryPoll{poll: model.ChatPoll{
+		ID:             poll.ID,
+		MessageID:      messageID,
+		FitGroupID:     poll.FitGroupID,
+		CreatedBy:      poll.CreatedBy,
+		Question:       poll.Question,
+		MultipleChoice: poll.MultipleChoice,
+		Anonymous:      poll.Anonymous,
+		CreatedAt:      poll.CreatedAt,
+	}}
+	if poll.Deadline != nil {
+		deadline := poll.Deadline.Round(time.Microsecond)
+		stored.poll.Deadline = &deadline
+	}
+	for i := range poll.Options {
+		poll.Options[i].ID = s.nextID("poll_option")
+		stored.options = append(stored.options, memoryPollOption{id: poll.Options[i].ID, text: poll.Options[i].Text})
+	}
+	s.polls[poll.ID] = stored
+	return poll, nil
+}
+
+func (repo *MemoryPollRepository) GetPollByID(pollID int) (*model.ChatPoll, error) {
+	s := repo.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	p, ok := s.polls[pollID]
+	if !ok {
+		return nil, fmt.Errorf("no poll found for %v", pollID)
+	}
+	return p.view(), nil
+}
+
+func (repo *MemoryPollRepository) GetPollByMessageID(messageID string) (*model.ChatPoll, error) {
+	id, err := canonicalUUID(messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	s := repo.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, p := range s.polls {
+		if p.poll.MessageID == id {
+			return p.view(), nil
+		}
+	}
+	return nil, fmt.Errorf("no poll found for %v", messageID)
+}
+
+// ReplaceVotes 는 사용자의 기존 투표를 optionIDs 로 바꿉니다.
+// 투표가 종료되었거나 마감되었으면 아무것도 바꾸지 않고 false 를 반환합니다.
+func (repo *MemoryPollRepository) ReplaceVotes(pollID, userID int, optionIDs []int) (bool, error) {
+	s := repo.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.polls[pollID]
+	if !ok {
+		return false, fmt.Errorf("error locking poll: %w", sql.ErrNoRows)
+	}
+	if p.poll.ClosedAt != nil || (p.poll.Deadline != nil && !p.poll.Deadline.After(time.Now())) {
+		return false, nil
+	}
+
+	// 하나라도 실패하면 기존 투표를 유지
+	now := memoryNow()
+	var added []memoryPollVote
+	for _, optionID := range optionIDs {
+		if !s.pollOptionExistsLocked(optionID) {
+			return false, fmt.Errorf("error saving poll vote: %w", errForeignKey("poll_vote", "poll_vote_option_id_fkey"))
+		}
+		for _, v := range added {
+			if v.optionID == optionID {
+				return false, fmt.Errorf("error saving poll vote: %w", errDuplicateKey("poll_vote_pkey"))
+			}
+		}
+		added = append(added, memoryPollVote{optionID: optionID, userID: userID, votedAt: now})
+	}
+
+	votes := p.votes[:0:0]
+	for _, v := range p.votes {
+		if v.userID != userID {
+			votes = append(votes, v)
+		}
+	}
+	p.votes = append(votes, added...)
+	return true, nil
+}
+
+func (repo *MemoryPollRepository) GetUserVotes(pollID, userID int) ([]int, error) {
+	s := repo.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	p, ok := s.polls[pollID]
+	if !ok {
+		return nil, nil
+	}
+	var optionIDs []int
+	for _, v := range p.votes {
+		if v.userID == userID {
+			optionIDs = append(optionIDs, v.optionID)
+		}
+	}
+	return optionIDs, nil
+}
+
+// ClosePoll 은 투표를 종료합니다. 이미 종료된 투표면 false 를 반환하므로 결과 메시지는 한 번만 보내게 됩니다.
+func (repo *MemoryPollRepository) ClosePoll(pollID int) (bool, error) {
+	s := repo.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.polls[pollID]
+	if !ok || p.poll.ClosedAt != nil {
+		return false, nil
+	}
+	closedAt := memoryNow()
+	p.poll.ClosedAt = &closedAt
+	return true, nil
+}
+
+// GetExpiredOpenPollIDs 는 마감 시간이 지났지만 아직 종료 처리되지 않은 투표를 조회합니다.
+func (repo *MemoryPollRepository) GetExpiredOpenPollIDs(now time.Time) ([]int, error) {
+	now = now.Round(time.Microsecond)
+	s := repo.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var polls []model.ChatPoll
+	for _, p := range s.polls {
+		if p.poll.ClosedAt == nil && p.poll.Deadline != nil && !p.poll.Deadline.After(now) {
+			polls = append(polls, p.poll)
+		}
+	}
+	sort.Slice(polls, func(i, j int) bool {
+		if !polls[i].Deadline.Equal(*polls[j].Deadline) {
+			return polls[i].Deadline.Before(*polls[j].Deadline)
+		}
+		return polls[i].ID < polls[j].ID
+	})
+
+	var pollIDs []int
+	for _, p := range polls {
+		pollIDs = append(pollIDs, p.ID)
+	}
+	return pollIDs, nil
+}
+
+// RestorePoll 은 아카이브한 투표를 선택지, 투표 결과와 함께 다시 저장합니다. 투표 메시지는 먼저 복원되어 있어야 합니다.
+// 이미 같은 메시지의 투표가 있으면 저장하지 않고 false 를 반환합니다.
+func (repo *MemoryPollRepository) RestorePoll(poll *model.ChatPoll) (bool, error) {
+	messageID, err := canonicalUUID(poll.MessageID)
+	if err != nil {
+		return false, fmt.Errorf("error restoring poll: %w", err)
+	}
+
+	s := repo.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, p := range s.polls {
+		if p.poll.MessageID == messageID {
+			return false, nil
+		}
+	}
+	if _, ok := s.messages[messageID]; !ok {
+		return false, fmt.Errorf("error restoring poll: %w", errForeignKey("poll", "poll_message_id_fkey"))
+	}
+	if _, ok := s.fitGroups[poll.FitGroupID]; !ok {
+		return false, fmt.Errorf("error restoring poll: %w", errForeignKey("poll", "poll_fit_group_id_fkey"))
+	}
+
+	stored := &memoryPoll{poll: model.ChatPoll{
+		ID:             s.nextID("poll"),
+		MessageID:      messageID,
+		FitGroupID:     poll.FitGroupID,
+		CreatedBy:      poll.CreatedBy,
+		Question:       poll.Question,
+		MultipleChoice: poll.MultipleChoice,
+		Anonymous:      poll.Anonymous,
+		CreatedAt:      poll.CreatedAt.Round(time.Microsecond),
+	}}
+	if poll.Deadline != nil {
+		deadline := poll.Deadline.Round(time.Microsecond)
+		stored.poll.Deadline = &deadline
+	}
+	votedAt := stored.poll.CreatedAt
+	if poll.ClosedAt != nil {
+		closedAt := poll.ClosedAt.Round(time.Microsecond)
+		stored.poll.ClosedAt = &closedAt
+		votedAt = closedAt
+	}
+	for _, option := range poll.Options {
+		optionID := s.nextID("poll_option")
+		stored.options = append(stored.options, memoryPollOption{id: optionID, text: option.Text})
+		for _, userID := range option.VoterUserIDs {
+			stored.votes = append(stored.votes, memoryPollVote{optionID: optionID, userID: userID, votedAt: votedAt})
+		}
+	}
+	s.polls[stored.poll.ID] = stored
+	return true, nil
+}
+
+// pollOptionExistsLocked 는 poll_vote.option_id 외래 키처럼 어떤 투표의 선택지든 존재하는지 확인합니다.
+func (s *MemoryStore) pollOptionExistsLocked(optionID int) bool {
+	for _, p := range s.polls {
+		for _, o := range p.options {
+			if o.id == optionID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// view 는 선택지별 집계와 투표자 목록을 채운 투표를 반환합니다. 투표자는 투표한 순서입니다.
+func (p *memoryPoll) view() *model.ChatPoll {
+	poll := p.poll
+	poll.Options = make([]model.PollOption, 0, len(p.options))
+	voters := make(map[int]bool)
+	for _, o := range p.options {
+		option := model.PollOption{ID: o.id, Text: o.text}
+		for _, v := range p.votes {
+			if v.optionID == o.id {
+				option.VoteCount++
+				option.VoterUserIDs = append(option.VoterUserIDs, v.userID)
+			}
+		}
+		poll.Options = append(poll.Options, option)
+	}
+	for _, v := range p.votes {
+		voters[v.userID] = true
+	}
+	poll.TotalVoters = len(voters)
+	if p.poll.Deadline != nil {
+		deadline := *p.poll.Deadline
+		poll.Deadline = &deadline
+	}
+	if p.poll.ClosedAt != nil {
+		closedAt := *p.poll.ClosedAt
+		poll.ClosedAt = &closedAt
+	}
+	return &poll
+}
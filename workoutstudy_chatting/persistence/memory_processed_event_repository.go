@@ -0,0 +1,50 @@
+package persistence
+
+import "time"
+
+type MemoryProcessedEventRepository struct {
+	store *MemoryStore
+}
+
+var _ ProcessedEventRepository = (*MemoryProcessedEventRepository)(nil)
+
+func NewMemoryProcessedEventRepository(store *MemoryStore) ProcessedEventRepository {
+	return &MemoryProcessedEventRepository{store: store}
+}
+
+func (repo *MemoryProcessedEventRepository) IsEventProcessed(topic, eventKey string) (bool, error) {
+	s := repo.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, ok := s.processedEvents[processedEventKey{topic: topic, eventKey: eventKey}]
+	return ok, nil
+}
+
+func (repo *MemoryProcessedEventRepository) MarkEventProcessed(topic, eventKey string) error {
+	s := repo.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := processedEventKey{topic: topic, eventKey: eventKey}
+	if _, ok := s.processedEvents[key]; !ok {
+		s.processedEvents[key] = memoryNow()
+	}
+	return nil
+}
+
+func (repo *MemoryProcessedEventRepository) DeleteProcessedEventsBefore(before time.Time) (int64, error) {
+	s := repo.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	before = before.Round(time.Microsecond)
+	var deleted int64
+	for key, processedAt := range s.processedEvents {
+		if processedAt.Before(before) {
+			delete(s.processedEvents, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
@@ -0,0 +1,158 @@
+package persistence
+
+import (
+	"fmt"
+	"sort"
+	"time"
+	"workoutstudy_chatting/model"
+)
+
+type MemoryReminderRepository struct {
+	store *MemoryStore
+}
+
+var _ ReminderRepository = (*MemoryReminderRepository)(nil)
+
+func NewMemoryReminderRepository(store *MemoryStore) ReminderRepository {
+	return &MemoryReminderRepository{store: store}
+}
+
+var reminderRecurrences = map[string]bool{"DAILY": true, "WEEKLY": true, "CYCLE_DEADLINE": true}
+
+func (repo *MemoryReminderRepository) SaveReminder(reminder *model.Reminder) (*model.Reminder, error) {
+	if !reminderRecurrences[reminder.Recurrence] {
+		return nil, fmt.Errorf("error saving reminder: new row for relation %q violates check constraint %q", "scheduled_reminder", "scheduled_reminder_recurrence_check")
+	}
+
+	s := repo.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.fitGroups[reminder.FitGroupID]; !ok {
+		return nil, fmt.Errorf("error saving reminder: %w", errForeignKey("scheduled_reminder", "scheduled_reminder_fit_group_id_fkey"))
+	}
+
+	saved := model.Reminder{
+		ID:         s.nextID("scheduled_reminder"),
+		FitGroupID: reminder.FitGroupID,
+		Recurrence: reminder.Recurrence,
+		Message:    reminder.Message,
+		TimeOfDay:  reminder.TimeOfDay,
+		Weekdays:   copyInts(reminder.Weekdays),
+		DaysBefore: reminder.DaysBefore,
+		NextRunAt:  reminder.NextRunAt.Round(time.Microsecond),
+		CreatedBy:  reminder.CreatedBy,
+		CreatedAt:  memoryNow(),
+	}
+	s.reminders[saved.ID] = saved
+	return copyReminder(saved), nil
+}
+
+func (repo *MemoryReminderRepository) GetRemindersByFitGroup(fitGroupID int) ([]model.Reminder, error) {
+	reminders := repo.queryReminders(func(r model.Reminder) bool { return r.FitGroupID == fitGroupID }, func(a, b model.Reminder) bool {
+		return a.ID < b.ID
+	})
+	return reminders, nil
+}
+
+func (repo *MemoryReminderRepository) DeleteReminder(fitGroupID, reminderID int) (bool, error) {
+	s := repo.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, ok := s.reminders[reminderID]
+	if !ok || r.FitGroupID != fitGroupID {
+		return false, nil
+	}
+	delete(s.reminders, reminderID)
+	return true, nil
+}
+
+// GetDueReminders 는 실행 시간이 지난 리마인더를 오래된 순으로 조회합니다. 비활성화된 리마인더는 제외합니다.
+func (repo *MemoryReminderRepository) GetDueReminders(now time.Time, limit int) ([]model.Reminder, error) {
+	now = now.Round(time.Microsecond)
+	reminders := repo.queryReminders(func(r model.Reminder) bool { return !r.NextRunAt.After(now) && r.DisabledAt == nil }, func(a, b model.Reminder) bool {
+		if !a.NextRunAt.Equal(b.NextRunAt) {
+			return a.NextRunAt.Before(b.NextRunAt)
+		}
+		return a.ID < b.ID
+	})
+	if len(reminders) > limit {
+		reminders = reminders[:limit]
+	}
+	return reminders, nil
+}
+
+// AdvanceReminder 는 next_run_at 이 expectedNextRunAt 일 때만 다음 실행 시간을 갱신합니다.
+// ranAt 이 nil 이면 실행하지 않고 건너뛴 것으로 보고 last_run_at 을 유지합니다.
+func (repo *MemoryReminderRepository) AdvanceReminder(reminderID int, expectedNextRunAt, nextRunAt time.Time, ranAt *time.Time) (bool, error) {
+	s := repo.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, ok := s.reminders[reminderID]
+	if !ok || !r.NextRunAt.Equal(expectedNextRunAt.Round(time.Microsecond)) {
+		return false, nil
+	}
+	r.NextRunAt = nextRunAt.Round(time.Microsecond)
+	if ranAt != nil {
+		lastRunAt := ranAt.Round(time.Microsecond)
+		r.LastRunAt = &lastRunAt
+	}
+	s.reminders[reminderID] = r
+	return true, nil
+}
+
+// DisableReminder 는 next_run_at 이 expectedNextRunAt 일 때만 리마인더를 비활성화합니다.
+func (repo *MemoryReminderRepository) DisableReminder(reminderID int, expectedNextRunAt time.Time, reason string) (bool, error) {
+	s := repo.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, ok := s.reminders[reminderID]
+	if !ok || r.DisabledAt != nil || !r.NextRunAt.Equal(expectedNextRunAt.Round(time.Microsecond)) {
+		return false, nil
+	}
+	disabledAt := memoryNow()
+	r.DisabledAt = &disabledAt
+	r.DisabledReason = reason
+	s.reminders[reminderID] = r
+	return true, nil
+}
+
+func (repo *MemoryReminderRepository) queryReminders(match func(r model.Reminder) bool, less func(a, b model.Reminder) bool) []model.Reminder {
+	s := repo.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var reminders []model.Reminder
+	for _, r := range s.reminders {
+		if match(r) {
+			reminders = append(reminders, *copyReminder(r))
+		}
+	}
+	sort.Slice(reminders, func(i, j int) bool { return less(reminders[i], reminders[j]) })
+	return reminders
+}
+
+// copyReminder 는 저장된 리마인더가 호출자에 의해 바뀌지 않도록 슬라이스와 포인터 필드를 복사합니다.
+func copyReminder(r model.Reminder) *model.Reminder {
+	r.Weekdays = copyInts(r.Weekdays)
+	if r.LastRunAt != nil {
+		lastRunAt := *r.LastRunAt
+		r.LastRunAt = &lastRunAt
+	}
+	if r.DisabledAt != nil {
+		disabledAt := *r.DisabledAt
+		r.DisabledAt = &disabledAt
+	}
+	return &r
+}
+
+// copyInts 는 INTEGER[] 컬럼을 읽은 것처럼 빈 배열이면 nil 을 반환합니다.
+func copyInts(values []int) []int {
+	if len(values) == 0 {
+		return nil
+	}
+	return append([]int(nil), values...)
+}
@@ -0,0 +1,81 @@
+package persistence
+
+import (
+	"fmt"
+	"sort"
+	"workoutstudy_chatting/model"
+)
+
+type MemoryRetentionRepository struct {
+	store *MemoryStore
+}
+
+var _ RetentionRepository = (*MemoryRetentionRepository)(nil)
+
+func NewMemoryRetentionRepository(store *MemoryStore) RetentionRepository {
+	return &MemoryRetentionRepository{store: store}
+}
+
+func (repo *MemoryRetentionRepository) GetRetentionPolicies() ([]model.RetentionPolicy, error) {
+	s := repo.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var policies []model.RetentionPolicy
+	for _, p := range s.retentionPolicies {
+		policies = append(policies, p)
+	}
+	sort.Slice(policies, func(i, j int) bool { return policies[i].FitGroupID < policies[j].FitGroupID })
+	return policies, nil
+}
+
+// GetRetentionPolicy 는 fit group 별 정책이 없으면 nil, nil 을 반환합니다.
+func (repo *MemoryRetentionRepository) GetRetentionPolicy(fitGroupID int) (*model.RetentionPolicy, error) {
+	s := repo.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	p, ok := s.retentionPolicies[fitGroupID]
+	if !ok {
+		return nil, nil
+	}
+	return &p, nil
+}
+
+func (repo *MemoryRetentionRepository) SaveRetentionPolicy(policy *model.RetentionPolicy) (*model.RetentionPolicy, error) {
+	if policy.RetentionDays < 0 {
+		return nil, fmt.Errorf("error saving retention policy: new row for relation %q violates check constraint %q", "message_retention_policy", "message_retention_policy_retention_days_check")
+	}
+
+	s := repo.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.fitGroups[policy.FitGroupID]; !ok {
+		return nil, fmt.Errorf("error saving retention policy: %w", errForeignKey("message_retention_policy", "message_retention_policy_fit_group_id_fkey"))
+	}
+
+	now := memoryNow()
+	stored, ok := s.retentionPolicies[policy.FitGroupID]
+	if !ok {
+		stored = model.RetentionPolicy{FitGroupID: policy.FitGroupID, CreatedAt: now, CreatedBy: policy.UpdatedBy}
+	}
+	stored.RetentionDays = policy.RetentionDays
+	stored.UpdatedAt = now
+	stored.UpdatedBy = policy.UpdatedBy
+	s.retentionPolicies[policy.FitGroupID] = stored
+
+	policy.CreatedAt = stored.CreatedAt
+	policy.CreatedBy = stored.CreatedBy
+	policy.UpdatedAt = stored.UpdatedAt
+	return policy, nil
+}
+
+func (repo *MemoryRetentionRepository) DeleteRetentionPolicy(fitGroupID int) error {
+	s := repo.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.retentionPolicies, fitGroupID)
+	return nil
+}
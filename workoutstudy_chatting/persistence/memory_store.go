@@ -0,0 +1,348 @@
+package persistence
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"workoutstudy_chatting/model"
+)
+
+/*
+MemoryStore 는 DB 없이 서비스를 실행하기 위한 메모리 저장소입니다. (database.driver: memory)
+모든 메모리 repository 가 하나의 MemoryStore 를 공유해 테이블 간 조회(JOIN)와 외래 키 제약,
+ON DELETE CASCADE 를 Postgres 스키마와 같게 처리합니다. 프로세스가 끝나면 데이터는 사라집니다.
+*/
+type MemoryStore struct {
+	mu sync.RWMutex
+
+	users         map[int]model.User
+	fitGroups     map[int]model.FitGroup
+	fitMates      map[int]model.FitMate
+	fitGroupMates map[fitGroupMateKey]struct{}
+	messages      map[string]*memoryMessage
+
+	retentionPolicies    map[int]model.RetentionPolicy
+	moderationItems      map[int]model.ModerationItem
+	restrictions         map[int]model.ChatRestriction
+	auditLogs            []model.ModerationAuditLog
+	pins                 map[pinKey]model.PinnedMessage
+	polls                map[int]*memoryPoll
+	conversations        map[int]model.DirectConversation
+	directMessages       map[string]*memoryDirectMessage
+	readStates           map[readStateKey]time.Time
+	roomSettings         map[int]model.ChatRoomSetting
+	reminders            map[int]model.Reminder
+	processedEvents      map[processedEventKey]time.Time // 처리 시간
+	pendingFitMateEvents map[int]memoryPendingFitMateEvent
+
+	sequences map[string]int // SERIAL 컬럼 값
+}
+
+type fitGroupMateKey struct{ fitGroupID, fitMateID int }
+
+type pinKey struct {
+	fitGroupID int
+	messageID  string
+}
+
+type readStateKey struct{ conversationID, userID int }
+
+type processedEventKey struct{ topic, eventKey string }
+
+// memoryPendingFitMateEvent 는 pending_fit_mate_event 테이블의 행입니다. payload 는 GetFitMatesApiResponse JSON 입니다.
+type memoryPendingFitMateEvent struct {
+	payload    string
+	receivedAt time.Time
+	expiresAt  time.Time
+}
+
+// memoryMessage 는 message 테이블의 행입니다. ChatMessage 에 없는 서버 기록 컬럼을 함께 둡니다.
+type memoryMessage struct {
+	model.ChatMessage
+	CreatedAt  time.Time
+	DeletedBy  string
+	RestoredAt *time.Time // 아카이브에서 복원한 메시지일 경우 복원 시간
+}
+
+type memoryDirectMessage struct {
+	model.DirectMessage
+	CreatedAt time.Time
+}
+
+type memoryPoll struct {
+	poll    model.ChatPoll // Options 는 선택지 정의만 담고 집계는 조회 시 계산
+	options []memoryPollOption
+	votes   []memoryPollVote // 투표 순서 유지
+}
+
+type memoryPollOption struct {
+	id   int
+	text string
+}
+
+type memoryPollVote struct {
+	optionID int
+	userID   int
+	votedAt  time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	s := &MemoryStore{
+		users:                make(map[int]model.User),
+		fitGroups:            make(map[int]model.FitGroup),
+		fitMates:             make(map[int]model.FitMate),
+		fitGroupMates:        make(map[fitGroupMateKey]struct{}),
+		messages:             make(map[string]*memoryMessage),
+		retentionPolicies:    make(map[int]model.RetentionPolicy),
+		moderationItems:      make(map[int]model.ModerationItem),
+		restrictions:         make(map[int]model.ChatRestriction),
+		pins:                 make(map[pinKey]model.PinnedMessage),
+		polls:                make(map[int]*memoryPoll),
+		conversations:        make(map[int]model.DirectConversation),
+		directMessages:       make(map[string]*memoryDirectMessage),
+		readStates:           make(map[readStateKey]time.Time),
+		roomSettings:         make(map[int]model.ChatRoomSetting),
+		reminders:            make(map[int]model.Reminder),
+		processedEvents:      make(map[processedEventKey]time.Time),
+		pendingFitMateEvents: make(map[int]memoryPendingFitMateEvent),
+		sequences:            make(map[string]int),
+	}
+	// 마이그레이션 0009 에서 추가하는 핏봇 사용자
+	now := memoryNow()
+	s.users[model.SystemBotUserID] = model.User{ID: model.SystemBotUserID, Nickname: "핏봇", CreatedAt: now, UpdatedAt: now}
+	return s
+}
+
+// MemorySeed 는 로컬 개발용 초기 데이터입니다. Kafka 없이도 채팅방을 열 수 있도록 사용자, fit group, fit mate 를 넣습니다.
+type MemorySeed struct {
+	Users     []model.User     `json:"users"`
+	FitGroups []model.FitGroup `json:"fitGroups"`
+	FitMates  []model.FitMate  `json:"fitMates"`
+}
+
+// LoadMemorySeed 는 JSON 파일에서 초기 데이터를 읽습니다.
+func LoadMemorySeed(path string) (MemorySeed, error) {
+	var seed MemorySeed
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return seed, fmt.Errorf("read seed file: %w", err)
+	}
+	if err := json.Unmarshal(data, &seed); err != nil {
+		return seed, fmt.Errorf("parse seed file %s: %w", path, err)
+	}
+	return seed, nil
+}
+
+// Seed 는 초기 데이터를 repository 와 같은 제약으로 저장합니다. 이미 있는 ID 는 덮어씁니다.
+func (s *MemoryStore) Seed(seed MemorySeed) error {
+	users := NewMemoryUserRepository(s)
+	fitGroups := NewMemoryFitGroupRepository(s)
+	fitMates := NewMemoryFitMateRepository(s)
+	now := memoryNow()
+	for i := range seed.Users {
+		// 사용자 생성 이벤트와 달리 시간이 없으면 현재 시간으로 채움
+		if seed.Users[i].CreatedAt.IsZero() {
+			seed.Users[i].CreatedAt = now
+		}
+		if seed.Users[i].UpdatedAt.IsZero() {
+			seed.Users[i].UpdatedAt = now
+		}
+		if _, err := users.SaveUser(&seed.Users[i]); err != nil {
+			return err
+		}
+	}
+	for i := range seed.FitGroups {
+		if _, err := fitGroups.SaveFitGroup(&seed.FitGroups[i]); err != nil {
+			return err
+		}
+	}
+	for i := range seed.FitMates {
+		if _, err := fitMates.SaveFitMate(&seed.FitMates[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// nextID 는 SERIAL 컬럼처럼 테이블별로 1부터 증가하는 ID 를 반환합니다. s.mu 를 잡은 상태에서 호출합니다.
+func (s *MemoryStore) nextID(table string) int {
+	s.sequences[table]++
+	return s.sequences[table]
+}
+
+// memoryNow 는 NOW() 와 같이 TIMESTAMP(6) 정밀도의 현재 시간을 반환합니다.
+func memoryNow() time.Time {
+	return time.Now().Round(time.Microsecond)
+}
+
+var uuidPattern = regexp.MustCompile(`^\{?([0-9a-fA-F]{8})-?([0-9a-fA-F]{4})-?([0-9a-fA-F]{4})-?([0-9a-fA-F]{4})-?([0-9a-fA-F]{12})\}?$`)
+
+// canonicalUUID 는 UUID 컬럼처럼 값을 소문자, 하이픈 형식으로 바꿉니다. UUID 가 아니면 Postgres 와 같은 오류를 반환합니다.
+func canonicalUUID(value string) (string, error) {
+	m := uuidPattern.FindStringSubmatch(value)
+	if m == nil {
+		return "", fmt.Errorf("invalid input syntax for type uuid: %q", value)
+	}
+	return strings.ToLower(strings.Join(m[1:], "-")), nil
+}
+
+func errDuplicateKey(constraint string) error {
+	return fmt.Errorf("duplicate key value violates unique constraint %q", constraint)
+}
+
+func errForeignKey(table, constraint string) error {
+	return fmt.Errorf("insert or update on table %q violates foreign key constraint %q", table, constraint)
+}
+
+func errReferenced(table, constraint, referencing string) error {
+	return fmt.Errorf("update or delete on table %q violates foreign key constraint %q on table %q", table, constraint, referencing)
+}
+
+// deleteMessageLocked 는 메시지와 ON DELETE CASCADE 로 연결된 행을 지웁니다.
+func (s *MemoryStore) deleteMessageLocked(messageID string) {
+	delete(s.messages, messageID)
+	for key := range s.pins {
+		if key.messageID == messageID {
+			delete(s.pins, key)
+		}
+	}
+	for id, item := range s.moderationItems {
+		if item.MessageID == messageID {
+			delete(s.moderationItems, id)
+		}
+	}
+	for id, p := range s.polls {
+		if p.poll.MessageID == messageID {
+			delete(s.polls, id)
+		}
+	}
+}
+
+// fitGroupReferencedLocked 는 ON DELETE CASCADE 가 아닌 외래 키로 fit group 을 참조하는 행이 있으면 오류를 반환합니다.
+func (s *MemoryStore) fitGroupReferencedLocked(fitGroupID int) error {
+	for _, fm := range s.fitMates {
+		if fm.FitGroupID == fitGroupID {
+			return errReferenced("fit_group", "fit_mate_fit_group_id_fkey", "fit_mate")
+		}
+	}
+	for _, m := range s.messages {
+		if m.FitGroupID == fitGroupID {
+			return errReferenced("fit_group", "message_fit_group_id_fkey", "message")
+		}
+	}
+	return nil
+}
+
+// deleteFitGroupLocked 는 fit group 과 ON DELETE CASCADE 로 연결된 행을 지웁니다.
+func (s *MemoryStore) deleteFitGroupLocked(fitGroupID int) {
+	delete(s.fitGroups, fitGroupID)
+	delete(s.retentionPolicies, fitGroupID)
+	delete(s.roomSettings, fitGroupID)
+	for key := range s.fitGroupMates {
+		if key.fitGroupID == fitGroupID {
+			delete(s.fitGroupMates, key)
+		}
+	}
+	for id, item := range s.moderationItems {
+		if item.FitGroupID == fitGroupID {
+			delete(s.moderationItems, id)
+		}
+	}
+	for id, r := range s.restrictions {
+		if r.FitGroupID == fitGroupID {
+			delete(s.restrictions, id)
+		}
+	}
+	for key := range s.pins {
+		if key.fitGroupID == fitGroupID {
+			delete(s.pins, key)
+		}
+	}
+	for id, p := range s.polls {
+		if p.poll.FitGroupID == fitGroupID {
+			delete(s.polls, id)
+		}
+	}
+	for id, r := range s.reminders {
+		if r.FitGroupID == fitGroupID {
+			delete(s.reminders, id)
+		}
+	}
+}
+
+// userReferencedLocked 는 사용자를 참조하는 행이 있으면 오류를 반환합니다. "user" 를 참조하는 외래 키는 모두 CASCADE 가 아닙니다.
+func (s *MemoryStore) userReferencedLocked(userID int) error {
+	for _, fg := range s.fitGroups {
+		if fg.FitLeaderUserID == userID {
+			return errReferenced("user", "fit_group_fit_leader_user_id_fkey", "fit_group")
+		}
+	}
+	for _, fm := range s.fitMates {
+		if fm.UserID == userID {
+			return errReferenced("user", "fit_mate_user_id_fkey", "fit_mate")
+		}
+	}
+	for _, m := range s.messages {
+		if m.UserID == userID {
+			return errReferenced("user", "message_user_id_fkey", "message")
+		}
+	}
+	for _, c := range s.conversations {
+		if c.UserAID == userID || c.UserBID == userID {
+			return errReferenced("user", "direct_conversation_user_a_id_fkey", "direct_conversation")
+		}
+	}
+	for _, dm := range s.directMessages {
+		if dm.SenderUserID == userID {
+			return errReferenced("user", "direct_message_sender_user_id_fkey", "direct_message")
+		}
+	}
+	return nil
+}
+
+// sortedMessagesLocked 는 조건에 맞는 메시지를 message_time 순으로 정렬해 반환합니다. 시간이 같으면 message_id 순입니다.
+func (s *MemoryStore) sortedMessagesLocked(match func(m *memoryMessage) bool, desc bool) []*memoryMessage {
+	var rows []*memoryMessage
+	for _, m := range s.messages {
+		if match(m) {
+			rows = append(rows, m)
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		a, b := rows[i], rows[j]
+		if !a.MessageTime.Equal(b.MessageTime) {
+			if desc {
+				return a.MessageTime.After(b.MessageTime)
+			}
+			return a.MessageTime.Before(b.MessageTime)
+		}
+		return a.ID < b.ID
+	})
+	return rows
+}
+
+// NewMemoryRepositories 는 하나의 MemoryStore 를 공유하는 repository 묶음을 반환합니다.
+func NewMemoryRepositories(store *MemoryStore) Repositories {
+	return Repositories{
+		Chat:                NewMemoryChatRepository(store),
+		FitGroup:            NewMemoryFitGroupRepository(store),
+		FitMate:             NewMemoryFitMateRepository(store),
+		User:                NewMemoryUserRepository(store),
+		ChatRestriction:     NewMemoryChatRestrictionRepository(store),
+		ChatRoomSetting:     NewMemoryChatRoomSettingRepository(store),
+		DirectMessage:       NewMemoryDirectMessageRepository(store),
+		Moderation:          NewMemoryModerationRepository(store),
+		ModerationAudit:     NewMemoryModerationAuditRepository(store),
+		PinnedMessage:       NewMemoryPinnedMessageRepository(store),
+		Poll:                NewMemoryPollRepository(store),
+		Reminder:            NewMemoryReminderRepository(store),
+		Retention:           NewMemoryRetentionRepository(store),
+		ProcessedEvent:      NewMemoryProcessedEventRepository(store),
+		PendingFitMateEvent: NewMemoryPendingFitMateEventRepository(store),
+	}
+}
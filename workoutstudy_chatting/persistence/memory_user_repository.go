@@ -0,0 +1,112 @@
+package persistence
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+	"unicode/utf8"
+	"workoutstudy_chatting/model"
+)
+
+type MemoryUserRepository struct {
+	store *MemoryStore
+}
+
+var _ UserRepository = (*MemoryUserRepository)(nil)
+
+func NewMemoryUserRepository(store *MemoryStore) UserRepository {
+	return &MemoryUserRepository{store: store}
+}
+
+// nicknameMaxLength 는 "user".nickname 컬럼의 VARCHAR 길이입니다.
+const nicknameMaxLength = 10
+
+func (repo *MemoryUserRepository) SaveUser(user *model.User) (*model.User, error) {
+	if err := checkNickname(user.Nickname); err != nil {
+		return nil, fmt.Errorf("error saving user: %w", err)
+	}
+
+	s := repo.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := *user
+	stored.CreatedAt = user.CreatedAt.Round(time.Microsecond)
+	stored.UpdatedAt = user.UpdatedAt.Round(time.Microsecond)
+	// 이미 있으면 생성 정보는 유지 (ON CONFLICT DO UPDATE)
+	if existing, exists := s.users[user.ID]; exists {
+		stored.CreatedAt = existing.CreatedAt
+		stored.CreatedBy = existing.CreatedBy
+	}
+	s.users[user.ID] = stored
+	return user, nil
+}
+
+func (repo *MemoryUserRepository) UpdateUser(user *model.User) (*model.User, error) {
+	if err := checkNickname(user.Nickname); err != nil {
+		return nil, fmt.Errorf("error updating user: %w", err)
+	}
+
+	s := repo.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.users[user.ID]
+	if !ok {
+		return nil, fmt.Errorf("error updating user: %w", sql.ErrNoRows)
+	}
+	stored.Nickname = user.Nickname
+	stored.State = user.State
+	stored.UpdatedAt = user.UpdatedAt.Round(time.Microsecond)
+	stored.UpdatedBy = user.UpdatedBy
+	s.users[user.ID] = stored
+	return user, nil
+}
+
+func (repo *MemoryUserRepository) DeleteUser(userID int) error {
+	s := repo.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.users[userID]; !ok {
+		return nil
+	}
+	if err := s.userReferencedLocked(userID); err != nil {
+		return fmt.Errorf("error deleting user: %w", err)
+	}
+	delete(s.users, userID)
+	return nil
+}
+
+func (repo *MemoryUserRepository) GetUserByID(userID int) (*model.User, error) {
+	s := repo.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	user, ok := s.users[userID]
+	if !ok {
+		return nil, fmt.Errorf("no user found for ID: %d", userID)
+	}
+	return &user, nil
+}
+
+func (repo *MemoryUserRepository) GetUserIDs() ([]int, error) {
+	s := repo.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	userIDs := make([]int, 0, len(s.users))
+	for id := range s.users {
+		userIDs = append(userIDs, id)
+	}
+	sort.Ints(userIDs)
+	return userIDs, nil
+}
+
+func checkNickname(nickname string) error {
+	if utf8.RuneCountInString(nickname) > nicknameMaxLength {
+		return fmt.Errorf("value too long for type character varying(%d)", nicknameMaxLength)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+-- 기존 배포 DB 에도 그대로 적용될 수 있도록 0001~0009 는 IF NOT EXISTS 로 작성합니다.
+CREATE TABLE IF NOT EXISTS "user" (
+	id INTEGER PRIMARY KEY,
+	nickname VARCHAR(10) NOT NULL,
+	state BOOLEAN DEFAULT false NOT NULL,
+	created_at TIMESTAMP(6) WITH TIME ZONE NOT NULL,
+	updated_at TIMESTAMP(6) WITH TIME ZONE NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS fit_group (
+	id INTEGER PRIMARY KEY,
+	fit_leader_user_id INTEGER REFERENCES "user"(id) NOT NULL,
+	fit_group_name VARCHAR(30),
+	category INTEGER NOT NULL,
+	cycle INTEGER NOT NULL,
+	frequency INTEGER NOT NULL,
+	present_fit_mate_count INTEGER NOT NULL,
+	max_fit_mate INTEGER NOT NULL,
+	state BOOLEAN DEFAULT false NOT NULL,
+	created_at TIMESTAMP(6) WITH TIME ZONE NOT NULL,
+	created_by VARCHAR(30),
+	updated_at TIMESTAMP(6) WITH TIME ZONE NOT NULL,
+	updated_by VARCHAR(30)
+);
+
+CREATE TABLE IF NOT EXISTS fit_mate (
+	id INTEGER PRIMARY KEY,
+	user_id INTEGER REFERENCES "user"(id) NOT NULL,
+	fit_group_id INTEGER REFERENCES fit_group(id) NOT NULL,
+	state BOOLEAN DEFAULT false NOT NULL,
+	created_at TIMESTAMP(6) WITH TIME ZONE NOT NULL,
+	created_by VARCHAR(30),
+	updated_at TIMESTAMP(6) WITH TIME ZONE NOT NULL,
+	updated_by VARCHAR(30)
+);
+
+CREATE TABLE IF NOT EXISTS message (
+	message_id UUID PRIMARY KEY,
+	user_id INTEGER REFERENCES "user"(id) NOT NULL,
+	fit_group_id INTEGER REFERENCES fit_group(id) NOT NULL,
+	message TEXT NOT NULL,
+	message_time TIMESTAMP(6),
+	message_type VARCHAR(8) CHECK (message_type IN ('CHATTING', 'TICKET')),
+	created_at TIMESTAMP(6) WITH TIME ZONE NOT NULL,
+	created_by VARCHAR(30),
+	updated_at TIMESTAMP(6) WITH TIME ZONE NOT NULL,
+	updated_by VARCHAR(30)
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_message_fit_group_time;
+DROP TABLE IF EXISTS message_retention_policy;
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS message_retention_policy (
+	fit_group_id INTEGER PRIMARY KEY REFERENCES fit_group(id) ON DELETE CASCADE,
+	retention_days INTEGER NOT NULL CHECK (retention_days >= 0),
+	created_at TIMESTAMP(6) WITH TIME ZONE NOT NULL,
+	created_by VARCHAR(30),
+	updated_at TIMESTAMP(6) WITH TIME ZONE NOT NULL,
+	updated_by VARCHAR(30)
+);
+
+CREATE INDEX IF NOT EXISTS idx_message_fit_group_time ON message (fit_group_id, message_time);
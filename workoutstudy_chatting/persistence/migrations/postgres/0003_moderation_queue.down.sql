@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS moderation_queue;
+ALTER TABLE message DROP COLUMN IF EXISTS deleted_by;
+ALTER TABLE message DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,17 @@
+ALTER TABLE message ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP(6) WITH TIME ZONE;
+ALTER TABLE message ADD COLUMN IF NOT EXISTS deleted_by VARCHAR(30);
+
+CREATE TABLE IF NOT EXISTS moderation_queue (
+	id SERIAL PRIMARY KEY,
+	message_id UUID REFERENCES message(message_id) ON DELETE CASCADE NOT NULL,
+	fit_group_id INTEGER REFERENCES fit_group(id) ON DELETE CASCADE NOT NULL,
+	user_id INTEGER NOT NULL,
+	message TEXT NOT NULL,
+	reasons TEXT NOT NULL,
+	status VARCHAR(10) DEFAULT 'PENDING' NOT NULL CHECK (status IN ('PENDING', 'APPROVED', 'REMOVED')),
+	created_at TIMESTAMP(6) WITH TIME ZONE NOT NULL,
+	reviewed_at TIMESTAMP(6) WITH TIME ZONE,
+	reviewed_by VARCHAR(30)
+);
+
+CREATE INDEX IF NOT EXISTS idx_moderation_queue_fit_group_status ON moderation_queue (fit_group_id, status);
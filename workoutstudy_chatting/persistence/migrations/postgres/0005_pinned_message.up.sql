@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS pinned_message (
+	fit_group_id INTEGER REFERENCES fit_group(id) ON DELETE CASCADE NOT NULL,
+	message_id UUID REFERENCES message(message_id) ON DELETE CASCADE NOT NULL,
+	pinned_by INTEGER NOT NULL,
+	pinned_at TIMESTAMP(6) WITH TIME ZONE NOT NULL,
+	PRIMARY KEY (fit_group_id, message_id)
+);
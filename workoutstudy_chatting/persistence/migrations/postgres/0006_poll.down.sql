@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS poll_vote;
+DROP TABLE IF EXISTS poll_option;
+DROP TABLE IF EXISTS poll;
+
+-- 이미 저장된 공지/투표/시스템 메시지는 지우지 않으므로 컬럼 길이는 유지하고, 새로 저장되는 행에만 제약을 적용합니다.
+ALTER TABLE message DROP CONSTRAINT IF EXISTS message_message_type_check;
+ALTER TABLE message ADD CONSTRAINT message_message_type_check CHECK (message_type IN ('CHATTING', 'TICKET')) NOT VALID;
@@ -0,0 +1,34 @@
+-- 공지(ANNOUNCEMENT), 투표(POLL), 시스템(SYSTEM) 메시지 타입 추가
+ALTER TABLE message ALTER COLUMN message_type TYPE VARCHAR(20);
+ALTER TABLE message DROP CONSTRAINT IF EXISTS message_message_type_check;
+ALTER TABLE message ADD CONSTRAINT message_message_type_check CHECK (message_type IN ('CHATTING', 'TICKET', 'ANNOUNCEMENT', 'POLL', 'SYSTEM'));
+
+CREATE TABLE IF NOT EXISTS poll (
+	id SERIAL PRIMARY KEY,
+	message_id UUID UNIQUE REFERENCES message(message_id) ON DELETE CASCADE NOT NULL,
+	fit_group_id INTEGER REFERENCES fit_group(id) ON DELETE CASCADE NOT NULL,
+	created_by INTEGER NOT NULL,
+	question TEXT NOT NULL,
+	multiple_choice BOOLEAN DEFAULT false NOT NULL,
+	anonymous BOOLEAN DEFAULT false NOT NULL,
+	deadline TIMESTAMP(6) WITH TIME ZONE,
+	closed_at TIMESTAMP(6) WITH TIME ZONE,
+	created_at TIMESTAMP(6) WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_poll_open_deadline ON poll (deadline) WHERE closed_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS poll_option (
+	id SERIAL PRIMARY KEY,
+	poll_id INTEGER REFERENCES poll(id) ON DELETE CASCADE NOT NULL,
+	position INTEGER NOT NULL,
+	text VARCHAR(100) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS poll_vote (
+	poll_id INTEGER REFERENCES poll(id) ON DELETE CASCADE NOT NULL,
+	option_id INTEGER REFERENCES poll_option(id) ON DELETE CASCADE NOT NULL,
+	user_id INTEGER NOT NULL,
+	voted_at TIMESTAMP(6) WITH TIME ZONE NOT NULL,
+	PRIMARY KEY (poll_id, option_id, user_id)
+);
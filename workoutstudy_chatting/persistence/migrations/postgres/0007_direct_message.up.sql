@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS direct_conversation (
+	id SERIAL PRIMARY KEY,
+	user_a_id INTEGER REFERENCES "user"(id) NOT NULL,
+	user_b_id INTEGER REFERENCES "user"(id) NOT NULL,
+	created_at TIMESTAMP(6) WITH TIME ZONE NOT NULL,
+	last_message_at TIMESTAMP(6) WITH TIME ZONE,
+	UNIQUE (user_a_id, user_b_id),
+	CHECK (user_a_id < user_b_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_direct_conversation_user_b ON direct_conversation (user_b_id);
+
+CREATE TABLE IF NOT EXISTS direct_message (
+	message_id UUID PRIMARY KEY,
+	conversation_id INTEGER REFERENCES direct_conversation(id) ON DELETE CASCADE NOT NULL,
+	sender_user_id INTEGER REFERENCES "user"(id) NOT NULL,
+	message TEXT NOT NULL,
+	message_time TIMESTAMP(6) NOT NULL,
+	created_at TIMESTAMP(6) WITH TIME ZONE NOT NULL,
+	deleted_at TIMESTAMP(6) WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_direct_message_conversation_time ON direct_message (conversation_id, message_time);
+
+CREATE TABLE IF NOT EXISTS direct_read_state (
+	conversation_id INTEGER REFERENCES direct_conversation(id) ON DELETE CASCADE NOT NULL,
+	user_id INTEGER NOT NULL,
+	last_read_at TIMESTAMP(6) WITH TIME ZONE NOT NULL,
+	PRIMARY KEY (conversation_id, user_id)
+);
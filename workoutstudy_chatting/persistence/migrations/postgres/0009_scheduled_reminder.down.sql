@@ -0,0 +1,5 @@
+-- 핏봇이 보낸 메시지가 남아 있으면 핏봇 사용자는 지우지 않습니다.
+DELETE FROM "user" WHERE id = 0 AND NOT EXISTS (SELECT 1 FROM message WHERE user_id = 0);
+
+DROP TABLE IF EXISTS scheduled_reminder;
+ALTER TABLE chat_room_setting DROP COLUMN IF EXISTS time_zone;
@@ -0,0 +1,20 @@
+ALTER TABLE chat_room_setting ADD COLUMN IF NOT EXISTS time_zone VARCHAR(64) DEFAULT 'Asia/Seoul' NOT NULL;
+
+CREATE TABLE IF NOT EXISTS scheduled_reminder (
+	id SERIAL PRIMARY KEY,
+	fit_group_id INTEGER REFERENCES fit_group(id) ON DELETE CASCADE NOT NULL,
+	recurrence VARCHAR(20) NOT NULL CHECK (recurrence IN ('DAILY', 'WEEKLY', 'CYCLE_DEADLINE')),
+	message TEXT DEFAULT '' NOT NULL,
+	time_of_day VARCHAR(5) NOT NULL,
+	weekdays INTEGER[] DEFAULT '{}' NOT NULL,
+	days_before INTEGER DEFAULT 0 NOT NULL,
+	next_run_at TIMESTAMP(6) WITH TIME ZONE NOT NULL,
+	last_run_at TIMESTAMP(6) WITH TIME ZONE,
+	created_by INTEGER NOT NULL,
+	created_at TIMESTAMP(6) WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_scheduled_reminder_next_run ON scheduled_reminder (next_run_at);
+
+-- 리마인더, 투표 결과 등 시스템 메시지를 보내는 핏봇 사용자
+INSERT INTO "user" (id, nickname, state, created_at, updated_at) VALUES (0, '핏봇', false, NOW(), NOW()) ON CONFLICT (id) DO NOTHING;
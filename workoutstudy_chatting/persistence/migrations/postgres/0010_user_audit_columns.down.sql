@@ -0,0 +1,2 @@
+ALTER TABLE "user" DROP COLUMN IF EXISTS updated_by;
+ALTER TABLE "user" DROP COLUMN IF EXISTS created_by;
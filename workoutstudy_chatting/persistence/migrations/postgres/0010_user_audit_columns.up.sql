@@ -0,0 +1,3 @@
+-- UserService 가 채우는 created_by/updated_by 를 저장합니다.
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS created_by VARCHAR(30);
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS updated_by VARCHAR(30);
@@ -0,0 +1,10 @@
+-- fit group 보다 먼저 도착한 fit mate 이벤트. fit group 이 생성되면 다시 처리하고, expires_at 이 지나면 버립니다.
+-- 이벤트가 fit mate 전체 목록을 담으므로 fit group 별로 가장 최근 이벤트만 보관합니다. (payload 는 GetFitMatesApiResponse JSON)
+CREATE TABLE IF NOT EXISTS pending_fit_mate_event (
+	fit_group_id INTEGER PRIMARY KEY,
+	payload TEXT NOT NULL,
+	received_at TIMESTAMP(6) WITH TIME ZONE NOT NULL,
+	expires_at TIMESTAMP(6) WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_pending_fit_mate_event_expires_at ON pending_fit_mate_event (expires_at);
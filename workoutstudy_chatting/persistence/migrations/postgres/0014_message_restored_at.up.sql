@@ -0,0 +1,2 @@
+-- 아카이브에서 복원한 메시지의 복원 시간. 복원한 메시지는 보관 기간이 지나도 다시 아카이브하지 않습니다.
+ALTER TABLE message ADD COLUMN IF NOT EXISTS restored_at TIMESTAMP(6) WITH TIME ZONE;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS message;
+DROP TABLE IF EXISTS fit_mate;
+DROP TABLE IF EXISTS fit_group;
+DROP TABLE IF EXISTS "user";
@@ -0,0 +1,64 @@
+-- migrations/postgres 와 같은 버전, 같은 스키마를 SQLite 문법으로 작성합니다.
+-- - 시간 컬럼은 TIMESTAMP 로 선언해야 time.Time 으로 읽힙니다. 값은 UTC 문자열로 저장됩니다. (persistence.Dialect 참고)
+-- - UUID, 배열 컬럼은 TEXT 로 저장합니다. UUID 는 쿼리의 uuid() 함수로 정규화합니다.
+-- - VARCHAR 길이는 검사하지 않습니다.
+-- - SQLite 는 CHECK 제약을 변경할 수 없으므로, 이후 마이그레이션에서 바뀌는 message_type 검사는 트리거로 구현합니다.
+CREATE TABLE "user" (
+	id INTEGER PRIMARY KEY,
+	nickname VARCHAR(10) NOT NULL,
+	state BOOLEAN DEFAULT false NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE fit_group (
+	id INTEGER PRIMARY KEY,
+	fit_leader_user_id INTEGER REFERENCES "user"(id) NOT NULL,
+	fit_group_name VARCHAR(30),
+	category INTEGER NOT NULL,
+	cycle INTEGER NOT NULL,
+	frequency INTEGER NOT NULL,
+	present_fit_mate_count INTEGER NOT NULL,
+	max_fit_mate INTEGER NOT NULL,
+	state BOOLEAN DEFAULT false NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	created_by VARCHAR(30),
+	updated_at TIMESTAMP NOT NULL,
+	updated_by VARCHAR(30)
+);
+
+CREATE TABLE fit_mate (
+	id INTEGER PRIMARY KEY,
+	user_id INTEGER REFERENCES "user"(id) NOT NULL,
+	fit_group_id INTEGER REFERENCES fit_group(id) NOT NULL,
+	state BOOLEAN DEFAULT false NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	created_by VARCHAR(30),
+	updated_at TIMESTAMP NOT NULL,
+	updated_by VARCHAR(30)
+);
+
+CREATE TABLE message (
+	message_id TEXT PRIMARY KEY NOT NULL,
+	user_id INTEGER REFERENCES "user"(id) NOT NULL,
+	fit_group_id INTEGER REFERENCES fit_group(id) NOT NULL,
+	message TEXT NOT NULL,
+	message_time TIMESTAMP,
+	message_type VARCHAR(8),
+	created_at TIMESTAMP NOT NULL,
+	created_by VARCHAR(30),
+	updated_at TIMESTAMP NOT NULL,
+	updated_by VARCHAR(30)
+);
+
+CREATE TRIGGER message_message_type_check_insert BEFORE INSERT ON message
+WHEN NEW.message_type NOT IN ('CHATTING', 'TICKET')
+BEGIN
+	SELECT RAISE(ABORT, 'new row for relation "message" violates check constraint "message_message_type_check"');
+END;
+
+CREATE TRIGGER message_message_type_check_update BEFORE UPDATE OF message_type ON message
+WHEN NEW.message_type NOT IN ('CHATTING', 'TICKET')
+BEGIN
+	SELECT RAISE(ABORT, 'new row for relation "message" violates check constraint "message_message_type_check"');
+END;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS moderation_queue;
+ALTER TABLE message DROP COLUMN deleted_by;
+ALTER TABLE message DROP COLUMN deleted_at;
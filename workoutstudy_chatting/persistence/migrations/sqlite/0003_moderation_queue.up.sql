@@ -0,0 +1,17 @@
+ALTER TABLE message ADD COLUMN deleted_at TIMESTAMP;
+ALTER TABLE message ADD COLUMN deleted_by VARCHAR(30);
+
+CREATE TABLE moderation_queue (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	message_id TEXT REFERENCES message(message_id) ON DELETE CASCADE NOT NULL,
+	fit_group_id INTEGER REFERENCES fit_group(id) ON DELETE CASCADE NOT NULL,
+	user_id INTEGER NOT NULL,
+	message TEXT NOT NULL,
+	reasons TEXT NOT NULL,
+	status VARCHAR(10) DEFAULT 'PENDING' NOT NULL CHECK (status IN ('PENDING', 'APPROVED', 'REMOVED')),
+	created_at TIMESTAMP NOT NULL,
+	reviewed_at TIMESTAMP,
+	reviewed_by VARCHAR(30)
+);
+
+CREATE INDEX idx_moderation_queue_fit_group_status ON moderation_queue (fit_group_id, status);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS moderation_audit;
+DROP TABLE IF EXISTS chat_restriction;
@@ -0,0 +1,27 @@
+CREATE TABLE chat_restriction (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	fit_group_id INTEGER REFERENCES fit_group(id) ON DELETE CASCADE NOT NULL,
+	user_id INTEGER NOT NULL,
+	type VARCHAR(10) NOT NULL CHECK (type IN ('MUTE', 'BAN')),
+	reason TEXT DEFAULT '' NOT NULL,
+	expires_at TIMESTAMP,
+	created_at TIMESTAMP NOT NULL,
+	created_by VARCHAR(30),
+	revoked_at TIMESTAMP,
+	revoked_by VARCHAR(30)
+);
+
+CREATE INDEX idx_chat_restriction_active ON chat_restriction (fit_group_id, user_id) WHERE revoked_at IS NULL;
+
+CREATE TABLE moderation_audit (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	fit_group_id INTEGER NOT NULL,
+	actor_user_id INTEGER NOT NULL,
+	action VARCHAR(20) NOT NULL,
+	target_user_id INTEGER,
+	target_message_id TEXT,
+	detail TEXT DEFAULT '' NOT NULL,
+	created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_moderation_audit_fit_group ON moderation_audit (fit_group_id, created_at);
@@ -0,0 +1,7 @@
+CREATE TABLE pinned_message (
+	fit_group_id INTEGER REFERENCES fit_group(id) ON DELETE CASCADE NOT NULL,
+	message_id TEXT REFERENCES message(message_id) ON DELETE CASCADE NOT NULL,
+	pinned_by INTEGER NOT NULL,
+	pinned_at TIMESTAMP NOT NULL,
+	PRIMARY KEY (fit_group_id, message_id)
+);
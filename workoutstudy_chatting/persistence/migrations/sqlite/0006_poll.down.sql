@@ -0,0 +1,19 @@
+DROP TABLE IF EXISTS poll_vote;
+DROP TABLE IF EXISTS poll_option;
+DROP TABLE IF EXISTS poll;
+
+-- 트리거는 새로 저장되는 행만 검사하므로 이미 저장된 공지/투표/시스템 메시지는 그대로 남습니다.
+DROP TRIGGER IF EXISTS message_message_type_check_insert;
+DROP TRIGGER IF EXISTS message_message_type_check_update;
+
+CREATE TRIGGER message_message_type_check_insert BEFORE INSERT ON message
+WHEN NEW.message_type NOT IN ('CHATTING', 'TICKET')
+BEGIN
+	SELECT RAISE(ABORT, 'new row for relation "message" violates check constraint "message_message_type_check"');
+END;
+
+CREATE TRIGGER message_message_type_check_update BEFORE UPDATE OF message_type ON message
+WHEN NEW.message_type NOT IN ('CHATTING', 'TICKET')
+BEGIN
+	SELECT RAISE(ABORT, 'new row for relation "message" violates check constraint "message_message_type_check"');
+END;
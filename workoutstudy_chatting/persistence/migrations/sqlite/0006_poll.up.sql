@@ -0,0 +1,45 @@
+-- 공지(ANNOUNCEMENT), 투표(POLL), 시스템(SYSTEM) 메시지 타입 추가
+DROP TRIGGER IF EXISTS message_message_type_check_insert;
+DROP TRIGGER IF EXISTS message_message_type_check_update;
+
+CREATE TRIGGER message_message_type_check_insert BEFORE INSERT ON message
+WHEN NEW.message_type NOT IN ('CHATTING', 'TICKET', 'ANNOUNCEMENT', 'POLL', 'SYSTEM')
+BEGIN
+	SELECT RAISE(ABORT, 'new row for relation "message" violates check constraint "message_message_type_check"');
+END;
+
+CREATE TRIGGER message_message_type_check_update BEFORE UPDATE OF message_type ON message
+WHEN NEW.message_type NOT IN ('CHATTING', 'TICKET', 'ANNOUNCEMENT', 'POLL', 'SYSTEM')
+BEGIN
+	SELECT RAISE(ABORT, 'new row for relation "message" violates check constraint "message_message_type_check"');
+END;
+
+CREATE TABLE poll (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	message_id TEXT UNIQUE REFERENCES message(message_id) ON DELETE CASCADE NOT NULL,
+	fit_group_id INTEGER REFERENCES fit_group(id) ON DELETE CASCADE NOT NULL,
+	created_by INTEGER NOT NULL,
+	question TEXT NOT NULL,
+	multiple_choice BOOLEAN DEFAULT false NOT NULL,
+	anonymous BOOLEAN DEFAULT false NOT NULL,
+	deadline TIMESTAMP,
+	closed_at TIMESTAMP,
+	created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_poll_open_deadline ON poll (deadline) WHERE closed_at IS NULL;
+
+CREATE TABLE poll_option (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	poll_id INTEGER REFERENCES poll(id) ON DELETE CASCADE NOT NULL,
+	position INTEGER NOT NULL,
+	text VARCHAR(100) NOT NULL
+);
+
+CREATE TABLE poll_vote (
+	poll_id INTEGER REFERENCES poll(id) ON DELETE CASCADE NOT NULL,
+	option_id INTEGER REFERENCES poll_option(id) ON DELETE CASCADE NOT NULL,
+	user_id INTEGER NOT NULL,
+	voted_at TIMESTAMP NOT NULL,
+	PRIMARY KEY (poll_id, option_id, user_id)
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS direct_read_state;
+DROP TABLE IF EXISTS direct_message;
+DROP TABLE IF EXISTS direct_conversation;
@@ -0,0 +1,30 @@
+CREATE TABLE direct_conversation (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	user_a_id INTEGER REFERENCES "user"(id) NOT NULL,
+	user_b_id INTEGER REFERENCES "user"(id) NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	last_message_at TIMESTAMP,
+	UNIQUE (user_a_id, user_b_id),
+	CHECK (user_a_id < user_b_id)
+);
+
+CREATE INDEX idx_direct_conversation_user_b ON direct_conversation (user_b_id);
+
+CREATE TABLE direct_message (
+	message_id TEXT PRIMARY KEY NOT NULL,
+	conversation_id INTEGER REFERENCES direct_conversation(id) ON DELETE CASCADE NOT NULL,
+	sender_user_id INTEGER REFERENCES "user"(id) NOT NULL,
+	message TEXT NOT NULL,
+	message_time TIMESTAMP NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	deleted_at TIMESTAMP
+);
+
+CREATE INDEX idx_direct_message_conversation_time ON direct_message (conversation_id, message_time);
+
+CREATE TABLE direct_read_state (
+	conversation_id INTEGER REFERENCES direct_conversation(id) ON DELETE CASCADE NOT NULL,
+	user_id INTEGER NOT NULL,
+	last_read_at TIMESTAMP NOT NULL,
+	PRIMARY KEY (conversation_id, user_id)
+);
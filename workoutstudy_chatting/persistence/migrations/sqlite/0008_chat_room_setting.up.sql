@@ -0,0 +1,8 @@
+CREATE TABLE chat_room_setting (
+	fit_group_id INTEGER PRIMARY KEY REFERENCES fit_group(id) ON DELETE CASCADE,
+	slow_mode_seconds INTEGER DEFAULT 0 NOT NULL CHECK (slow_mode_seconds >= 0),
+	created_at TIMESTAMP NOT NULL,
+	created_by VARCHAR(30),
+	updated_at TIMESTAMP NOT NULL,
+	updated_by VARCHAR(30)
+);
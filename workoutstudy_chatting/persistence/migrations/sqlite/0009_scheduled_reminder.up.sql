@@ -0,0 +1,21 @@
+ALTER TABLE chat_room_setting ADD COLUMN time_zone VARCHAR(64) DEFAULT 'Asia/Seoul' NOT NULL;
+
+CREATE TABLE scheduled_reminder (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	fit_group_id INTEGER REFERENCES fit_group(id) ON DELETE CASCADE NOT NULL,
+	recurrence VARCHAR(20) NOT NULL CHECK (recurrence IN ('DAILY', 'WEEKLY', 'CYCLE_DEADLINE')),
+	message TEXT DEFAULT '' NOT NULL,
+	time_of_day VARCHAR(5) NOT NULL,
+	-- Postgres 배열 표기('{1,3,5}')로 저장합니다.
+	weekdays TEXT DEFAULT '{}' NOT NULL,
+	days_before INTEGER DEFAULT 0 NOT NULL,
+	next_run_at TIMESTAMP NOT NULL,
+	last_run_at TIMESTAMP,
+	created_by INTEGER NOT NULL,
+	created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_scheduled_reminder_next_run ON scheduled_reminder (next_run_at);
+
+-- 리마인더, 투표 결과 등 시스템 메시지를 보내는 핏봇 사용자
+INSERT INTO "user" (id, nickname, state, created_at, updated_at) VALUES (0, '핏봇', false, NOW(), NOW()) ON CONFLICT (id) DO NOTHING;
@@ -0,0 +1,2 @@
+ALTER TABLE "user" DROP COLUMN updated_by;
+ALTER TABLE "user" DROP COLUMN created_by;
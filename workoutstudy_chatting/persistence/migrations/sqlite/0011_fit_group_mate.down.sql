@@ -0,0 +1 @@
+DROP TABLE IF EXISTS fit_group_mate;
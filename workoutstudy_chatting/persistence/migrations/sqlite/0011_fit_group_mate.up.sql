@@ -0,0 +1,12 @@
+-- GetFitMatesIdsByFitGroupId, GetFitMatesByFitGroupId 가 조회하는 fit group - fit mate 연결 테이블
+CREATE TABLE fit_group_mate (
+	fit_group_id INTEGER REFERENCES fit_group(id) ON DELETE CASCADE NOT NULL,
+	fit_mate_id INTEGER REFERENCES fit_mate(id) ON DELETE CASCADE NOT NULL,
+	PRIMARY KEY (fit_group_id, fit_mate_id)
+);
+
+CREATE INDEX idx_fit_group_mate_fit_mate ON fit_group_mate (fit_mate_id);
+
+-- 이미 저장된 fit mate 를 채워 넣습니다. 이후에는 SaveFitMate 가 함께 저장합니다.
+INSERT OR IGNORE INTO fit_group_mate (fit_group_id, fit_mate_id)
+SELECT fit_group_id, id FROM fit_mate;
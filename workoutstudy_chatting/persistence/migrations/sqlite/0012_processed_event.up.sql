@@ -0,0 +1,10 @@
+-- 처리를 마친 Kafka 이벤트 기록. 재전달된 이벤트는 이미 반영했으면 건너뜁니다.
+-- event_key 는 이벤트 ID 헤더가 있으면 'id:<이벤트 ID>', 없으면 '<파티션>-<오프셋>' 입니다.
+CREATE TABLE processed_event (
+	topic VARCHAR(249) NOT NULL,
+	event_key VARCHAR(255) NOT NULL,
+	processed_at TIMESTAMP NOT NULL,
+	PRIMARY KEY (topic, event_key)
+);
+
+CREATE INDEX idx_processed_event_processed_at ON processed_event (processed_at);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS pending_fit_mate_event;
@@ -0,0 +1 @@
+ALTER TABLE message DROP COLUMN restored_at;
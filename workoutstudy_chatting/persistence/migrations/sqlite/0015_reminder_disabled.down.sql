@@ -0,0 +1,2 @@
+ALTER TABLE scheduled_reminder DROP COLUMN disabled_reason;
+ALTER TABLE scheduled_reminder DROP COLUMN disabled_at;
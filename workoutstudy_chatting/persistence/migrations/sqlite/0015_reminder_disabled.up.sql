@@ -0,0 +1,3 @@
+-- 실행할 수 없는 리마인더(fit group 없음, 잘못된 설정)의 비활성화 시간과 사유. 비활성화된 리마인더는 실행하지 않습니다.
+ALTER TABLE scheduled_reminder ADD COLUMN disabled_at TIMESTAMP;
+ALTER TABLE scheduled_reminder ADD COLUMN disabled_reason TEXT;
@@ -0,0 +1,278 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"log"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 저장소마다 같은 버전, 같은 이름의 마이그레이션을 migrations/<dialect> 에 둡니다.
+//
+//go:embed migrations/postgres/*.sql migrations/sqlite/*.sql
+var migrationFiles embed.FS
+
+// migrationLockKey 는 여러 레플리카가 동시에 마이그레이션하지 않도록 잡는 pg_advisory_lock 키입니다.
+const migrationLockKey int64 = 0x63686174 // "chat"
+
+// Migration 은 migrations/<dialect>/NNNN_이름.up.sql, NNNN_이름.down.sql 한 쌍입니다.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus 는 마이그레이션 적용 여부입니다. 바이너리에 없는 버전이 DB 에 적용되어 있으면 Unknown 입니다.
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	AppliedAt *time.Time
+	Unknown   bool
+}
+
+type Migrator struct {
+	DB         *SQLDB
+	migrations []Migration
+}
+
+// NewMigrator 는 바이너리에 포함된 db.Dialect 의 마이그레이션으로 Migrator 를 만듭니다.
+func NewMigrator(db *SQLDB) (*Migrator, error) {
+	migrations, err := LoadMigrations(migrationFiles, path.Join("migrations", db.Dialect.Name()))
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{DB: db, migrations: migrations}, nil
+}
+
+// LoadMigrations 는 dir 의 *.up.sql, *.down.sql 파일을 버전 순서로 읽습니다.
+func LoadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("read migrations: %w", err)
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		fileName := entry.Name()
+		var direction string
+		switch {
+		case strings.HasSuffix(fileName, ".up.sql"):
+			direction = "up"
+		case strings.HasSuffix(fileName, ".down.sql"):
+			direction = "down"
+		default:
+			continue
+		}
+
+		base := strings.TrimSuffix(fileName, "."+direction+".sql")
+		prefix, name, ok := strings.Cut(base, "_")
+		version, err := strconv.Atoi(prefix)
+		if !ok || err != nil || version <= 0 {
+			return nil, fmt.Errorf("invalid migration file name %q (expected NNNN_name.up.sql)", fileName)
+		}
+
+		body, err := fs.ReadFile(fsys, path.Join(dir, fileName))
+		if err != nil {
+			return nil, fmt.Errorf("read migration %s: %w", fileName, err)
+		}
+
+		m, exists := byVersion[version]
+		if !exists {
+			m = &Migration{Version: version, Name: name}
+			byVersion[version] = m
+		} else if m.Name != name {
+			return nil, fmt.Errorf("migration version %d has conflicting names %q and %q", version, m.Name, name)
+		}
+		if direction == "up" {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %04d_%s must have both up and down files", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Up 은 적용되지 않은 마이그레이션을 버전 순서로 모두 적용하고, 적용한 목록을 반환합니다.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		versions, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, migration := range m.migrations {
+			if _, ok := versions[migration.Version]; ok {
+				continue
+			}
+			if err := runMigration(ctx, conn, migration, migration.Up,
+				`INSERT INTO schema_version (version, name, applied_at) VALUES ($1, $2, NOW())`,
+				migration.Version, migration.Name); err != nil {
+				return err
+			}
+			log.Printf("Applied migration %04d_%s", migration.Version, migration.Name)
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down 은 가장 최근에 적용된 마이그레이션부터 steps 개를 되돌리고, 되돌린 목록을 반환합니다.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var reverted []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		versions, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for i := len(m.migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := versions[migration.Version]; !ok {
+				continue
+			}
+			if err := runMigration(ctx, conn, migration, migration.Down,
+				`DELETE FROM schema_version WHERE version = $1`, migration.Version); err != nil {
+				return err
+			}
+			log.Printf("Reverted migration %04d_%s", migration.Version, migration.Name)
+			reverted = append(reverted, migration)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Status 는 바이너리에 포함된 마이그레이션과 DB 에만 기록된 마이그레이션의 적용 여부를 버전 순서로 반환합니다.
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	var statuses []MigrationStatus
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		rows, err := conn.QueryContext(ctx, `SELECT version, name, applied_at FROM schema_version`)
+		if err != nil {
+			return fmt.Errorf("query schema_version: %w", err)
+		}
+		defer rows.Close()
+
+		applied := make(map[int]MigrationStatus)
+		for rows.Next() {
+			var s MigrationStatus
+			var appliedAt time.Time
+			if err := rows.Scan(&s.Version, &s.Name, &appliedAt); err != nil {
+				return err
+			}
+			s.AppliedAt = &appliedAt
+			s.Unknown = true
+			applied[s.Version] = s
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			s := MigrationStatus{Version: migration.Version, Name: migration.Name}
+			if a, ok := applied[migration.Version]; ok {
+				s.AppliedAt = a.AppliedAt
+				delete(applied, migration.Version)
+			}
+			statuses = append(statuses, s)
+		}
+		for _, s := range applied {
+			statuses = append(statuses, s)
+		}
+		sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+		return nil
+	})
+	return statuses, err
+}
+
+// withLock 은 마이그레이션 잠금을 잡은 연결에서 fn 을 실행합니다.
+// Postgres 는 세션 단위 advisory lock 을 잡고, 다른 레플리카가 마이그레이션 중이면 끝날 때까지 기다립니다.
+// SQLite 는 한 프로세스만 파일을 사용하고 각 마이그레이션 트랜잭션이 쓰기 잠금을 잡으므로 별도 잠금이 없습니다.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.DB.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire connection: %w", err)
+	}
+	defer conn.Close()
+
+	schemaVersionDDL := `CREATE TABLE IF NOT EXISTS schema_version (
+		version INTEGER PRIMARY KEY,
+		name VARCHAR(100) NOT NULL,
+		applied_at TIMESTAMP NOT NULL
+	)`
+	if m.DB.Dialect.Name() == DialectPostgres {
+		if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, migrationLockKey); err != nil {
+			return fmt.Errorf("acquire migration lock: %w", err)
+		}
+		defer func() {
+			// ctx 가 취소되었더라도 잠금은 풀어야 하므로 별도의 context 사용
+			unlockCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if _, err := conn.ExecContext(unlockCtx, `SELECT pg_advisory_unlock($1)`, migrationLockKey); err != nil {
+				log.Printf("Failed to release migration lock: %v", err)
+			}
+		}()
+
+		schemaVersionDDL = `CREATE TABLE IF NOT EXISTS schema_version (
+		version INTEGER PRIMARY KEY,
+		name VARCHAR(100) NOT NULL,
+		applied_at TIMESTAMP(6) WITH TIME ZONE NOT NULL
+	)`
+	}
+
+	if _, err := conn.ExecContext(ctx, schemaVersionDDL); err != nil {
+		return fmt.Errorf("create schema_version: %w", err)
+	}
+	return fn(conn)
+}
+
+func appliedVersions(ctx context.Context, conn *sql.Conn) (map[int]struct{}, error) {
+	rows, err := conn.QueryContext(ctx, `SELECT version FROM schema_version`)
+	if err != nil {
+		return nil, fmt.Errorf("query schema_version: %w", err)
+	}
+	defer rows.Close()
+
+	versions := make(map[int]struct{})
+	for rows.Next() {
+		var version int
+		if err := rows.Scan(&version); err != nil {
+			return nil, err
+		}
+		versions[version] = struct{}{}
+	}
+	return versions, rows.Err()
+}
+
+// runMigration 은 스크립트와 schema_version 기록을 한 트랜잭션으로 실행합니다.
+func runMigration(ctx context.Context, conn *sql.Conn, migration Migration, script, record string, args ...interface{}) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return fmt.Errorf("migration %04d_%s: %w", migration.Version, migration.Name, err)
+	}
+	if _, err := tx.ExecContext(ctx, record, args...); err != nil {
+		return fmt.Errorf("record migration %04d_%s: %w", migration.Version, migration.Name, err)
+	}
+	return tx.Commit()
+}
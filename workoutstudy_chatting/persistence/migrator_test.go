@@ -0,0 +1,216 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"workoutstudy_chatting/config"
+)
+
+func openTestSQLite(t *testing.T) *SQLDB {
+	t.Helper()
+	cfg := config.Default().Database
+	cfg.Driver = config.DriverSQLite
+	cfg.Path = filepath.Join(t.TempDir(), "migrate.db")
+	db, err := OpenDB(cfg)
+	if err != nil {
+		t.Fatalf("OpenDB: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func migrationNames(migrations []Migration) []string {
+	names := make([]string, 0, len(migrations))
+	for _, m := range migrations {
+		names = append(names, fmt.Sprintf("%04d_%s", m.Version, m.Name))
+	}
+	return names
+}
+
+// schemaVersions 는 schema_version 에 기록된 마이그레이션을 "NNNN_이름" 으로 버전 순서대로 반환합니다.
+func schemaVersions(t *testing.T, db *SQLDB) []string {
+	t.Helper()
+	rows, err := db.Query(`SELECT version, name FROM schema_version ORDER BY version`)
+	if err != nil {
+		t.Fatalf("query schema_version: %v", err)
+	}
+	defer rows.Close()
+	var recorded []Migration
+	for rows.Next() {
+		var m Migration
+		if err := rows.Scan(&m.Version, &m.Name); err != nil {
+			t.Fatalf("scan schema_version: %v", err)
+		}
+		recorded = append(recorded, m)
+	}
+	return migrationNames(recorded)
+}
+
+// pendingNames 는 Status 결과 중 적용되지 않은 마이그레이션을 "NNNN_이름" 으로 반환합니다.
+func pendingNames(statuses []MigrationStatus) []string {
+	var pending []Migration
+	for _, s := range statuses {
+		if s.AppliedAt == nil {
+			pending = append(pending, Migration{Version: s.Version, Name: s.Name})
+		}
+	}
+	return migrationNames(pending)
+}
+
+func TestMigratorUpDownStatusOnSQLite(t *testing.T) {
+	ctx := context.Background()
+	db := openTestSQLite(t)
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		t.Fatalf("NewMigrator: %v", err)
+	}
+	all := migrationNames(migrator.migrations)
+	if len(all) < 2 {
+		t.Fatalf("embedded migrations = %v", all)
+	}
+
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status before Up: %v", err)
+	}
+	if got := pendingNames(statuses); !reflect.DeepEqual(got, all) {
+		t.Fatalf("pending before Up = %v, want all %v", got, all)
+	}
+
+	applied, err := migrator.Up(ctx)
+	if err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+	if got := migrationNames(applied); !reflect.DeepEqual(got, all) {
+		t.Fatalf("Up applied %v, want %v", got, all)
+	}
+	if got := schemaVersions(t, db); !reflect.DeepEqual(got, all) {
+		t.Fatalf("schema_version = %v, want %v", got, all)
+	}
+
+	// 두 번째 Up 은 아무것도 적용하지 않고 기록도 바꾸지 않음
+	applied, err = migrator.Up(ctx)
+	if err != nil || len(applied) != 0 {
+		t.Fatalf("second Up = %v, %v, want nothing applied", migrationNames(applied), err)
+	}
+	if got := schemaVersions(t, db); !reflect.DeepEqual(got, all) {
+		t.Fatalf("schema_version after second Up = %v, want %v", got, all)
+	}
+
+	statuses, err = migrator.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	if len(statuses) != len(all) || len(pendingNames(statuses)) != 0 {
+		t.Fatalf("Status after Up = %+v, want all %d applied", statuses, len(all))
+	}
+	for _, s := range statuses {
+		if s.Unknown || time.Since(*s.AppliedAt) > time.Hour {
+			t.Fatalf("status %+v, want known and applied just now", s)
+		}
+	}
+
+	// Down 은 최근 마이그레이션부터 되돌리고, 다시 Up 하면 그것만 적용
+	reverted, err := migrator.Down(ctx, 2)
+	if err != nil {
+		t.Fatalf("Down: %v", err)
+	}
+	lastTwo := []string{all[len(all)-1], all[len(all)-2]}
+	if got := migrationNames(reverted); !reflect.DeepEqual(got, lastTwo) {
+		t.Fatalf("Down reverted %v, want %v", got, lastTwo)
+	}
+	if got := schemaVersions(t, db); !reflect.DeepEqual(got, all[:len(all)-2]) {
+		t.Fatalf("schema_version after Down = %v", got)
+	}
+	statuses, _ = migrator.Status(ctx)
+	if got := pendingNames(statuses); !reflect.DeepEqual(got, all[len(all)-2:]) {
+		t.Fatalf("pending after Down = %v, want %v", got, all[len(all)-2:])
+	}
+	applied, err = migrator.Up(ctx)
+	if err != nil {
+		t.Fatalf("Up after Down: %v", err)
+	}
+	if got := migrationNames(applied); !reflect.DeepEqual(got, all[len(all)-2:]) {
+		t.Fatalf("Up after Down applied %v, want %v", got, all[len(all)-2:])
+	}
+
+	// 모든 down 스크립트가 실행되고, 처음부터 다시 적용할 수 있음
+	if reverted, err := migrator.Down(ctx, len(all)+1); err != nil || len(reverted) != len(all) {
+		t.Fatalf("Down all = %d reverted, %v, want %d", len(reverted), err, len(all))
+	}
+	if got := schemaVersions(t, db); len(got) != 0 {
+		t.Fatalf("schema_version after Down all = %v, want empty", got)
+	}
+	if applied, err := migrator.Up(ctx); err != nil || len(applied) != len(all) {
+		t.Fatalf("Up after Down all = %d applied, %v, want %d", len(applied), err, len(all))
+	}
+
+	// 이 바이너리에 없는 버전이 적용되어 있으면 Unknown 으로 표시
+	if _, err := db.Exec(`INSERT INTO schema_version (version, name, applied_at) VALUES (9999, 'from_newer_binary', NOW())`); err != nil {
+		t.Fatalf("insert unknown version: %v", err)
+	}
+	statuses, err = migrator.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status with unknown version: %v", err)
+	}
+	last := statuses[len(statuses)-1]
+	if len(statuses) != len(all)+1 || last.Version != 9999 || last.Name != "from_newer_binary" || !last.Unknown || last.AppliedAt == nil {
+		t.Fatalf("last status = %+v (of %d), want unknown applied 9999", last, len(statuses))
+	}
+}
+
+func TestLoadMigrations(t *testing.T) {
+	file := func(body string) *fstest.MapFile { return &fstest.MapFile{Data: []byte(body)} }
+	tests := []struct {
+		name    string
+		files   fstest.MapFS
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "버전 순서로 읽고 다른 파일은 무시",
+			files: fstest.MapFS{
+				"m/0010_b.up.sql": file("b"), "m/0010_b.down.sql": file("-b"),
+				"m/0002_a.up.sql": file("a"), "m/0002_a.down.sql": file("-a"),
+				"m/README.md": file("docs"),
+			},
+			want: []string{"0002_a", "0010_b"},
+		},
+		{name: "down 파일이 없음", files: fstest.MapFS{"m/0001_a.up.sql": file("a")}, wantErr: "must have both up and down"},
+		{name: "버전이 숫자가 아님", files: fstest.MapFS{"m/first_a.up.sql": file("a")}, wantErr: "invalid migration file name"},
+		{name: "버전이 0", files: fstest.MapFS{"m/0000_a.up.sql": file("a")}, wantErr: "invalid migration file name"},
+		{
+			name:    "같은 버전에 다른 이름",
+			files:   fstest.MapFS{"m/0001_a.up.sql": file("a"), "m/0001_b.down.sql": file("-b")},
+			wantErr: "conflicting names",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrations, err := LoadMigrations(tt.files, "m")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadMigrations err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadMigrations: %v", err)
+			}
+			if got := migrationNames(migrations); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("LoadMigrations = %v, want %v", got, tt.want)
+			}
+			if migrations[0].Up != "a" || migrations[0].Down != "-a" {
+				t.Fatalf("migration 0002 = %+v", migrations[0])
+			}
+		})
+	}
+}
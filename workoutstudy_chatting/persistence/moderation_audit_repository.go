@@ -0,0 +1,72 @@
+package persistence
+
+import (
+	"fmt"
+	"log"
+	"workoutstudy_chatting/model"
+)
+
+type ModerationAuditRepository interface {
+	SaveAuditLog(auditLog *model.ModerationAuditLog) error
+	GetAuditLogs(fitGroupID int, limit int) ([]model.ModerationAuditLog, error)
+}
+
+type ModerationAuditRepositoryImpl struct {
+	DB *SQLDB
+}
+
+var _ ModerationAuditRepository = (*ModerationAuditRepositoryImpl)(nil)
+
+func NewModerationAuditRepository(db *SQLDB) ModerationAuditRepository {
+	return &ModerationAuditRepositoryImpl{DB: db}
+}
+
+func (repo *ModerationAuditRepositoryImpl) SaveAuditLog(auditLog *model.ModerationAuditLog) error {
+	query := `
+	INSERT INTO moderation_audit (fit_group_id, actor_user_id, action, target_user_id, target_message_id, detail, created_at)
+	VALUES ($1, $2, $3, NULLIF($4, 0), $5::uuid, $6, NOW())
+	RETURNING id, created_at
+	`
+	// 빈 문자열은 UUID 로 변환할 수 없으므로 NULL 로 저장
+	var targetMessageID interface{}
+	if auditLog.TargetMessageID != "" {
+		targetMessageID = auditLog.TargetMessageID
+	}
+	err := repo.DB.QueryRow(query, auditLog.FitGroupID, auditLog.ActorUserID, auditLog.Action, auditLog.TargetUserID, targetMessageID, auditLog.Detail).
+		Scan(&auditLog.ID, &auditLog.CreatedAt)
+	if err != nil {
+		log.Printf("Repository layer: Error saving moderation audit log: %v", err)
+		return fmt.Errorf("error saving moderation audit log: %w", err)
+	}
+	return nil
+}
+
+// GetAuditLogs 는 최신 기록부터 최대 limit 개를 조회합니다.
+func (repo *ModerationAuditRepositoryImpl) GetAuditLogs(fitGroupID int, limit int) ([]model.ModerationAuditLog, error) {
+	query := `
+	SELECT id, fit_group_id, actor_user_id, action, COALESCE(target_user_id, 0), COALESCE(target_message_id::text, ''), detail, created_at
+	FROM moderation_audit
+	WHERE fit_group_id = $1
+	ORDER BY created_at DESC
+	LIMIT $2
+	`
+	rows, err := repo.DB.Query(query, fitGroupID, limit)
+	if err != nil {
+		log.Printf("Repository layer: Error retrieving moderation audit logs: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var logs []model.ModerationAuditLog
+	for rows.Next() {
+		var l model.ModerationAuditLog
+		if err := rows.Scan(&l.ID, &l.FitGroupID, &l.ActorUserID, &l.Action, &l.TargetUserID, &l.TargetMessageID, &l.Detail, &l.CreatedAt); err != nil {
+			return nil, err
+		}
+		logs = append(logs, l)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
@@ -0,0 +1,111 @@
+package persistence
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"workoutstudy_chatting/model"
+)
+
+type ModerationRepository interface {
+	SaveModerationItem(item *model.ModerationItem) (*model.ModerationItem, error)
+	GetModerationItemByID(id int) (*model.ModerationItem, error)
+	GetModerationItems(fitGroupID int, status model.ModerationStatus) ([]model.ModerationItem, error)
+	UpdateModerationStatus(id int, status model.ModerationStatus, reviewedBy string) error
+}
+
+type ModerationRepositoryImpl struct {
+	DB *SQLDB
+}
+
+var _ ModerationRepository = (*ModerationRepositoryImpl)(nil)
+
+func NewModerationRepository(db *SQLDB) ModerationRepository {
+	return &ModerationRepositoryImpl{DB: db}
+}
+
+func (repo *ModerationRepositoryImpl) SaveModerationItem(item *model.ModerationItem) (*model.ModerationItem, error) {
+	query := `
+	INSERT INTO moderation_queue (message_id, fit_group_id, user_id, message, reasons, status, created_at)
+	VALUES ($1::uuid, $2, $3, $4, $5, $6, NOW())
+	RETURNING id, created_at
+	`
+	if item.Status == "" {
+		item.Status = model.ModerationPending
+	}
+	err := repo.DB.QueryRow(query, item.MessageID, item.FitGroupID, item.UserID, item.Message, item.Reasons, item.Status).Scan(&item.ID, &item.CreatedAt)
+	if err != nil {
+		log.Printf("Repository layer: Error saving moderation item: %v", err)
+		return nil, fmt.Errorf("error saving moderation item: %w", err)
+	}
+	return item, nil
+}
+
+func (repo *ModerationRepositoryImpl) GetModerationItemByID(id int) (*model.ModerationItem, error) {
+	query := `
+	SELECT id, message_id, fit_group_id, user_id, message, reasons, status, created_at, reviewed_at, COALESCE(reviewed_by, '')
+	FROM moderation_queue
+	WHERE id = $1
+	`
+	item, err := scanModerationItem(repo.DB.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("no moderation item found for ID: %d", id)
+		}
+		return nil, err
+	}
+	return item, nil
+}
+
+func (repo *ModerationRepositoryImpl) GetModerationItems(fitGroupID int, status model.ModerationStatus) ([]model.ModerationItem, error) {
+	query := `
+	SELECT id, message_id, fit_group_id, user_id, message, reasons, status, created_at, reviewed_at, COALESCE(reviewed_by, '')
+	FROM moderation_queue
+	WHERE fit_group_id = $1 AND status = $2
+	ORDER BY created_at ASC
+	`
+	rows, err := repo.DB.Query(query, fitGroupID, status)
+	if err != nil {
+		log.Printf("Repository layer: Error retrieving moderation items: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []model.ModerationItem
+	for rows.Next() {
+		item, err := scanModerationItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, *item)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (repo *ModerationRepositoryImpl) UpdateModerationStatus(id int, status model.ModerationStatus, reviewedBy string) error {
+	query := `UPDATE moderation_queue SET status = $2, reviewed_at = NOW(), reviewed_by = $3 WHERE id = $1`
+	if _, err := repo.DB.Exec(query, id, status, reviewedBy); err != nil {
+		log.Printf("Repository layer: Error updating moderation item %d: %v", id, err)
+		return fmt.Errorf("error updating moderation item: %w", err)
+	}
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanModerationItem(row rowScanner) (*model.ModerationItem, error) {
+	var item model.ModerationItem
+	var reviewedAt sql.NullTime
+	if err := row.Scan(&item.ID, &item.MessageID, &item.FitGroupID, &item.UserID, &item.Message, &item.Reasons, &item.Status, &item.CreatedAt, &reviewedAt, &item.ReviewedBy); err != nil {
+		return nil, err
+	}
+	if reviewedAt.Valid {
+		item.ReviewedAt = &reviewedAt.Time
+	}
+	return &item, nil
+}
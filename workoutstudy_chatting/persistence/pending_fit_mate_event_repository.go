@@ -0,0 +1,120 @@
+package persistence
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+	"workoutstudy_chatting/model"
+)
+
+// PendingFitMateEventRepository 는 fit group 보다 먼저 도착한 fit mate 이벤트를 fit group 별로 보관합니다.
+type PendingFitMateEventRepository interface {
+	SavePendingFitMateEvent(event *model.PendingFitMateEvent) error
+	GetPendingFitMateEvent(fitGroupID int) (*model.PendingFitMateEvent, error)
+	GetPendingFitMateEventGroupIDs() ([]int, error)
+	DeletePendingFitMateEvent(event *model.PendingFitMateEvent) (bool, error)
+	DeleteExpiredPendingFitMateEvents(now time.Time) (int64, error)
+	CountPendingFitMateEvents() (int, error)
+}
+
+type PendingFitMateEventRepositoryImpl struct {
+	DB *SQLDB
+}
+
+var _ PendingFitMateEventRepository = (*PendingFitMateEventRepositoryImpl)(nil)
+
+func NewPendingFitMateEventRepository(db *SQLDB) PendingFitMateEventRepository {
+	return &PendingFitMateEventRepositoryImpl{DB: db}
+}
+
+// SavePendingFitMateEvent 는 같은 fit group 의 보류 이벤트가 있으면 새 이벤트로 덮어씁니다.
+func (repo *PendingFitMateEventRepositoryImpl) SavePendingFitMateEvent(event *model.PendingFitMateEvent) error {
+	payload, err := json.Marshal(event.FitMates)
+	if err != nil {
+		return fmt.Errorf("error encoding pending fit mate event: %w", err)
+	}
+	query := `
+	INSERT INTO pending_fit_mate_event (fit_group_id, payload, received_at, expires_at) VALUES ($1, $2, $3, $4)
+	ON CONFLICT (fit_group_id) DO UPDATE SET payload = EXCLUDED.payload, received_at = EXCLUDED.received_at, expires_at = EXCLUDED.expires_at`
+	if _, err := repo.DB.Exec(query, event.FitGroupID, string(payload), event.ReceivedAt, event.ExpiresAt); err != nil {
+		log.Printf("Repository layer: Error saving pending fit mate event: %v", err)
+		return fmt.Errorf("error saving pending fit mate event: %w", err)
+	}
+	return nil
+}
+
+// GetPendingFitMateEvent 는 보류 이벤트가 없으면 nil 을 반환합니다.
+func (repo *PendingFitMateEventRepositoryImpl) GetPendingFitMateEvent(fitGroupID int) (*model.PendingFitMateEvent, error) {
+	query := `SELECT payload, received_at, expires_at FROM pending_fit_mate_event WHERE fit_group_id = $1`
+	event := model.PendingFitMateEvent{FitGroupID: fitGroupID}
+	var payload string
+	err := repo.DB.QueryRow(query, fitGroupID).Scan(&payload, &event.ReceivedAt, &event.ExpiresAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		log.Printf("Repository layer: Error querying pending fit mate event: %v", err)
+		return nil, fmt.Errorf("error querying pending fit mate event: %w", err)
+	}
+	if err := json.Unmarshal([]byte(payload), &event.FitMates); err != nil {
+		return nil, fmt.Errorf("error decoding pending fit mate event for fit group %d: %w", fitGroupID, err)
+	}
+	return &event, nil
+}
+
+// GetPendingFitMateEventGroupIDs 는 보류 이벤트가 있는 fit group ID 를 먼저 도착한 순서로 조회합니다.
+func (repo *PendingFitMateEventRepositoryImpl) GetPendingFitMateEventGroupIDs() ([]int, error) {
+	rows, err := repo.DB.Query(`SELECT fit_group_id FROM pending_fit_mate_event ORDER BY received_at, fit_group_id`)
+	if err != nil {
+		log.Printf("Repository layer: Error retrieving pending fit mate events: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var fitGroupIDs []int
+	for rows.Next() {
+		var fitGroupID int
+		if err := rows.Scan(&fitGroupID); err != nil {
+			return nil, err
+		}
+		fitGroupIDs = append(fitGroupIDs, fitGroupID)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return fitGroupIDs, nil
+}
+
+// DeletePendingFitMateEvent 는 보관 중인 이벤트가 event 와 같을 때만 지웁니다.
+// 다시 처리하는 사이 같은 fit group 의 새 이벤트가 보류되었으면 지우지 않고 false 를 반환합니다.
+func (repo *PendingFitMateEventRepositoryImpl) DeletePendingFitMateEvent(event *model.PendingFitMateEvent) (bool, error) {
+	payload, err := json.Marshal(event.FitMates)
+	if err != nil {
+		return false, fmt.Errorf("error encoding pending fit mate event: %w", err)
+	}
+	result, err := repo.DB.Exec(`DELETE FROM pending_fit_mate_event WHERE fit_group_id = $1 AND payload = $2`, event.FitGroupID, string(payload))
+	if err != nil {
+		return false, fmt.Errorf("error deleting pending fit mate event: %w", err)
+	}
+	deleted, err := result.RowsAffected()
+	return deleted > 0, err
+}
+
+// DeleteExpiredPendingFitMateEvents 는 now 에 만료된 보류 이벤트를 지우고 지운 개수를 반환합니다.
+func (repo *PendingFitMateEventRepositoryImpl) DeleteExpiredPendingFitMateEvents(now time.Time) (int64, error) {
+	result, err := repo.DB.Exec(`DELETE FROM pending_fit_mate_event WHERE expires_at <= $1`, now)
+	if err != nil {
+		return 0, fmt.Errorf("error deleting expired pending fit mate events: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+func (repo *PendingFitMateEventRepositoryImpl) CountPendingFitMateEvents() (int, error) {
+	var count int
+	if err := repo.DB.QueryRow(`SELECT COUNT(*) FROM pending_fit_mate_event`).Scan(&count); err != nil {
+		return 0, fmt.Errorf("error counting pending fit mate events: %w", err)
+	}
+	return count, nil
+}
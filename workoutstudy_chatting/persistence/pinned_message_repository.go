@@ -0,0 +1,88 @@
+package persistence
+
+import (
+	"fmt"
+	"log"
+	"workoutstudy_chatting/model"
+)
+
+type PinnedMessageRepository interface {
+	PinMessage(pin *model.PinnedMessage) (*model.PinnedMessage, error)
+	UnpinMessage(fitGroupID int, messageID string) (bool, error)
+	GetPinnedMessages(fitGroupID int) ([]model.PinnedMessage, error)
+}
+
+type PinnedMessageRepositoryImpl struct {
+	DB *SQLDB
+}
+
+var _ PinnedMessageRepository = (*PinnedMessageRepositoryImpl)(nil)
+
+func NewPinnedMessageRepository(db *SQLDB) PinnedMessageRepository {
+	return &PinnedMessageRepositoryImpl{DB: db}
+}
+
+// PinMessage 는 이미 고정된 메시지를 다시 고정하면 고정 시간과 고정한 사용자를 갱신합니다.
+func (repo *PinnedMessageRepositoryImpl) PinMessage(pin *model.PinnedMessage) (*model.PinnedMessage, error) {
+	query := `
+	INSERT INTO pinned_message (fit_group_id, message_id, pinned_by, pinned_at)
+	VALUES ($1, $2::uuid, $3, NOW())
+	ON CONFLICT (fit_group_id, message_id) DO UPDATE SET pinned_by = EXCLUDED.pinned_by, pinned_at = NOW()
+	RETURNING pinned_at
+	`
+	if err := repo.DB.QueryRow(query, pin.FitGroupID, pin.MessageID, pin.PinnedBy).Scan(&pin.PinnedAt); err != nil {
+		log.Printf("Repository layer: Error pinning message: %v", err)
+		return nil, fmt.Errorf("error pinning message: %w", err)
+	}
+	return pin, nil
+}
+
+// UnpinMessage 는 고정을 해제하고, 고정되어 있지 않았으면 false 를 반환합니다.
+func (repo *PinnedMessageRepositoryImpl) UnpinMessage(fitGroupID int, messageID string) (bool, error) {
+	query := `DELETE FROM pinned_message WHERE fit_group_id = $1 AND message_id = $2::uuid`
+	result, err := repo.DB.Exec(query, fitGroupID, messageID)
+	if err != nil {
+		log.Printf("Repository layer: Error unpinning message: %v", err)
+		return false, fmt.Errorf("error unpinning message: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// GetPinnedMessages 는 최근에 고정된 순서로 메시지 내용과 함께 조회합니다. 삭제된 메시지는 제외합니다.
+func (repo *PinnedMessageRepositoryImpl) GetPinnedMessages(fitGroupID int) ([]model.PinnedMessage, error) {
+	query := `
+	SELECT p.fit_group_id, p.message_id, p.pinned_by, p.pinned_at,
+		m.user_id, m.message, m.message_time, m.message_type
+	FROM pinned_message p
+	JOIN message m ON m.message_id = p.message_id
+	WHERE p.fit_group_id = $1 AND m.deleted_at IS NULL
+	ORDER BY p.pinned_at DESC
+	`
+	rows, err := repo.DB.Query(query, fitGroupID)
+	if err != nil {
+		log.Printf("Repository layer: Error retrieving pinned messages: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var pins []model.PinnedMessage
+	for rows.Next() {
+		var p model.PinnedMessage
+		msg := &model.ChatMessage{}
+		if err := rows.Scan(&p.FitGroupID, &p.MessageID, &p.PinnedBy, &p.PinnedAt, &msg.UserID, &msg.Message, &msg.MessageTime, &msg.MessageType); err != nil {
+			return nil, err
+		}
+		msg.ID = p.MessageID
+		msg.FitGroupID = p.FitGroupID
+		p.Message = msg
+		pins = append(pins, p)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return pins, nil
+}
@@ -0,0 +1,283 @@
+package persistence
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+	"workoutstudy_chatting/model"
+)
+
+type PollRepository interface {
+	CreatePoll(poll *model.ChatPoll) (*model.ChatPoll, error)
+	GetPollByID(pollID int) (*model.ChatPoll, error)
+	GetPollByMessageID(messageID string) (*model.ChatPoll, error)
+	ReplaceVotes(pollID, userID int, optionIDs []int) (bool, error)
+	GetUserVotes(pollID, userID int) ([]int, error)
+	ClosePoll(pollID int) (bool, error)
+	GetExpiredOpenPollIDs(now time.Time) ([]int, error)
+	RestorePoll(poll *model.ChatPoll) (bool, error)
+}
+
+type PollRepositoryImpl struct {
+	DB *SQLDB
+}
+
+var _ PollRepository = (*PollRepositoryImpl)(nil)
+
+func NewPollRepository(db *SQLDB) PollRepository {
+	return &PollRepositoryImpl{DB: db}
+}
+
+// CreatePoll 은 투표와 선택지를 한 트랜잭션으로 저장합니다. 투표 메시지는 먼저 저장되어 있어야 합니다.
+func (repo *PollRepositoryImpl) CreatePoll(poll *model.ChatPoll) (*model.ChatPoll, error) {
+	tx, err := repo.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `
+	INSERT INTO poll (message_id, fit_group_id, created_by, question, multiple_choice, anonymous, deadline, created_at)
+	VALUES ($1::uuid, $2, $3, $4, $5, $6, $7, NOW())
+	RETURNING id, created_at
+	`
+	if err := tx.QueryRow(query, poll.MessageID, poll.FitGroupID, poll.CreatedBy, poll.Question, poll.MultipleChoice, poll.Anonymous, poll.Deadline).Scan(&poll.ID, &poll.CreatedAt); err != nil {
+		log.Printf("Repository layer: Error creating poll: %v", err)
+		return nil, fmt.Errorf("error creating poll: %w", err)
+	}
+
+	optionQuery := `INSERT INTO poll_option (poll_id, position, text) VALUES ($1, $2, $3) RETURNING id`
+	for i := range poll.Options {
+		if err := tx.QueryRow(optionQuery, poll.ID, i, poll.Options[i].Text).Scan(&poll.Options[i].ID); err != nil {
+			log.Printf("Repository layer: Error creating poll option: %v", err)
+			return nil, fmt.Errorf("error creating poll option: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return poll, nil
+}
+
+func (repo *PollRepositoryImpl) GetPollByID(pollID int) (*model.ChatPoll, error) {
+	return repo.getPoll(`WHERE id = $1`, pollID)
+}
+
+func (repo *PollRepositoryImpl) GetPollByMessageID(messageID string) (*model.ChatPoll, error) {
+	return repo.getPoll(`WHERE message_id = $1::uuid`, messageID)
+}
+
+// getPoll 은 투표와 선택지별 집계, 투표자 목록을 조회합니다.
+func (repo *PollRepositoryImpl) getPoll(where string, arg interface{}) (*model.ChatPoll, error) {
+	query := `
+	SELECT id, message_id, fit_group_id, created_by, question, multiple_choice, anonymous, deadline, closed_at, created_at,
+		(SELECT COUNT(DISTINCT user_id) FROM poll_vote WHERE poll_id = poll.id)
+	FROM poll
+	` + where
+	var p model.ChatPoll
+	var deadline, closedAt sql.NullTime
+	err := repo.DB.QueryRow(query, arg).Scan(&p.ID, &p.MessageID, &p.FitGroupID, &p.CreatedBy, &p.Question, &p.MultipleChoice, &p.Anonymous, &deadline, &closedAt, &p.CreatedAt, &p.TotalVoters)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Printf("Repository layer: No poll found for %v", arg)
+			return nil, fmt.Errorf("no poll found for %v", arg)
+		}
+		log.Printf("Repository layer: Error querying poll: %v", err)
+		return nil, err
+	}
+	if deadline.Valid {
+		p.Deadline = &deadline.Time
+	}
+	if closedAt.Valid {
+		p.ClosedAt = &closedAt.Time
+	}
+
+	optionQuery := `
+	SELECT o.id, o.text, v.user_id
+	FROM poll_option o
+	LEFT JOIN poll_vote v ON v.option_id = o.id
+	WHERE o.poll_id = $1
+	ORDER BY o.position, v.voted_at
+	`
+	rows, err := repo.DB.Query(optionQuery, p.ID)
+	if err != nil {
+		log.Printf("Repository layer: Error querying poll options: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var optionID int
+		var text string
+		var voter sql.NullInt64
+		if err := rows.Scan(&optionID, &text, &voter); err != nil {
+			return nil, err
+		}
+		if n := len(p.Options); n == 0 || p.Options[n-1].ID != optionID {
+			p.Options = append(p.Options, model.PollOption{ID: optionID, Text: text})
+		}
+		if voter.Valid {
+			option := &p.Options[len(p.Options)-1]
+			option.VoteCount++
+			option.VoterUserIDs = append(option.VoterUserIDs, int(voter.Int64))
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// ReplaceVotes 는 사용자의 기존 투표를 optionIDs 로 바꿉니다.
+// 투표가 종료되었거나 마감되었으면 아무것도 바꾸지 않고 false 를 반환합니다.
+func (repo *PollRepositoryImpl) ReplaceVotes(pollID, userID int, optionIDs []int) (bool, error) {
+	tx, err := repo.DB.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	// 종료 처리와 동시에 실행되지 않도록 투표 행을 잠금
+	var open bool
+	lockQuery := `
+	SELECT closed_at IS NULL AND (deadline IS NULL OR deadline > NOW())
+	FROM poll WHERE id = $1 FOR UPDATE
+	`
+	if err := tx.QueryRow(lockQuery, pollID).Scan(&open); err != nil {
+		log.Printf("Repository layer: Error locking poll: %v", err)
+		return false, fmt.Errorf("error locking poll: %w", err)
+	}
+	if !open {
+		return false, nil
+	}
+
+	if _, err := tx.Exec(`DELETE FROM poll_vote WHERE poll_id = $1 AND user_id = $2`, pollID, userID); err != nil {
+		log.Printf("Repository layer: Error deleting poll votes: %v", err)
+		return false, fmt.Errorf("error deleting poll votes: %w", err)
+	}
+	for _, optionID := range optionIDs {
+		if _, err := tx.Exec(`INSERT INTO poll_vote (poll_id, option_id, user_id, voted_at) VALUES ($1, $2, $3, NOW())`, pollID, optionID, userID); err != nil {
+			log.Printf("Repository layer: Error saving poll vote: %v", err)
+			return false, fmt.Errorf("error saving poll vote: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (repo *PollRepositoryImpl) GetUserVotes(pollID, userID int) ([]int, error) {
+	query := `SELECT option_id FROM poll_vote WHERE poll_id = $1 AND user_id = $2`
+	rows, err := repo.DB.Query(query, pollID, userID)
+	if err != nil {
+		log.Printf("Repository layer: Error querying user poll votes: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var optionIDs []int
+	for rows.Next() {
+		var optionID int
+		if err := rows.Scan(&optionID); err != nil {
+			return nil, err
+		}
+		optionIDs = append(optionIDs, optionID)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return optionIDs, nil
+}
+
+// ClosePoll 은 투표를 종료합니다. 이미 종료된 투표면 false 를 반환하므로 결과 메시지는 한 번만 보내게 됩니다.
+func (repo *PollRepositoryImpl) ClosePoll(pollID int) (bool, error) {
+	result, err := repo.DB.Exec(`UPDATE poll SET closed_at = NOW() WHERE id = $1 AND closed_at IS NULL`, pollID)
+	if err != nil {
+		log.Printf("Repository layer: Error closing poll: %v", err)
+		return false, fmt.Errorf("error closing poll: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// GetExpiredOpenPollIDs 는 마감 시간이 지났지만 아직 종료 처리되지 않은 투표를 조회합니다.
+func (repo *PollRepositoryImpl) GetExpiredOpenPollIDs(now time.Time) ([]int, error) {
+	query := `SELECT id FROM poll WHERE closed_at IS NULL AND deadline <= $1 ORDER BY deadline`
+	rows, err := repo.DB.Query(query, now)
+	if err != nil {
+		log.Printf("Repository layer: Error querying expired polls: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var pollIDs []int
+	for rows.Next() {
+		var pollID int
+		if err := rows.Scan(&pollID); err != nil {
+			return nil, err
+		}
+		pollIDs = append(pollIDs, pollID)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return pollIDs, nil
+}
+
+// RestorePoll 은 아카이브한 투표를 선택지, 투표 결과와 함께 다시 저장합니다. 투표 메시지는 먼저 복원되어 있어야 합니다.
+// 이미 같은 메시지의 투표가 있으면 저장하지 않고 false 를 반환합니다.
+// 투표 시간은 아카이브하지 않으므로 종료 시간(없으면 생성 시간)으로 기록합니다.
+func (repo *PollRepositoryImpl) RestorePoll(poll *model.ChatPoll) (bool, error) {
+	tx, err := repo.DB.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	query := `
+	INSERT INTO poll (message_id, fit_group_id, created_by, question, multiple_choice, anonymous, deadline, closed_at, created_at)
+	VALUES ($1::uuid, $2, $3, $4, $5, $6, $7, $8, $9)
+	ON CONFLICT (message_id) DO NOTHING
+	RETURNING id
+	`
+	var pollID int
+	err = tx.QueryRow(query, poll.MessageID, poll.FitGroupID, poll.CreatedBy, poll.Question, poll.MultipleChoice, poll.Anonymous, poll.Deadline, poll.ClosedAt, poll.CreatedAt).Scan(&pollID)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		log.Printf("Repository layer: Error restoring poll: %v", err)
+		return false, fmt.Errorf("error restoring poll: %w", err)
+	}
+
+	votedAt := poll.CreatedAt
+	if poll.ClosedAt != nil {
+		votedAt = *poll.ClosedAt
+	}
+	optionQuery := `INSERT INTO poll_option (poll_id, position, text) VALUES ($1, $2, $3) RETURNING id`
+	for i, option := range poll.Options {
+		var optionID int
+		if err := tx.QueryRow(optionQuery, pollID, i, option.Text).Scan(&optionID); err != nil {
+			log.Printf("Repository layer: Error restoring poll option: %v", err)
+			return false, fmt.Errorf("error restoring poll option: %w", err)
+		}
+		for _, userID := range option.VoterUserIDs {
+			if _, err := tx.Exec(`INSERT INTO poll_vote (poll_id, option_id, user_id, voted_at) VALUES ($1, $2, $3, $4)`, pollID, optionID, userID, votedAt); err != nil {
+				log.Printf("Repository layer: Error restoring poll vote: %v", err)
+				return false, fmt.Errorf("error restoring poll vote: %w", err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
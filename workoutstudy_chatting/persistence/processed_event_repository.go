@@ -0,0 +1,53 @@
+package persistence
+
+import (
+	"fmt"
+	"log"
+	"time"
+)
+
+// ProcessedEventRepository 는 처리를 마친 Kafka 이벤트를 기록합니다. 재전달된 이벤트를 다시 반영하지 않도록 사용합니다.
+type ProcessedEventRepository interface {
+	IsEventProcessed(topic, eventKey string) (bool, error)
+	MarkEventProcessed(topic, eventKey string) error
+	DeleteProcessedEventsBefore(before time.Time) (int64, error)
+}
+
+type ProcessedEventRepositoryImpl struct {
+	DB *SQLDB
+}
+
+var _ ProcessedEventRepository = (*ProcessedEventRepositoryImpl)(nil)
+
+func NewProcessedEventRepository(db *SQLDB) ProcessedEventRepository {
+	return &ProcessedEventRepositoryImpl{DB: db}
+}
+
+func (repo *ProcessedEventRepositoryImpl) IsEventProcessed(topic, eventKey string) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM processed_event WHERE topic = $1 AND event_key = $2)`
+	var exists bool
+	if err := repo.DB.QueryRow(query, topic, eventKey).Scan(&exists); err != nil {
+		log.Printf("Repository layer: Error checking processed event: %v", err)
+		return false, fmt.Errorf("error checking processed event: %w", err)
+	}
+	return exists, nil
+}
+
+// MarkEventProcessed 는 이미 기록된 이벤트면 아무것도 하지 않습니다.
+func (repo *ProcessedEventRepositoryImpl) MarkEventProcessed(topic, eventKey string) error {
+	query := `INSERT INTO processed_event (topic, event_key, processed_at) VALUES ($1, $2, NOW()) ON CONFLICT (topic, event_key) DO NOTHING`
+	if _, err := repo.DB.Exec(query, topic, eventKey); err != nil {
+		log.Printf("Repository layer: Error marking processed event: %v", err)
+		return fmt.Errorf("error marking processed event: %w", err)
+	}
+	return nil
+}
+
+// DeleteProcessedEventsBefore 는 before 이전에 처리한 기록을 지우고 지운 개수를 반환합니다.
+func (repo *ProcessedEventRepositoryImpl) DeleteProcessedEventsBefore(before time.Time) (int64, error) {
+	result, err := repo.DB.Exec(`DELETE FROM processed_event WHERE processed_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("error deleting processed events: %w", err)
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,156 @@
+package persistence
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+	"workoutstudy_chatting/model"
+
+	"github.com/lib/pq"
+)
+
+type ReminderRepository interface {
+	SaveReminder(reminder *model.Reminder) (*model.Reminder, error)
+	GetRemindersByFitGroup(fitGroupID int) ([]model.Reminder, error)
+	DeleteReminder(fitGroupID, reminderID int) (bool, error)
+	GetDueReminders(now time.Time, limit int) ([]model.Reminder, error)
+	AdvanceReminder(reminderID int, expectedNextRunAt, nextRunAt time.Time, ranAt *time.Time) (bool, error)
+	DisableReminder(reminderID int, expectedNextRunAt time.Time, reason string) (bool, error)
+}
+
+type ReminderRepositoryImpl struct {
+	DB *SQLDB
+}
+
+var _ ReminderRepository = (*ReminderRepositoryImpl)(nil)
+
+func NewReminderRepository(db *SQLDB) ReminderRepository {
+	return &ReminderRepositoryImpl{DB: db}
+}
+
+const reminderColumns = `id, fit_group_id, recurrence, message, time_of_day, weekdays, days_before, next_run_at, last_run_at, created_by, created_at, disabled_at, disabled_reason`
+
+func (repo *ReminderRepositoryImpl) SaveReminder(reminder *model.Reminder) (*model.Reminder, error) {
+	query := `
+	INSERT INTO scheduled_reminder (fit_group_id, recurrence, message, time_of_day, weekdays, days_before, next_run_at, created_by, created_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW())
+	RETURNING ` + reminderColumns
+	weekdays := pq.Int64Array{}
+	for _, d := range reminder.Weekdays {
+		weekdays = append(weekdays, int64(d))
+	}
+	saved, err := scanReminder(repo.DB.QueryRow(query, reminder.FitGroupID, reminder.Recurrence, reminder.Message, reminder.TimeOfDay,
+		weekdays, reminder.DaysBefore, reminder.NextRunAt, reminder.CreatedBy))
+	if err != nil {
+		log.Printf("Repository layer: Error saving reminder: %v", err)
+		return nil, fmt.Errorf("error saving reminder: %w", err)
+	}
+	return saved, nil
+}
+
+func (repo *ReminderRepositoryImpl) GetRemindersByFitGroup(fitGroupID int) ([]model.Reminder, error) {
+	query := `SELECT ` + reminderColumns + ` FROM scheduled_reminder WHERE fit_group_id = $1 ORDER BY id`
+	return repo.queryReminders(query, fitGroupID)
+}
+
+func (repo *ReminderRepositoryImpl) DeleteReminder(fitGroupID, reminderID int) (bool, error) {
+	result, err := repo.DB.Exec(`DELETE FROM scheduled_reminder WHERE id = $1 AND fit_group_id = $2`, reminderID, fitGroupID)
+	if err != nil {
+		log.Printf("Repository layer: Error deleting reminder: %v", err)
+		return false, fmt.Errorf("error deleting reminder: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// GetDueReminders 는 실행 시간이 지난 리마인더를 오래된 순으로 조회합니다. 비활성화된 리마인더는 제외합니다.
+func (repo *ReminderRepositoryImpl) GetDueReminders(now time.Time, limit int) ([]model.Reminder, error) {
+	query := `SELECT ` + reminderColumns + ` FROM scheduled_reminder WHERE next_run_at <= $1 AND disabled_at IS NULL ORDER BY next_run_at LIMIT $2`
+	return repo.queryReminders(query, now, limit)
+}
+
+// AdvanceReminder 는 next_run_at 이 expectedNextRunAt 일 때만 다음 실행 시간을 갱신합니다.
+// 여러 인스턴스가 같은 리마인더를 가져가도 갱신에 성공한 한 곳에서만 실행하기 위한 조건부 갱신입니다.
+// ranAt 이 nil 이면 실행하지 않고 건너뛴 것으로 보고 last_run_at 을 유지합니다.
+func (repo *ReminderRepositoryImpl) AdvanceReminder(reminderID int, expectedNextRunAt, nextRunAt time.Time, ranAt *time.Time) (bool, error) {
+	query := `
+	UPDATE scheduled_reminder SET next_run_at = $3, last_run_at = COALESCE($4, last_run_at)
+	WHERE id = $1 AND next_run_at = $2
+	`
+	result, err := repo.DB.Exec(query, reminderID, expectedNextRunAt, nextRunAt, ranAt)
+	if err != nil {
+		log.Printf("Repository layer: Error advancing reminder: %v", err)
+		return false, fmt.Errorf("error advancing reminder: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// DisableReminder 는 next_run_at 이 expectedNextRunAt 일 때만 리마인더를 비활성화합니다.
+// AdvanceReminder 와 같은 조건부 갱신으로, 여러 인스턴스 중 갱신에 성공한 한 곳에서만 true 를 반환합니다.
+func (repo *ReminderRepositoryImpl) DisableReminder(reminderID int, expectedNextRunAt time.Time, reason string) (bool, error) {
+	query := `
+	UPDATE scheduled_reminder SET disabled_at = NOW(), disabled_reason = $3
+	WHERE id = $1 AND next_run_at = $2 AND disabled_at IS NULL
+	`
+	result, err := repo.DB.Exec(query, reminderID, expectedNextRunAt, reason)
+	if err != nil {
+		log.Printf("Repository layer: Error disabling reminder: %v", err)
+		return false, fmt.Errorf("error disabling reminder: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+func (repo *ReminderRepositoryImpl) queryReminders(query string, args ...interface{}) ([]model.Reminder, error) {
+	rows, err := repo.DB.Query(query, args...)
+	if err != nil {
+		log.Printf("Repository layer: Error querying reminders: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reminders []model.Reminder
+	for rows.Next() {
+		r, err := scanReminder(rows)
+		if err != nil {
+			return nil, err
+		}
+		reminders = append(reminders, *r)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return reminders, nil
+}
+
+func scanReminder(row rowScanner) (*model.Reminder, error) {
+	var r model.Reminder
+	var weekdays pq.Int64Array
+	var lastRunAt, disabledAt sql.NullTime
+	var disabledReason sql.NullString
+	if err := row.Scan(&r.ID, &r.FitGroupID, &r.Recurrence, &r.Message, &r.TimeOfDay, &weekdays, &r.DaysBefore, &r.NextRunAt, &lastRunAt, &r.CreatedBy, &r.CreatedAt, &disabledAt, &disabledReason); err != nil {
+		return nil, err
+	}
+	for _, d := range weekdays {
+		r.Weekdays = append(r.Weekdays, int(d))
+	}
+	if lastRunAt.Valid {
+		r.LastRunAt = &lastRunAt.Time
+	}
+	if disabledAt.Valid {
+		r.DisabledAt = &disabledAt.Time
+		r.DisabledReason = disabledReason.String
+	}
+	return &r, nil
+}
@@ -0,0 +1,41 @@
+package persistence
+
+// Repositories 는 서비스가 사용하는 repository 묶음입니다. 저장소 종류(database.driver)에 따라 구현이 달라집니다.
+type Repositories struct {
+	Chat                ChatRepository
+	FitGroup            FitGroupRepository
+	FitMate             FitMateRepository
+	User                UserRepository
+	ChatRestriction     ChatRestrictionRepository
+	ChatRoomSetting     ChatRoomSettingRepository
+	DirectMessage       DirectMessageRepository
+	Moderation          ModerationRepository
+	ModerationAudit     ModerationAuditRepository
+	PinnedMessage       PinnedMessageRepository
+	Poll                PollRepository
+	Reminder            ReminderRepository
+	Retention           RetentionRepository
+	ProcessedEvent      ProcessedEventRepository
+	PendingFitMateEvent PendingFitMateEventRepository
+}
+
+// NewSQLRepositories 는 db.Dialect(Postgres, SQLite) 로 쿼리하는 repository 묶음을 반환합니다.
+func NewSQLRepositories(db *SQLDB) Repositories {
+	return Repositories{
+		Chat:                NewChatRepository(db),
+		FitGroup:            NewFitGroupRepository(db),
+		FitMate:             NewPostgresFitMateRepository(db),
+		User:                NewUserRepository(db),
+		ChatRestriction:     NewChatRestrictionRepository(db),
+		ChatRoomSetting:     NewChatRoomSettingRepository(db),
+		DirectMessage:       NewDirectMessageRepository(db),
+		Moderation:          NewModerationRepository(db),
+		ModerationAudit:     NewModerationAuditRepository(db),
+		PinnedMessage:       NewPinnedMessageRepository(db),
+		Poll:                NewPollRepository(db),
+		Reminder:            NewReminderRepository(db),
+		Retention:           NewRetentionRepository(db),
+		ProcessedEvent:      NewProcessedEventRepository(db),
+		PendingFitMateEvent: NewPendingFitMateEventRepository(db),
+	}
+}
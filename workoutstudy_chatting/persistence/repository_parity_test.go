@@ -0,0 +1,279 @@
+package persistence
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+	"workoutstudy_chatting/config"
+	"workoutstudy_chatting/model"
+)
+
+// backends 는 같은 테스트를 실행할 저장소 구현(메모리, SQLite)을 새로 만들어 반환합니다.
+func backends(t *testing.T) map[string]Repositories {
+	t.Helper()
+	cfg := config.Default().Database
+	cfg.Driver = config.DriverSQLite
+	cfg.Path = filepath.Join(t.TempDir(), "parity.db")
+	db := InitializeDB(cfg)
+	t.Cleanup(func() { db.Close() })
+
+	return map[string]Repositories{
+		"memory": NewMemoryRepositories(NewMemoryStore()),
+		"sqlite": NewSQLRepositories(db),
+	}
+}
+
+// seedFitGroup 은 리더 사용자와 fit group 을 저장합니다.
+func seedFitGroup(t *testing.T, repos Repositories, fitGroupID, leaderID int) {
+	t.Helper()
+	now := time.Now()
+	if _, err := repos.User.SaveUser(&model.User{ID: leaderID, Nickname: "leader", CreatedAt: now, CreatedBy: "test", UpdatedAt: now, UpdatedBy: "test"}); err != nil {
+		t.Fatalf("SaveUser: %v", err)
+	}
+	if _, err := repos.FitGroup.SaveFitGroup(&model.FitGroup{ID: fitGroupID, FitLeaderUserID: leaderID, FitGroupName: "group", Cycle: 1, Frequency: 3, MaxFitMate: 10, CreatedBy: "test"}); err != nil {
+		t.Fatalf("SaveFitGroup: %v", err)
+	}
+}
+
+func TestRepositoryParity(t *testing.T) {
+	base := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repos Repositories)
+	}{
+		{
+			name: "없는 fit group 은 sql.ErrNoRows",
+			run: func(t *testing.T, repos Repositories) {
+				if _, err := repos.FitGroup.GetFitGroupByID(404); !errors.Is(err, sql.ErrNoRows) {
+					t.Fatalf("GetFitGroupByID err = %v, want sql.ErrNoRows", err)
+				}
+			},
+		},
+		{
+			name: "없는 사용자는 에러",
+			run: func(t *testing.T, repos Repositories) {
+				if user, err := repos.User.GetUserByID(404); err == nil {
+					t.Fatalf("GetUserByID = %+v, want error", user)
+				}
+			},
+		},
+		{
+			name: "설정이 없는 채팅방은 기본값",
+			run: func(t *testing.T, repos Repositories) {
+				setting, err := repos.ChatRoomSetting.GetChatRoomSetting(404)
+				if err != nil {
+					t.Fatalf("GetChatRoomSetting: %v", err)
+				}
+				if setting.SlowModeSeconds != 0 || setting.TimeZone != model.DefaultTimeZone {
+					t.Fatalf("setting = %+v, want defaults", setting)
+				}
+			},
+		},
+		{
+			name: "사용자 저장은 생성 정보를 두고 덮어씀",
+			run: func(t *testing.T, repos Repositories) {
+				created := base
+				for i, nickname := range []string{"first", "second"} {
+					at := base.Add(time.Duration(i) * time.Hour)
+					user := &model.User{ID: 1, Nickname: nickname, CreatedAt: at, CreatedBy: nickname, UpdatedAt: at, UpdatedBy: nickname}
+					if _, err := repos.User.SaveUser(user); err != nil {
+						t.Fatalf("SaveUser %s: %v", nickname, err)
+					}
+				}
+				user, err := repos.User.GetUserByID(1)
+				if err != nil {
+					t.Fatalf("GetUserByID: %v", err)
+				}
+				if user.Nickname != "second" || user.UpdatedBy != "second" || user.CreatedBy != "first" || !user.CreatedAt.Equal(created) {
+					t.Fatalf("user = %+v, want nickname/updatedBy second, createdBy first at %v", user, created)
+				}
+				// 0 은 마이그레이션이 넣는 시스템 사용자(핏봇)
+				if ids, _ := repos.User.GetUserIDs(); !reflect.DeepEqual(ids, []int{0, 1}) {
+					t.Fatalf("GetUserIDs = %v, want [0 1]", ids)
+				}
+			},
+		},
+		{
+			name: "fit group 저장은 같은 ID 를 덮어쓰고 ID 순으로 조회",
+			run: func(t *testing.T, repos Repositories) {
+				seedFitGroup(t, repos, 30, 1)
+				seedFitGroup(t, repos, 10, 1)
+				updated := &model.FitGroup{ID: 30, FitLeaderUserID: 1, FitGroupName: "renamed", Cycle: 2, Frequency: 5, MaxFitMate: 10, CreatedBy: "other"}
+				if _, err := repos.FitGroup.SaveFitGroup(updated); err != nil {
+					t.Fatalf("SaveFitGroup: %v", err)
+				}
+				fitGroup, err := repos.FitGroup.GetFitGroupByID(30)
+				if err != nil {
+					t.Fatalf("GetFitGroupByID: %v", err)
+				}
+				if fitGroup.FitGroupName != "renamed" || fitGroup.Cycle != 2 || fitGroup.CreatedBy != "test" || fitGroup.UpdatedBy != "other" {
+					t.Fatalf("fitGroup = %+v", fitGroup)
+				}
+				if ids, _ := repos.FitGroup.GetFitGroupIDs(); !reflect.DeepEqual(ids, []int{10, 30}) {
+					t.Fatalf("GetFitGroupIDs = %v, want [10 30]", ids)
+				}
+			},
+		},
+		{
+			name: "fit mate 저장은 같은 ID 를 덮어쓰고 fit group 연결을 옮김",
+			run: func(t *testing.T, repos Repositories) {
+				seedFitGroup(t, repos, 1, 1)
+				seedFitGroup(t, repos, 2, 1)
+				for _, fitMate := range []*model.FitMate{
+					{ID: 5, UserID: 1, FitGroupID: 1, State: true, CreatedBy: "first"},
+					{ID: 5, UserID: 1, FitGroupID: 1, State: true, CreatedBy: "first"}, // 재전달
+					{ID: 5, UserID: 1, FitGroupID: 2, State: false, CreatedBy: "second"},
+				} {
+					if _, err := repos.FitMate.SaveFitMate(fitMate); err != nil {
+						t.Fatalf("SaveFitMate: %v", err)
+					}
+				}
+				fitMate, err := repos.FitMate.GetFitMateByID("5")
+				if err != nil {
+					t.Fatalf("GetFitMateByID: %v", err)
+				}
+				if fitMate.FitGroupID != 2 || fitMate.State || fitMate.CreatedBy != "first" || fitMate.UpdatedBy != "second" {
+					t.Fatalf("fitMate = %+v", fitMate)
+				}
+				if ids, _ := repos.FitMate.GetFitMatesIdsByFitGroupId(1); len(ids) != 0 {
+					t.Fatalf("fit group 1 mates = %v, want none", ids)
+				}
+				if ids, _ := repos.FitMate.GetFitMatesIdsByFitGroupId(2); !reflect.DeepEqual(ids, []int{5}) {
+					t.Fatalf("fit group 2 mates = %v, want [5]", ids)
+				}
+			},
+		},
+		{
+			name: "채팅방 설정 저장은 다른 설정을 유지",
+			run: func(t *testing.T, repos Repositories) {
+				seedFitGroup(t, repos, 1, 1)
+				if _, err := repos.ChatRoomSetting.SaveSlowMode(1, 30, "leader"); err != nil {
+					t.Fatalf("SaveSlowMode: %v", err)
+				}
+				if _, err := repos.ChatRoomSetting.SaveTimeZone(1, "America/New_York", "leader"); err != nil {
+					t.Fatalf("SaveTimeZone: %v", err)
+				}
+				setting, err := repos.ChatRoomSetting.SaveSlowMode(1, 10, "admin")
+				if err != nil {
+					t.Fatalf("SaveSlowMode: %v", err)
+				}
+				if setting.SlowModeSeconds != 10 || setting.TimeZone != "America/New_York" || setting.UpdatedBy != "admin" {
+					t.Fatalf("setting = %+v", setting)
+				}
+			},
+		},
+		{
+			name: "리마인더는 fit group 별로 ID 순, 실행할 것은 실행 시간 순",
+			run: func(t *testing.T, repos Repositories) {
+				seedFitGroup(t, repos, 1, 1)
+				seedFitGroup(t, repos, 2, 1)
+				save := func(fitGroupID int, nextRunAt time.Time) int {
+					r, err := repos.Reminder.SaveReminder(&model.Reminder{FitGroupID: fitGroupID, Recurrence: "DAILY", Message: "운동", TimeOfDay: "09:00", NextRunAt: nextRunAt, CreatedBy: 1})
+					if err != nil {
+						t.Fatalf("SaveReminder: %v", err)
+					}
+					return r.ID
+				}
+				late := save(1, base.Add(2*time.Hour))
+				early := save(2, base)
+				middle := save(1, base.Add(time.Hour))
+				future := save(1, base.Add(48*time.Hour))
+
+				var byGroup []int
+				reminders, err := repos.Reminder.GetRemindersByFitGroup(1)
+				if err != nil {
+					t.Fatalf("GetRemindersByFitGroup: %v", err)
+				}
+				for _, r := range reminders {
+					byGroup = append(byGroup, r.ID)
+				}
+				if want := []int{late, middle, future}; !reflect.DeepEqual(byGroup, want) {
+					t.Fatalf("GetRemindersByFitGroup = %v, want %v", byGroup, want)
+				}
+
+				due := func(limit int) []int {
+					reminders, err := repos.Reminder.GetDueReminders(base.Add(3*time.Hour), limit)
+					if err != nil {
+						t.Fatalf("GetDueReminders: %v", err)
+					}
+					var ids []int
+					for _, r := range reminders {
+						ids = append(ids, r.ID)
+					}
+					return ids
+				}
+				if got, want := due(10), []int{early, middle, late}; !reflect.DeepEqual(got, want) {
+					t.Fatalf("GetDueReminders = %v, want %v", got, want)
+				}
+				if got, want := due(2), []int{early, middle}; !reflect.DeepEqual(got, want) {
+					t.Fatalf("GetDueReminders limit 2 = %v, want %v", got, want)
+				}
+
+				// 비활성화는 한 번만 성공하고, 비활성화된 리마인더는 실행 대상에서 빠짐
+				if ok, err := repos.Reminder.DisableReminder(middle, base.Add(time.Hour), "invalid"); err != nil || !ok {
+					t.Fatalf("DisableReminder = %v, %v, want true", ok, err)
+				}
+				if ok, _ := repos.Reminder.DisableReminder(middle, base.Add(time.Hour), "invalid"); ok {
+					t.Fatal("DisableReminder succeeded twice")
+				}
+				if got, want := due(10), []int{early, late}; !reflect.DeepEqual(got, want) {
+					t.Fatalf("GetDueReminders after disable = %v, want %v", got, want)
+				}
+			},
+		},
+		{
+			name: "리마인더 갱신은 예상한 실행 시간일 때만 성공",
+			run: func(t *testing.T, repos Repositories) {
+				seedFitGroup(t, repos, 1, 1)
+				r, err := repos.Reminder.SaveReminder(&model.Reminder{FitGroupID: 1, Recurrence: "DAILY", TimeOfDay: "09:00", NextRunAt: base, CreatedBy: 1})
+				if err != nil {
+					t.Fatalf("SaveReminder: %v", err)
+				}
+				next := base.Add(24 * time.Hour)
+				if ok, err := repos.Reminder.AdvanceReminder(r.ID, base, next, &base); err != nil || !ok {
+					t.Fatalf("AdvanceReminder = %v, %v, want true", ok, err)
+				}
+				if ok, _ := repos.Reminder.AdvanceReminder(r.ID, base, next.Add(24*time.Hour), &base); ok {
+					t.Fatal("AdvanceReminder with stale next_run_at succeeded")
+				}
+				if ok, _ := repos.Reminder.DeleteReminder(2, r.ID); ok {
+					t.Fatal("DeleteReminder from another fit group succeeded")
+				}
+				if ok, _ := repos.Reminder.DeleteReminder(1, r.ID); !ok {
+					t.Fatal("DeleteReminder failed")
+				}
+			},
+		},
+		{
+			name: "처리한 이벤트 기록은 중복 저장해도 하나",
+			run: func(t *testing.T, repos Repositories) {
+				for i := 0; i < 2; i++ {
+					if err := repos.ProcessedEvent.MarkEventProcessed("fit-group", "1-42"); err != nil {
+						t.Fatalf("MarkEventProcessed: %v", err)
+					}
+				}
+				if ok, err := repos.ProcessedEvent.IsEventProcessed("fit-group", "1-42"); err != nil || !ok {
+					t.Fatalf("IsEventProcessed = %v, %v, want true", ok, err)
+				}
+				if ok, _ := repos.ProcessedEvent.IsEventProcessed("fit-mate", "1-42"); ok {
+					t.Fatal("IsEventProcessed matched another topic")
+				}
+				deleted, err := repos.ProcessedEvent.DeleteProcessedEventsBefore(time.Now().Add(time.Hour))
+				if err != nil || deleted != 1 {
+					t.Fatalf("DeleteProcessedEventsBefore = %d, %v, want 1", deleted, err)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, repos := range backends(t) {
+				t.Run(name, func(t *testing.T) { tt.run(t, repos) })
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package persistence
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"workoutstudy_chatting/model"
+)
+
+type RetentionRepository interface {
+	GetRetentionPolicies() ([]model.RetentionPolicy, error)
+	GetRetentionPolicy(fitGroupID int) (*model.RetentionPolicy, error)
+	SaveRetentionPolicy(policy *model.RetentionPolicy) (*model.RetentionPolicy, error)
+	DeleteRetentionPolicy(fitGroupID int) error
+}
+
+type RetentionRepositoryImpl struct {
+	DB *SQLDB
+}
+
+var _ RetentionRepository = (*RetentionRepositoryImpl)(nil)
+
+func NewRetentionRepository(db *SQLDB) RetentionRepository {
+	return &RetentionRepositoryImpl{DB: db}
+}
+
+func (repo *RetentionRepositoryImpl) GetRetentionPolicies() ([]model.RetentionPolicy, error) {
+	query := `
+	SELECT fit_group_id, retention_days, created_at, created_by, updated_at, updated_by
+	FROM message_retention_policy
+	ORDER BY fit_group_id
+	`
+	rows, err := repo.DB.Query(query)
+	if err != nil {
+		log.Printf("Repository layer: Error retrieving retention policies: %v", err)
+		return nil, fmt.Errorf("error retrieving retention policies: %w", err)
+	}
+	defer rows.Close()
+
+	var policies []model.RetentionPolicy
+	for rows.Next() {
+		var p model.RetentionPolicy
+		if err := rows.Scan(&p.FitGroupID, &p.RetentionDays, &p.CreatedAt, &p.CreatedBy, &p.UpdatedAt, &p.UpdatedBy); err != nil {
+			return nil, err
+		}
+		policies = append(policies, p)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return policies, nil
+}
+
+// GetRetentionPolicy 는 fit group 별 정책이 없으면 nil, nil 을 반환합니다.
+func (repo *RetentionRepositoryImpl) GetRetentionPolicy(fitGroupID int) (*model.RetentionPolicy, error) {
+	query := `
+	SELECT fit_group_id, retention_days, created_at, created_by, updated_at, updated_by
+	FROM message_retention_policy
+	WHERE fit_group_id = $1
+	`
+	var p model.RetentionPolicy
+	err := repo.DB.QueryRow(query, fitGroupID).Scan(&p.FitGroupID, &p.RetentionDays, &p.CreatedAt, &p.CreatedBy, &p.UpdatedAt, &p.UpdatedBy)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		log.Printf("Repository layer: Error querying retention policy for fitGroupID %d: %v", fitGroupID, err)
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (repo *RetentionRepositoryImpl) SaveRetentionPolicy(policy *model.RetentionPolicy) (*model.RetentionPolicy, error) {
+	query := `
+	INSERT INTO message_retention_policy (fit_group_id, retention_days, created_at, created_by, updated_at, updated_by)
+	VALUES ($1, $2, NOW(), $3, NOW(), $3)
+	ON CONFLICT (fit_group_id) DO UPDATE
+	SET retention_days = EXCLUDED.retention_days, updated_at = NOW(), updated_by = EXCLUDED.updated_by
+	RETURNING created_at, created_by, updated_at, updated_by
+	`
+	err := repo.DB.QueryRow(query, policy.FitGroupID, policy.RetentionDays, policy.UpdatedBy).
+		Scan(&policy.CreatedAt, &policy.CreatedBy, &policy.UpdatedAt, &policy.UpdatedBy)
+	if err != nil {
+		log.Printf("Repository layer: Error saving retention policy: %v", err)
+		return nil, fmt.Errorf("error saving retention policy: %w", err)
+	}
+	return policy, nil
+}
+
+func (repo *RetentionRepositoryImpl) DeleteRetentionPolicy(fitGroupID int) error {
+	query := `DELETE FROM message_retention_policy WHERE fit_group_id = $1`
+	if _, err := repo.DB.Exec(query, fitGroupID); err != nil {
+		log.Printf("Repository layer: Error deleting retention policy: %v", err)
+		return fmt.Errorf("error deleting retention policy: %w", err)
+	}
+	return nil
+}
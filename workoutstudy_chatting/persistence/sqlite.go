@@ -0,0 +1,42 @@
+package persistence
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"net/url"
+	"time"
+
+	"modernc.org/sqlite"
+)
+
+// SQLite 에 없는 Postgres 함수를 등록합니다. 연결을 열기 전에 등록해야 모든 연결에서 사용할 수 있습니다.
+func init() {
+	// NOW() 는 Arg 로 저장한 시간과 비교할 수 있도록 같은 형식의 UTC 문자열을 반환합니다.
+	sqlite.MustRegisterScalarFunction("now", 0, func(*sqlite.FunctionContext, []driver.Value) (driver.Value, error) {
+		return formatSQLiteTime(time.Now()), nil
+	})
+	// uuid($n) 은 $n::uuid 처럼 UUID 를 정규화하고 잘못된 값이면 오류를 반환합니다.
+	sqlite.MustRegisterDeterministicScalarFunction("uuid", 1, func(_ *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+		switch v := args[0].(type) {
+		case nil:
+			return nil, nil
+		case string:
+			return canonicalUUID(v)
+		case []byte:
+			return canonicalUUID(string(v))
+		default:
+			return nil, fmt.Errorf("cannot cast type %T to uuid", v)
+		}
+	})
+}
+
+// sqliteDSN 은 modernc.org/sqlite 연결 문자열입니다.
+// 외래 키를 검사하고, 쓰기 트랜잭션이 겹치면 기다리며, 트랜잭션 시작 시 쓰기 잠금을 잡습니다.
+func sqliteDSN(path string) string {
+	params := url.Values{}
+	params.Add("_pragma", "foreign_keys(1)")
+	params.Add("_pragma", "busy_timeout(5000)")
+	params.Add("_pragma", "journal_mode(WAL)")
+	params.Set("_txlock", "immediate")
+	return "file:" + path + "?" + params.Encode()
+}
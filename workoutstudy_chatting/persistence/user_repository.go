@@ -12,22 +12,27 @@ type UserRepository interface {
 	UpdateUser(user *model.User) (*model.User, error)
 	DeleteUser(userID int) error
 	GetUserByID(userID int) (*model.User, error)
+	GetUserIDs() ([]int, error)
 }
 
 type UserRepositoryImpl struct {
-	DB *sql.DB
+	DB *SQLDB
 }
 
 var _ UserRepository = &UserRepositoryImpl{}
 
-func NewUserRepository(db *sql.DB) UserRepository {
+func NewUserRepository(db *SQLDB) UserRepository {
 	return &UserRepositoryImpl{DB: db}
 }
 
+// SaveUser 는 같은 ID 의 사용자가 있으면 생성 정보는 두고 나머지를 덮어씁니다. (이벤트 재처리 시 같은 상태로 수렴)
 func (repo *UserRepositoryImpl) SaveUser(user *model.User) (*model.User, error) {
-	query := `INSERT INTO "user" (id, nickname, state, created_at, updated_at) VALUES ($1, $2, $3, $4, $5) RETURNING id`
+	query := `
+	INSERT INTO "user" (id, nickname, state, created_at, created_by, updated_at, updated_by) VALUES ($1, $2, $3, $4, $5, $6, $7)
+	ON CONFLICT (id) DO UPDATE SET nickname = EXCLUDED.nickname, state = EXCLUDED.state, updated_at = EXCLUDED.updated_at, updated_by = EXCLUDED.updated_by
+	RETURNING id`
 
-	err := repo.DB.QueryRow(query, user.ID, user.Nickname, user.State, user.CreatedAt, user.UpdatedAt).Scan(&user.ID)
+	err := repo.DB.QueryRow(query, user.ID, user.Nickname, user.State, user.CreatedAt, user.CreatedBy, user.UpdatedAt, user.UpdatedBy).Scan(&user.ID)
 	if err != nil {
 		log.Printf("Error saving user: %v", err)
 		return nil, fmt.Errorf("error saving user: %w", err)
@@ -36,10 +41,10 @@ func (repo *UserRepositoryImpl) SaveUser(user *model.User) (*model.User, error)
 }
 
 func (repo *UserRepositoryImpl) UpdateUser(user *model.User) (*model.User, error) {
-	query := `UPDATE user SET nickname = $2, state = $3, updated_at = $4 WHERE id = $1 RETURNING id`
+	query := `UPDATE "user" SET nickname = $2, state = $3, updated_at = $4, updated_by = $5 WHERE id = $1 RETURNING id`
 
 	// 쿼리 실행
-	err := repo.DB.QueryRow(query, user.ID, user.Nickname, user.State, user.UpdatedAt).Scan(&user.ID)
+	err := repo.DB.QueryRow(query, user.ID, user.Nickname, user.State, user.UpdatedAt, user.UpdatedBy).Scan(&user.ID)
 	if err != nil {
 		log.Printf("Error updating user: %v", err)
 		return nil, fmt.Errorf("error updating user: %w", err)
@@ -48,7 +53,7 @@ func (repo *UserRepositoryImpl) UpdateUser(user *model.User) (*model.User, error
 }
 
 func (repo *UserRepositoryImpl) DeleteUser(userID int) error {
-	query := `DELETE FROM user WHERE id = $1`
+	query := `DELETE FROM "user" WHERE id = $1`
 
 	// 쿼리 실행
 	_, err := repo.DB.Exec(query, userID)
@@ -60,11 +65,11 @@ func (repo *UserRepositoryImpl) DeleteUser(userID int) error {
 }
 
 func (repo *UserRepositoryImpl) GetUserByID(userID int) (*model.User, error) {
-	query := `SELECT id, nickname, state, created_at, updated_at FROM user WHERE id = $1`
+	query := `SELECT id, nickname, state, created_at, COALESCE(created_by, ''), updated_at, COALESCE(updated_by, '') FROM "user" WHERE id = $1`
 
 	// 쿼리 실행
 	user := model.User{}
-	err := repo.DB.QueryRow(query, userID).Scan(&user.ID, &user.Nickname, &user.State, &user.CreatedAt, &user.UpdatedAt)
+	err := repo.DB.QueryRow(query, userID).Scan(&user.ID, &user.Nickname, &user.State, &user.CreatedAt, &user.CreatedBy, &user.UpdatedAt, &user.UpdatedBy)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			log.Printf("No user found for ID: %v", userID)
@@ -75,3 +80,26 @@ func (repo *UserRepositoryImpl) GetUserByID(userID int) (*model.User, error) {
 	}
 	return &user, nil
 }
+
+// GetUserIDs 는 DB 에 있는 모든 사용자 ID 를 오름차순으로 조회합니다.
+func (repo *UserRepositoryImpl) GetUserIDs() ([]int, error) {
+	rows, err := repo.DB.Query(`SELECT id FROM "user" ORDER BY id`)
+	if err != nil {
+		log.Printf("Error retrieving user IDs: %v", err)
+		return nil, fmt.Errorf("error retrieving user IDs: %w", err)
+	}
+	defer rows.Close()
+
+	var userIDs []int
+	for rows.Next() {
+		var userID int
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("error retrieving user IDs: %w", err)
+		}
+		userIDs = append(userIDs, userID)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error retrieving user IDs: %w", err)
+	}
+	return userIDs, nil
+}
@@ -0,0 +1,99 @@
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Rate 는 초당 보충되는 토큰 수와 최대 버스트 크기입니다.
+type Rate struct {
+	PerSecond float64
+	Burst     int
+}
+
+// TokenBucket 은 단일 키에 대한 토큰 버킷입니다. 동시 사용에 안전합니다.
+type TokenBucket struct {
+	mu     sync.Mutex
+	rate   Rate
+	tokens float64
+	last   time.Time
+}
+
+func NewTokenBucket(rate Rate) *TokenBucket {
+	return &TokenBucket{rate: rate, tokens: float64(rate.Burst), last: time.Now()}
+}
+
+// Allow 는 토큰 1개를 소비할 수 있으면 true 를, 아니면 다음 토큰까지 기다려야 하는 시간을 반환합니다.
+func (b *TokenBucket) Allow() (bool, time.Duration) {
+	return b.allowAt(time.Now())
+}
+
+func (b *TokenBucket) allowAt(now time.Time) (bool, time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.rate.PerSecond <= 0 {
+		return true, 0
+	}
+
+	elapsed := now.Sub(b.last).Seconds()
+	if elapsed > 0 {
+		b.tokens = math.Min(float64(b.rate.Burst), b.tokens+elapsed*b.rate.PerSecond)
+		b.last = now
+	}
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	wait := (1 - b.tokens) / b.rate.PerSecond
+	return false, time.Duration(wait * float64(time.Second))
+}
+
+func (b *TokenBucket) idleSince() time.Time {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.last
+}
+
+// KeyedLimiter 는 키(사용자+채팅방 등) 별로 TokenBucket 을 관리합니다.
+// 오래 사용되지 않은 버킷은 주기적으로 정리합니다.
+type KeyedLimiter struct {
+	mu          sync.Mutex
+	rate        Rate
+	buckets     map[string]*TokenBucket
+	idleTTL     time.Duration
+	lastCleanup time.Time
+}
+
+func NewKeyedLimiter(rate Rate, idleTTL time.Duration) *KeyedLimiter {
+	return &KeyedLimiter{
+		rate:        rate,
+		buckets:     make(map[string]*TokenBucket),
+		idleTTL:     idleTTL,
+		lastCleanup: time.Now(),
+	}
+}
+
+func (l *KeyedLimiter) Allow(key string) (bool, time.Duration) {
+	now := time.Now()
+
+	l.mu.Lock()
+	if now.Sub(l.lastCleanup) > l.idleTTL {
+		for k, b := range l.buckets {
+			if now.Sub(b.idleSince()) > l.idleTTL {
+				delete(l.buckets, k)
+			}
+		}
+		l.lastCleanup = now
+	}
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = NewTokenBucket(l.rate)
+		l.buckets[key] = bucket
+	}
+	l.mu.Unlock()
+
+	return bucket.allowAt(now)
+}
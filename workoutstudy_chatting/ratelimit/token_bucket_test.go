@@ -0,0 +1,91 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTokenBucketAllowAt(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	type call struct {
+		at       time.Duration // start 기준
+		wantOK   bool
+		wantWait time.Duration
+	}
+	tests := []struct {
+		name  string
+		rate  Rate
+		calls []call
+	}{
+		{
+			name: "burst 소진 후 대기 시간",
+			rate: Rate{PerSecond: 1, Burst: 2},
+			calls: []call{
+				{0, true, 0},
+				{0, true, 0},
+				{0, false, time.Second},
+				{500 * time.Millisecond, false, 500 * time.Millisecond},
+				{time.Second, true, 0},
+				{time.Second, false, time.Second},
+			},
+		},
+		{
+			name: "오래 쉬어도 burst 까지만 충전",
+			rate: Rate{PerSecond: 2, Burst: 3},
+			calls: []call{
+				{0, true, 0},
+				{0, true, 0},
+				{0, true, 0},
+				{time.Hour, true, 0},
+				{time.Hour, true, 0},
+				{time.Hour, true, 0},
+				{time.Hour, false, 500 * time.Millisecond},
+			},
+		},
+		{
+			name: "시간이 거꾸로 가면 충전하지 않음",
+			rate: Rate{PerSecond: 1, Burst: 1},
+			calls: []call{
+				{time.Second, true, 0},
+				{0, false, time.Second},
+			},
+		},
+		{
+			name: "초당 1개 미만",
+			rate: Rate{PerSecond: 0.5, Burst: 1},
+			calls: []call{
+				{0, true, 0},
+				{0, false, 2 * time.Second},
+				{time.Second, false, time.Second},
+				{2 * time.Second, true, 0},
+			},
+		},
+		{
+			name: "perSecond 0 이면 제한하지 않음",
+			rate: Rate{PerSecond: 0, Burst: 0},
+			calls: []call{
+				{0, true, 0},
+				{0, true, 0},
+				{0, true, 0},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &TokenBucket{rate: tt.rate, tokens: float64(tt.rate.Burst), last: start}
+			for i, c := range tt.calls {
+				ok, wait := b.allowAt(start.Add(c.at))
+				if ok != c.wantOK || !approx(wait, c.wantWait) {
+					t.Fatalf("call %d at %v: got (%v, %v), want (%v, %v)", i, c.at, ok, wait, c.wantOK, c.wantWait)
+				}
+			}
+		})
+	}
+}
+
+// approx 는 부동소수점 계산 오차를 허용하여 비교합니다.
+func approx(got, want time.Duration) bool {
+	diff := got - want
+	return diff > -time.Millisecond && diff < time.Millisecond
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"workoutstudy_chatting/service"
+)
+
+const reconcileUsage = `usage: workoutstudy_chatting reconcile
+
+DB 의 fit group, fit mate, 사용자를 fit-group, auth 서비스에서 다시 조회한 정보로 맞추고 변경 내역을 JSON 으로 출력`
+
+// runReconcileCommand 는 정합성 맞추기를 한 번 실행합니다. 서버는 시작하지 않습니다.
+// 중간에 실패하거나 중단(SIGINT, SIGTERM)되어도 그때까지의 변경 내역은 출력합니다.
+func runReconcileCommand(reconciliation service.ReconciliationUseCase, args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("unexpected arguments %v\n%s", args, reconcileUsage)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	report, err := reconciliation.RunReconciliation(ctx)
+	if report != nil {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if encodeErr := encoder.Encode(report); encodeErr != nil && err == nil {
+			err = encodeErr
+		}
+	}
+	return err
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"workoutstudy_chatting/service"
+)
+
+const retentionUsage = `usage: workoutstudy_chatting retention run
+
+보관 기간이 지난 메시지를 retention.archiveDir 에 아카이브하고 DB 에서 삭제한 뒤 실행 결과를 JSON 으로 출력`
+
+// runRetentionCommand 는 보관 기간 정책을 한 번 실행합니다. 서버는 시작하지 않습니다.
+// 메시지를 대량으로 삭제할 수 있으므로 HTTP API 대신 운영자만 실행할 수 있는 서브커맨드로 제공합니다.
+func runRetentionCommand(retention service.RetentionUseCase, args []string) error {
+	if len(args) != 1 || args[0] != "run" {
+		return fmt.Errorf("unexpected arguments %v\n%s", args, retentionUsage)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	report, err := retention.RunRetention(ctx)
+	if report != nil {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if encodeErr := encoder.Encode(report); encodeErr != nil && err == nil {
+			err = encodeErr
+		}
+	}
+	return err
+}
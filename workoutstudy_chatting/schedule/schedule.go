@@ -0,0 +1,158 @@
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Recurrence 는 반복 주기 종류입니다.
+type Recurrence string
+
+const (
+	Daily         Recurrence = "DAILY"
+	Weekly        Recurrence = "WEEKLY"         // Weekdays 에 지정한 요일마다
+	CycleDeadline Recurrence = "CYCLE_DEADLINE" // fit group 인증 주기 마감 DaysBefore 일 전
+)
+
+// fit group 인증 주기 ( 1: 일주일, 2: 한달, 3: 일년 )
+const (
+	CycleWeek  = 1
+	CycleMonth = 2
+	CycleYear  = 3
+)
+
+// Spec 은 반복 일정입니다. 시간은 모두 fit group 의 시간대 기준입니다.
+type Spec struct {
+	Recurrence Recurrence
+	Minute     int            // 0시 기준 분 (0 ~ 1439)
+	Weekdays   []time.Weekday // Weekly 일 때 사용
+	DaysBefore int            // CycleDeadline 일 때 사용. 1 이면 주기의 마지막 날
+	Cycle      int            // CycleDeadline 일 때 사용
+}
+
+var ErrInvalidSpec = errors.New("invalid schedule")
+
+// ParseTimeOfDay 는 "HH:MM" 을 0시 기준 분으로 변환합니다.
+func ParseTimeOfDay(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("%w: time of day must be HH:MM: %q", ErrInvalidSpec, s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// FormatTimeOfDay 는 0시 기준 분을 "HH:MM" 으로 변환합니다.
+func FormatTimeOfDay(minute int) string {
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+}
+
+func (s Spec) Validate() error {
+	if s.Minute < 0 || s.Minute >= 24*60 {
+		return fmt.Errorf("%w: minute out of range: %d", ErrInvalidSpec, s.Minute)
+	}
+	switch s.Recurrence {
+	case Daily:
+		return nil
+	case Weekly:
+		if len(s.Weekdays) == 0 {
+			return fmt.Errorf("%w: weekly schedule needs at least one weekday", ErrInvalidSpec)
+		}
+		for _, d := range s.Weekdays {
+			if d < time.Sunday || d > time.Saturday {
+				return fmt.Errorf("%w: invalid weekday: %d", ErrInvalidSpec, d)
+			}
+		}
+		return nil
+	case CycleDeadline:
+		maxDays := 27 // 가장 짧은 달(28일)보다 작아야 매 주기 한 번 실행됨
+		if s.Cycle == CycleWeek {
+			maxDays = 6
+		}
+		if s.Cycle < CycleWeek || s.Cycle > CycleYear {
+			return fmt.Errorf("%w: unknown cycle: %d", ErrInvalidSpec, s.Cycle)
+		}
+		if s.DaysBefore < 1 || s.DaysBefore > maxDays {
+			return fmt.Errorf("%w: days before must be between 1 and %d", ErrInvalidSpec, maxDays)
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown recurrence: %q", ErrInvalidSpec, s.Recurrence)
+	}
+}
+
+// Next 는 after 이후 가장 빠른 실행 시간을 반환합니다.
+// 날짜 계산은 loc 기준으로 하므로 서머타임이 있는 시간대에서도 같은 현지 시각에 실행됩니다.
+func (s Spec) Next(after time.Time, loc *time.Location) (time.Time, error) {
+	if err := s.Validate(); err != nil {
+		return time.Time{}, err
+	}
+	local := after.In(loc)
+
+	switch s.Recurrence {
+	case Daily, Weekly:
+		// 오늘부터 최대 7일 뒤까지 확인하면 모든 요일이 한 번씩 포함됨
+		for i := 0; i <= 7; i++ {
+			day := time.Date(local.Year(), local.Month(), local.Day()+i, 0, 0, 0, 0, loc)
+			if s.Recurrence == Weekly && !containsWeekday(s.Weekdays, day.Weekday()) {
+				continue
+			}
+			if candidate := s.at(day, loc); candidate.After(after) {
+				return candidate, nil
+			}
+		}
+	case CycleDeadline:
+		_, end := CycleBounds(s.Cycle, local)
+		for i := 0; i < 3; i++ {
+			day := time.Date(end.Year(), end.Month(), end.Day()-s.DaysBefore, 0, 0, 0, 0, loc)
+			if candidate := s.at(day, loc); candidate.After(after) {
+				return candidate, nil
+			}
+			_, end = CycleBounds(s.Cycle, end)
+		}
+	}
+	return time.Time{}, fmt.Errorf("%w: no next run after %v", ErrInvalidSpec, after)
+}
+
+func (s Spec) at(day time.Time, loc *time.Location) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), s.Minute/60, s.Minute%60, 0, 0, loc)
+}
+
+// CycleBounds 는 t 가 속한 인증 주기의 시작과 끝(다음 주기 시작)을 t 의 시간대 기준으로 반환합니다.
+// 주간 주기는 월요일에 시작합니다.
+func CycleBounds(cycle int, t time.Time) (start, end time.Time) {
+	loc := t.Location()
+	switch cycle {
+	case CycleMonth:
+		start = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, loc)
+		end = start.AddDate(0, 1, 0)
+	case CycleYear:
+		start = time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, loc)
+		end = start.AddDate(1, 0, 0)
+	default:
+		offset := (int(t.Weekday()) + 6) % 7 // 월요일 0
+		start = time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, loc)
+		end = start.AddDate(0, 0, 7)
+	}
+	return start, end
+}
+
+// DaysLeft 는 t 가 속한 날부터 주기 끝까지 남은 날 수(오늘 포함)를 반환합니다.
+func DaysLeft(cycle int, t time.Time) int {
+	_, end := CycleBounds(cycle, t)
+	today := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	days := 0
+	for d := today; d.Before(end); d = d.AddDate(0, 0, 1) {
+		days++
+	}
+	return days
+}
+
+func containsWeekday(days []time.Weekday, d time.Weekday) bool {
+	for _, day := range days {
+		if day == d {
+			return true
+		}
+	}
+	return false
+}
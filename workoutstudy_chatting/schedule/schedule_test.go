@@ -0,0 +1,258 @@
+package schedule
+
+import (
+	"errors"
+	"testing"
+	"time"
+	_ "time/tzdata" // 시간대 데이터가 없는 환경에서도 서머타임 시간대를 읽기 위함
+)
+
+func mustLoad(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("load %s: %v", name, err)
+	}
+	return loc
+}
+
+func TestSpecNext(t *testing.T) {
+	newYork := mustLoad(t, "America/New_York")
+	seoul := mustLoad(t, "Asia/Seoul")
+	tests := []struct {
+		name  string
+		spec  Spec
+		loc   *time.Location
+		after time.Time
+		want  time.Time
+	}{
+		{
+			name:  "daily 같은 날 아직 안 지난 시간",
+			spec:  Spec{Recurrence: Daily, Minute: 9 * 60},
+			loc:   seoul,
+			after: time.Date(2024, 5, 1, 8, 0, 0, 0, seoul),
+			want:  time.Date(2024, 5, 1, 9, 0, 0, 0, seoul),
+		},
+		{
+			name:  "daily 실행 시각과 같으면 다음 날",
+			spec:  Spec{Recurrence: Daily, Minute: 9 * 60},
+			loc:   seoul,
+			after: time.Date(2024, 5, 1, 9, 0, 0, 0, seoul),
+			want:  time.Date(2024, 5, 2, 9, 0, 0, 0, seoul),
+		},
+		{
+			name:  "daily 서머타임 시작일에도 같은 현지 시각 (23시간 뒤)",
+			spec:  Spec{Recurrence: Daily, Minute: 9 * 60},
+			loc:   newYork,
+			after: time.Date(2024, 3, 9, 10, 0, 0, 0, newYork),
+			want:  time.Date(2024, 3, 10, 9, 0, 0, 0, newYork),
+		},
+		{
+			name:  "daily 서머타임 종료일에도 같은 현지 시각 (25시간 뒤)",
+			spec:  Spec{Recurrence: Daily, Minute: 9 * 60},
+			loc:   newYork,
+			after: time.Date(2024, 11, 2, 10, 0, 0, 0, newYork),
+			want:  time.Date(2024, 11, 3, 9, 0, 0, 0, newYork),
+		},
+		{
+			name:  "after 의 시간대와 관계없이 loc 기준으로 계산",
+			spec:  Spec{Recurrence: Daily, Minute: 9 * 60},
+			loc:   seoul,
+			after: time.Date(2024, 5, 1, 1, 0, 0, 0, time.UTC), // 서울 10:00
+			want:  time.Date(2024, 5, 2, 9, 0, 0, 0, seoul),
+		},
+		{
+			name:  "weekly 다음 지정 요일",
+			spec:  Spec{Recurrence: Weekly, Minute: 7 * 60, Weekdays: []time.Weekday{time.Monday, time.Friday}},
+			loc:   seoul,
+			after: time.Date(2024, 5, 1, 12, 0, 0, 0, seoul), // 수요일
+			want:  time.Date(2024, 5, 3, 7, 0, 0, 0, seoul),
+		},
+		{
+			name:  "weekly 같은 요일 시간이 지났으면 다음 주",
+			spec:  Spec{Recurrence: Weekly, Minute: 7 * 60, Weekdays: []time.Weekday{time.Wednesday}},
+			loc:   seoul,
+			after: time.Date(2024, 5, 1, 12, 0, 0, 0, seoul),
+			want:  time.Date(2024, 5, 8, 7, 0, 0, 0, seoul),
+		},
+		{
+			name:  "weekly 월말을 넘어감",
+			spec:  Spec{Recurrence: Weekly, Minute: 7 * 60, Weekdays: []time.Weekday{time.Monday}},
+			loc:   seoul,
+			after: time.Date(2024, 12, 31, 12, 0, 0, 0, seoul), // 화요일
+			want:  time.Date(2025, 1, 6, 7, 0, 0, 0, seoul),
+		},
+		{
+			name:  "월 주기 마감 전날 윤년 2월",
+			spec:  Spec{Recurrence: CycleDeadline, Minute: 21 * 60, DaysBefore: 1, Cycle: CycleMonth},
+			loc:   seoul,
+			after: time.Date(2024, 2, 10, 0, 0, 0, 0, seoul),
+			want:  time.Date(2024, 2, 29, 21, 0, 0, 0, seoul),
+		},
+		{
+			name:  "월 주기 마감 전날 평년 2월",
+			spec:  Spec{Recurrence: CycleDeadline, Minute: 21 * 60, DaysBefore: 1, Cycle: CycleMonth},
+			loc:   seoul,
+			after: time.Date(2023, 2, 10, 0, 0, 0, 0, seoul),
+			want:  time.Date(2023, 2, 28, 21, 0, 0, 0, seoul),
+		},
+		{
+			name:  "월 주기 이번 달 마감이 지났으면 다음 달 마지막 날",
+			spec:  Spec{Recurrence: CycleDeadline, Minute: 21 * 60, DaysBefore: 1, Cycle: CycleMonth},
+			loc:   seoul,
+			after: time.Date(2024, 2, 29, 22, 0, 0, 0, seoul),
+			want:  time.Date(2024, 3, 31, 21, 0, 0, 0, seoul),
+		},
+		{
+			name:  "월 주기 30일인 달",
+			spec:  Spec{Recurrence: CycleDeadline, Minute: 21 * 60, DaysBefore: 3, Cycle: CycleMonth},
+			loc:   seoul,
+			after: time.Date(2024, 4, 1, 0, 0, 0, 0, seoul),
+			want:  time.Date(2024, 4, 28, 21, 0, 0, 0, seoul),
+		},
+		{
+			name:  "주 주기 마감 전날은 일요일",
+			spec:  Spec{Recurrence: CycleDeadline, Minute: 20 * 60, DaysBefore: 1, Cycle: CycleWeek},
+			loc:   seoul,
+			after: time.Date(2024, 5, 1, 0, 0, 0, 0, seoul),
+			want:  time.Date(2024, 5, 5, 20, 0, 0, 0, seoul),
+		},
+		{
+			name:  "주 주기 서머타임이 시작하는 주",
+			spec:  Spec{Recurrence: CycleDeadline, Minute: 20 * 60, DaysBefore: 1, Cycle: CycleWeek},
+			loc:   newYork,
+			after: time.Date(2024, 3, 5, 0, 0, 0, 0, newYork),
+			want:  time.Date(2024, 3, 10, 20, 0, 0, 0, newYork),
+		},
+		{
+			name:  "연 주기 마감이 지났으면 다음 해",
+			spec:  Spec{Recurrence: CycleDeadline, Minute: 12 * 60, DaysBefore: 1, Cycle: CycleYear},
+			loc:   seoul,
+			after: time.Date(2024, 12, 31, 13, 0, 0, 0, seoul),
+			want:  time.Date(2025, 12, 31, 12, 0, 0, 0, seoul),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.spec.Next(tt.after, tt.loc)
+			if err != nil {
+				t.Fatalf("Next: %v", err)
+			}
+			if !got.Equal(tt.want) {
+				t.Fatalf("Next = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpecNextInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		spec Spec
+	}{
+		{"분 범위 초과", Spec{Recurrence: Daily, Minute: 24 * 60}},
+		{"요일 없는 weekly", Spec{Recurrence: Weekly, Minute: 0}},
+		{"잘못된 요일", Spec{Recurrence: Weekly, Weekdays: []time.Weekday{7}}},
+		{"알 수 없는 주기", Spec{Recurrence: CycleDeadline, DaysBefore: 1, Cycle: 4}},
+		{"주 주기 daysBefore 초과", Spec{Recurrence: CycleDeadline, DaysBefore: 7, Cycle: CycleWeek}},
+		{"월 주기 daysBefore 초과", Spec{Recurrence: CycleDeadline, DaysBefore: 28, Cycle: CycleMonth}},
+		{"알 수 없는 반복", Spec{Recurrence: "HOURLY"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.spec.Next(time.Now(), time.UTC); !errors.Is(err, ErrInvalidSpec) {
+				t.Fatalf("Next error = %v, want ErrInvalidSpec", err)
+			}
+		})
+	}
+}
+
+func TestCycleBounds(t *testing.T) {
+	newYork := mustLoad(t, "America/New_York")
+	seoul := mustLoad(t, "Asia/Seoul")
+	tests := []struct {
+		name               string
+		cycle              int
+		t                  time.Time
+		wantStart, wantEnd time.Time
+		wantDaysLeft       int
+	}{
+		{
+			name:         "주 주기는 월요일 시작",
+			cycle:        CycleWeek,
+			t:            time.Date(2024, 5, 1, 15, 0, 0, 0, seoul), // 수요일
+			wantStart:    time.Date(2024, 4, 29, 0, 0, 0, 0, seoul),
+			wantEnd:      time.Date(2024, 5, 6, 0, 0, 0, 0, seoul),
+			wantDaysLeft: 5,
+		},
+		{
+			name:         "주 주기 일요일은 주의 마지막 날",
+			cycle:        CycleWeek,
+			t:            time.Date(2024, 5, 5, 23, 59, 0, 0, seoul),
+			wantStart:    time.Date(2024, 4, 29, 0, 0, 0, 0, seoul),
+			wantEnd:      time.Date(2024, 5, 6, 0, 0, 0, 0, seoul),
+			wantDaysLeft: 1,
+		},
+		{
+			name:         "주 주기 서머타임 시작 주 (167시간)",
+			cycle:        CycleWeek,
+			t:            time.Date(2024, 3, 10, 12, 0, 0, 0, newYork),
+			wantStart:    time.Date(2024, 3, 4, 0, 0, 0, 0, newYork),
+			wantEnd:      time.Date(2024, 3, 11, 0, 0, 0, 0, newYork),
+			wantDaysLeft: 1,
+		},
+		{
+			name:         "월 주기 1월 31일",
+			cycle:        CycleMonth,
+			t:            time.Date(2024, 1, 31, 12, 0, 0, 0, seoul),
+			wantStart:    time.Date(2024, 1, 1, 0, 0, 0, 0, seoul),
+			wantEnd:      time.Date(2024, 2, 1, 0, 0, 0, 0, seoul),
+			wantDaysLeft: 1,
+		},
+		{
+			name:         "월 주기 윤년 2월",
+			cycle:        CycleMonth,
+			t:            time.Date(2024, 2, 1, 0, 0, 0, 0, seoul),
+			wantStart:    time.Date(2024, 2, 1, 0, 0, 0, 0, seoul),
+			wantEnd:      time.Date(2024, 3, 1, 0, 0, 0, 0, seoul),
+			wantDaysLeft: 29,
+		},
+		{
+			name:         "월 주기 서머타임 종료 달",
+			cycle:        CycleMonth,
+			t:            time.Date(2024, 11, 3, 1, 30, 0, 0, newYork),
+			wantStart:    time.Date(2024, 11, 1, 0, 0, 0, 0, newYork),
+			wantEnd:      time.Date(2024, 12, 1, 0, 0, 0, 0, newYork),
+			wantDaysLeft: 28,
+		},
+		{
+			name:         "월 주기 12월은 다음 해 1월에 끝남",
+			cycle:        CycleMonth,
+			t:            time.Date(2024, 12, 15, 0, 0, 0, 0, seoul),
+			wantStart:    time.Date(2024, 12, 1, 0, 0, 0, 0, seoul),
+			wantEnd:      time.Date(2025, 1, 1, 0, 0, 0, 0, seoul),
+			wantDaysLeft: 17,
+		},
+		{
+			name:         "연 주기",
+			cycle:        CycleYear,
+			t:            time.Date(2024, 12, 31, 23, 0, 0, 0, seoul),
+			wantStart:    time.Date(2024, 1, 1, 0, 0, 0, 0, seoul),
+			wantEnd:      time.Date(2025, 1, 1, 0, 0, 0, 0, seoul),
+			wantDaysLeft: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := CycleBounds(tt.cycle, tt.t)
+			if !start.Equal(tt.wantStart) || !end.Equal(tt.wantEnd) {
+				t.Fatalf("CycleBounds = [%v, %v), want [%v, %v)", start, end, tt.wantStart, tt.wantEnd)
+			}
+			if got := DaysLeft(tt.cycle, tt.t); got != tt.wantDaysLeft {
+				t.Fatalf("DaysLeft = %d, want %d", got, tt.wantDaysLeft)
+			}
+		})
+	}
+}
@@ -0,0 +1,265 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"workoutstudy_chatting/command"
+	"workoutstudy_chatting/model"
+	"workoutstudy_chatting/persistence"
+	"workoutstudy_chatting/schedule"
+	"workoutstudy_chatting/util"
+)
+
+type ChatCommandUseCase interface {
+	HandleCommand(msg model.ChatMessage) (*model.CommandReply, bool, error)
+}
+
+var _ ChatCommandUseCase = (*ChatCommandService)(nil)
+
+// ChatCommandService 는 "/" 로 시작하는 채팅 메시지를 command.Router 에 등록된 명령어로 처리합니다.
+// 명령어 메시지는 저장/브로드캐스트하지 않고, 비공개 응답은 입력한 사용자에게, 공개 응답은 핏봇 메시지로 채팅방에 보냅니다.
+type ChatCommandService struct {
+	router     *command.Router
+	chatRepo   persistence.ChatRepository
+	moderation ChatModerationUseCase
+	notifier   RoomNotifier
+}
+
+func NewChatCommandService(router *command.Router, chatRepo persistence.ChatRepository, moderation ChatModerationUseCase, notifier RoomNotifier) *ChatCommandService {
+	return &ChatCommandService{
+		router:     router,
+		chatRepo:   chatRepo,
+		moderation: moderation,
+		notifier:   notifier,
+	}
+}
+
+/*
+HandleCommand
+1. 일반 채팅(CHATTING) 메시지가 "/명령어" 형식이 아니면 handled 가 false
+2. 차단된 사용자는 ErrBanned, 뮤트된 사용자는 공개 응답을 비공개로 바꿔 채팅 제한을 우회하지 못하도록 함
+3. 비공개 응답은 reply 로 반환하고, 공개 응답은 핏봇 메시지로 저장 후 채팅방에 전송 (reply 는 nil)
+*/
+func (s *ChatCommandService) HandleCommand(msg model.ChatMessage) (*model.CommandReply, bool, error) {
+	if msg.MessageType != model.Chatting {
+		return nil, false, nil
+	}
+	name, args, ok := command.Parse(msg.Message)
+	if !ok {
+		return nil, false, nil
+	}
+
+	restriction := s.moderation.CheckRestriction(msg.FitGroupID, msg.UserID)
+	if errors.Is(restriction, ErrBanned) {
+		return nil, true, restriction
+	}
+
+	reply := &model.CommandReply{Type: model.FrameCommandReply, Command: name, MessageID: msg.ID}
+	resp, err := s.router.Dispatch(command.Request{
+		FitGroupID: msg.FitGroupID,
+		UserID:     msg.UserID,
+		Name:       name,
+		Args:       args,
+		Text:       msg.Message,
+	})
+	if errors.Is(err, command.ErrUnknownCommand) {
+		reply.Message = "알 수 없는 명령어입니다. /help 로 사용할 수 있는 명령어를 확인해주세요."
+		return reply, true, nil
+	}
+	if err != nil {
+		log.Printf("Error executing command /%s in fit group %d: %v", name, msg.FitGroupID, err)
+		reply.Message = "명령어 처리에 실패했습니다."
+		return reply, true, nil
+	}
+
+	if resp.Visibility != command.Public || errors.Is(restriction, ErrMuted) {
+		reply.Message = resp.Text
+		return reply, true, nil
+	}
+
+	botMsg := model.ChatMessage{
+		ID:          util.NewUUID(),
+		UserID:      model.SystemBotUserID,
+		FitGroupID:  msg.FitGroupID,
+		Message:     resp.Text,
+		MessageTime: time.Now(),
+		MessageType: model.System,
+	}
+	if err := s.chatRepo.SaveMessage(botMsg); err != nil {
+		return nil, true, err
+	}
+	s.notifier.Post(msg.FitGroupID, botMsg)
+	return nil, true, nil
+}
+
+// FitGroupCommands 는 fit group 채팅방 기본 명령어입니다. command.Router.MustRegister 로 등록합니다.
+type FitGroupCommands struct {
+	chatRepo     persistence.ChatRepository
+	fitGroupRepo persistence.FitGroupRepository
+	settings     ChatRoomSettingUseCase
+	pins         PinnedMessageUseCase
+}
+
+func NewFitGroupCommands(
+	chatRepo persistence.ChatRepository,
+	fitGroupRepo persistence.FitGroupRepository,
+	settings ChatRoomSettingUseCase,
+	pins PinnedMessageUseCase,
+) *FitGroupCommands {
+	return &FitGroupCommands{
+		chatRepo:     chatRepo,
+		fitGroupRepo: fitGroupRepo,
+		settings:     settings,
+		pins:         pins,
+	}
+}
+
+// Commands 는 등록할 명령어 목록을 반환합니다.
+func (c *FitGroupCommands) Commands() []command.Command {
+	return []command.Command{
+		{Name: "progress", Usage: "/progress [all]", Description: "이번 인증 주기의 내 인증 현황을 보여줍니다. all 을 붙이면 전체 현황을 채팅방에 공유합니다.", Handler: c.progress},
+		{Name: "penalty", Usage: "/penalty", Description: "지난 인증 주기에 인증 횟수를 채우지 못한 fit mate 를 채팅방에 공유합니다.", Handler: c.penalty},
+		{Name: "members", Usage: "/members", Description: "채팅방의 fit mate 목록을 보여줍니다.", Handler: c.members},
+		{Name: "pin", Usage: "/pin [messageId]", Description: "고정 메시지 목록을 보여줍니다. fit leader 는 messageId 로 메시지를 고정할 수 있습니다.", Handler: c.pin},
+	}
+}
+
+func (c *FitGroupCommands) progress(req command.Request) (command.Response, error) {
+	all := len(req.Args) == 1 && strings.EqualFold(req.Args[0], "all")
+	if len(req.Args) > 0 && !all {
+		return command.Response{}, command.ErrUsage
+	}
+
+	fitGroup, localNow, err := c.fitGroupNow(req.FitGroupID)
+	if err != nil {
+		return command.Response{}, err
+	}
+	start, end := schedule.CycleBounds(fitGroup.Cycle, localNow)
+	progress, err := c.chatRepo.CountCertifications(req.FitGroupID, start, end)
+	if err != nil {
+		return command.Response{}, err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "이번 인증 주기 마감까지 %d일 남았습니다.", schedule.DaysLeft(fitGroup.Cycle, localNow))
+	for _, p := range progress {
+		if all || p.UserID == req.UserID {
+			fmt.Fprintf(&b, "\n%s %d/%d", p.Nickname, p.Count, fitGroup.Frequency)
+		}
+	}
+	if all {
+		return command.Response{Visibility: command.Public, Text: b.String()}, nil
+	}
+	return command.Response{Visibility: command.Private, Text: b.String()}, nil
+}
+
+// penalty 는 직전 인증 주기에 fitGroup.Frequency 만큼 인증하지 못한 fit mate 를 공개합니다.
+func (c *FitGroupCommands) penalty(req command.Request) (command.Response, error) {
+	if len(req.Args) > 0 {
+		return command.Response{}, command.ErrUsage
+	}
+	fitGroup, localNow, err := c.fitGroupNow(req.FitGroupID)
+	if err != nil {
+		return command.Response{}, err
+	}
+	currentStart, _ := schedule.CycleBounds(fitGroup.Cycle, localNow)
+	start, end := schedule.CycleBounds(fitGroup.Cycle, currentStart.Add(-time.Nanosecond))
+	progress, err := c.chatRepo.CountCertifications(req.FitGroupID, start, end)
+	if err != nil {
+		return command.Response{}, err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "지난 인증 주기(%s ~ %s) 미달 fit mate", start.Format("2006-01-02"), end.AddDate(0, 0, -1).Format("2006-01-02"))
+	missed := 0
+	for _, p := range progress {
+		if p.Count < fitGroup.Frequency {
+			fmt.Fprintf(&b, "\n%s %d/%d", p.Nickname, p.Count, fitGroup.Frequency)
+			missed++
+		}
+	}
+	if missed == 0 {
+		b.WriteString("\n모두 인증 횟수를 채웠습니다.")
+	}
+	return command.Response{Visibility: command.Public, Text: b.String()}, nil
+}
+
+func (c *FitGroupCommands) members(req command.Request) (command.Response, error) {
+	fitGroup, localNow, err := c.fitGroupNow(req.FitGroupID)
+	if err != nil {
+		return command.Response{}, err
+	}
+	start, end := schedule.CycleBounds(fitGroup.Cycle, localNow)
+	// 인증 현황 조회는 인증하지 않은 fit mate 도 포함하므로 fit mate 목록으로 사용
+	progress, err := c.chatRepo.CountCertifications(req.FitGroupID, start, end)
+	if err != nil {
+		return command.Response{}, err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s fit mate %d/%d명", fitGroup.FitGroupName, len(progress), fitGroup.MaxFitMate)
+	for _, p := range progress {
+		b.WriteString("\n" + p.Nickname)
+		if p.UserID == fitGroup.FitLeaderUserID {
+			b.WriteString(" (fit leader)")
+		}
+	}
+	return command.Response{Visibility: command.Private, Text: b.String()}, nil
+}
+
+func (c *FitGroupCommands) pin(req command.Request) (command.Response, error) {
+	if len(req.Args) > 1 {
+		return command.Response{}, command.ErrUsage
+	}
+	if len(req.Args) == 1 {
+		// 다른 fit group 의 메시지는 이 채팅방에서 고정할 수 없음
+		msg, err := c.chatRepo.GetMessageByID(req.Args[0])
+		if err != nil || msg.FitGroupID != req.FitGroupID {
+			return command.Response{Visibility: command.Private, Text: "고정할 메시지를 찾을 수 없습니다."}, nil
+		}
+		_, err = c.pins.PinMessage(msg.ID, req.UserID)
+		switch {
+		case err == nil:
+		case errors.Is(err, ErrNotFitLeader):
+			return command.Response{Visibility: command.Private, Text: "메시지 고정은 fit leader 만 할 수 있습니다."}, nil
+		case errors.Is(err, ErrPinLimitExceeded):
+			return command.Response{Visibility: command.Private, Text: fmt.Sprintf("메시지는 최대 %d개까지 고정할 수 있습니다.", MaxPinnedMessages)}, nil
+		default:
+			return command.Response{}, err
+		}
+		// 고정 이벤트는 PinnedMessageService 가 채팅방에 전송
+		return command.Response{Visibility: command.Private, Text: "메시지를 고정했습니다."}, nil
+	}
+
+	pins, err := c.pins.GetPinnedMessages(req.FitGroupID, req.UserID)
+	if err != nil {
+		return command.Response{}, err
+	}
+	if len(pins.Pins) == 0 {
+		return command.Response{Visibility: command.Private, Text: "고정된 메시지가 없습니다."}, nil
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "고정 메시지 %d/%d", len(pins.Pins), pins.MaxPins)
+	for _, p := range pins.Pins {
+		if p.Message != nil {
+			b.WriteString("\n- " + p.Message.Message)
+		}
+	}
+	return command.Response{Visibility: command.Private, Text: b.String()}, nil
+}
+
+// fitGroupNow 는 fit group 과 fit group 시간대 기준 현재 시간을 반환합니다.
+func (c *FitGroupCommands) fitGroupNow(fitGroupID int) (*model.FitGroup, time.Time, error) {
+	fitGroup, err := c.fitGroupRepo.GetFitGroupByID(fitGroupID)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	loc, err := c.settings.GetLocation(fitGroupID)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return fitGroup, time.Now().In(loc), nil
+}
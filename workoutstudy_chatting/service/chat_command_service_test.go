@@ -0,0 +1,131 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"workoutstudy_chatting/command"
+	"workoutstudy_chatting/model"
+	"workoutstudy_chatting/persistence"
+)
+
+// newChatCommandFixture 는 채팅 제한 fixture(fit group 1: 리더 1, 멤버 2, 3)에 테스트 명령어를 등록한 ChatCommandService 를 만듭니다.
+//   - /whisper, /shout: 인자를 비공개/공개로 응답
+//   - /strict: 항상 사용법 오류
+//   - /broken: 항상 실패
+func newChatCommandFixture(t *testing.T) (persistence.Repositories, *recordingRoom, *ChatModerationService, *ChatCommandService) {
+	t.Helper()
+	repos, room, moderation := newChatModerationFixture(t)
+	echo := func(visibility command.Visibility) command.HandlerFunc {
+		return func(req command.Request) (command.Response, error) {
+			return command.Response{Visibility: visibility, Text: fmt.Sprintf("%d: %s", req.UserID, strings.Join(req.Args, " "))}, nil
+		}
+	}
+	router := command.NewRouter()
+	router.MustRegister(
+		command.Command{Name: "whisper", Handler: echo(command.Private)},
+		command.Command{Name: "shout", Handler: echo(command.Public)},
+		command.Command{Name: "strict", Usage: "/strict <n>", Handler: func(command.Request) (command.Response, error) {
+			return command.Response{}, command.ErrUsage
+		}},
+		command.Command{Name: "broken", Handler: func(command.Request) (command.Response, error) {
+			return command.Response{}, errors.New("db down")
+		}},
+	)
+	return repos, room, moderation, NewChatCommandService(router, repos.Chat, moderation, room)
+}
+
+// botMessages 는 fit group 1 에 저장된 핏봇 메시지 본문을 반환합니다.
+func botMessages(t *testing.T, repos persistence.Repositories) []string {
+	t.Helper()
+	messages, err := repos.Chat.RetrieveMessages(1, time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("RetrieveMessages: %v", err)
+	}
+	var texts []string
+	for _, msg := range messages {
+		if msg.UserID == model.SystemBotUserID && msg.MessageType == model.System {
+			texts = append(texts, msg.Message)
+		}
+	}
+	return texts
+}
+
+func TestHandleCommand(t *testing.T) {
+	tests := []struct {
+		name        string
+		restrict    func(m *ChatModerationService) error
+		messageType model.MessageType // 비어 있으면 CHATTING
+		text        string
+		wantHandled bool
+		wantErr     error
+		wantReply   string // 비공개 응답. 비어 있으면 reply 없음
+		wantPosted  []string
+	}{
+		{name: "일반 메시지", text: "오늘 운동 완료", wantHandled: false},
+		{name: "/ 다음이 영문자가 아닌 메시지", text: "/ㅋㅋ", wantHandled: false},
+		{name: "채팅이 아닌 메시지", messageType: model.Ticket, text: "/shout 인증", wantHandled: false},
+		{name: "비공개 응답은 입력한 사용자에게만", text: "/whisper 안녕", wantHandled: true, wantReply: "2: 안녕"},
+		{name: "공개 응답은 핏봇 메시지로 채팅방에", text: "/shout 다들 화이팅", wantHandled: true, wantPosted: []string{"2: 다들 화이팅"}},
+		{name: "대문자 명령어", text: "/SHOUT 크게", wantHandled: true, wantPosted: []string{"2: 크게"}},
+		{name: "모르는 명령어는 비공개 안내", text: "/nope", wantHandled: true, wantReply: "알 수 없는 명령어입니다. /help 로 사용할 수 있는 명령어를 확인해주세요."},
+		{name: "사용법 오류는 비공개 안내", text: "/strict", wantHandled: true, wantReply: "사용법: /strict <n>"},
+		{name: "처리 실패는 비공개 안내", text: "/broken", wantHandled: true, wantReply: "명령어 처리에 실패했습니다."},
+		{
+			name:        "뮤트된 사용자의 공개 응답은 비공개로",
+			restrict:    func(m *ChatModerationService) error { _, err := m.MuteMember(1, 1, 2, time.Hour, ""); return err },
+			text:        "/shout 몰래",
+			wantHandled: true,
+			wantReply:   "2: 몰래",
+		},
+		{
+			name:        "차단된 사용자는 명령어 불가",
+			restrict:    func(m *ChatModerationService) error { _, err := m.BanMember(1, 1, 2, time.Hour, ""); return err },
+			text:        "/whisper 안녕",
+			wantHandled: true,
+			wantErr:     ErrBanned,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repos, room, moderation, service := newChatCommandFixture(t)
+			if tt.restrict != nil {
+				if err := tt.restrict(moderation); err != nil {
+					t.Fatalf("restrict: %v", err)
+				}
+			}
+			room.events = nil
+			messageType := tt.messageType
+			if messageType == "" {
+				messageType = model.Chatting
+			}
+
+			reply, handled, err := service.HandleCommand(model.ChatMessage{ID: "client-1", UserID: 2, FitGroupID: 1, Message: tt.text, MessageType: messageType})
+			if handled != tt.wantHandled || !errors.Is(err, tt.wantErr) {
+				t.Fatalf("HandleCommand handled = %v, err = %v, want %v, %v", handled, err, tt.wantHandled, tt.wantErr)
+			}
+			if tt.wantReply == "" {
+				if reply != nil {
+					t.Fatalf("reply = %+v, want none", reply)
+				}
+			} else if reply == nil || reply.Message != tt.wantReply || reply.Type != model.FrameCommandReply || reply.MessageID != "client-1" {
+				t.Fatalf("reply = %+v, want %q", reply, tt.wantReply)
+			}
+
+			if got := botMessages(t, repos); fmt.Sprint(got) != fmt.Sprint(tt.wantPosted) {
+				t.Fatalf("saved bot messages = %v, want %v", got, tt.wantPosted)
+			}
+			var wantRoom []string
+			for _, text := range tt.wantPosted {
+				wantRoom = append(wantRoom, "post 1:"+text)
+			}
+			if got := room.recorded(); fmt.Sprint(got) != fmt.Sprint(wantRoom) {
+				t.Fatalf("room events = %v, want %v", got, wantRoom)
+			}
+		})
+	}
+}
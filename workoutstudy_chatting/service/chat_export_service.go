@@ -0,0 +1,70 @@
+package service
+
+import (
+	"log"
+	"workoutstudy_chatting/export"
+	"workoutstudy_chatting/model"
+	"workoutstudy_chatting/persistence"
+)
+
+type ChatExportUseCase interface {
+	AuthorizeExport(opts model.ChatExportOptions) (*model.FitGroup, error)
+	ExportChatHistory(fitGroup *model.FitGroup, opts model.ChatExportOptions, w export.Writer) error
+}
+
+var _ ChatExportUseCase = (*ChatExportService)(nil)
+
+type ChatExportService struct {
+	chatRepo     persistence.ChatRepository
+	fitGroupRepo persistence.FitGroupRepository
+	fitMateRepo  persistence.FitMateRepository
+}
+
+func NewChatExportService(chatRepo persistence.ChatRepository, fitGroupRepo persistence.FitGroupRepository, fitMateRepo persistence.FitMateRepository) *ChatExportService {
+	return &ChatExportService{
+		chatRepo:     chatRepo,
+		fitGroupRepo: fitGroupRepo,
+		fitMateRepo:  fitMateRepo,
+	}
+}
+
+/*
+AuthorizeExport
+1. fit group 조회
+2. 요청 사용자가 fit leader 이거나 fit mate 인지 확인
+3. 삭제된 메시지 포함 옵션은 fit leader 만 허용
+*/
+func (s *ChatExportService) AuthorizeExport(opts model.ChatExportOptions) (*model.FitGroup, error) {
+	fitGroup, err := s.fitGroupRepo.GetFitGroupByID(opts.FitGroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	isLeader := fitGroup.FitLeaderUserID == opts.UserID
+	if !isLeader {
+		isMate, err := s.fitMateRepo.CheckFitMateExists(opts.UserID, opts.FitGroupID)
+		if err != nil {
+			return nil, err
+		}
+		if !isMate {
+			return nil, ErrNotFitGroupMember
+		}
+	}
+
+	if opts.IncludeDeleted && !isLeader {
+		return nil, ErrNotFitLeader
+	}
+	return fitGroup, nil
+}
+
+// ExportChatHistory 는 AuthorizeExport 를 통과한 요청에 대해 전체 채팅 내역을 w 로 스트리밍합니다.
+func (s *ChatExportService) ExportChatHistory(fitGroup *model.FitGroup, opts model.ChatExportOptions, w export.Writer) error {
+	log.Printf("Service layer: Exporting chat history for fit_group_id: %d, format: %s, user: %d", fitGroup.ID, opts.Format, opts.UserID)
+	if err := w.Begin(fitGroup); err != nil {
+		return err
+	}
+	if err := s.chatRepo.StreamMessageHistory(fitGroup.ID, opts.IncludeDeleted, w.WriteEntry); err != nil {
+		return err
+	}
+	return w.End()
+}
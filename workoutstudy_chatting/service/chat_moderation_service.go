@@ -0,0 +1,294 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+	"workoutstudy_chatting/model"
+	"workoutstudy_chatting/persistence"
+)
+
+var (
+	ErrMuted         = errors.New("user is muted in this fit group")
+	ErrBanned        = errors.New("user is banned from this fit group chat")
+	ErrInvalidTarget = errors.New("invalid moderation target")
+)
+
+// 최대 제한 기간. 영구 제한은 duration 0 으로 설정
+const maxRestrictionDuration = 30 * 24 * time.Hour
+
+type ChatModerationUseCase interface {
+	MuteMember(fitGroupID, leaderID, targetUserID int, duration time.Duration, reason string) (*model.ChatRestriction, error)
+	UnmuteMember(fitGroupID, leaderID, targetUserID int) error
+	BanMember(fitGroupID, leaderID, targetUserID int, duration time.Duration, reason string) (*model.ChatRestriction, error)
+	UnbanMember(fitGroupID, leaderID, targetUserID int) error
+	DeleteMessage(messageID string, leaderID int) error
+	GetActiveRestrictions(fitGroupID, leaderID int) ([]model.ChatRestriction, error)
+	GetAuditLogs(fitGroupID, leaderID, limit int) ([]model.ModerationAuditLog, error)
+	CheckRestriction(fitGroupID, userID int) error
+	CheckAnnouncement(fitGroupID, userID int) error
+	RecordAudit(auditLog model.ModerationAuditLog)
+}
+
+var _ ChatModerationUseCase = (*ChatModerationService)(nil)
+
+type restrictionCacheEntry struct {
+	err       error // nil, ErrMuted, ErrBanned
+	expiresAt time.Time
+}
+
+// ChatModerationService 는 fit leader 의 채팅방 관리 기능(뮤트, 차단, 메시지 삭제)을 제공합니다.
+// 모든 조치는 moderation_audit 테이블에 기록됩니다.
+type ChatModerationService struct {
+	restrictionRepo persistence.ChatRestrictionRepository
+	auditRepo       persistence.ModerationAuditRepository
+	chatRepo        persistence.ChatRepository
+	fitGroupRepo    persistence.FitGroupRepository
+	fitMateRepo     persistence.FitMateRepository
+	notifier        RoomNotifier
+
+	mu    sync.Mutex
+	cache map[string]restrictionCacheEntry // key: fitGroupID:userID
+}
+
+func NewChatModerationService(
+	restrictionRepo persistence.ChatRestrictionRepository,
+	auditRepo persistence.ModerationAuditRepository,
+	chatRepo persistence.ChatRepository,
+	fitGroupRepo persistence.FitGroupRepository,
+	fitMateRepo persistence.FitMateRepository,
+	notifier RoomNotifier,
+) *ChatModerationService {
+	return &ChatModerationService{
+		restrictionRepo: restrictionRepo,
+		auditRepo:       auditRepo,
+		chatRepo:        chatRepo,
+		fitGroupRepo:    fitGroupRepo,
+		fitMateRepo:     fitMateRepo,
+		notifier:        notifier,
+		cache:           make(map[string]restrictionCacheEntry),
+	}
+}
+
+func (s *ChatModerationService) MuteMember(fitGroupID, leaderID, targetUserID int, duration time.Duration, reason string) (*model.ChatRestriction, error) {
+	restriction, err := s.restrict(model.RestrictionMute, fitGroupID, leaderID, targetUserID, duration, reason)
+	if err != nil {
+		return nil, err
+	}
+	s.notifier.Publish(fitGroupID, model.RoomEvent{Type: model.EventMemberMuted, FitGroupID: fitGroupID, TargetUserID: targetUserID, ActorUserID: leaderID, Payload: restriction})
+	return restriction, nil
+}
+
+func (s *ChatModerationService) UnmuteMember(fitGroupID, leaderID, targetUserID int) error {
+	if err := s.lift(model.RestrictionMute, model.ActionUnmute, fitGroupID, leaderID, targetUserID); err != nil {
+		return err
+	}
+	s.notifier.Publish(fitGroupID, model.RoomEvent{Type: model.EventMemberUnmuted, FitGroupID: fitGroupID, TargetUserID: targetUserID, ActorUserID: leaderID})
+	return nil
+}
+
+// BanMember 는 멤버를 채팅방에서 내보내고 기간 동안 재입장을 막습니다.
+func (s *ChatModerationService) BanMember(fitGroupID, leaderID, targetUserID int, duration time.Duration, reason string) (*model.ChatRestriction, error) {
+	restriction, err := s.restrict(model.RestrictionBan, fitGroupID, leaderID, targetUserID, duration, reason)
+	if err != nil {
+		return nil, err
+	}
+	s.notifier.Publish(fitGroupID, model.RoomEvent{Type: model.EventMemberBanned, FitGroupID: fitGroupID, TargetUserID: targetUserID, ActorUserID: leaderID, Payload: restriction})
+	s.notifier.Kick(fitGroupID, targetUserID, "채팅방에서 차단되었습니다.")
+	return restriction, nil
+}
+
+func (s *ChatModerationService) UnbanMember(fitGroupID, leaderID, targetUserID int) error {
+	return s.lift(model.RestrictionBan, model.ActionUnban, fitGroupID, leaderID, targetUserID)
+}
+
+// DeleteMessage 는 fit leader 가 채팅방의 다른 사람 메시지를 삭제합니다.
+func (s *ChatModerationService) DeleteMessage(messageID string, leaderID int) error {
+	msg, err := s.chatRepo.GetMessageByID(messageID)
+	if err != nil {
+		return err
+	}
+	if err := s.checkFitLeader(msg.FitGroupID, leaderID); err != nil {
+		return err
+	}
+	if err := s.chatRepo.SoftDeleteMessage(messageID, strconv.Itoa(leaderID)); err != nil {
+		return err
+	}
+
+	s.RecordAudit(model.ModerationAuditLog{FitGroupID: msg.FitGroupID, ActorUserID: leaderID, Action: model.ActionDeleteMessage, TargetUserID: msg.UserID, TargetMessageID: messageID})
+	s.notifier.Publish(msg.FitGroupID, model.RoomEvent{Type: model.EventMessageDeleted, FitGroupID: msg.FitGroupID, MessageID: messageID, ActorUserID: leaderID})
+	return nil
+}
+
+func (s *ChatModerationService) GetActiveRestrictions(fitGroupID, leaderID int) ([]model.ChatRestriction, error) {
+	if err := s.checkFitLeader(fitGroupID, leaderID); err != nil {
+		return nil, err
+	}
+	return s.restrictionRepo.GetActiveRestrictionsByFitGroup(fitGroupID)
+}
+
+func (s *ChatModerationService) GetAuditLogs(fitGroupID, leaderID, limit int) ([]model.ModerationAuditLog, error) {
+	if err := s.checkFitLeader(fitGroupID, leaderID); err != nil {
+		return nil, err
+	}
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+	return s.auditRepo.GetAuditLogs(fitGroupID, limit)
+}
+
+// CheckRestriction 은 사용자가 차단(ErrBanned) 또는 뮤트(ErrMuted) 상태인지 확인합니다.
+// 메시지마다 호출되므로 결과를 짧게 캐시하고, 이 서비스에서 제한을 변경하면 캐시를 비웁니다.
+func (s *ChatModerationService) CheckRestriction(fitGroupID, userID int) error {
+	key := fmt.Sprintf("%d:%d", fitGroupID, userID)
+	now := time.Now()
+
+	s.mu.Lock()
+	entry, ok := s.cache[key]
+	s.mu.Unlock()
+	if ok && now.Before(entry.expiresAt) {
+		return entry.err
+	}
+
+	restrictions, err := s.restrictionRepo.GetActiveRestrictions(fitGroupID, userID)
+	if err != nil {
+		// 제한 조회 실패로 채팅 전체가 막히지 않도록 허용
+		log.Printf("Error checking chat restriction for user %d in fit group %d: %v", userID, fitGroupID, err)
+		return nil
+	}
+
+	entry = restrictionCacheEntry{expiresAt: now.Add(30 * time.Second)}
+	for _, r := range restrictions {
+		if r.Type == model.RestrictionBan {
+			entry.err = ErrBanned
+			break
+		}
+		if r.Type == model.RestrictionMute {
+			entry.err = ErrMuted
+		}
+		// 만료 시점이 캐시 만료보다 빠르면 만료 시점까지만 캐시
+		if r.ExpiresAt != nil && r.ExpiresAt.Before(entry.expiresAt) {
+			entry.expiresAt = *r.ExpiresAt
+		}
+	}
+
+	s.mu.Lock()
+	s.cache[key] = entry
+	s.mu.Unlock()
+	return entry.err
+}
+
+// CheckAnnouncement 는 공지(ANNOUNCEMENT) 메시지를 보낼 수 있는지 확인합니다. fit leader 만 공지를 보낼 수 있습니다.
+func (s *ChatModerationService) CheckAnnouncement(fitGroupID, userID int) error {
+	return s.checkFitLeader(fitGroupID, userID)
+}
+
+// RecordAudit 은 moderation 조치를 기록합니다. 기록 실패는 조치 자체를 실패시키지 않습니다.
+func (s *ChatModerationService) RecordAudit(auditLog model.ModerationAuditLog) {
+	if err := s.auditRepo.SaveAuditLog(&auditLog); err != nil {
+		log.Printf("Error recording moderation audit %s in fit group %d: %v", auditLog.Action, auditLog.FitGroupID, err)
+	}
+}
+
+func (s *ChatModerationService) restrict(restrictionType model.RestrictionType, fitGroupID, leaderID, targetUserID int, duration time.Duration, reason string) (*model.ChatRestriction, error) {
+	if duration < 0 || duration > maxRestrictionDuration {
+		return nil, fmt.Errorf("restriction duration must be between 0 and %v: %v", maxRestrictionDuration, duration)
+	}
+	if err := s.checkFitLeader(fitGroupID, leaderID); err != nil {
+		return nil, err
+	}
+	if targetUserID == leaderID {
+		return nil, ErrInvalidTarget
+	}
+	isMate, err := s.fitMateRepo.CheckFitMateExists(targetUserID, fitGroupID)
+	if err != nil {
+		return nil, err
+	}
+	if !isMate {
+		return nil, ErrNotFitGroupMember
+	}
+
+	restriction := &model.ChatRestriction{
+		FitGroupID: fitGroupID,
+		UserID:     targetUserID,
+		Type:       restrictionType,
+		Reason:     reason,
+		CreatedBy:  strconv.Itoa(leaderID),
+	}
+	if duration > 0 {
+		expiresAt := time.Now().Add(duration)
+		restriction.ExpiresAt = &expiresAt
+	}
+	restriction, err = s.restrictionRepo.SaveRestriction(restriction)
+	if err != nil {
+		return nil, err
+	}
+	s.invalidate(fitGroupID, targetUserID)
+
+	action := model.ActionMute
+	if restrictionType == model.RestrictionBan {
+		action = model.ActionBan
+	}
+	detail := reason
+	if duration > 0 {
+		detail = fmt.Sprintf("%s (duration: %v)", reason, duration)
+	}
+	s.RecordAudit(model.ModerationAuditLog{FitGroupID: fitGroupID, ActorUserID: leaderID, Action: action, TargetUserID: targetUserID, Detail: detail})
+	return restriction, nil
+}
+
+func (s *ChatModerationService) lift(restrictionType model.RestrictionType, action model.ModerationAction, fitGroupID, leaderID, targetUserID int) error {
+	if err := s.checkFitLeader(fitGroupID, leaderID); err != nil {
+		return err
+	}
+	if _, err := s.restrictionRepo.RevokeRestrictions(fitGroupID, targetUserID, restrictionType, strconv.Itoa(leaderID)); err != nil {
+		return err
+	}
+	s.invalidate(fitGroupID, targetUserID)
+	s.RecordAudit(model.ModerationAuditLog{FitGroupID: fitGroupID, ActorUserID: leaderID, Action: action, TargetUserID: targetUserID})
+	return nil
+}
+
+func (s *ChatModerationService) invalidate(fitGroupID, userID int) {
+	s.mu.Lock()
+	delete(s.cache, fmt.Sprintf("%d:%d", fitGroupID, userID))
+	s.mu.Unlock()
+}
+
+func (s *ChatModerationService) checkFitLeader(fitGroupID, userID int) error {
+	fitGroup, err := s.fitGroupRepo.GetFitGroupByID(fitGroupID)
+	if err != nil {
+		return err
+	}
+	if fitGroup.FitLeaderUserID != userID {
+		return ErrNotFitLeader
+	}
+	return nil
+}
+
+// RestrictedChatService 는 뮤트/차단된 사용자의 메시지와 fit leader 가 아닌 사용자의 공지 저장을 막는 ChatUseCase 데코레이터입니다.
+type RestrictedChatService struct {
+	ChatUseCase
+	moderation ChatModerationUseCase
+}
+
+var _ ChatUseCase = (*RestrictedChatService)(nil)
+
+func NewRestrictedChatService(next ChatUseCase, moderation ChatModerationUseCase) *RestrictedChatService {
+	return &RestrictedChatService{ChatUseCase: next, moderation: moderation}
+}
+
+func (s *RestrictedChatService) SaveChatMessage(msg model.ChatMessage) (model.ChatMessage, error) {
+	if err := s.moderation.CheckRestriction(msg.FitGroupID, msg.UserID); err != nil {
+		return msg, err
+	}
+	if msg.MessageType == model.Announcement {
+		if err := s.moderation.CheckAnnouncement(msg.FitGroupID, msg.UserID); err != nil {
+			return msg, err
+		}
+	}
+	return s.ChatUseCase.SaveChatMessage(msg)
+}
@@ -0,0 +1,278 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"workoutstudy_chatting/model"
+	"workoutstudy_chatting/persistence"
+)
+
+// recordingRoom 은 채팅방으로 보낸 이벤트를 "MEMBER_MUTED 1:3", "kick 1:3", "post 1:메시지" 형식으로 보낸 순서대로 기록합니다.
+// 메시지 이벤트는 대상 사용자 대신 메시지 ID 를 기록합니다.
+type recordingRoom struct {
+	mu     sync.Mutex
+	events []string
+}
+
+var _ RoomNotifier = (*recordingRoom)(nil)
+
+func (r *recordingRoom) record(format string, args ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, fmt.Sprintf(format, args...))
+}
+
+func (r *recordingRoom) Publish(fitGroupID int, event model.RoomEvent) {
+	target := strconv.Itoa(event.TargetUserID)
+	if event.MessageID != "" {
+		target = event.MessageID
+	}
+	r.record("%s %d:%s", event.Type, fitGroupID, target)
+}
+
+func (r *recordingRoom) Post(fitGroupID int, msg model.ChatMessage) {
+	r.record("post %d:%s", fitGroupID, msg.Message)
+}
+
+func (r *recordingRoom) Kick(fitGroupID, userID int, reason string) {
+	r.record("kick %d:%d", fitGroupID, userID)
+}
+
+func (r *recordingRoom) recorded() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.events...)
+}
+
+// saveFitMates 는 사용자들을 fit group 의 활성 fit mate 로 저장합니다. fit mate ID 는 fitGroupID*10+userID 입니다.
+func saveFitMates(t *testing.T, repos persistence.Repositories, fitGroupID int, userIDs ...int) {
+	t.Helper()
+	for _, userID := range userIDs {
+		if _, err := repos.FitMate.SaveFitMate(&model.FitMate{ID: fitGroupID*10 + userID, UserID: userID, FitGroupID: fitGroupID, CreatedBy: "test", UpdatedBy: "test"}); err != nil {
+			t.Fatalf("SaveFitMate %d:%d: %v", fitGroupID, userID, err)
+		}
+	}
+}
+
+func auditActions(t *testing.T, repos persistence.Repositories, fitGroupID int) []string {
+	t.Helper()
+	logs, err := repos.ModerationAudit.GetAuditLogs(fitGroupID, 100)
+	if err != nil {
+		t.Fatalf("GetAuditLogs: %v", err)
+	}
+	var actions []string
+	for _, l := range logs {
+		actions = append(actions, fmt.Sprintf("%s %d->%d", l.Action, l.ActorUserID, l.TargetUserID))
+	}
+	return actions
+}
+
+const moderatedMessageID = "00000000-0000-0000-0000-0000000000a1"
+
+// newChatModerationFixture 는 fit group 1(리더 1, 멤버 2, 3)과 fit group 2(리더 4)를 만들고
+// fit group 1 에 사용자 3 의 메시지를 저장합니다.
+func newChatModerationFixture(t *testing.T) (persistence.Repositories, *recordingRoom, *ChatModerationService) {
+	t.Helper()
+	repos := persistence.NewMemoryRepositories(persistence.NewMemoryStore())
+	saveUsers(t, repos, 1, 2, 3, 4, 5)
+	seedFitGroup(t, repos, 1, 1)
+	seedFitGroup(t, repos, 2, 4)
+	saveFitMates(t, repos, 1, 2, 3)
+	if err := repos.Chat.SaveMessage(model.ChatMessage{ID: moderatedMessageID, UserID: 3, FitGroupID: 1, Message: "광고", MessageTime: time.Now(), MessageType: model.Chatting}); err != nil {
+		t.Fatalf("SaveMessage: %v", err)
+	}
+	room := &recordingRoom{}
+	service := NewChatModerationService(repos.ChatRestriction, repos.ModerationAudit, repos.Chat, repos.FitGroup, repos.FitMate, room)
+	return repos, room, service
+}
+
+func TestChatModerationRequiresFitLeader(t *testing.T) {
+	tests := []struct {
+		name        string
+		run         func(s *ChatModerationService) error
+		wantErr     error
+		wantRoom    []string
+		wantAudit   []string
+		wantDeleted bool
+	}{
+		{
+			name:      "리더가 뮤트",
+			run:       func(s *ChatModerationService) error { _, err := s.MuteMember(1, 1, 3, time.Hour, "도배"); return err },
+			wantRoom:  []string{"MEMBER_MUTED 1:3"},
+			wantAudit: []string{"MUTE 1->3"},
+		},
+		{
+			name:    "멤버는 뮤트 불가",
+			run:     func(s *ChatModerationService) error { _, err := s.MuteMember(1, 2, 3, time.Hour, "도배"); return err },
+			wantErr: ErrNotFitLeader,
+		},
+		{
+			name:    "다른 fit group 의 리더는 뮤트 불가",
+			run:     func(s *ChatModerationService) error { _, err := s.MuteMember(1, 4, 3, time.Hour, "도배"); return err },
+			wantErr: ErrNotFitLeader,
+		},
+		{
+			name:    "리더 자신은 뮤트 불가",
+			run:     func(s *ChatModerationService) error { _, err := s.MuteMember(1, 1, 1, time.Hour, "도배"); return err },
+			wantErr: ErrInvalidTarget,
+		},
+		{
+			name:    "멤버가 아닌 사용자는 뮤트 불가",
+			run:     func(s *ChatModerationService) error { _, err := s.MuteMember(1, 1, 5, time.Hour, "도배"); return err },
+			wantErr: ErrNotFitGroupMember,
+		},
+		{
+			name:      "리더가 차단하면 채팅방에서 내보냄",
+			run:       func(s *ChatModerationService) error { _, err := s.BanMember(1, 1, 3, 0, "욕설"); return err },
+			wantRoom:  []string{"MEMBER_BANNED 1:3", "kick 1:3"},
+			wantAudit: []string{"BAN 1->3"},
+		},
+		{
+			name:    "멤버는 차단 불가",
+			run:     func(s *ChatModerationService) error { _, err := s.BanMember(1, 3, 2, 0, "욕설"); return err },
+			wantErr: ErrNotFitLeader,
+		},
+		{
+			name:      "리더가 뮤트 해제",
+			run:       func(s *ChatModerationService) error { return s.UnmuteMember(1, 1, 3) },
+			wantRoom:  []string{"MEMBER_UNMUTED 1:3"},
+			wantAudit: []string{"UNMUTE 1->3"},
+		},
+		{
+			name:    "멤버는 뮤트 해제 불가",
+			run:     func(s *ChatModerationService) error { return s.UnmuteMember(1, 3, 3) },
+			wantErr: ErrNotFitLeader,
+		},
+		{
+			name:    "멤버는 차단 해제 불가",
+			run:     func(s *ChatModerationService) error { return s.UnbanMember(1, 2, 3) },
+			wantErr: ErrNotFitLeader,
+		},
+		{
+			name:        "리더가 다른 사람 메시지 삭제",
+			run:         func(s *ChatModerationService) error { return s.DeleteMessage(moderatedMessageID, 1) },
+			wantRoom:    []string{"MESSAGE_DELETED 1:" + moderatedMessageID},
+			wantAudit:   []string{"DELETE_MESSAGE 1->3"},
+			wantDeleted: true,
+		},
+		{
+			name:    "작성자라도 리더 권한으로 삭제 불가",
+			run:     func(s *ChatModerationService) error { return s.DeleteMessage(moderatedMessageID, 3) },
+			wantErr: ErrNotFitLeader,
+		},
+		{
+			name:    "멤버는 제한 목록 조회 불가",
+			run:     func(s *ChatModerationService) error { _, err := s.GetActiveRestrictions(1, 2); return err },
+			wantErr: ErrNotFitLeader,
+		},
+		{
+			name:    "멤버는 audit 조회 불가",
+			run:     func(s *ChatModerationService) error { _, err := s.GetAuditLogs(1, 2, 10); return err },
+			wantErr: ErrNotFitLeader,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repos, room, service := newChatModerationFixture(t)
+			if err := tt.run(service); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if got := room.recorded(); fmt.Sprint(got) != fmt.Sprint(tt.wantRoom) {
+				t.Fatalf("room events = %v, want %v", got, tt.wantRoom)
+			}
+			if got := auditActions(t, repos, 1); fmt.Sprint(got) != fmt.Sprint(tt.wantAudit) {
+				t.Fatalf("audit = %v, want %v", got, tt.wantAudit)
+			}
+
+			msg, err := repos.Chat.GetMessageByID(moderatedMessageID)
+			if err != nil {
+				t.Fatalf("GetMessageByID: %v", err)
+			}
+			if deleted := msg.DeletedAt != nil; deleted != tt.wantDeleted {
+				t.Fatalf("message deleted = %v, want %v", deleted, tt.wantDeleted)
+			}
+		})
+	}
+}
+
+func TestChatModerationRejectsInvalidDuration(t *testing.T) {
+	for _, duration := range []time.Duration{-time.Second, maxRestrictionDuration + time.Second} {
+		_, _, service := newChatModerationFixture(t)
+		if _, err := service.MuteMember(1, 1, 3, duration, "도배"); err == nil {
+			t.Fatalf("MuteMember(%v) succeeded, want duration error", duration)
+		}
+	}
+}
+
+// savingChat 은 저장 요청된 메시지를 기록하는 ChatUseCase 입니다.
+type savingChat struct {
+	ChatUseCase
+	saved []string
+}
+
+func (c *savingChat) SaveChatMessage(msg model.ChatMessage) (model.ChatMessage, error) {
+	c.saved = append(c.saved, msg.Message)
+	return msg, nil
+}
+
+func TestRestrictedChatServiceEnforcesRestrictions(t *testing.T) {
+	_, _, moderation := newChatModerationFixture(t)
+	next := &savingChat{}
+	chat := NewRestrictedChatService(next, moderation)
+	send := func(userID int, messageType model.MessageType, message string) error {
+		_, err := chat.SaveChatMessage(model.ChatMessage{UserID: userID, FitGroupID: 1, Message: message, MessageType: messageType})
+		return err
+	}
+
+	steps := []struct {
+		name    string
+		setup   func() error
+		userID  int
+		msgType model.MessageType
+		wantErr error
+	}{
+		{name: "제한 없음", userID: 3, msgType: model.Chatting},
+		{name: "뮤트", setup: func() error { _, err := moderation.MuteMember(1, 1, 3, time.Hour, ""); return err }, userID: 3, msgType: model.Chatting, wantErr: ErrMuted},
+		{name: "뮤트는 다른 멤버에 영향 없음", userID: 2, msgType: model.Chatting},
+		{name: "뮤트 해제", setup: func() error { return moderation.UnmuteMember(1, 1, 3) }, userID: 3, msgType: model.Chatting},
+		{name: "차단이 뮤트보다 우선", setup: func() error {
+			if _, err := moderation.MuteMember(1, 1, 2, 0, ""); err != nil {
+				return err
+			}
+			_, err := moderation.BanMember(1, 1, 2, 0, "")
+			return err
+		}, userID: 2, msgType: model.Chatting, wantErr: ErrBanned},
+		{name: "기간이 지난 뮤트", setup: func() error {
+			_, err := moderation.MuteMember(1, 1, 3, time.Millisecond, "")
+			time.Sleep(5 * time.Millisecond)
+			return err
+		}, userID: 3, msgType: model.Chatting},
+		{name: "멤버는 공지 불가", userID: 3, msgType: model.Announcement, wantErr: ErrNotFitLeader},
+		{name: "리더는 공지 가능", userID: 1, msgType: model.Announcement},
+	}
+
+	var wantSaved []string
+	for _, step := range steps {
+		if step.setup != nil {
+			if err := step.setup(); err != nil {
+				t.Fatalf("%s: setup: %v", step.name, err)
+			}
+		}
+		if err := send(step.userID, step.msgType, step.name); !errors.Is(err, step.wantErr) {
+			t.Fatalf("%s: err = %v, want %v", step.name, err, step.wantErr)
+		}
+		if step.wantErr == nil {
+			wantSaved = append(wantSaved, step.name)
+		}
+	}
+	if !reflect.DeepEqual(next.saved, wantSaved) {
+		t.Fatalf("saved = %v, want %v", next.saved, wantSaved)
+	}
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+	"workoutstudy_chatting/config"
+	"workoutstudy_chatting/model"
+	"workoutstudy_chatting/ratelimit"
+)
+
+// RateLimitConfig 는 채팅 메시지 전송 제한 설정입니다.
+type RateLimitConfig struct {
+	UserRoom        ratelimit.Rate // 사용자 + 채팅방 단위 제한
+	Connection      ratelimit.Rate // 웹소켓 연결 단위 제한 (핸들러에서 사용)
+	DuplicateWindow time.Duration  // 같은 내용의 메시지를 중복으로 간주하는 시간
+}
+
+// NewRateLimitConfig 는 서비스 설정의 rateLimit 항목으로 전송 제한 설정을 만듭니다.
+func NewRateLimitConfig(cfg config.RateLimitConfig) RateLimitConfig {
+	return RateLimitConfig{
+		UserRoom:        ratelimit.Rate{PerSecond: cfg.UserRoom.PerSecond, Burst: cfg.UserRoom.Burst},
+		Connection:      ratelimit.Rate{PerSecond: cfg.Connection.PerSecond, Burst: cfg.Connection.Burst},
+		DuplicateWindow: cfg.DuplicateWindow,
+	}
+}
+
+// RateLimitError 는 메시지가 전송 제한에 걸렸을 때 반환됩니다.
+type RateLimitError struct {
+	Code       model.ChatErrorCode
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("message rejected: %s (retry after %v)", e.Code, e.RetryAfter)
+}
+
+// 슬로우 모드 최대 간격. 이보다 오래된 최근 메시지 기록은 정리합니다.
+const MaxSlowModeSeconds = 3600
+
+type recentMessage struct {
+	id     string
+	digest [sha256.Size]byte
+	sentAt time.Time
+}
+
+// RateLimitedChatService 는 ChatUseCase 를 감싸 SaveChatMessage 앞에서 전송 제한을 적용합니다.
+// 1. 중복 메시지 (같은 messageId 또는 DuplicateWindow 내 같은 내용)
+// 2. 슬로우 모드 (fit leader 는 제외)
+// 3. 사용자 + 채팅방 토큰 버킷
+type RateLimitedChatService struct {
+	ChatUseCase
+	settings ChatRoomSettingUseCase
+	config   RateLimitConfig
+	limiter  *ratelimit.KeyedLimiter
+
+	mu          sync.Mutex
+	recent      map[string]recentMessage // key: userID:fitGroupID
+	lastCleanup time.Time
+}
+
+var _ ChatUseCase = (*RateLimitedChatService)(nil)
+
+func NewRateLimitedChatService(next ChatUseCase, settings ChatRoomSettingUseCase, config RateLimitConfig) *RateLimitedChatService {
+	return &RateLimitedChatService{
+		ChatUseCase: next,
+		settings:    settings,
+		config:      config,
+		limiter:     ratelimit.NewKeyedLimiter(config.UserRoom, 10*time.Minute),
+		recent:      make(map[string]recentMessage),
+		lastCleanup: time.Now(),
+	}
+}
+
+func (s *RateLimitedChatService) SaveChatMessage(msg model.ChatMessage) (model.ChatMessage, error) {
+	key := fmt.Sprintf("%d:%d", msg.UserID, msg.FitGroupID)
+	now := time.Now()
+	digest := sha256.Sum256([]byte(msg.Message))
+
+	s.mu.Lock()
+	last, hasLast := s.recent[key]
+	s.mu.Unlock()
+
+	if hasLast {
+		if msg.ID != "" && msg.ID == last.id {
+			return msg, &RateLimitError{Code: model.ErrorDuplicateMessage}
+		}
+		if digest == last.digest && now.Sub(last.sentAt) < s.config.DuplicateWindow {
+			return msg, &RateLimitError{Code: model.ErrorDuplicateMessage, RetryAfter: s.config.DuplicateWindow - now.Sub(last.sentAt)}
+		}
+	}
+
+	if hasLast {
+		if wait := s.slowModeWait(msg, now.Sub(last.sentAt)); wait > 0 {
+			return msg, &RateLimitError{Code: model.ErrorSlowMode, RetryAfter: wait}
+		}
+	}
+
+	if ok, wait := s.limiter.Allow(key); !ok {
+		return msg, &RateLimitError{Code: model.ErrorRateLimited, RetryAfter: wait}
+	}
+
+	saved, err := s.ChatUseCase.SaveChatMessage(msg)
+	if err != nil {
+		return saved, err
+	}
+
+	s.mu.Lock()
+	s.recent[key] = recentMessage{id: msg.ID, digest: digest, sentAt: now}
+	if now.Sub(s.lastCleanup) > 10*time.Minute {
+		for k, m := range s.recent {
+			if now.Sub(m.sentAt) > MaxSlowModeSeconds*time.Second {
+				delete(s.recent, k)
+			}
+		}
+		s.lastCleanup = now
+	}
+	s.mu.Unlock()
+	return saved, nil
+}
+
+// slowModeWait 는 슬로우 모드로 인해 더 기다려야 하는 시간을 반환합니다. 설정 조회 실패 시 제한하지 않습니다.
+func (s *RateLimitedChatService) slowModeWait(msg model.ChatMessage, sinceLast time.Duration) time.Duration {
+	setting, err := s.settings.GetChatRoomSetting(msg.FitGroupID)
+	if err != nil {
+		log.Printf("Error loading chat room setting for fit group %d: %v", msg.FitGroupID, err)
+		return 0
+	}
+	if setting.SlowModeSeconds <= 0 {
+		return 0
+	}
+	if leaderID, err := s.settings.GetFitLeaderUserID(msg.FitGroupID); err == nil && leaderID == msg.UserID {
+		return 0
+	}
+	interval := time.Duration(setting.SlowModeSeconds) * time.Second
+	if sinceLast >= interval {
+		return 0
+	}
+	return interval - sinceLast
+}
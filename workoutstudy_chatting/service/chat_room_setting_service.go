@@ -0,0 +1,152 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+	"workoutstudy_chatting/model"
+	"workoutstudy_chatting/persistence"
+)
+
+var ErrInvalidTimeZone = errors.New("invalid time zone")
+
+// 메시지마다 DB 를 조회하지 않도록 채팅방 설정을 잠시 캐시합니다.
+const chatRoomSettingCacheTTL = 30 * time.Second
+
+type ChatRoomSettingUseCase interface {
+	GetChatRoomSetting(fitGroupID int) (*model.ChatRoomSetting, error)
+	GetFitLeaderUserID(fitGroupID int) (int, error)
+	SetSlowMode(fitGroupID, userID, slowModeSeconds int) (*model.ChatRoomSetting, error)
+	SetTimeZone(fitGroupID, userID int, timeZone string) (*model.ChatRoomSetting, error)
+	GetLocation(fitGroupID int) (*time.Location, error)
+}
+
+var _ ChatRoomSettingUseCase = (*ChatRoomSettingService)(nil)
+
+type chatRoomSettingCacheEntry struct {
+	setting         *model.ChatRoomSetting
+	fitLeaderUserID int
+	expiresAt       time.Time
+}
+
+type ChatRoomSettingService struct {
+	repo         persistence.ChatRoomSettingRepository
+	fitGroupRepo persistence.FitGroupRepository
+
+	mu    sync.Mutex
+	cache map[int]chatRoomSettingCacheEntry
+}
+
+func NewChatRoomSettingService(repo persistence.ChatRoomSettingRepository, fitGroupRepo persistence.FitGroupRepository) *ChatRoomSettingService {
+	return &ChatRoomSettingService{
+		repo:         repo,
+		fitGroupRepo: fitGroupRepo,
+		cache:        make(map[int]chatRoomSettingCacheEntry),
+	}
+}
+
+func (s *ChatRoomSettingService) GetChatRoomSetting(fitGroupID int) (*model.ChatRoomSetting, error) {
+	entry, err := s.load(fitGroupID)
+	if err != nil {
+		return nil, err
+	}
+	return entry.setting, nil
+}
+
+func (s *ChatRoomSettingService) GetFitLeaderUserID(fitGroupID int) (int, error) {
+	entry, err := s.load(fitGroupID)
+	if err != nil {
+		return 0, err
+	}
+	return entry.fitLeaderUserID, nil
+}
+
+// SetSlowMode 는 fit leader 만 변경할 수 있습니다.
+func (s *ChatRoomSettingService) SetSlowMode(fitGroupID, userID, slowModeSeconds int) (*model.ChatRoomSetting, error) {
+	if slowModeSeconds < 0 || slowModeSeconds > MaxSlowModeSeconds {
+		return nil, fmt.Errorf("slow mode seconds must be between 0 and %d: %d", MaxSlowModeSeconds, slowModeSeconds)
+	}
+	fitGroup, err := s.fitGroupRepo.GetFitGroupByID(fitGroupID)
+	if err != nil {
+		return nil, err
+	}
+	if fitGroup.FitLeaderUserID != userID {
+		return nil, ErrNotFitLeader
+	}
+
+	setting, err := s.repo.SaveSlowMode(fitGroupID, slowModeSeconds, strconv.Itoa(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	delete(s.cache, fitGroupID)
+	s.mu.Unlock()
+	return setting, nil
+}
+
+// SetTimeZone 은 fit leader 만 변경할 수 있습니다. timeZone 은 IANA 시간대 이름(예: Asia/Seoul)입니다.
+func (s *ChatRoomSettingService) SetTimeZone(fitGroupID, userID int, timeZone string) (*model.ChatRoomSetting, error) {
+	if _, err := time.LoadLocation(timeZone); err != nil || timeZone == "" || timeZone == "Local" {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidTimeZone, timeZone)
+	}
+	fitGroup, err := s.fitGroupRepo.GetFitGroupByID(fitGroupID)
+	if err != nil {
+		return nil, err
+	}
+	if fitGroup.FitLeaderUserID != userID {
+		return nil, ErrNotFitLeader
+	}
+
+	setting, err := s.repo.SaveTimeZone(fitGroupID, timeZone, strconv.Itoa(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	delete(s.cache, fitGroupID)
+	s.mu.Unlock()
+	return setting, nil
+}
+
+// GetLocation 은 fit group 의 시간대를 반환합니다. 저장된 시간대를 불러올 수 없으면 기본 시간대를 사용합니다.
+func (s *ChatRoomSettingService) GetLocation(fitGroupID int) (*time.Location, error) {
+	setting, err := s.GetChatRoomSetting(fitGroupID)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := time.LoadLocation(setting.TimeZone)
+	if err != nil {
+		log.Printf("Invalid time zone %q for fit group %d, using %s: %v", setting.TimeZone, fitGroupID, model.DefaultTimeZone, err)
+		return time.LoadLocation(model.DefaultTimeZone)
+	}
+	return loc, nil
+}
+
+func (s *ChatRoomSettingService) load(fitGroupID int) (chatRoomSettingCacheEntry, error) {
+	now := time.Now()
+	s.mu.Lock()
+	entry, ok := s.cache[fitGroupID]
+	s.mu.Unlock()
+	if ok && now.Before(entry.expiresAt) {
+		return entry, nil
+	}
+
+	setting, err := s.repo.GetChatRoomSetting(fitGroupID)
+	if err != nil {
+		return entry, err
+	}
+	fitGroup, err := s.fitGroupRepo.GetFitGroupByID(fitGroupID)
+	if err != nil {
+		return entry, err
+	}
+
+	entry = chatRoomSettingCacheEntry{setting: setting, fitLeaderUserID: fitGroup.FitLeaderUserID, expiresAt: now.Add(chatRoomSettingCacheTTL)}
+	s.mu.Lock()
+	s.cache[fitGroupID] = entry
+	s.mu.Unlock()
+	return entry, nil
+}
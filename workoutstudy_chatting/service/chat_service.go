@@ -2,6 +2,7 @@ package service
 
 import (
 	"log"
+	"strconv"
 	"time"
 	"workoutstudy_chatting/model"
 	"workoutstudy_chatting/persistence"
@@ -9,17 +10,19 @@ import (
 
 type ChatUseCase interface {
 	RetrieveMessages(fitGroupID int, messageTime time.Time, messageID string) ([]model.ChatMessage, string, error)
-	SaveChatMessage(msg model.ChatMessage) error
+	SaveChatMessage(msg model.ChatMessage) (model.ChatMessage, error)
+	DeleteChatMessage(messageID string, userID int) error
 }
 
 var _ ChatUseCase = (*ChatService)(nil)
 
 type ChatService struct {
-	repo persistence.ChatRepository
+	repo     persistence.ChatRepository
+	notifier RoomNotifier
 }
 
-func NewChatService(repo persistence.ChatRepository) *ChatService {
-	return &ChatService{repo: repo}
+func NewChatService(repo persistence.ChatRepository, notifier RoomNotifier) *ChatService {
+	return &ChatService{repo: repo, notifier: notifier}
 }
 
 /*
@@ -58,6 +61,26 @@ func (s *ChatService) RetrieveMessages(fitGroupID int, messageTime time.Time, me
 	return filteredMessages, latestMessageId, nil
 }
 
-func (s *ChatService) SaveChatMessage(msg model.ChatMessage) error {
-	return s.repo.SaveMessage(msg)
+// SaveChatMessage 는 저장된 메시지를 반환합니다. 앞단의 데코레이터가 메시지를 변경할 수 있으므로 브로드캐스트에는 반환값을 사용합니다.
+func (s *ChatService) SaveChatMessage(msg model.ChatMessage) (model.ChatMessage, error) {
+	if err := s.repo.SaveMessage(msg); err != nil {
+		return msg, err
+	}
+	return msg, nil
+}
+
+// DeleteChatMessage 는 본인이 보낸 메시지만 삭제(soft delete)합니다.
+func (s *ChatService) DeleteChatMessage(messageID string, userID int) error {
+	msg, err := s.repo.GetMessageByID(messageID)
+	if err != nil {
+		return err
+	}
+	if msg.UserID != userID {
+		return ErrNotMessageOwner
+	}
+	if err := s.repo.SoftDeleteMessage(messageID, strconv.Itoa(userID)); err != nil {
+		return err
+	}
+	s.notifier.Publish(msg.FitGroupID, model.RoomEvent{Type: model.EventMessageDeleted, FitGroupID: msg.FitGroupID, MessageID: messageID, ActorUserID: userID})
+	return nil
 }
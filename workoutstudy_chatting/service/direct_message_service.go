@@ -0,0 +1,136 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"workoutstudy_chatting/model"
+	"workoutstudy_chatting/persistence"
+)
+
+var (
+	ErrNoSharedFitGroup           = errors.New("users do not share an active fit group")
+	ErrNotConversationParticipant = errors.New("user is not a participant of the conversation")
+	ErrEmptyMessage               = errors.New("message is empty")
+)
+
+// 대화 내역 조회 시 한 번에 가져오는 최대 메시지 수
+const maxDirectMessagePage = 100
+
+type DirectMessageUseCase interface {
+	OpenConversation(userID, peerUserID int) (*model.DirectConversation, error)
+	GetConversation(conversationID, userID int) (*model.DirectConversation, error)
+	GetConversations(userID int) ([]model.DirectConversationSummary, error)
+	SendDirectMessage(conversation *model.DirectConversation, msg model.DirectMessage) (model.DirectMessage, error)
+	GetDirectMessages(conversationID, userID int, before time.Time, limit int) ([]model.DirectMessage, error)
+	MarkRead(conversationID, userID int) error
+	CountUnread(conversationID, userID int) (int, error)
+}
+
+var _ DirectMessageUseCase = (*DirectMessageService)(nil)
+
+// DirectMessageService 는 활성 fit group 을 함께 하는 사용자 사이의 1:1 대화를 제공합니다.
+type DirectMessageService struct {
+	repo        persistence.DirectMessageRepository
+	fitMateRepo persistence.FitMateRepository
+}
+
+func NewDirectMessageService(repo persistence.DirectMessageRepository, fitMateRepo persistence.FitMateRepository) *DirectMessageService {
+	return &DirectMessageService{repo: repo, fitMateRepo: fitMateRepo}
+}
+
+// OpenConversation 은 두 사용자 사이의 대화방을 반환하고, 없으면 생성합니다.
+func (s *DirectMessageService) OpenConversation(userID, peerUserID int) (*model.DirectConversation, error) {
+	if userID == peerUserID {
+		return nil, ErrInvalidTarget
+	}
+	if err := s.checkSharedFitGroup(userID, peerUserID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetOrCreateConversation(userID, peerUserID)
+}
+
+func (s *DirectMessageService) GetConversation(conversationID, userID int) (*model.DirectConversation, error) {
+	conversation, err := s.repo.GetConversationByID(conversationID)
+	if err != nil {
+		return nil, err
+	}
+	if !conversation.HasParticipant(userID) {
+		return nil, ErrNotConversationParticipant
+	}
+	return conversation, nil
+}
+
+func (s *DirectMessageService) GetConversations(userID int) ([]model.DirectConversationSummary, error) {
+	summaries, err := s.repo.GetConversationSummaries(userID)
+	if err != nil {
+		return nil, err
+	}
+	if summaries == nil {
+		summaries = []model.DirectConversationSummary{}
+	}
+	return summaries, nil
+}
+
+// SendDirectMessage 는 메시지를 저장합니다. 함께 하던 fit group 에서 나간 뒤에는 이전 대화방이 있어도 보낼 수 없습니다.
+func (s *DirectMessageService) SendDirectMessage(conversation *model.DirectConversation, msg model.DirectMessage) (model.DirectMessage, error) {
+	if !conversation.HasParticipant(msg.SenderUserID) {
+		return msg, ErrNotConversationParticipant
+	}
+	if strings.TrimSpace(msg.Message) == "" {
+		return msg, ErrEmptyMessage
+	}
+	msg.ConversationID = conversation.ID
+	msg.RecipientUserID = conversation.PeerOf(msg.SenderUserID)
+	if err := s.checkSharedFitGroup(msg.SenderUserID, msg.RecipientUserID); err != nil {
+		return msg, err
+	}
+	if err := s.repo.SaveDirectMessage(msg); err != nil {
+		return msg, err
+	}
+	return msg, nil
+}
+
+// GetDirectMessages 는 before 이전 메시지를 최신순으로 조회합니다.
+func (s *DirectMessageService) GetDirectMessages(conversationID, userID int, before time.Time, limit int) ([]model.DirectMessage, error) {
+	conversation, err := s.GetConversation(conversationID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 || limit > maxDirectMessagePage {
+		limit = maxDirectMessagePage
+	}
+	messages, err := s.repo.RetrieveDirectMessages(conversationID, before, limit)
+	if err != nil {
+		return nil, err
+	}
+	for i := range messages {
+		messages[i].RecipientUserID = conversation.PeerOf(messages[i].SenderUserID)
+	}
+	if messages == nil {
+		messages = []model.DirectMessage{}
+	}
+	return messages, nil
+}
+
+func (s *DirectMessageService) MarkRead(conversationID, userID int) error {
+	if _, err := s.GetConversation(conversationID, userID); err != nil {
+		return err
+	}
+	return s.repo.MarkRead(conversationID, userID)
+}
+
+func (s *DirectMessageService) CountUnread(conversationID, userID int) (int, error) {
+	return s.repo.CountUnread(conversationID, userID)
+}
+
+func (s *DirectMessageService) checkSharedFitGroup(userID, peerUserID int) error {
+	shared, err := s.fitMateRepo.CheckSharedActiveFitGroup(userID, peerUserID)
+	if err != nil {
+		return err
+	}
+	if !shared {
+		return ErrNoSharedFitGroup
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"workoutstudy_chatting/model"
+	"workoutstudy_chatting/persistence"
+)
+
+// leaveFitGroup 은 saveFitMates 로 저장한 fit mate 를 탈퇴 상태로 바꿉니다.
+func leaveFitGroup(t *testing.T, repos persistence.Repositories, fitGroupID, userID int) {
+	t.Helper()
+	if _, err := repos.FitMate.SaveFitMate(&model.FitMate{ID: fitGroupID*10 + userID, UserID: userID, FitGroupID: fitGroupID, State: true, CreatedBy: "test", UpdatedBy: "test"}); err != nil {
+		t.Fatalf("leave fit group %d:%d: %v", fitGroupID, userID, err)
+	}
+}
+
+// newDirectMessageFixture 는 다음 fit group 을 만듭니다. 사용자 6 은 어느 fit group 에도 속하지 않습니다.
+//   - fit group 1: 리더 1, 멤버 2, 3, 탈퇴한 멤버 7
+//   - fit group 2 (삭제됨): 리더 4, 멤버 2, 5
+func newDirectMessageFixture(t *testing.T) (persistence.Repositories, *DirectMessageService) {
+	t.Helper()
+	repos := persistence.NewMemoryRepositories(persistence.NewMemoryStore())
+	saveUsers(t, repos, 1, 2, 3, 4, 5, 6, 7)
+	seedFitGroup(t, repos, 1, 1)
+	seedFitGroup(t, repos, 2, 4)
+	saveFitMates(t, repos, 1, 2, 3, 7)
+	leaveFitGroup(t, repos, 1, 7)
+	saveFitMates(t, repos, 2, 2, 5)
+	if _, err := repos.FitGroup.SaveFitGroup(&model.FitGroup{ID: 2, FitLeaderUserID: 4, FitGroupName: "group", Cycle: 1, Frequency: 3, MaxFitMate: 10, State: true, CreatedBy: "test"}); err != nil {
+		t.Fatalf("delete fit group 2: %v", err)
+	}
+	return repos, NewDirectMessageService(repos.DirectMessage, repos.FitMate)
+}
+
+func TestOpenConversationRequiresSharedActiveFitGroup(t *testing.T) {
+	tests := []struct {
+		name         string
+		userID, peer int
+		wantErr      error
+	}{
+		{name: "리더와 멤버", userID: 1, peer: 2},
+		{name: "멤버끼리", userID: 3, peer: 2},
+		{name: "탈퇴한 멤버", userID: 2, peer: 7, wantErr: ErrNoSharedFitGroup},
+		{name: "삭제된 fit group 만 함께 함", userID: 2, peer: 5, wantErr: ErrNoSharedFitGroup},
+		{name: "삭제된 fit group 의 리더", userID: 5, peer: 4, wantErr: ErrNoSharedFitGroup},
+		{name: "fit group 이 없는 사용자", userID: 2, peer: 6, wantErr: ErrNoSharedFitGroup},
+		{name: "자기 자신", userID: 2, peer: 2, wantErr: ErrInvalidTarget},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, service := newDirectMessageFixture(t)
+			conversation, err := service.OpenConversation(tt.userID, tt.peer)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("OpenConversation err = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !conversation.HasParticipant(tt.userID) || conversation.PeerOf(tt.userID) != tt.peer {
+				t.Fatalf("conversation = %+v", conversation)
+			}
+			// 상대가 열어도 같은 대화방
+			reopened, err := service.OpenConversation(tt.peer, tt.userID)
+			if err != nil || reopened.ID != conversation.ID {
+				t.Fatalf("reopened = %+v, %v, want conversation %d", reopened, err, conversation.ID)
+			}
+		})
+	}
+}
+
+func TestSendDirectMessage(t *testing.T) {
+	repos, service := newDirectMessageFixture(t)
+	conversation, err := service.OpenConversation(2, 3)
+	if err != nil {
+		t.Fatalf("OpenConversation: %v", err)
+	}
+	sentCount := 0
+	newMessage := func(senderID int, message string) model.DirectMessage {
+		sentCount++
+		return model.DirectMessage{ID: fmt.Sprintf("00000000-0000-0000-0000-%012d", sentCount), SenderUserID: senderID, Message: message, MessageTime: time.Now()}
+	}
+	send := func(senderID int, message string) error {
+		_, err := service.SendDirectMessage(conversation, newMessage(senderID, message))
+		return err
+	}
+
+	sent, err := service.SendDirectMessage(conversation, newMessage(2, "같이 뛰어요"))
+	if err != nil {
+		t.Fatalf("SendDirectMessage: %v", err)
+	}
+	if sent.ConversationID != conversation.ID || sent.RecipientUserID != 3 {
+		t.Fatalf("sent = %+v", sent)
+	}
+	if err := send(1, "끼어들기"); !errors.Is(err, ErrNotConversationParticipant) {
+		t.Fatalf("send by non-participant err = %v", err)
+	}
+	if err := send(3, "  "); !errors.Is(err, ErrEmptyMessage) {
+		t.Fatalf("send empty err = %v", err)
+	}
+	if _, err := service.GetDirectMessages(conversation.ID, 1, time.Now(), 10); !errors.Is(err, ErrNotConversationParticipant) {
+		t.Fatalf("history by non-participant err = %v", err)
+	}
+	if n, err := service.CountUnread(conversation.ID, 3); err != nil || n != 1 {
+		t.Fatalf("unread = %d, %v, want 1", n, err)
+	}
+
+	// 함께 하던 fit group 에서 나가면 기존 대화방에도 보낼 수 없지만 내역은 볼 수 있음
+	leaveFitGroup(t, repos, 1, 3)
+	if err := send(2, "어디 갔어요?"); !errors.Is(err, ErrNoSharedFitGroup) {
+		t.Fatalf("send after leaving err = %v, want ErrNoSharedFitGroup", err)
+	}
+	messages, err := service.GetDirectMessages(conversation.ID, 3, time.Now().Add(time.Second), 10)
+	if err != nil || len(messages) != 1 || messages[0].Message != "같이 뛰어요" || messages[0].RecipientUserID != 3 {
+		t.Fatalf("history = %+v, %v", messages, err)
+	}
+}
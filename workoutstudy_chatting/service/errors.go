@@ -0,0 +1,13 @@
+package service
+
+import "errors"
+
+// 핸들러에서 HTTP 상태 코드로 변환하기 위한 서비스 레이어 공통 에러
+var (
+	ErrNotFitGroupMember = errors.New("user is not a member of the fit group")
+	ErrNotFitLeader      = errors.New("only the fit leader can perform this action")
+	ErrNotMessageOwner   = errors.New("user is not the owner of the message")
+
+	ErrReconciliationRunning = errors.New("reconciliation is already running")
+	ErrArchiveNotConfigured  = errors.New("archive storage is not configured (retention.archiveDir)")
+)
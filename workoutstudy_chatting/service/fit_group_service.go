@@ -1,6 +1,8 @@
 package service
 
 import (
+	"database/sql"
+	"errors"
 	"log"
 	"workoutstudy_chatting/model"
 	"workoutstudy_chatting/persistence"
@@ -10,19 +12,26 @@ type FitGroupUseCase interface {
 	GetFitGroupByID(fitGroupID int) (*model.FitGroup, error)
 	GetFitMatesByFitGroupId(fitGroupID int) ([]int, error)
 	SaveFitGroup(fitGroup *model.FitGroup) (*model.FitGroup, error)
-	HandleFitGroupEvent(apiResponse model.GetFitGroupDetailApiResponse, fitGroupEvents chan int) error
+	HandleFitGroupEvent(apiResponse model.GetFitGroupDetailApiResponse) error
+	ReconcileFitGroup(apiResponse model.GetFitGroupDetailApiResponse) (string, error)
 }
 
 // 인터페이스 구현 확인
 var _ FitGroupUseCase = (*FitGroupService)(nil)
 
+// PendingFitMateEventReplayer 는 fit group 보다 먼저 도착해 보류한 fit mate 이벤트를 다시 처리합니다.
+type PendingFitMateEventReplayer interface {
+	ReplayPendingFitMateEvent(fitGroupID int) error
+}
+
 type FitGroupService struct {
-	repo            persistence.FitGroupRepository
-	fitGroupCreated chan int
+	repo       persistence.FitGroupRepository
+	pending    PendingFitMateEventReplayer // fit group 생성 후 보류한 fit mate 이벤트 처리
+	membership MembershipNotifier          // 삭제된 fit group 의 사용자 웹소켓 구독 해지
 }
 
-func NewFitGroupService(repo persistence.FitGroupRepository, ch chan int) *FitGroupService {
-	return &FitGroupService{repo: repo, fitGroupCreated: ch}
+func NewFitGroupService(repo persistence.FitGroupRepository, pending PendingFitMateEventReplayer, membership MembershipNotifier) *FitGroupService {
+	return &FitGroupService{repo: repo, pending: pending, membership: membership}
 }
 
 func (s *FitGroupService) GetFitGroupByID(fitGroupID int) (*model.FitGroup, error) {
@@ -45,47 +54,66 @@ func (s *FitGroupService) SaveFitGroup(fitGroup *model.FitGroup) (*model.FitGrou
 	return s.repo.SaveFitGroup(fitGroup)
 }
 
-func (s *FitGroupService) HandleFitGroupEvent(apiResponse model.GetFitGroupDetailApiResponse, fitGroupEvents chan int) error {
+func (s *FitGroupService) HandleFitGroupEvent(apiResponse model.GetFitGroupDetailApiResponse) error {
 	// 1. Get Fit group detail API 의 fitGroupId로 fit_group 테이블 조회
 	fitGroup, err := s.repo.GetFitGroupByID(apiResponse.FitGroupId)
-	if err != nil {
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
 		return err
 	}
 
 	// 1-a. 존재할 시 Create skip -> Delete 로 이동
 	if fitGroup != nil {
-		// 2. API Response 의 state 확인
-		if apiResponse.State {
-			// 2-a. state 가 true 일 시 DB에서 해당 fit_group의 state 를 true 로 변경 -> 삭제
-			return s.repo.DeleteFitGroup(apiResponse.FitGroupId)
-		} else {
-			// 2-b. state 가 false 일 시 진행 skip, proceed to Update
-			// Update
-			// 2. API Response 와 DB 의 fit_group 정보 비교
-			if shouldUpdate(fitGroup, apiResponse) {
-				// 2-a. 다를 시 DB 정보를 API Response 로 업데이트
-				return s.repo.UpdateFitGroup(convertApiToModel(apiResponse))
-			}
-			// 2-b. 같을 시 진행 skip
-			return nil // No changes needed, nothing to update
-		}
+		_, err := s.syncFitGroup(fitGroup, apiResponse)
+		return err
 	} else {
 		// 1-b-1. DB에 존재하지 않을 시 fit_group 테이블에 API Response 로 row 생성
 		newFitGroup, err := s.repo.SaveFitGroup(convertApiToModel(apiResponse))
 		if err != nil {
 			return err
 		}
-		// 1-b-2. row 생성 이후, fit_group row가 입력됐다는 것을 Get Fit Mate list API Handler 에게 알림
-		select {
-		case fitGroupEvents <- newFitGroup.ID:
-			log.Printf("Notified fit mate handler of new fit group ID %d", newFitGroup.ID)
-		default:
-			log.Printf("Failed to notify fit mate handler of new fit group ID %d", newFitGroup.ID)
+		// 1-b-2. row 생성 이후, 먼저 도착해 보류한 fit mate 이벤트가 있으면 다시 처리
+		// 실패해도 fit group 은 생성되었으므로 이벤트는 성공으로 처리하고, 보류 이벤트 재처리 작업이 다시 시도
+		if err := s.pending.ReplayPendingFitMateEvent(newFitGroup.ID); err != nil {
+			log.Printf("Failed to replay pending fit mate event for new fit group ID %d: %v", newFitGroup.ID, err)
 		}
 		return nil
 	}
 }
 
+// ReconcileFitGroup 은 DB 에 있는 fit group 을 fit-group 서비스의 상세 정보에 맞추고 수행한 조치를 반환합니다.
+// HandleFitGroupEvent 와 같은 기준으로 삭제하거나 수정하며, 바꿀 것이 없으면 빈 문자열을 반환합니다.
+func (s *FitGroupService) ReconcileFitGroup(apiResponse model.GetFitGroupDetailApiResponse) (string, error) {
+	fitGroup, err := s.repo.GetFitGroupByID(apiResponse.FitGroupId)
+	if err != nil {
+		return "", err
+	}
+	return s.syncFitGroup(fitGroup, apiResponse)
+}
+
+// syncFitGroup 은 이미 있는 fit group 을 API Response 대로 삭제(model.ReconcileDeleted)하거나 수정(model.ReconcileUpdated)합니다.
+func (s *FitGroupService) syncFitGroup(fitGroup *model.FitGroup, apiResponse model.GetFitGroupDetailApiResponse) (string, error) {
+	// 2. API Response 의 state 확인
+	if apiResponse.State {
+		// 2-a. state 가 true 일 시 DB에서 해당 fit_group의 state 를 true 로 변경 -> 삭제
+		if err := s.repo.DeleteFitGroup(apiResponse.FitGroupId); err != nil {
+			return "", err
+		}
+		s.membership.FitGroupRemoved(apiResponse.FitGroupId)
+		return model.ReconcileDeleted, nil
+	}
+	// 2-b. state 가 false 일 시 진행 skip, proceed to Update
+	// 3. API Response 와 DB 의 fit_group 정보 비교
+	if shouldUpdate(fitGroup, apiResponse) {
+		// 3-a. 다를 시 DB 정보를 API Response 로 업데이트
+		if err := s.repo.UpdateFitGroup(convertApiToModel(apiResponse)); err != nil {
+			return "", err
+		}
+		return model.ReconcileUpdated, nil
+	}
+	// 3-b. 같을 시 진행 skip
+	return "", nil // No changes needed, nothing to update
+}
+
 func shouldUpdate(existing *model.FitGroup, response model.GetFitGroupDetailApiResponse) bool {
 	// Example of comparison logic; extend this based on actual fields that matter
 	return existing.FitLeaderUserID != response.FitLeaderUserId ||
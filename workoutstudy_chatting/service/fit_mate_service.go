@@ -1,9 +1,10 @@
 package service
 
 import (
-	"fmt"
+	"context"
 	"log"
 	"strconv"
+	"sync/atomic"
 	"time"
 	"workoutstudy_chatting/model"
 	"workoutstudy_chatting/persistence"
@@ -15,21 +16,34 @@ type FitMateUseCase interface {
 	SaveFitMate(*model.FitMate) (*model.FitMate, error)
 	DeleteFitMate(id int) ([]int, error)
 	UpdateFitMate(*model.FitMate) (*model.FitMate, error)
-	HandleFitMateEvent(apiResponse model.GetFitMatesApiResponse, fitGroupEvents chan int) error
+	HandleFitMateEvent(apiResponse model.GetFitMatesApiResponse) error
+	ReconcileFitMates(apiResponse model.GetFitMatesApiResponse) (added, removed []int, err error)
+	ReplayPendingFitMateEvent(fitGroupID int) error
+	GetPendingFitMateEventStats() (*model.PendingFitMateEventStats, error)
 }
 
 // 인터페이스 구현 확인
 var _ FitMateUseCase = (*FitMateService)(nil)
 
 type FitMateService struct {
-	repo           persistence.FitMateRepository
-	fitGroupEvents chan int
+	repo         persistence.FitMateRepository
+	pending      persistence.PendingFitMateEventRepository // fit group 보다 먼저 도착한 fit mate 이벤트 보관
+	pendingTTL   time.Duration
+	pendingStats pendingFitMateEventCounters
+	membership   MembershipNotifier // fit mate 가입/탈퇴를 사용자 웹소켓 구독에 반영
 }
 
-func NewFitMateService(repo persistence.FitMateRepository, ch chan int) *FitMateService {
+// pendingFitMateEventCounters 는 서버 시작 이후 보류 이벤트 처리 누적값입니다. (model.PendingFitMateEventStats)
+type pendingFitMateEventCounters struct {
+	parked, replayed, superseded, expired, replayFailures atomic.Int64
+}
+
+func NewFitMateService(repo persistence.FitMateRepository, pending persistence.PendingFitMateEventRepository, pendingTTL time.Duration, membership MembershipNotifier) *FitMateService {
 	return &FitMateService{
-		repo:           repo,
-		fitGroupEvents: ch,
+		repo:       repo,
+		pending:    pending,
+		pendingTTL: pendingTTL,
+		membership: membership,
 	}
 }
 
@@ -104,7 +118,8 @@ func (s *FitMateService) UpdateFitMate(fitMate *model.FitMate) (*model.FitMate,
 /*
 비교 및 조치 수행
 1. Get Fit Mate list API 의 fitGroupId로 fit_group 테이블에서 fit_group 조회
-1-a. fit_group 존재하지 않을 시 Wait -> fitGroup이 최초 생성되어 아직 Get Fit Group Detail API의 처리가 끝나지 않은 것.
+1-a. fit_group 존재하지 않을 시 보류 -> fitGroup이 최초 생성되어 아직 Get Fit Group Detail API의 처리가 끝나지 않은 것.
+1-a-1. pending_fit_mate_event 에 저장하고, fit_group 이 생성되면 ReplayPendingFitMateEvent 로 2 부터 다시 처리
 1-b. fit_group 존재할 시 다음 단계 진행
 2. fit_group 존재할 시 fitGroupId로 fit_mate 테이블 조회
 2-a-1. 조회된 fit_mate 가 null -> 최초 생성된 fit_group 임을 의미
@@ -117,7 +132,7 @@ func (s *FitMateService) UpdateFitMate(fitMate *model.FitMate) (*model.FitMate,
 4-b-1. fit_mate 삭제(DELETE), Hard Delete 로 진행
 */
 // fit_mate_service.go
-func (s *FitMateService) HandleFitMateEvent(apiResponse model.GetFitMatesApiResponse, fitGroupEvents chan int) error {
+func (s *FitMateService) HandleFitMateEvent(apiResponse model.GetFitMatesApiResponse) error {
 	// fitGroupId로 fit_group 테이블 조회
 	fitGroupExists, err := s.repo.CheckFitGroupExists(apiResponse.FitGroupId)
 	if err != nil {
@@ -125,30 +140,17 @@ func (s *FitMateService) HandleFitMateEvent(apiResponse model.GetFitMatesApiResp
 		return err
 	}
 
-	// fit_group 존재하지 않을 시 10초 대기
+	// fit_group 존재하지 않을 시 보류
 	if !fitGroupExists {
-		log.Printf("FitGroup ID %d does not exist. Waiting...", apiResponse.FitGroupId)
-		timeout := time.After(10 * time.Second)
-		select {
-		case fitGroupID := <-fitGroupEvents:
-			if fitGroupID != apiResponse.FitGroupId {
-				log.Printf("Waiting for FitGroup ID %d, but received %d", apiResponse.FitGroupId, fitGroupID)
-				return nil // 혹은 적절한 에러 처리
-			}
-			log.Printf("Proceeding with FitGroup ID %d", fitGroupID)
-		case <-timeout:
-			log.Printf("Timeout waiting for FitGroup ID %d", apiResponse.FitGroupId)
-			// 10초 후 DB 재조회
-			fitGroupExists, err = s.repo.CheckFitGroupExists(apiResponse.FitGroupId)
-			if err != nil {
-				log.Printf("Error checking fit group existence after timeout: %v", err)
-				return err
-			}
-			if !fitGroupExists {
-				log.Printf("FitGroup ID %d not found after 10 seconds", apiResponse.FitGroupId)
-				return fmt.Errorf("fitGroup ID %d not found after 10 seconds", apiResponse.FitGroupId)
-			}
+		if err := s.parkFitMateEvent(apiResponse); err != nil {
+			return err
+		}
+		// 보류하는 사이 fit group 이 생성되어 보류 이벤트를 확인하지 못했을 수 있으므로 다시 확인
+		fitGroupExists, err = s.repo.CheckFitGroupExists(apiResponse.FitGroupId)
+		if err != nil || !fitGroupExists {
+			return nil // 보류 이벤트 재처리 작업이 다시 확인
 		}
+		return s.ReplayPendingFitMateEvent(apiResponse.FitGroupId)
 	}
 
 	fitMateIds, err := s.repo.GetFitMatesIdsByFitGroupId(apiResponse.FitGroupId)
@@ -157,10 +159,161 @@ func (s *FitMateService) HandleFitMateEvent(apiResponse model.GetFitMatesApiResp
 		return err
 	}
 
+	if _, _, err = s.compareAndUpdateFitMates(apiResponse, fitMateIds); err != nil {
+		return err
+	}
+	s.dropSupersededFitMateEvent(apiResponse.FitGroupId)
+	return nil
+}
+
+// parkFitMateEvent 는 fit group 보다 먼저 도착한 이벤트를 보류합니다. 같은 fit group 의 이전 보류 이벤트는 새 이벤트로 대체됩니다.
+func (s *FitMateService) parkFitMateEvent(apiResponse model.GetFitMatesApiResponse) error {
+	now := time.Now()
+	previous, err := s.pending.GetPendingFitMateEvent(apiResponse.FitGroupId)
+	if err != nil {
+		return err
+	}
+	event := &model.PendingFitMateEvent{
+		FitGroupID: apiResponse.FitGroupId,
+		FitMates:   apiResponse,
+		ReceivedAt: now,
+		ExpiresAt:  now.Add(s.pendingTTL),
+	}
+	if err := s.pending.SavePendingFitMateEvent(event); err != nil {
+		log.Printf("Error parking fit mate event for FitGroup ID %d: %v", apiResponse.FitGroupId, err)
+		return err
+	}
+	s.pendingStats.parked.Add(1)
+	if previous != nil {
+		s.pendingStats.superseded.Add(1)
+	}
+	log.Printf("FitGroup ID %d does not exist. Parked fit mate event until %s", apiResponse.FitGroupId, event.ExpiresAt.Format(time.RFC3339))
+	return nil
+}
+
+// dropSupersededFitMateEvent 는 바로 반영한 이벤트보다 먼저 보류된 이벤트를 버립니다. 나중에 다시 처리해 이전 목록으로 되돌리지 않도록 합니다.
+func (s *FitMateService) dropSupersededFitMateEvent(fitGroupID int) {
+	previous, err := s.pending.GetPendingFitMateEvent(fitGroupID)
+	if err != nil {
+		log.Printf("Error checking pending fit mate event for FitGroup ID %d: %v", fitGroupID, err)
+		return
+	}
+	if previous == nil {
+		return
+	}
+	if deleted, err := s.pending.DeletePendingFitMateEvent(previous); err != nil {
+		log.Printf("Error dropping pending fit mate event for FitGroup ID %d: %v", fitGroupID, err)
+	} else if deleted {
+		s.pendingStats.superseded.Add(1)
+	}
+}
+
+/*
+ReplayPendingFitMateEvent 는 fit group 이 생성되어 있으면 보류한 fit mate 이벤트를 다시 처리합니다.
+보류 이벤트가 없거나 fit group 이 아직 없으면 아무것도 하지 않고, TTL 이 지났으면 버립니다.
+실패하면 보류 상태로 남겨 보류 이벤트 재처리 작업(StartPendingFitMateEventReplayer)이 다시 시도합니다.
+*/
+func (s *FitMateService) ReplayPendingFitMateEvent(fitGroupID int) error {
+	event, err := s.pending.GetPendingFitMateEvent(fitGroupID)
+	if err != nil || event == nil {
+		return err
+	}
+	if !time.Now().Before(event.ExpiresAt) {
+		if deleted, err := s.pending.DeletePendingFitMateEvent(event); err != nil {
+			return err
+		} else if deleted {
+			s.pendingStats.expired.Add(1)
+			log.Printf("Dropped pending fit mate event for FitGroup ID %d: fit group not created by %s", fitGroupID, event.ExpiresAt.Format(time.RFC3339))
+		}
+		return nil
+	}
+
+	fitGroupExists, err := s.repo.CheckFitGroupExists(fitGroupID)
+	if err != nil || !fitGroupExists {
+		return err
+	}
+	fitMateIds, err := s.repo.GetFitMatesIdsByFitGroupId(fitGroupID)
+	if err == nil {
+		_, _, err = s.compareAndUpdateFitMates(event.FitMates, fitMateIds)
+	}
+	if err != nil {
+		s.pendingStats.replayFailures.Add(1)
+		log.Printf("Error replaying pending fit mate event for FitGroup ID %d: %v", fitGroupID, err)
+		return err
+	}
+
+	// 다시 처리하는 사이 새 이벤트가 보류되었으면 지우지 않고 다음에 그 이벤트를 처리
+	if _, err := s.pending.DeletePendingFitMateEvent(event); err != nil {
+		return err
+	}
+	s.pendingStats.replayed.Add(1)
+	log.Printf("Replayed fit mate event for FitGroup ID %d parked %s ago", fitGroupID, time.Since(event.ReceivedAt).Round(time.Second))
+	return nil
+}
+
+// StartPendingFitMateEventReplayer 는 interval 마다 만료된 보류 이벤트를 버리고, fit group 이 생성된 보류 이벤트를 다시 처리합니다.
+// fit group 이벤트 처리 직후 다시 처리하지 못한 경우(다른 인스턴스에서 fit group 생성, 재처리 실패 등)를 보완합니다.
+func (s *FitMateService) StartPendingFitMateEventReplayer(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Pending fit mate event replayer stopped")
+			return
+		case <-ticker.C:
+		}
+
+		expired, err := s.pending.DeleteExpiredPendingFitMateEvents(time.Now())
+		if err != nil {
+			log.Printf("Error dropping expired pending fit mate events: %v", err)
+		} else if expired > 0 {
+			s.pendingStats.expired.Add(expired)
+			log.Printf("Dropped %d expired pending fit mate events", expired)
+		}
+
+		fitGroupIDs, err := s.pending.GetPendingFitMateEventGroupIDs()
+		if err != nil {
+			log.Printf("Error retrieving pending fit mate events: %v", err)
+			continue
+		}
+		for _, fitGroupID := range fitGroupIDs {
+			if ctx.Err() != nil {
+				break
+			}
+			// 실패는 ReplayPendingFitMateEvent 에서 기록하고 다음 주기에 다시 시도
+			_ = s.ReplayPendingFitMateEvent(fitGroupID)
+		}
+	}
+}
+
+func (s *FitMateService) GetPendingFitMateEventStats() (*model.PendingFitMateEventStats, error) {
+	pending, err := s.pending.CountPendingFitMateEvents()
+	if err != nil {
+		return nil, err
+	}
+	return &model.PendingFitMateEventStats{
+		Pending:        pending,
+		Parked:         s.pendingStats.parked.Load(),
+		Replayed:       s.pendingStats.replayed.Load(),
+		Superseded:     s.pendingStats.superseded.Load(),
+		Expired:        s.pendingStats.expired.Load(),
+		ReplayFailures: s.pendingStats.replayFailures.Load(),
+	}, nil
+}
+
+// ReconcileFitMates 는 DB 에 있는 fit group 의 fit mate 를 fit-group 서비스의 목록에 맞추고 추가, 삭제한 fit mate ID 를 반환합니다.
+func (s *FitMateService) ReconcileFitMates(apiResponse model.GetFitMatesApiResponse) (added, removed []int, err error) {
+	fitMateIds, err := s.repo.GetFitMatesIdsByFitGroupId(apiResponse.FitGroupId)
+	if err != nil {
+		return nil, nil, err
+	}
 	return s.compareAndUpdateFitMates(apiResponse, fitMateIds)
 }
 
-func (s *FitMateService) compareAndUpdateFitMates(apiResponse model.GetFitMatesApiResponse, dbFitMateIds []int) error {
+// compareAndUpdateFitMates 는 추가, 삭제한 fit mate ID 를 반환합니다. 중간에 실패하면 그때까지 반영한 ID 와 오류를 반환합니다.
+func (s *FitMateService) compareAndUpdateFitMates(apiResponse model.GetFitMatesApiResponse, dbFitMateIds []int) (added, removed []int, err error) {
 	apiFitMateIdsMap := make(map[int]bool)
 	dbFitMateMap := make(map[int]*model.FitMate)
 
@@ -175,11 +328,19 @@ func (s *FitMateService) compareAndUpdateFitMates(apiResponse model.GetFitMatesA
 	// DB에 존재하는 FitMate들 삭제
 	for _, dbId := range dbFitMateIds {
 		if !apiFitMateIdsMap[dbId] {
-			_, err := s.repo.DeleteFitMate(dbId)
+			// 구독 해지를 위해 삭제 전에 user ID 조회
+			dbFitMate, err := s.repo.GetFitMateByID(strconv.Itoa(dbId))
+			if err != nil {
+				log.Printf("Error fetching fit mate ID %d: %v", dbId, err)
+				return added, removed, err
+			}
+			_, err = s.repo.DeleteFitMate(dbId)
 			if err != nil {
 				log.Printf("Error deleting fit mate ID %d: %v", dbId, err)
-				return err
+				return added, removed, err
 			}
+			s.membership.MemberLeft(apiResponse.FitGroupId, dbFitMate.UserID)
+			removed = append(removed, dbId)
 		}
 	}
 
@@ -196,12 +357,14 @@ func (s *FitMateService) compareAndUpdateFitMates(apiResponse model.GetFitMatesA
 			_, err := s.repo.SaveFitMate(newFitMate)
 			if err != nil {
 				log.Printf("Error adding new fit mate ID %d: %v", apiDetail.FitMateId, err)
-				return err
+				return added, removed, err
 			}
+			s.membership.MemberJoined(apiResponse.FitGroupId, newFitMate.UserID)
+			added = append(added, apiDetail.FitMateId)
 		}
 	}
 
-	return nil
+	return added, removed, nil
 }
 
 // TODO : fit mate UPDATE 할 게 현재 딱히 없음. state 는 조회 결과에 없고, nickname 은 user-create-event로 처리함
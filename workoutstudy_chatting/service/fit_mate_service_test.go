@@ -0,0 +1,150 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"workoutstudy_chatting/model"
+	"workoutstudy_chatting/persistence"
+)
+
+// recordingMembership 은 fit mate 가입/탈퇴와 fit group 삭제 알림을 "joined 5:2" 형식으로 기록합니다.
+type recordingMembership struct {
+	mu     sync.Mutex
+	events []string
+}
+
+var _ MembershipNotifier = (*recordingMembership)(nil)
+
+func (m *recordingMembership) record(format string, args ...interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.events = append(m.events, fmt.Sprintf(format, args...))
+}
+
+func (m *recordingMembership) MemberJoined(fitGroupID, userID int) {
+	m.record("joined %d:%d", fitGroupID, userID)
+}
+
+func (m *recordingMembership) MemberLeft(fitGroupID, userID int) {
+	m.record("left %d:%d", fitGroupID, userID)
+}
+
+func (m *recordingMembership) FitGroupRemoved(fitGroupID int) {
+	m.record("removed %d", fitGroupID)
+}
+
+func (m *recordingMembership) recorded() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	events := append([]string(nil), m.events...)
+	sort.Strings(events)
+	return events
+}
+
+// saveUsers 는 fit group 리더와 fit mate 로 쓸 사용자를 저장합니다.
+func saveUsers(t *testing.T, repos persistence.Repositories, userIDs ...int) {
+	t.Helper()
+	now := time.Now()
+	for _, id := range userIDs {
+		user := &model.User{ID: id, Nickname: fmt.Sprintf("user%d", id), CreatedAt: now, CreatedBy: "test", UpdatedAt: now, UpdatedBy: "test"}
+		if _, err := repos.User.SaveUser(user); err != nil {
+			t.Fatalf("SaveUser %d: %v", id, err)
+		}
+	}
+}
+
+func fitMatesResponse(fitGroupID int, mates ...model.Mate) model.GetFitMatesApiResponse {
+	return model.GetFitMatesApiResponse{
+		FitGroupId:      fitGroupID,
+		FitLeaderDetail: model.Leader{FitLeaderUserId: 1},
+		FitMateDetails:  mates,
+	}
+}
+
+func TestPendingFitMateEventReplayedAfterFitGroupCreated(t *testing.T) {
+	tests := []struct {
+		name         string
+		pendingTTL   time.Duration
+		events       []model.GetFitMatesApiResponse // fit group 보다 먼저 도착한 fit mate 이벤트
+		wantMates    []int
+		wantJoined   []string
+		wantReplayed int64
+		wantExpired  int64
+	}{
+		{
+			name:         "보류한 이벤트를 fit group 생성 후 반영",
+			pendingTTL:   time.Hour,
+			events:       []model.GetFitMatesApiResponse{fitMatesResponse(5, model.Mate{FitMateId: 10, FitMateUserId: 2}, model.Mate{FitMateId: 11, FitMateUserId: 3})},
+			wantMates:    []int{10, 11},
+			wantJoined:   []string{"joined 5:2", "joined 5:3"},
+			wantReplayed: 1,
+		},
+		{
+			name:       "같은 fit group 의 마지막 이벤트만 반영",
+			pendingTTL: time.Hour,
+			events: []model.GetFitMatesApiResponse{
+				fitMatesResponse(5, model.Mate{FitMateId: 10, FitMateUserId: 2}),
+				fitMatesResponse(5, model.Mate{FitMateId: 11, FitMateUserId: 3}),
+			},
+			wantMates:    []int{11},
+			wantJoined:   []string{"joined 5:3"},
+			wantReplayed: 1,
+		},
+		{
+			name:        "TTL 이 지난 이벤트는 버림",
+			pendingTTL:  0,
+			events:      []model.GetFitMatesApiResponse{fitMatesResponse(5, model.Mate{FitMateId: 10, FitMateUserId: 2})},
+			wantExpired: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repos := persistence.NewMemoryRepositories(persistence.NewMemoryStore())
+			saveUsers(t, repos, 1, 2, 3)
+			membership := &recordingMembership{}
+			fitMates := NewFitMateService(repos.FitMate, repos.PendingFitMateEvent, tt.pendingTTL, membership)
+			fitGroups := NewFitGroupService(repos.FitGroup, fitMates, membership)
+
+			for _, event := range tt.events {
+				if err := fitMates.HandleFitMateEvent(event); err != nil {
+					t.Fatalf("HandleFitMateEvent: %v", err)
+				}
+			}
+			if ids, _ := repos.FitMate.GetFitMatesIdsByFitGroupId(5); len(ids) != 0 {
+				t.Fatalf("fit mates saved before fit group exists: %v", ids)
+			}
+			if stats, _ := fitMates.GetPendingFitMateEventStats(); stats.Pending != 1 || stats.Parked != int64(len(tt.events)) {
+				t.Fatalf("stats after parking = %+v", stats)
+			}
+
+			if err := fitGroups.HandleFitGroupEvent(model.GetFitGroupDetailApiResponse{FitGroupId: 5, FitLeaderUserId: 1, FitGroupName: "morning run", Cycle: 1, Frequency: 3, MaxFitMate: 10}); err != nil {
+				t.Fatalf("HandleFitGroupEvent: %v", err)
+			}
+
+			ids, err := repos.FitMate.GetFitMatesIdsByFitGroupId(5)
+			if err != nil {
+				t.Fatalf("GetFitMatesIdsByFitGroupId: %v", err)
+			}
+			sort.Ints(ids)
+			// nil 과 빈 목록을 같게 비교
+			if fmt.Sprint(ids) != fmt.Sprint(tt.wantMates) {
+				t.Fatalf("fit mates = %v, want %v", ids, tt.wantMates)
+			}
+			if got := membership.recorded(); fmt.Sprint(got) != fmt.Sprint(tt.wantJoined) {
+				t.Fatalf("membership = %v, want %v", got, tt.wantJoined)
+			}
+			if pending, _ := repos.PendingFitMateEvent.GetPendingFitMateEvent(5); pending != nil {
+				t.Fatalf("pending event was not removed: %+v", pending)
+			}
+			stats, _ := fitMates.GetPendingFitMateEventStats()
+			if stats.Pending != 0 || stats.Replayed != tt.wantReplayed || stats.Expired != tt.wantExpired {
+				t.Fatalf("stats after replay = %+v", stats)
+			}
+		})
+	}
+}
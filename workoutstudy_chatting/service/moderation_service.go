@@ -0,0 +1,159 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"workoutstudy_chatting/model"
+	"workoutstudy_chatting/moderation"
+	"workoutstudy_chatting/persistence"
+)
+
+// ModerationError 는 필터 체인에서 Reject 된 메시지에 대해 반환됩니다.
+type ModerationError struct {
+	Reasons []string
+}
+
+func (e *ModerationError) Error() string {
+	return "message rejected by moderation: " + strings.Join(e.Reasons, "; ")
+}
+
+// ModeratedChatService 는 ChatUseCase 를 감싸 저장 전에 moderation 파이프라인을 실행합니다.
+// Mask 는 가려진 메시지를 저장하고, Flag 는 저장 후 moderation queue 에 등록하며, Reject 는 저장하지 않습니다.
+type ModeratedChatService struct {
+	ChatUseCase
+	pipeline       *moderation.Pipeline
+	moderationRepo persistence.ModerationRepository
+}
+
+var _ ChatUseCase = (*ModeratedChatService)(nil)
+
+func NewModeratedChatService(next ChatUseCase, pipeline *moderation.Pipeline, moderationRepo persistence.ModerationRepository) *ModeratedChatService {
+	return &ModeratedChatService{
+		ChatUseCase:    next,
+		pipeline:       pipeline,
+		moderationRepo: moderationRepo,
+	}
+}
+
+func (s *ModeratedChatService) SaveChatMessage(msg model.ChatMessage) (model.ChatMessage, error) {
+	result := s.pipeline.Run(msg)
+	if result.Action == moderation.Reject {
+		log.Printf("Message from user %d in fit group %d rejected: %v", msg.UserID, msg.FitGroupID, result.Reasons)
+		return msg, &ModerationError{Reasons: result.Reasons}
+	}
+
+	saved, err := s.ChatUseCase.SaveChatMessage(result.Message)
+	if err != nil {
+		return saved, err
+	}
+
+	if result.Action == moderation.Flag {
+		_, err := s.moderationRepo.SaveModerationItem(&model.ModerationItem{
+			MessageID:  saved.ID,
+			FitGroupID: saved.FitGroupID,
+			UserID:     saved.UserID,
+			Message:    msg.Message, // 검토를 위해 가리기 전 원문을 보관
+			Reasons:    strings.Join(result.Reasons, "; "),
+		})
+		if err != nil {
+			// 메시지는 이미 저장되었으므로 전송은 성공으로 처리
+			log.Printf("Error adding message %s to moderation queue: %v", saved.ID, err)
+		}
+	}
+	return saved, nil
+}
+
+type ModerationUseCase interface {
+	GetModerationQueue(fitGroupID, userID int, status model.ModerationStatus) ([]model.ModerationItem, error)
+	ReviewModerationItem(moderationID, userID int, status model.ModerationStatus) (*model.ModerationItem, error)
+}
+
+var _ ModerationUseCase = (*ModerationService)(nil)
+
+// ModerationService 는 fit leader 의 moderation queue 검토를 처리합니다.
+type ModerationService struct {
+	moderationRepo persistence.ModerationRepository
+	chatRepo       persistence.ChatRepository
+	fitGroupRepo   persistence.FitGroupRepository
+	auditRepo      persistence.ModerationAuditRepository
+	notifier       RoomNotifier
+}
+
+func NewModerationService(moderationRepo persistence.ModerationRepository, chatRepo persistence.ChatRepository, fitGroupRepo persistence.FitGroupRepository, auditRepo persistence.ModerationAuditRepository, notifier RoomNotifier) *ModerationService {
+	return &ModerationService{
+		moderationRepo: moderationRepo,
+		chatRepo:       chatRepo,
+		fitGroupRepo:   fitGroupRepo,
+		auditRepo:      auditRepo,
+		notifier:       notifier,
+	}
+}
+
+func (s *ModerationService) GetModerationQueue(fitGroupID, userID int, status model.ModerationStatus) ([]model.ModerationItem, error) {
+	if err := s.checkFitLeader(fitGroupID, userID); err != nil {
+		return nil, err
+	}
+	if status == "" {
+		status = model.ModerationPending
+	}
+	return s.moderationRepo.GetModerationItems(fitGroupID, status)
+}
+
+/*
+ReviewModerationItem
+1. moderation queue 항목 조회 후 요청자가 해당 fit group 의 fit leader 인지 확인
+2-a. APPROVED : 메시지 유지
+2-b. REMOVED : 메시지 soft delete
+3. 검토 결과 기록
+*/
+func (s *ModerationService) ReviewModerationItem(moderationID, userID int, status model.ModerationStatus) (*model.ModerationItem, error) {
+	if status != model.ModerationApproved && status != model.ModerationRemoved {
+		return nil, fmt.Errorf("invalid moderation status: %q", status)
+	}
+
+	item, err := s.moderationRepo.GetModerationItemByID(moderationID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkFitLeader(item.FitGroupID, userID); err != nil {
+		return nil, err
+	}
+
+	reviewedBy := strconv.Itoa(userID)
+	if status == model.ModerationRemoved {
+		if err := s.chatRepo.SoftDeleteMessage(item.MessageID, reviewedBy); err != nil {
+			log.Printf("Error removing moderated message %s: %v", item.MessageID, err)
+		} else {
+			s.notifier.Publish(item.FitGroupID, model.RoomEvent{Type: model.EventMessageDeleted, FitGroupID: item.FitGroupID, MessageID: item.MessageID, ActorUserID: userID})
+		}
+	}
+	if err := s.moderationRepo.UpdateModerationStatus(moderationID, status, reviewedBy); err != nil {
+		return nil, err
+	}
+
+	auditLog := &model.ModerationAuditLog{
+		FitGroupID:      item.FitGroupID,
+		ActorUserID:     userID,
+		Action:          model.ActionReviewFlag,
+		TargetUserID:    item.UserID,
+		TargetMessageID: item.MessageID,
+		Detail:          string(status),
+	}
+	if err := s.auditRepo.SaveAuditLog(auditLog); err != nil {
+		log.Printf("Error recording moderation review audit: %v", err)
+	}
+	return s.moderationRepo.GetModerationItemByID(moderationID)
+}
+
+func (s *ModerationService) checkFitLeader(fitGroupID, userID int) error {
+	fitGroup, err := s.fitGroupRepo.GetFitGroupByID(fitGroupID)
+	if err != nil {
+		return err
+	}
+	if fitGroup.FitLeaderUserID != userID {
+		return ErrNotFitLeader
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"workoutstudy_chatting/model"
+	"workoutstudy_chatting/persistence"
+)
+
+// MaxPinnedMessages 는 fit group 당 고정할 수 있는 최대 메시지 수입니다.
+const MaxPinnedMessages = 10
+
+var (
+	ErrPinLimitExceeded = errors.New("pinned message limit exceeded")
+	ErrNotPinned        = errors.New("message is not pinned")
+)
+
+type PinnedMessageUseCase interface {
+	PinMessage(messageID string, leaderID int) (*model.PinnedMessage, error)
+	UnpinMessage(messageID string, leaderID int) error
+	GetPinnedMessages(fitGroupID, userID int) (*model.FitGroupPins, error)
+}
+
+var _ PinnedMessageUseCase = (*PinnedMessageService)(nil)
+
+// PinnedMessageService 는 fit group 채팅방의 고정 메시지를 관리합니다.
+// 고정/해제는 fit leader 만 가능하며 채팅방에 이벤트로 알리고 moderation audit 에 기록합니다.
+type PinnedMessageService struct {
+	pinRepo      persistence.PinnedMessageRepository
+	chatRepo     persistence.ChatRepository
+	fitGroupRepo persistence.FitGroupRepository
+	fitMateRepo  persistence.FitMateRepository
+	auditRepo    persistence.ModerationAuditRepository
+	notifier     RoomNotifier
+}
+
+func NewPinnedMessageService(
+	pinRepo persistence.PinnedMessageRepository,
+	chatRepo persistence.ChatRepository,
+	fitGroupRepo persistence.FitGroupRepository,
+	fitMateRepo persistence.FitMateRepository,
+	auditRepo persistence.ModerationAuditRepository,
+	notifier RoomNotifier,
+) *PinnedMessageService {
+	return &PinnedMessageService{
+		pinRepo:      pinRepo,
+		chatRepo:     chatRepo,
+		fitGroupRepo: fitGroupRepo,
+		fitMateRepo:  fitMateRepo,
+		auditRepo:    auditRepo,
+		notifier:     notifier,
+	}
+}
+
+// PinMessage 는 메시지를 고정합니다. 이미 고정된 메시지는 고정 시간만 갱신하고, 새로 고정할 때는 MaxPinnedMessages 를 넘을 수 없습니다.
+func (s *PinnedMessageService) PinMessage(messageID string, leaderID int) (*model.PinnedMessage, error) {
+	msg, err := s.chatRepo.GetMessageByID(messageID)
+	if err != nil {
+		return nil, err
+	}
+	if msg.DeletedAt != nil {
+		return nil, ErrInvalidTarget
+	}
+	if err := s.checkFitLeader(msg.FitGroupID, leaderID); err != nil {
+		return nil, err
+	}
+
+	pins, err := s.pinRepo.GetPinnedMessages(msg.FitGroupID)
+	if err != nil {
+		return nil, err
+	}
+	alreadyPinned := false
+	for _, p := range pins {
+		if p.MessageID == messageID {
+			alreadyPinned = true
+			break
+		}
+	}
+	if !alreadyPinned && len(pins) >= MaxPinnedMessages {
+		return nil, ErrPinLimitExceeded
+	}
+
+	pin, err := s.pinRepo.PinMessage(&model.PinnedMessage{FitGroupID: msg.FitGroupID, MessageID: messageID, PinnedBy: leaderID})
+	if err != nil {
+		return nil, err
+	}
+	pin.Message = msg
+
+	s.recordAudit(model.ModerationAuditLog{FitGroupID: msg.FitGroupID, ActorUserID: leaderID, Action: model.ActionPinMessage, TargetUserID: msg.UserID, TargetMessageID: messageID})
+	s.notifier.Publish(msg.FitGroupID, model.RoomEvent{Type: model.EventMessagePinned, FitGroupID: msg.FitGroupID, MessageID: messageID, ActorUserID: leaderID, Payload: pin})
+	return pin, nil
+}
+
+func (s *PinnedMessageService) UnpinMessage(messageID string, leaderID int) error {
+	msg, err := s.chatRepo.GetMessageByID(messageID)
+	if err != nil {
+		return err
+	}
+	if err := s.checkFitLeader(msg.FitGroupID, leaderID); err != nil {
+		return err
+	}
+
+	unpinned, err := s.pinRepo.UnpinMessage(msg.FitGroupID, messageID)
+	if err != nil {
+		return err
+	}
+	if !unpinned {
+		return ErrNotPinned
+	}
+
+	s.recordAudit(model.ModerationAuditLog{FitGroupID: msg.FitGroupID, ActorUserID: leaderID, Action: model.ActionUnpinMessage, TargetUserID: msg.UserID, TargetMessageID: messageID})
+	s.notifier.Publish(msg.FitGroupID, model.RoomEvent{Type: model.EventMessageUnpinned, FitGroupID: msg.FitGroupID, MessageID: messageID, ActorUserID: leaderID})
+	return nil
+}
+
+// GetPinnedMessages 는 fit group 정보와 고정 메시지 목록을 함께 조회합니다. fit group 멤버만 조회할 수 있습니다.
+func (s *PinnedMessageService) GetPinnedMessages(fitGroupID, userID int) (*model.FitGroupPins, error) {
+	fitGroup, err := s.fitGroupRepo.GetFitGroupByID(fitGroupID)
+	if err != nil {
+		return nil, err
+	}
+	if fitGroup.FitLeaderUserID != userID {
+		isMate, err := s.fitMateRepo.CheckFitMateExists(userID, fitGroupID)
+		if err != nil {
+			return nil, err
+		}
+		if !isMate {
+			return nil, ErrNotFitGroupMember
+		}
+	}
+
+	pins, err := s.pinRepo.GetPinnedMessages(fitGroupID)
+	if err != nil {
+		return nil, err
+	}
+	if pins == nil {
+		pins = []model.PinnedMessage{}
+	}
+	return &model.FitGroupPins{FitGroup: fitGroup, Pins: pins, MaxPins: MaxPinnedMessages}, nil
+}
+
+func (s *PinnedMessageService) checkFitLeader(fitGroupID, userID int) error {
+	fitGroup, err := s.fitGroupRepo.GetFitGroupByID(fitGroupID)
+	if err != nil {
+		return err
+	}
+	if fitGroup.FitLeaderUserID != userID {
+		return ErrNotFitLeader
+	}
+	return nil
+}
+
+func (s *PinnedMessageService) recordAudit(auditLog model.ModerationAuditLog) {
+	if err := s.auditRepo.SaveAuditLog(&auditLog); err != nil {
+		log.Printf("Error recording moderation audit %s in fit group %d: %v", auditLog.Action, auditLog.FitGroupID, err)
+	}
+}
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"workoutstudy_chatting/model"
+	"workoutstudy_chatting/persistence"
+)
+
+func pinTestMessageID(n int) string {
+	return fmt.Sprintf("00000000-0000-0000-0000-%012d", n)
+}
+
+// newPinnedMessageFixture 는 fit group 1(리더 1, 멤버 2)에 메시지 1 ~ count 를 저장합니다. 사용자 3 은 멤버가 아닙니다.
+func newPinnedMessageFixture(t *testing.T, count int) (persistence.Repositories, *recordingRoom, *PinnedMessageService) {
+	t.Helper()
+	repos := persistence.NewMemoryRepositories(persistence.NewMemoryStore())
+	saveUsers(t, repos, 1, 2, 3)
+	seedFitGroup(t, repos, 1, 1)
+	saveFitMates(t, repos, 1, 2)
+	now := time.Now()
+	for i := 1; i <= count; i++ {
+		msg := model.ChatMessage{ID: pinTestMessageID(i), UserID: 2, FitGroupID: 1, Message: fmt.Sprintf("메시지 %d", i), MessageTime: now.Add(time.Duration(i) * time.Second), MessageType: model.Chatting}
+		if err := repos.Chat.SaveMessage(msg); err != nil {
+			t.Fatalf("SaveMessage %d: %v", i, err)
+		}
+	}
+	room := &recordingRoom{}
+	service := NewPinnedMessageService(repos.PinnedMessage, repos.Chat, repos.FitGroup, repos.FitMate, repos.ModerationAudit, room)
+	return repos, room, service
+}
+
+func pinnedIDs(t *testing.T, repos persistence.Repositories) map[string]bool {
+	t.Helper()
+	pins, err := repos.PinnedMessage.GetPinnedMessages(1)
+	if err != nil {
+		t.Fatalf("GetPinnedMessages: %v", err)
+	}
+	ids := make(map[string]bool, len(pins))
+	for _, p := range pins {
+		ids[p.MessageID] = true
+	}
+	return ids
+}
+
+func TestPinMessageCap(t *testing.T) {
+	repos, room, service := newPinnedMessageFixture(t, MaxPinnedMessages+1)
+
+	for i := 1; i <= MaxPinnedMessages; i++ {
+		pin, err := service.PinMessage(pinTestMessageID(i), 1)
+		if err != nil {
+			t.Fatalf("PinMessage %d: %v", i, err)
+		}
+		if pin.PinnedBy != 1 || pin.Message == nil || pin.Message.ID != pinTestMessageID(i) {
+			t.Fatalf("pin %d = %+v", i, pin)
+		}
+	}
+
+	extra := pinTestMessageID(MaxPinnedMessages + 1)
+	if _, err := service.PinMessage(extra, 1); !errors.Is(err, ErrPinLimitExceeded) {
+		t.Fatalf("PinMessage over the cap err = %v, want ErrPinLimitExceeded", err)
+	}
+	// 이미 고정된 메시지는 상한에 걸리지 않고 다시 고정됨
+	if _, err := service.PinMessage(pinTestMessageID(1), 1); err != nil {
+		t.Fatalf("re-pinning at the cap: %v", err)
+	}
+	if got := len(pinnedIDs(t, repos)); got != MaxPinnedMessages {
+		t.Fatalf("pinned %d messages, want %d", got, MaxPinnedMessages)
+	}
+
+	// 하나를 해제하면 다시 고정할 수 있음
+	if err := service.UnpinMessage(pinTestMessageID(2), 1); err != nil {
+		t.Fatalf("UnpinMessage: %v", err)
+	}
+	if _, err := service.PinMessage(extra, 1); err != nil {
+		t.Fatalf("PinMessage after unpin: %v", err)
+	}
+	pinned := pinnedIDs(t, repos)
+	if len(pinned) != MaxPinnedMessages || pinned[pinTestMessageID(2)] || !pinned[extra] {
+		t.Fatalf("pinned = %v", pinned)
+	}
+
+	// 고정 MaxPinnedMessages 번, 재고정, 해제, 새 고정 순서로 이벤트가 나감
+	events := room.recorded()
+	if len(events) != MaxPinnedMessages+3 {
+		t.Fatalf("room events = %v", events)
+	}
+	wantTail := []string{
+		"MESSAGE_PINNED 1:" + pinTestMessageID(1),
+		"MESSAGE_UNPINNED 1:" + pinTestMessageID(2),
+		"MESSAGE_PINNED 1:" + extra,
+	}
+	if got := events[len(events)-3:]; fmt.Sprint(got) != fmt.Sprint(wantTail) {
+		t.Fatalf("last room events = %v, want %v", got, wantTail)
+	}
+}
+
+func TestPinAndUnpinPermissions(t *testing.T) {
+	pinned := pinTestMessageID(1)
+	unpinned := pinTestMessageID(2)
+	deleted := pinTestMessageID(3)
+
+	tests := []struct {
+		name       string
+		run        func(s *PinnedMessageService) error
+		wantErr    error
+		wantRoom   []string
+		wantAudit  []string
+		wantPinned []string
+	}{
+		{
+			name:       "리더가 고정",
+			run:        func(s *PinnedMessageService) error { _, err := s.PinMessage(unpinned, 1); return err },
+			wantRoom:   []string{"MESSAGE_PINNED 1:" + unpinned},
+			wantAudit:  []string{"PIN_MESSAGE 1->2"},
+			wantPinned: []string{pinned, unpinned},
+		},
+		{
+			name:       "멤버는 고정 불가",
+			run:        func(s *PinnedMessageService) error { _, err := s.PinMessage(unpinned, 2); return err },
+			wantErr:    ErrNotFitLeader,
+			wantPinned: []string{pinned},
+		},
+		{
+			name:       "삭제된 메시지는 고정 불가",
+			run:        func(s *PinnedMessageService) error { _, err := s.PinMessage(deleted, 1); return err },
+			wantErr:    ErrInvalidTarget,
+			wantPinned: []string{pinned},
+		},
+		{
+			name:      "리더가 해제하면 해제 이벤트",
+			run:       func(s *PinnedMessageService) error { return s.UnpinMessage(pinned, 1) },
+			wantRoom:  []string{"MESSAGE_UNPINNED 1:" + pinned},
+			wantAudit: []string{"UNPIN_MESSAGE 1->2"},
+		},
+		{
+			name:       "멤버는 해제 불가",
+			run:        func(s *PinnedMessageService) error { return s.UnpinMessage(pinned, 2) },
+			wantErr:    ErrNotFitLeader,
+			wantPinned: []string{pinned},
+		},
+		{
+			name:       "고정되지 않은 메시지 해제",
+			run:        func(s *PinnedMessageService) error { return s.UnpinMessage(unpinned, 1) },
+			wantErr:    ErrNotPinned,
+			wantPinned: []string{pinned},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repos, room, service := newPinnedMessageFixture(t, 3)
+			if _, err := service.PinMessage(pinned, 1); err != nil {
+				t.Fatalf("PinMessage: %v", err)
+			}
+			if err := repos.Chat.SoftDeleteMessage(deleted, "2"); err != nil {
+				t.Fatalf("SoftDeleteMessage: %v", err)
+			}
+			room.events = nil
+			auditBefore := len(auditActions(t, repos, 1))
+
+			if err := tt.run(service); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if got := room.recorded(); fmt.Sprint(got) != fmt.Sprint(tt.wantRoom) {
+				t.Fatalf("room events = %v, want %v", got, tt.wantRoom)
+			}
+			// audit 은 최신 기록부터 조회됨
+			audit := auditActions(t, repos, 1)
+			if got := audit[:len(audit)-auditBefore]; fmt.Sprint(got) != fmt.Sprint(tt.wantAudit) {
+				t.Fatalf("audit = %v, want %v", got, tt.wantAudit)
+			}
+			got := pinnedIDs(t, repos)
+			if len(got) != len(tt.wantPinned) {
+				t.Fatalf("pinned = %v, want %v", got, tt.wantPinned)
+			}
+			for _, id := range tt.wantPinned {
+				if !got[id] {
+					t.Fatalf("pinned = %v, want %v", got, tt.wantPinned)
+				}
+			}
+		})
+	}
+}
+
+func TestGetPinnedMessagesForMembers(t *testing.T) {
+	_, _, service := newPinnedMessageFixture(t, 1)
+	if _, err := service.PinMessage(pinTestMessageID(1), 1); err != nil {
+		t.Fatalf("PinMessage: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		userID   int
+		wantErr  error
+		wantPins int
+	}{
+		{name: "리더", userID: 1, wantPins: 1},
+		{name: "멤버", userID: 2, wantPins: 1},
+		{name: "멤버가 아닌 사용자", userID: 3, wantErr: ErrNotFitGroupMember},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pins, err := service.GetPinnedMessages(1, tt.userID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if len(pins.Pins) != tt.wantPins || pins.MaxPins != MaxPinnedMessages || pins.FitGroup.ID != 1 {
+				t.Fatalf("pins = %+v", pins)
+			}
+		})
+	}
+}
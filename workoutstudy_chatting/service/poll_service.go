@@ -0,0 +1,332 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+	"workoutstudy_chatting/model"
+	"workoutstudy_chatting/persistence"
+	"workoutstudy_chatting/util"
+)
+
+// 투표 생성 제한
+const (
+	minPollOptions       = 2
+	maxPollOptions       = 10
+	maxPollOptionLength  = 100
+	maxPollDeadlineAfter = 30 * 24 * time.Hour
+)
+
+var (
+	ErrInvalidPoll = errors.New("invalid poll")
+	ErrInvalidVote = errors.New("invalid poll vote")
+	ErrPollClosed  = errors.New("poll is closed")
+)
+
+type PollUseCase interface {
+	CreatePoll(msg model.ChatMessage) (*model.ChatPoll, error)
+	Vote(fitGroupID, userID, pollID int, optionIDs []int) (*model.ChatPoll, error)
+	ClosePoll(fitGroupID, userID, pollID int) (*model.ChatPoll, error)
+	GetPoll(messageID string, userID int) (*model.ChatPoll, error)
+	StartPollCloser(ctx context.Context, interval time.Duration)
+}
+
+var _ PollUseCase = (*PollService)(nil)
+
+// PollService 는 채팅방 투표의 생성, 투표, 종료를 처리합니다.
+// 집계가 바뀔 때마다 채팅방에 POLL_UPDATED 이벤트를 보내고, 종료되면 결과를 시스템 메시지로 남깁니다.
+type PollService struct {
+	pollRepo     persistence.PollRepository
+	chatRepo     persistence.ChatRepository
+	fitGroupRepo persistence.FitGroupRepository
+	fitMateRepo  persistence.FitMateRepository
+	notifier     RoomNotifier
+}
+
+func NewPollService(
+	pollRepo persistence.PollRepository,
+	chatRepo persistence.ChatRepository,
+	fitGroupRepo persistence.FitGroupRepository,
+	fitMateRepo persistence.FitMateRepository,
+	notifier RoomNotifier,
+) *PollService {
+	return &PollService{
+		pollRepo:     pollRepo,
+		chatRepo:     chatRepo,
+		fitGroupRepo: fitGroupRepo,
+		fitMateRepo:  fitMateRepo,
+		notifier:     notifier,
+	}
+}
+
+// validatePoll 은 투표 메시지의 질문, 선택지, 마감 시간을 확인합니다.
+func validatePoll(msg model.ChatMessage, now time.Time) error {
+	if msg.Poll == nil || strings.TrimSpace(msg.Message) == "" {
+		return fmt.Errorf("%w: poll question is required", ErrInvalidPoll)
+	}
+	options := msg.Poll.Options
+	if len(options) < minPollOptions || len(options) > maxPollOptions {
+		return fmt.Errorf("%w: poll must have %d to %d options", ErrInvalidPoll, minPollOptions, maxPollOptions)
+	}
+	seen := make(map[string]bool, len(options))
+	for _, o := range options {
+		text := strings.TrimSpace(o.Text)
+		if text == "" || utf8.RuneCountInString(text) > maxPollOptionLength {
+			return fmt.Errorf("%w: option text must be 1 to %d characters", ErrInvalidPoll, maxPollOptionLength)
+		}
+		if seen[text] {
+			return fmt.Errorf("%w: duplicate option %q", ErrInvalidPoll, text)
+		}
+		seen[text] = true
+	}
+	if d := msg.Poll.Deadline; d != nil && (!d.After(now) || d.Sub(now) > maxPollDeadlineAfter) {
+		return fmt.Errorf("%w: deadline must be within %v from now", ErrInvalidPoll, maxPollDeadlineAfter)
+	}
+	return nil
+}
+
+// CreatePoll 은 이미 저장된 투표 메시지에 대한 투표를 생성합니다.
+func (s *PollService) CreatePoll(msg model.ChatMessage) (*model.ChatPoll, error) {
+	poll := &model.ChatPoll{
+		MessageID:      msg.ID,
+		FitGroupID:     msg.FitGroupID,
+		CreatedBy:      msg.UserID,
+		Question:       msg.Message,
+		MultipleChoice: msg.Poll.MultipleChoice,
+		Anonymous:      msg.Poll.Anonymous,
+		Deadline:       msg.Poll.Deadline,
+	}
+	for _, o := range msg.Poll.Options {
+		poll.Options = append(poll.Options, model.PollOption{Text: strings.TrimSpace(o.Text)})
+	}
+	return s.pollRepo.CreatePoll(poll)
+}
+
+// Vote 는 사용자의 투표를 optionIDs 로 바꾸고 바뀐 집계를 채팅방에 알립니다. optionIDs 가 비어 있으면 투표를 취소합니다.
+func (s *PollService) Vote(fitGroupID, userID, pollID int, optionIDs []int) (*model.ChatPoll, error) {
+	poll, err := s.pollRepo.GetPollByID(pollID)
+	if err != nil {
+		return nil, err
+	}
+	if poll.FitGroupID != fitGroupID {
+		return nil, ErrInvalidVote
+	}
+	if poll.IsClosed(time.Now()) {
+		return nil, ErrPollClosed
+	}
+	if err := validateVote(poll, optionIDs); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.pollRepo.ReplaceVotes(pollID, userID, optionIDs)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, ErrPollClosed
+	}
+
+	poll, err = s.pollRepo.GetPollByID(pollID)
+	if err != nil {
+		return nil, err
+	}
+	s.notifier.Publish(fitGroupID, model.RoomEvent{Type: model.EventPollUpdated, FitGroupID: fitGroupID, MessageID: poll.MessageID, ActorUserID: userID, Payload: publicPoll(poll)})
+
+	result := publicPoll(poll)
+	result.MyOptionIDs = optionIDs
+	return result, nil
+}
+
+// ClosePoll 은 투표를 만든 사용자나 fit leader 가 투표를 종료합니다.
+func (s *PollService) ClosePoll(fitGroupID, userID, pollID int) (*model.ChatPoll, error) {
+	poll, err := s.pollRepo.GetPollByID(pollID)
+	if err != nil {
+		return nil, err
+	}
+	if poll.FitGroupID != fitGroupID {
+		return nil, ErrInvalidVote
+	}
+	if poll.CreatedBy != userID {
+		fitGroup, err := s.fitGroupRepo.GetFitGroupByID(fitGroupID)
+		if err != nil {
+			return nil, err
+		}
+		if fitGroup.FitLeaderUserID != userID {
+			return nil, ErrNotFitLeader
+		}
+	}
+	return s.close(pollID)
+}
+
+// GetPoll 은 투표 메시지의 투표와 집계를 조회합니다. fit group 멤버만 조회할 수 있습니다.
+func (s *PollService) GetPoll(messageID string, userID int) (*model.ChatPoll, error) {
+	poll, err := s.pollRepo.GetPollByMessageID(messageID)
+	if err != nil {
+		return nil, err
+	}
+	fitGroup, err := s.fitGroupRepo.GetFitGroupByID(poll.FitGroupID)
+	if err != nil {
+		return nil, err
+	}
+	if fitGroup.FitLeaderUserID != userID {
+		isMate, err := s.fitMateRepo.CheckFitMateExists(userID, poll.FitGroupID)
+		if err != nil {
+			return nil, err
+		}
+		if !isMate {
+			return nil, ErrNotFitGroupMember
+		}
+	}
+
+	myOptionIDs, err := s.pollRepo.GetUserVotes(poll.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+	result := publicPoll(poll)
+	result.MyOptionIDs = myOptionIDs
+	return result, nil
+}
+
+// StartPollCloser 는 interval 마다 마감 시간이 지난 투표를 종료하는 백그라운드 작업입니다. ctx 가 취소되면 종료합니다.
+func (s *PollService) StartPollCloser(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		pollIDs, err := s.pollRepo.GetExpiredOpenPollIDs(time.Now())
+		if err != nil {
+			log.Printf("Poll closer failed: %v", err)
+		}
+		for _, pollID := range pollIDs {
+			if _, err := s.close(pollID); err != nil {
+				log.Printf("Error closing expired poll %d: %v", pollID, err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Println("Poll closer stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// close 는 투표를 종료하고 최종 결과를 채팅방에 알립니다.
+// 여러 경로에서 동시에 종료해도 실제로 종료 처리한 한 곳에서만 결과 메시지를 보냅니다.
+func (s *PollService) close(pollID int) (*model.ChatPoll, error) {
+	closed, err := s.pollRepo.ClosePoll(pollID)
+	if err != nil {
+		return nil, err
+	}
+	poll, err := s.pollRepo.GetPollByID(pollID)
+	if err != nil {
+		return nil, err
+	}
+	result := publicPoll(poll)
+	if !closed {
+		return result, nil
+	}
+
+	s.notifier.Publish(poll.FitGroupID, model.RoomEvent{Type: model.EventPollClosed, FitGroupID: poll.FitGroupID, MessageID: poll.MessageID, Payload: result})
+
+	resultMsg := model.ChatMessage{
+		ID:          util.NewUUID(),
+		UserID:      model.SystemBotUserID,
+		FitGroupID:  poll.FitGroupID,
+		Message:     formatPollResult(poll),
+		MessageTime: time.Now(),
+		MessageType: model.System,
+	}
+	if err := s.chatRepo.SaveMessage(resultMsg); err != nil {
+		log.Printf("Error saving poll result message for poll %d: %v", pollID, err)
+		return result, nil
+	}
+	s.notifier.Post(poll.FitGroupID, resultMsg)
+	return result, nil
+}
+
+func validateVote(poll *model.ChatPoll, optionIDs []int) error {
+	if len(optionIDs) > 1 && !poll.MultipleChoice {
+		return fmt.Errorf("%w: poll allows a single choice", ErrInvalidVote)
+	}
+	valid := make(map[int]bool, len(poll.Options))
+	for _, o := range poll.Options {
+		valid[o.ID] = true
+	}
+	seen := make(map[int]bool, len(optionIDs))
+	for _, id := range optionIDs {
+		if !valid[id] || seen[id] {
+			return fmt.Errorf("%w: option %d", ErrInvalidVote, id)
+		}
+		seen[id] = true
+	}
+	return nil
+}
+
+// publicPoll 은 채팅방에 공개할 수 있는 투표 정보를 반환합니다. 익명 투표는 투표자 목록을 제외합니다.
+func publicPoll(poll *model.ChatPoll) *model.ChatPoll {
+	result := *poll
+	result.MyOptionIDs = nil
+	if poll.Anonymous {
+		result.Options = make([]model.PollOption, len(poll.Options))
+		for i, o := range poll.Options {
+			o.VoterUserIDs = nil
+			result.Options[i] = o
+		}
+	}
+	return &result
+}
+
+func formatPollResult(poll *model.ChatPoll) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "투표가 종료되었습니다: %s\n", poll.Question)
+	for i, o := range poll.Options {
+		fmt.Fprintf(&b, "%d. %s - %d표\n", i+1, o.Text, o.VoteCount)
+	}
+	fmt.Fprintf(&b, "참여 인원 %d명", poll.TotalVoters)
+	return b.String()
+}
+
+// PollChatService 는 투표 메시지(messageType POLL)를 저장할 때 투표를 함께 생성하는 ChatUseCase 데코레이터입니다.
+type PollChatService struct {
+	ChatUseCase
+	polls    PollUseCase
+	chatRepo persistence.ChatRepository
+}
+
+var _ ChatUseCase = (*PollChatService)(nil)
+
+func NewPollChatService(next ChatUseCase, polls PollUseCase, chatRepo persistence.ChatRepository) *PollChatService {
+	return &PollChatService{ChatUseCase: next, polls: polls, chatRepo: chatRepo}
+}
+
+func (s *PollChatService) SaveChatMessage(msg model.ChatMessage) (model.ChatMessage, error) {
+	if msg.MessageType != model.Poll {
+		// 투표가 아닌 메시지에 붙은 poll 필드는 무시
+		msg.Poll = nil
+		return s.ChatUseCase.SaveChatMessage(msg)
+	}
+	if err := validatePoll(msg, time.Now()); err != nil {
+		return msg, err
+	}
+
+	saved, err := s.ChatUseCase.SaveChatMessage(msg)
+	if err != nil {
+		return saved, err
+	}
+	poll, err := s.polls.CreatePoll(saved)
+	if err != nil {
+		// 선택지 없는 투표 메시지가 남지 않도록 삭제 처리
+		if delErr := s.chatRepo.SoftDeleteMessage(saved.ID, "system"); delErr != nil {
+			log.Printf("Error removing poll message %s after poll creation failure: %v", saved.ID, delErr)
+		}
+		return saved, err
+	}
+	saved.Poll = poll
+	return saved, nil
+}
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+	"workoutstudy_chatting/client"
+	"workoutstudy_chatting/model"
+	"workoutstudy_chatting/persistence"
+)
+
+type ReconciliationUseCase interface {
+	RunReconciliation(ctx context.Context) (*model.ReconciliationReport, error)
+}
+
+var _ ReconciliationUseCase = (*ReconciliationService)(nil)
+
+/*
+ReconciliationService 는 Kafka 이벤트를 놓쳐 어긋난 fit_group, fit_mate, user 테이블을
+fit-group, auth 서비스에서 다시 조회한 정보로 맞춥니다. 이벤트 처리와 같은 기준(shouldUpdate, compareAndUpdateFitMates)을 사용합니다.
+DB 에 있는 fit group 과 사용자만 확인하며, 새 fit group 이나 사용자는 이벤트로만 생성합니다.
+*/
+type ReconciliationService struct {
+	fitGroupRepo    persistence.FitGroupRepository
+	userRepo        persistence.UserRepository
+	fitGroupService FitGroupUseCase
+	fitMateService  FitMateUseCase
+	userService     UserUseCase
+	fitGroups       client.FitGroupClient
+	auth            client.AuthClient
+	running         sync.Mutex // 주기 작업과 수동 실행이 겹치지 않도록 한 번에 하나만 실행
+}
+
+func NewReconciliationService(fitGroupRepo persistence.FitGroupRepository, userRepo persistence.UserRepository, fitGroupService FitGroupUseCase, fitMateService FitMateUseCase, userService UserUseCase, fitGroups client.FitGroupClient, auth client.AuthClient) *ReconciliationService {
+	return &ReconciliationService{
+		fitGroupRepo:    fitGroupRepo,
+		userRepo:        userRepo,
+		fitGroupService: fitGroupService,
+		fitMateService:  fitMateService,
+		userService:     userService,
+		fitGroups:       fitGroups,
+		auth:            auth,
+	}
+}
+
+// StartReconciliationJob 은 interval 마다 정합성 맞추기를 실행합니다. 배포마다 다른 서비스를 전부 조회하지 않도록 첫 실행도 interval 뒤에 합니다.
+func (s *ReconciliationService) StartReconciliationJob(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Reconciliation job stopped")
+			return
+		case <-ticker.C:
+		}
+
+		report, err := s.RunReconciliation(ctx)
+		if err != nil {
+			log.Printf("Reconciliation job failed: %v", err)
+		}
+		if report != nil {
+			log.Printf("Reconciliation job finished: %d fit groups, %d users checked, %d fit groups and %d users changed or failed",
+				report.FitGroupCount, report.UserCount, len(report.FitGroups), len(report.Users))
+		}
+	}
+}
+
+/*
+RunReconciliation
+1. fit_group 테이블의 fit group 마다 fit-group 서비스에서 상세 정보 조회 -> 삭제되었으면 삭제, 다르면 수정
+2. 삭제하지 않은 fit group 은 fit mate 목록 조회 -> 없는 fit mate 삭제, 새 fit mate 추가
+3. user 테이블의 사용자마다 auth 서비스에서 사용자 정보 조회 -> 닉네임이 다르면 수정
+다른 서비스의 회로가 열리면 남은 항목은 확인하지 않고 그때까지의 결과와 오류를 반환합니다.
+*/
+func (s *ReconciliationService) RunReconciliation(ctx context.Context) (*model.ReconciliationReport, error) {
+	if !s.running.TryLock() {
+		return nil, ErrReconciliationRunning
+	}
+	defer s.running.Unlock()
+
+	report := &model.ReconciliationReport{StartedAt: time.Now()}
+	defer func() { report.FinishedAt = time.Now() }()
+
+	fitGroupIDs, err := s.fitGroupRepo.GetFitGroupIDs()
+	if err != nil {
+		return nil, err
+	}
+	for _, fitGroupID := range fitGroupIDs {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		result, err := s.reconcileFitGroup(ctx, fitGroupID)
+		report.FitGroupCount++
+		if result.Action != "" || len(result.AddedFitMateIDs) > 0 || len(result.RemovedFitMateIDs) > 0 {
+			report.FitGroups = append(report.FitGroups, result)
+		}
+		if errors.Is(err, client.ErrCircuitOpen) {
+			return report, fmt.Errorf("reconcile fit groups: %w", err)
+		}
+	}
+
+	userIDs, err := s.userRepo.GetUserIDs()
+	if err != nil {
+		return report, err
+	}
+	for _, userID := range userIDs {
+		if userID == model.SystemBotUserID {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		result, err := s.reconcileUser(ctx, userID)
+		report.UserCount++
+		if result.Action != "" {
+			report.Users = append(report.Users, result)
+		}
+		if errors.Is(err, client.ErrCircuitOpen) {
+			return report, fmt.Errorf("reconcile users: %w", err)
+		}
+	}
+	return report, nil
+}
+
+// reconcileFitGroup 은 fit group 하나를 맞춥니다. 실패하면 결과에 오류를 남기고 그 오류도 반환합니다.
+func (s *ReconciliationService) reconcileFitGroup(ctx context.Context, fitGroupID int) (model.FitGroupReconciliation, error) {
+	result := model.FitGroupReconciliation{FitGroupID: fitGroupID}
+	fail := func(err error) (model.FitGroupReconciliation, error) {
+		log.Printf("Error reconciling fit group ID %d: %v", fitGroupID, err)
+		result.Error = err.Error()
+		if result.Action == "" {
+			result.Action = model.ReconcileFailed
+		}
+		return result, err
+	}
+
+	detail, err := s.fitGroups.GetFitGroup(ctx, fitGroupID)
+	if errors.Is(err, client.ErrNotFound) {
+		result.Action = model.ReconcileNotFound
+		return result, nil
+	}
+	if err != nil {
+		return fail(err)
+	}
+	if result.Action, err = s.fitGroupService.ReconcileFitGroup(*detail); err != nil {
+		return fail(err)
+	}
+	if result.Action == model.ReconcileDeleted {
+		return result, nil
+	}
+
+	fitMates, err := s.fitGroups.GetFitMates(ctx, fitGroupID)
+	if err != nil {
+		return fail(err)
+	}
+	result.AddedFitMateIDs, result.RemovedFitMateIDs, err = s.fitMateService.ReconcileFitMates(*fitMates)
+	if err != nil {
+		return fail(err)
+	}
+	return result, nil
+}
+
+// reconcileUser 는 사용자 하나를 맞춥니다. 실패하면 결과에 오류를 남기고 그 오류도 반환합니다.
+func (s *ReconciliationService) reconcileUser(ctx context.Context, userID int) (model.UserReconciliation, error) {
+	result := model.UserReconciliation{UserID: userID}
+	fail := func(err error) (model.UserReconciliation, error) {
+		log.Printf("Error reconciling user ID %d: %v", userID, err)
+		result.Action = model.ReconcileFailed
+		result.Error = err.Error()
+		return result, err
+	}
+
+	userInfo, err := s.auth.GetUserInfo(ctx, userID)
+	if errors.Is(err, client.ErrNotFound) {
+		result.Action = model.ReconcileNotFound
+		return result, nil
+	}
+	if err != nil {
+		return fail(err)
+	}
+	updated, err := s.userService.ReconcileUser(*userInfo)
+	if err != nil {
+		return fail(err)
+	}
+	if updated {
+		result.Action = model.ReconcileUpdated
+	}
+	return result, nil
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+
+	"workoutstudy_chatting/client"
+	"workoutstudy_chatting/model"
+	"workoutstudy_chatting/persistence"
+)
+
+// fakeFitGroupClient 는 fitGroups, fitMates 에 있는 fit group 을 응답하고 없으면 client.ErrNotFound 를 반환합니다.
+type fakeFitGroupClient struct {
+	fitGroups map[int]model.GetFitGroupDetailApiResponse
+	fitMates  map[int]model.GetFitMatesApiResponse
+	err       error // 있으면 모든 조회에서 반환
+}
+
+var _ client.FitGroupClient = (*fakeFitGroupClient)(nil)
+
+func (c *fakeFitGroupClient) GetFitGroup(_ context.Context, fitGroupID int) (*model.GetFitGroupDetailApiResponse, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	fitGroup, ok := c.fitGroups[fitGroupID]
+	if !ok {
+		return nil, client.ErrNotFound
+	}
+	return &fitGroup, nil
+}
+
+func (c *fakeFitGroupClient) GetFitMates(_ context.Context, fitGroupID int) (*model.GetFitMatesApiResponse, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	fitMates, ok := c.fitMates[fitGroupID]
+	if !ok {
+		return nil, client.ErrNotFound
+	}
+	return &fitMates, nil
+}
+
+// fakeAuthClient 는 users 에 있는 사용자 정보를 응답하고 없으면 client.ErrNotFound 를 반환합니다.
+type fakeAuthClient struct {
+	users map[int]model.GetUserInfoApiResponse
+}
+
+var _ client.AuthClient = (*fakeAuthClient)(nil)
+
+func (c *fakeAuthClient) GetUserInfo(_ context.Context, userID int) (*model.GetUserInfoApiResponse, error) {
+	user, ok := c.users[userID]
+	if !ok {
+		return nil, client.ErrNotFound
+	}
+	return &user, nil
+}
+
+func fitGroupDetail(fitGroupID int, name string, deleted bool) model.GetFitGroupDetailApiResponse {
+	return model.GetFitGroupDetailApiResponse{FitGroupId: fitGroupID, FitLeaderUserId: 1, FitGroupName: name, Cycle: 1, Frequency: 3, MaxFitMate: 10, State: deleted}
+}
+
+// newReconciliationFixture 는 메모리 저장소에 사용자 1~4 와 fit group 5~8 을 저장합니다.
+// fit group 5, 7 에는 fit mate {ID}0(사용자 2), {ID}1(사용자 3)이 있습니다.
+func newReconciliationFixture(t *testing.T, fitGroups client.FitGroupClient, auth client.AuthClient) (persistence.Repositories, *recordingMembership, *ReconciliationService) {
+	t.Helper()
+	repos := persistence.NewMemoryRepositories(persistence.NewMemoryStore())
+	saveUsers(t, repos, 1, 2, 3, 4)
+	membership := &recordingMembership{}
+	fitMateService := NewFitMateService(repos.FitMate, repos.PendingFitMateEvent, 0, membership)
+	fitGroupService := NewFitGroupService(repos.FitGroup, fitMateService, membership)
+
+	for id := 5; id <= 8; id++ {
+		if err := fitGroupService.HandleFitGroupEvent(fitGroupDetail(id, "morning run", false)); err != nil {
+			t.Fatalf("HandleFitGroupEvent %d: %v", id, err)
+		}
+	}
+	for _, fitGroupID := range []int{5, 7} {
+		mates := fitMatesResponse(fitGroupID, model.Mate{FitMateId: fitGroupID * 10, FitMateUserId: 2}, model.Mate{FitMateId: fitGroupID*10 + 1, FitMateUserId: 3})
+		if err := fitMateService.HandleFitMateEvent(mates); err != nil {
+			t.Fatalf("HandleFitMateEvent %d: %v", fitGroupID, err)
+		}
+	}
+	membership.events = nil
+
+	service := NewReconciliationService(repos.FitGroup, repos.User, fitGroupService, fitMateService, NewUserService(repos.User), fitGroups, auth)
+	return repos, membership, service
+}
+
+func TestRunReconciliation(t *testing.T) {
+	fitGroups := &fakeFitGroupClient{
+		fitGroups: map[int]model.GetFitGroupDetailApiResponse{
+			5: fitGroupDetail(5, "evening run", false),
+			6: fitGroupDetail(6, "morning run", true),
+			7: fitGroupDetail(7, "morning run", false),
+		},
+		fitMates: map[int]model.GetFitMatesApiResponse{
+			5: fitMatesResponse(5, model.Mate{FitMateId: 51, FitMateUserId: 3}, model.Mate{FitMateId: 52, FitMateUserId: 4}),
+			7: fitMatesResponse(7, model.Mate{FitMateId: 70, FitMateUserId: 2}, model.Mate{FitMateId: 71, FitMateUserId: 3}),
+		},
+	}
+	auth := &fakeAuthClient{users: map[int]model.GetUserInfoApiResponse{
+		1: {UserID: 1, Nickname: "user1"},
+		2: {UserID: 2, Nickname: "renamed"},
+		3: {UserID: 3, Nickname: "user3"},
+	}}
+	repos, membership, service := newReconciliationFixture(t, fitGroups, auth)
+
+	report, err := service.RunReconciliation(context.Background())
+	if err != nil {
+		t.Fatalf("RunReconciliation: %v", err)
+	}
+
+	wantFitGroups := []model.FitGroupReconciliation{
+		{FitGroupID: 5, Action: model.ReconcileUpdated, AddedFitMateIDs: []int{52}, RemovedFitMateIDs: []int{50}},
+		{FitGroupID: 6, Action: model.ReconcileDeleted},
+		{FitGroupID: 8, Action: model.ReconcileNotFound},
+	}
+	if !reflect.DeepEqual(report.FitGroups, wantFitGroups) {
+		t.Fatalf("report.FitGroups = %+v, want %+v", report.FitGroups, wantFitGroups)
+	}
+	wantUsers := []model.UserReconciliation{
+		{UserID: 2, Action: model.ReconcileUpdated},
+		{UserID: 4, Action: model.ReconcileNotFound},
+	}
+	if !reflect.DeepEqual(report.Users, wantUsers) {
+		t.Fatalf("report.Users = %+v, want %+v", report.Users, wantUsers)
+	}
+	// 시스템 사용자(핏봇)는 확인하지 않음
+	if report.FitGroupCount != 4 || report.UserCount != 4 {
+		t.Fatalf("checked %d fit groups and %d users, want 4 and 4", report.FitGroupCount, report.UserCount)
+	}
+
+	if fitGroup, _ := repos.FitGroup.GetFitGroupByID(5); fitGroup == nil || fitGroup.FitGroupName != "evening run" {
+		t.Fatalf("fit group 5 = %+v, want renamed", fitGroup)
+	}
+	ids, _ := repos.FitMate.GetFitMatesIdsByFitGroupId(5)
+	sort.Ints(ids)
+	if !reflect.DeepEqual(ids, []int{51, 52}) {
+		t.Fatalf("fit group 5 mates = %v, want [51 52]", ids)
+	}
+	if _, err := repos.FitGroup.GetFitGroupByID(6); err == nil {
+		t.Fatal("deleted fit group 6 is still stored")
+	}
+	if user, _ := repos.User.GetUserByID(2); user == nil || user.Nickname != "renamed" {
+		t.Fatalf("user 2 = %+v, want renamed", user)
+	}
+	if user, _ := repos.User.GetUserByID(4); user == nil || user.Nickname != "user4" {
+		t.Fatalf("user 4 = %+v, want unchanged", user)
+	}
+	wantMembership := []string{"joined 5:4", "left 5:2", "removed 6"}
+	if got := membership.recorded(); !reflect.DeepEqual(got, wantMembership) {
+		t.Fatalf("membership = %v, want %v", got, wantMembership)
+	}
+
+	// 다시 실행하면 바꿀 것이 없음
+	report, err = service.RunReconciliation(context.Background())
+	if err != nil {
+		t.Fatalf("second RunReconciliation: %v", err)
+	}
+	for _, result := range report.FitGroups {
+		if result.Action != model.ReconcileNotFound {
+			t.Fatalf("second run changed fit group: %+v", result)
+		}
+	}
+	if len(report.Users) != 1 || report.Users[0].UserID != 4 {
+		t.Fatalf("second run users = %+v, want only user 4 not found", report.Users)
+	}
+}
+
+func TestRunReconciliationStopsWhenCircuitOpen(t *testing.T) {
+	fitGroups := &fakeFitGroupClient{err: fmt.Errorf("fit-group service: %w", client.ErrCircuitOpen)}
+	_, _, service := newReconciliationFixture(t, fitGroups, &fakeAuthClient{})
+
+	report, err := service.RunReconciliation(context.Background())
+	if !errors.Is(err, client.ErrCircuitOpen) {
+		t.Fatalf("RunReconciliation err = %v, want ErrCircuitOpen", err)
+	}
+	if report.FitGroupCount != 1 || report.UserCount != 0 {
+		t.Fatalf("checked %d fit groups and %d users, want to stop after the first fit group", report.FitGroupCount, report.UserCount)
+	}
+	if len(report.FitGroups) != 1 || report.FitGroups[0].Action != model.ReconcileFailed {
+		t.Fatalf("report.FitGroups = %+v, want one failure", report.FitGroups)
+	}
+}
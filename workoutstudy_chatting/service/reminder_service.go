@@ -0,0 +1,353 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"workoutstudy_chatting/model"
+	"workoutstudy_chatting/persistence"
+	"workoutstudy_chatting/schedule"
+	"workoutstudy_chatting/util"
+)
+
+const (
+	// 서버가 내려가 있는 동안 지난 리마인더는 이 시간 안이면 늦게라도 보내고, 더 지났으면 건너뜀
+	maxReminderDelay = time.Hour
+	// 한 번에 처리하는 리마인더 수
+	reminderBatchSize = 100
+	// fit group 당 최대 리마인더 수
+	maxRemindersPerFitGroup  = 20
+	maxReminderMessageLength = 500
+)
+
+var (
+	ErrInvalidReminder  = errors.New("invalid reminder")
+	ErrReminderNotFound = errors.New("reminder not found")
+	ErrTooManyReminders = errors.New("too many reminders")
+)
+
+type ReminderUseCase interface {
+	CreateReminder(leaderID int, reminder model.Reminder) (*model.Reminder, error)
+	GetReminders(fitGroupID, leaderID int) ([]model.Reminder, error)
+	DeleteReminder(fitGroupID, leaderID, reminderID int) error
+	SetTimeZone(fitGroupID, leaderID int, timeZone string) (*model.ChatRoomSetting, error)
+	RunDueReminders(now time.Time) (int, error)
+	StartScheduler(ctx context.Context, interval time.Duration)
+}
+
+var _ ReminderUseCase = (*ReminderService)(nil)
+
+// ReminderService 는 fit leader 가 등록한 정기 알림과 인증 주기 마감 알림을 핏봇 메시지로 채팅방에 보냅니다.
+// 다음 실행 시간을 scheduled_reminder 테이블에 저장하므로 재시작 후에도 일정이 유지됩니다.
+type ReminderService struct {
+	reminderRepo persistence.ReminderRepository
+	chatRepo     persistence.ChatRepository
+	fitGroupRepo persistence.FitGroupRepository
+	settings     ChatRoomSettingUseCase
+	notifier     RoomNotifier
+}
+
+func NewReminderService(
+	reminderRepo persistence.ReminderRepository,
+	chatRepo persistence.ChatRepository,
+	fitGroupRepo persistence.FitGroupRepository,
+	settings ChatRoomSettingUseCase,
+	notifier RoomNotifier,
+) *ReminderService {
+	return &ReminderService{
+		reminderRepo: reminderRepo,
+		chatRepo:     chatRepo,
+		fitGroupRepo: fitGroupRepo,
+		settings:     settings,
+		notifier:     notifier,
+	}
+}
+
+func (s *ReminderService) CreateReminder(leaderID int, reminder model.Reminder) (*model.Reminder, error) {
+	fitGroup, err := s.checkFitLeader(reminder.FitGroupID, leaderID)
+	if err != nil {
+		return nil, err
+	}
+	reminder.Message = strings.TrimSpace(reminder.Message)
+	if len([]rune(reminder.Message)) > maxReminderMessageLength {
+		return nil, fmt.Errorf("%w: message must be at most %d characters", ErrInvalidReminder, maxReminderMessageLength)
+	}
+	if reminder.Message == "" && reminder.Recurrence != string(schedule.CycleDeadline) {
+		return nil, fmt.Errorf("%w: message is required", ErrInvalidReminder)
+	}
+	spec, err := specOf(reminder, fitGroup.Cycle)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.reminderRepo.GetRemindersByFitGroup(reminder.FitGroupID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= maxRemindersPerFitGroup {
+		return nil, ErrTooManyReminders
+	}
+
+	loc, err := s.settings.GetLocation(reminder.FitGroupID)
+	if err != nil {
+		return nil, err
+	}
+	if reminder.NextRunAt, err = spec.Next(time.Now(), loc); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidReminder, err)
+	}
+	reminder.TimeOfDay = schedule.FormatTimeOfDay(spec.Minute)
+	reminder.CreatedBy = leaderID
+	return s.reminderRepo.SaveReminder(&reminder)
+}
+
+func (s *ReminderService) GetReminders(fitGroupID, leaderID int) ([]model.Reminder, error) {
+	if _, err := s.checkFitLeader(fitGroupID, leaderID); err != nil {
+		return nil, err
+	}
+	reminders, err := s.reminderRepo.GetRemindersByFitGroup(fitGroupID)
+	if err != nil {
+		return nil, err
+	}
+	if reminders == nil {
+		reminders = []model.Reminder{}
+	}
+	return reminders, nil
+}
+
+func (s *ReminderService) DeleteReminder(fitGroupID, leaderID, reminderID int) error {
+	if _, err := s.checkFitLeader(fitGroupID, leaderID); err != nil {
+		return err
+	}
+	deleted, err := s.reminderRepo.DeleteReminder(fitGroupID, reminderID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrReminderNotFound
+	}
+	return nil
+}
+
+// SetTimeZone 은 fit group 시간대를 바꾸고, 등록된 리마인더의 다음 실행 시간을 새 시간대 기준으로 다시 계산합니다.
+func (s *ReminderService) SetTimeZone(fitGroupID, leaderID int, timeZone string) (*model.ChatRoomSetting, error) {
+	setting, err := s.settings.SetTimeZone(fitGroupID, leaderID, timeZone)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.reschedule(fitGroupID); err != nil {
+		log.Printf("Error rescheduling reminders for fit group %d: %v", fitGroupID, err)
+	}
+	return setting, nil
+}
+
+func (s *ReminderService) reschedule(fitGroupID int) error {
+	fitGroup, err := s.fitGroupRepo.GetFitGroupByID(fitGroupID)
+	if err != nil {
+		return err
+	}
+	loc, err := s.settings.GetLocation(fitGroupID)
+	if err != nil {
+		return err
+	}
+	reminders, err := s.reminderRepo.GetRemindersByFitGroup(fitGroupID)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, r := range reminders {
+		spec, err := specOf(r, fitGroup.Cycle)
+		if err != nil {
+			log.Printf("Skipping invalid reminder %d: %v", r.ID, err)
+			continue
+		}
+		next, err := spec.Next(now, loc)
+		if err != nil {
+			log.Printf("Skipping reminder %d: %v", r.ID, err)
+			continue
+		}
+		if _, err := s.reminderRepo.AdvanceReminder(r.ID, r.NextRunAt, next, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// StartScheduler 는 interval 마다 실행 시간이 된 리마인더를 보내는 백그라운드 작업입니다. ctx 가 취소되면 종료합니다.
+func (s *ReminderService) StartScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if sent, err := s.RunDueReminders(time.Now()); err != nil {
+			log.Printf("Reminder scheduler failed: %v", err)
+		} else if sent > 0 {
+			log.Printf("Reminder scheduler sent %d reminders", sent)
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Println("Reminder scheduler stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+/*
+RunDueReminders
+1. 실행 시간이 지난 리마인더 조회
+2. fit group 시간대 기준으로 다음 실행 시간 계산
+3. next_run_at 조건부 갱신에 성공한 경우에만 메시지 전송 (여러 인스턴스에서 중복 전송 방지)
+4. maxReminderDelay 보다 오래 지난 리마인더는 보내지 않고 다음 실행 시간만 갱신
+fit group 이 없거나 설정이 잘못되어 다시 실행해도 실패하는 리마인더는 비활성화합니다.
+(다음 실행 시간을 갱신하지 않으면 계속 실행 대상으로 조회되어 다른 리마인더의 실행을 막음)
+*/
+func (s *ReminderService) RunDueReminders(now time.Time) (int, error) {
+	reminders, err := s.reminderRepo.GetDueReminders(now, reminderBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, r := range reminders {
+		fitGroup, err := s.fitGroupRepo.GetFitGroupByID(r.FitGroupID)
+		if errors.Is(err, sql.ErrNoRows) {
+			s.disableReminder(r, fmt.Sprintf("fit group %d not found", r.FitGroupID))
+			continue
+		}
+		if err != nil {
+			log.Printf("Error loading fit group %d for reminder %d: %v", r.FitGroupID, r.ID, err)
+			continue
+		}
+		loc, err := s.settings.GetLocation(r.FitGroupID)
+		if err != nil {
+			log.Printf("Error loading time zone for reminder %d: %v", r.ID, err)
+			continue
+		}
+		spec, err := specOf(r, fitGroup.Cycle)
+		if err != nil {
+			s.disableReminder(r, err.Error())
+			continue
+		}
+		next, err := spec.Next(now, loc)
+		if err != nil {
+			s.disableReminder(r, err.Error())
+			continue
+		}
+
+		// 비활성 fit group 이나 너무 늦은 리마인더는 보내지 않음
+		deliver := !fitGroup.State && now.Sub(r.NextRunAt) <= maxReminderDelay
+		var ranAt *time.Time
+		if deliver {
+			ranAt = &now
+		}
+		claimed, err := s.reminderRepo.AdvanceReminder(r.ID, r.NextRunAt, next, ranAt)
+		if err != nil {
+			log.Printf("Error advancing reminder %d: %v", r.ID, err)
+			continue
+		}
+		if !claimed || !deliver {
+			continue
+		}
+
+		text, err := s.reminderText(r, fitGroup, now.In(loc))
+		if err != nil {
+			log.Printf("Error building reminder %d message: %v", r.ID, err)
+			continue
+		}
+		if err := s.post(r.FitGroupID, text, now); err != nil {
+			log.Printf("Error posting reminder %d: %v", r.ID, err)
+			continue
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+// disableReminder 는 다시 실행해도 실패하는 리마인더를 비활성화합니다. 비활성화에 성공한 한 번만 로그를 남깁니다.
+func (s *ReminderService) disableReminder(r model.Reminder, reason string) {
+	disabled, err := s.reminderRepo.DisableReminder(r.ID, r.NextRunAt, reason)
+	if err != nil {
+		log.Printf("Error disabling reminder %d: %v", r.ID, err)
+		return
+	}
+	if disabled {
+		log.Printf("Disabled reminder %d of fit group %d: %s", r.ID, r.FitGroupID, reason)
+	}
+}
+
+// reminderText 는 보낼 메시지를 만듭니다. 인증 주기 마감 알림은 fit mate 별 이번 주기 인증 현황을 포함합니다.
+func (s *ReminderService) reminderText(r model.Reminder, fitGroup *model.FitGroup, localNow time.Time) (string, error) {
+	if r.Recurrence != string(schedule.CycleDeadline) {
+		return r.Message, nil
+	}
+
+	start, end := schedule.CycleBounds(fitGroup.Cycle, localNow)
+	progress, err := s.chatRepo.CountCertifications(fitGroup.ID, start, end)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	if r.Message != "" {
+		b.WriteString(r.Message)
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "이번 인증 주기 마감까지 %d일 남았습니다.", schedule.DaysLeft(fitGroup.Cycle, localNow))
+	for _, p := range progress {
+		fmt.Fprintf(&b, "\n%s %d/%d", p.Nickname, p.Count, fitGroup.Frequency)
+	}
+	return b.String(), nil
+}
+
+func (s *ReminderService) post(fitGroupID int, text string, now time.Time) error {
+	msg := model.ChatMessage{
+		ID:          util.NewUUID(),
+		UserID:      model.SystemBotUserID,
+		FitGroupID:  fitGroupID,
+		Message:     text,
+		MessageTime: now,
+		MessageType: model.System,
+	}
+	if err := s.chatRepo.SaveMessage(msg); err != nil {
+		return err
+	}
+	s.notifier.Post(fitGroupID, msg)
+	return nil
+}
+
+func (s *ReminderService) checkFitLeader(fitGroupID, userID int) (*model.FitGroup, error) {
+	fitGroup, err := s.fitGroupRepo.GetFitGroupByID(fitGroupID)
+	if err != nil {
+		return nil, err
+	}
+	if fitGroup.FitLeaderUserID != userID {
+		return nil, ErrNotFitLeader
+	}
+	return fitGroup, nil
+}
+
+// specOf 는 저장된 리마인더를 실행 일정으로 변환합니다.
+func specOf(r model.Reminder, cycle int) (schedule.Spec, error) {
+	minute, err := schedule.ParseTimeOfDay(r.TimeOfDay)
+	if err != nil {
+		return schedule.Spec{}, fmt.Errorf("%w: %v", ErrInvalidReminder, err)
+	}
+	spec := schedule.Spec{
+		Recurrence: schedule.Recurrence(r.Recurrence),
+		Minute:     minute,
+		DaysBefore: r.DaysBefore,
+		Cycle:      cycle,
+	}
+	for _, d := range r.Weekdays {
+		spec.Weekdays = append(spec.Weekdays, time.Weekday(d))
+	}
+	if err := spec.Validate(); err != nil {
+		return spec, fmt.Errorf("%w: %v", ErrInvalidReminder, err)
+	}
+	return spec, nil
+}
@@ -0,0 +1,328 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+	"workoutstudy_chatting/archive"
+	"workoutstudy_chatting/model"
+	"workoutstudy_chatting/persistence"
+)
+
+// 한 번에 아카이브할 메시지 수. 너무 크면 삭제 트랜잭션이 길어집니다.
+const retentionBatchSize = 1000
+
+type RetentionUseCase interface {
+	GetRetentionPolicies() ([]model.RetentionPolicy, error)
+	SetRetentionPolicy(fitGroupID, retentionDays, leaderID int) (*model.RetentionPolicy, error)
+	DeleteRetentionPolicy(fitGroupID, leaderID int) error
+	RunRetention(ctx context.Context) (*model.RetentionReport, error)
+	RestoreArchive(fitGroupID, leaderID int, start, end time.Time) (int, error)
+}
+
+var _ RetentionUseCase = (*RetentionService)(nil)
+
+type RetentionService struct {
+	chatRepo             persistence.ChatRepository
+	retentionRepo        persistence.RetentionRepository
+	fitGroupRepo         persistence.FitGroupRepository
+	pollRepo             persistence.PollRepository
+	storage              archive.Storage // nil 이면 아카이브 저장소가 설정되지 않아 아카이브와 복원을 하지 않음
+	defaultRetentionDays int             // 전역 보관 기간. 0 이면 fit group 별 정책이 있는 경우에만 아카이브
+}
+
+func NewRetentionService(chatRepo persistence.ChatRepository, retentionRepo persistence.RetentionRepository, fitGroupRepo persistence.FitGroupRepository, pollRepo persistence.PollRepository, storage archive.Storage, defaultRetentionDays int) *RetentionService {
+	return &RetentionService{
+		chatRepo:             chatRepo,
+		retentionRepo:        retentionRepo,
+		fitGroupRepo:         fitGroupRepo,
+		pollRepo:             pollRepo,
+		storage:              storage,
+		defaultRetentionDays: defaultRetentionDays,
+	}
+}
+
+func (s *RetentionService) GetRetentionPolicies() ([]model.RetentionPolicy, error) {
+	return s.retentionRepo.GetRetentionPolicies()
+}
+
+// SetRetentionPolicy 는 fit group 의 보관 기간을 설정합니다. fit leader 만 설정할 수 있습니다.
+func (s *RetentionService) SetRetentionPolicy(fitGroupID, retentionDays, leaderID int) (*model.RetentionPolicy, error) {
+	if retentionDays < 0 {
+		return nil, fmt.Errorf("retention days must not be negative: %d", retentionDays)
+	}
+	if err := s.checkFitLeader(fitGroupID, leaderID); err != nil {
+		return nil, err
+	}
+	updatedBy := strconv.Itoa(leaderID)
+	return s.retentionRepo.SaveRetentionPolicy(&model.RetentionPolicy{
+		FitGroupID:    fitGroupID,
+		RetentionDays: retentionDays,
+		CreatedBy:     updatedBy,
+		UpdatedBy:     updatedBy,
+	})
+}
+
+// DeleteRetentionPolicy 는 fit group 별 정책을 삭제해 전역 보관 기간을 따르도록 합니다. fit leader 만 삭제할 수 있습니다.
+func (s *RetentionService) DeleteRetentionPolicy(fitGroupID, leaderID int) error {
+	if err := s.checkFitLeader(fitGroupID, leaderID); err != nil {
+		return err
+	}
+	return s.retentionRepo.DeleteRetentionPolicy(fitGroupID)
+}
+
+// StartRetentionJob 은 interval 마다 RunRetention 을 실행하는 백그라운드 작업입니다. ctx 가 취소되면 종료합니다.
+// 배포 직후 메시지를 대량으로 삭제하지 않도록 첫 실행도 interval 뒤에 합니다.
+func (s *RetentionService) StartRetentionJob(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Retention job stopped")
+			return
+		case <-ticker.C:
+		}
+
+		report, err := s.RunRetention(ctx)
+		if err != nil {
+			log.Printf("Retention job failed: %v", err)
+		} else {
+			log.Printf("Retention job finished: %d fit groups, %d messages archived", report.FitGroupCount, report.ArchivedMessages)
+		}
+	}
+}
+
+/*
+RunRetention
+1. message 테이블에 메시지가 있는 fit group 목록 조회
+2. fit group 별 정책(없으면 전역 정책)으로 cutoff 계산
+3. cutoff 이전 메시지를 배치 단위로 조회 -> 날짜별 gzip JSONL 로 아카이브 저장
+(복원한 메시지, 고정된 메시지, 진행 중인 투표, 검토 대기 중인 메시지는 제외하고, 종료된 투표는 선택지와 투표 결과를 함께 저장)
+4. 아카이브 저장이 성공한 메시지만 DB 에서 삭제 (투표, moderation queue 기록은 ON DELETE CASCADE 로 함께 삭제)
+*/
+func (s *RetentionService) RunRetention(ctx context.Context) (*model.RetentionReport, error) {
+	if s.storage == nil {
+		return nil, ErrArchiveNotConfigured
+	}
+	report := &model.RetentionReport{StartedAt: time.Now()}
+
+	policies, err := s.retentionRepo.GetRetentionPolicies()
+	if err != nil {
+		return nil, err
+	}
+	policyByGroup := make(map[int]int, len(policies))
+	for _, p := range policies {
+		policyByGroup[p.FitGroupID] = p.RetentionDays
+	}
+
+	fitGroupIDs, err := s.chatRepo.GetFitGroupIDsWithMessages()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, fitGroupID := range fitGroupIDs {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+
+		days, ok := policyByGroup[fitGroupID]
+		if !ok {
+			days = s.defaultRetentionDays
+		}
+		if days <= 0 {
+			continue
+		}
+
+		cutoff := report.StartedAt.AddDate(0, 0, -days)
+		archived, keys, err := s.archiveFitGroup(ctx, fitGroupID, cutoff)
+		report.ArchivedMessages += archived
+		report.ArchiveObjects = append(report.ArchiveObjects, keys...)
+		if err != nil {
+			log.Printf("Error archiving fit group %d: %v", fitGroupID, err)
+			continue
+		}
+		if archived > 0 {
+			report.FitGroupCount++
+		}
+	}
+
+	report.FinishedAt = time.Now()
+	return report, nil
+}
+
+func (s *RetentionService) archiveFitGroup(ctx context.Context, fitGroupID int, cutoff time.Time) (int, []string, error) {
+	archived := 0
+	var keys []string
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return archived, keys, err
+		}
+
+		messages, err := s.chatRepo.RetrieveMessagesBefore(fitGroupID, cutoff, retentionBatchSize)
+		if err != nil {
+			return archived, keys, err
+		}
+		if len(messages) == 0 {
+			return archived, keys, nil
+		}
+		if err := s.attachPolls(messages); err != nil {
+			return archived, keys, err
+		}
+
+		batchKeys, err := s.writeArchive(fitGroupID, messages)
+		if err != nil {
+			return archived, keys, err
+		}
+		keys = append(keys, batchKeys...)
+
+		ids := make([]string, len(messages))
+		for i, msg := range messages {
+			ids[i] = msg.ID
+		}
+		if _, err := s.chatRepo.DeleteMessages(ids); err != nil {
+			// 아카이브는 이미 저장됨. 다음 실행 때 같은 메시지가 다시 아카이브되지만 복원 시 message_id 로 중복 제거됨
+			return archived, keys, err
+		}
+		archived += len(messages)
+
+		if len(messages) < retentionBatchSize {
+			return archived, keys, nil
+		}
+	}
+}
+
+// attachPolls 는 투표 메시지에 투표를 채웁니다. 메시지를 삭제하면 투표도 삭제되므로 조회에 실패하면 아카이브하지 않습니다.
+func (s *RetentionService) attachPolls(messages []model.ChatMessage) error {
+	for i := range messages {
+		if messages[i].MessageType != model.Poll {
+			continue
+		}
+		poll, err := s.pollRepo.GetPollByMessageID(messages[i].ID)
+		if err != nil {
+			return fmt.Errorf("error retrieving poll of message %s: %w", messages[i].ID, err)
+		}
+		messages[i].Poll = poll
+	}
+	return nil
+}
+
+// writeArchive 는 메시지를 날짜(UTC)별로 나누어 각각 하나의 아카이브 객체로 저장합니다.
+func (s *RetentionService) writeArchive(fitGroupID int, messages []model.ChatMessage) ([]string, error) {
+	byDate := make(map[string][]model.ChatMessage)
+	var dates []string
+	for _, msg := range messages {
+		date := msg.MessageTime.UTC().Format("2006-01-02")
+		if _, ok := byDate[date]; !ok {
+			dates = append(dates, date)
+		}
+		byDate[date] = append(byDate[date], msg)
+	}
+
+	batchID := time.Now().UTC().Format("20060102T150405.000000000")
+	keys := make([]string, 0, len(dates))
+	for _, date := range dates {
+		buf, err := archive.EncodeMessages(byDate[date])
+		if err != nil {
+			return keys, err
+		}
+		key := fmt.Sprintf("%s%s/%s.jsonl.gz", archivePrefix(fitGroupID), date, batchID)
+		if err := s.storage.Put(key, buf); err != nil {
+			return keys, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// RestoreArchive 는 [start, end] 구간에 해당하는 아카이브 메시지를 message 테이블로 복원하고 복원된 개수를 반환합니다.
+// fit leader 만 복원할 수 있습니다. 삭제된 메시지는 삭제된 상태로 복원합니다.
+func (s *RetentionService) RestoreArchive(fitGroupID, leaderID int, start, end time.Time) (int, error) {
+	if s.storage == nil {
+		return 0, ErrArchiveNotConfigured
+	}
+	if end.Before(start) {
+		return 0, fmt.Errorf("end %v is before start %v", end, start)
+	}
+	if err := s.checkFitLeader(fitGroupID, leaderID); err != nil {
+		return 0, err
+	}
+
+	prefix := archivePrefix(fitGroupID)
+	keys, err := s.storage.List(prefix)
+	if err != nil {
+		return 0, err
+	}
+
+	startDate := start.UTC().Format("2006-01-02")
+	endDate := end.UTC().Format("2006-01-02")
+
+	restored := 0
+	for _, key := range keys {
+		date := strings.SplitN(strings.TrimPrefix(key, prefix), "/", 2)[0]
+		if date < startDate || date > endDate {
+			continue
+		}
+
+		messages, err := s.readArchive(key)
+		if err != nil {
+			return restored, err
+		}
+
+		var inRange []model.ChatMessage
+		for _, msg := range messages {
+			if !msg.MessageTime.Before(start) && !msg.MessageTime.After(end) {
+				inRange = append(inRange, msg)
+			}
+		}
+		if len(inRange) == 0 {
+			continue
+		}
+
+		n, err := s.chatRepo.RestoreMessages(inRange)
+		if err != nil {
+			return restored, err
+		}
+		restored += n
+		// 메시지가 이미 복원되어 있어도 투표 복원이 실패했을 수 있으므로 모든 투표 메시지에 대해 시도
+		for _, msg := range inRange {
+			if msg.Poll == nil {
+				continue
+			}
+			if _, err := s.pollRepo.RestorePoll(msg.Poll); err != nil {
+				return restored, err
+			}
+		}
+		log.Printf("Restored %d messages from archive %s", n, key)
+	}
+	return restored, nil
+}
+
+func (s *RetentionService) readArchive(key string) ([]model.ChatMessage, error) {
+	r, err := s.storage.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return archive.DecodeMessages(r)
+}
+
+func (s *RetentionService) checkFitLeader(fitGroupID, userID int) error {
+	fitGroup, err := s.fitGroupRepo.GetFitGroupByID(fitGroupID)
+	if err != nil {
+		return err
+	}
+	if fitGroup.FitLeaderUserID != userID {
+		return ErrNotFitLeader
+	}
+	return nil
+}
+
+func archivePrefix(fitGroupID int) string {
+	return fmt.Sprintf("fit-group-%d/", fitGroupID)
+}
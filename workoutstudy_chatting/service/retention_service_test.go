@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"workoutstudy_chatting/archive"
+	"workoutstudy_chatting/model"
+	"workoutstudy_chatting/persistence"
+)
+
+// seedFitGroup 은 리더 사용자와 fit group 을 저장합니다.
+func seedFitGroup(t *testing.T, repos persistence.Repositories, fitGroupID, leaderID int) {
+	t.Helper()
+	if _, err := repos.FitGroup.SaveFitGroup(&model.FitGroup{ID: fitGroupID, FitLeaderUserID: leaderID, FitGroupName: "group", Cycle: 1, Frequency: 3, MaxFitMate: 10, CreatedBy: "test"}); err != nil {
+		t.Fatalf("SaveFitGroup: %v", err)
+	}
+}
+
+func newRetentionService(t *testing.T, repos persistence.Repositories) *RetentionService {
+	t.Helper()
+	storage, err := archive.NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalStorage: %v", err)
+	}
+	return NewRetentionService(repos.Chat, repos.Retention, repos.FitGroup, repos.Poll, storage, 0)
+}
+
+// 메시지 ID 는 uuid 여야 하므로 고정된 값을 씁니다.
+const (
+	oldMessageID     = "00000000-0000-0000-0000-000000000001"
+	deletedMessageID = "00000000-0000-0000-0000-000000000002"
+	recentMessageID  = "00000000-0000-0000-0000-000000000003"
+)
+
+// storedMessageIDs 는 테스트 메시지 중 DB 에 남아 있는 것의 ID 를 삭제된 메시지를 포함해 반환합니다.
+func storedMessageIDs(repos persistence.Repositories) []string {
+	var ids []string
+	for _, id := range []string{oldMessageID, deletedMessageID, recentMessageID} {
+		if msg, err := repos.Chat.GetMessageByID(id); err == nil && msg != nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func TestRetentionArchiveAndRestoreRoundTrip(t *testing.T) {
+	repos := persistence.NewMemoryRepositories(persistence.NewMemoryStore())
+	saveUsers(t, repos, 1, 2)
+	seedFitGroup(t, repos, 1, 1)
+	retention := newRetentionService(t, repos)
+
+	now := time.Now()
+	old := now.AddDate(0, 0, -40).Truncate(time.Second)
+	for _, msg := range []model.ChatMessage{
+		{ID: oldMessageID, UserID: 2, FitGroupID: 1, Message: "첫 메시지", MessageTime: old, MessageType: model.Chatting},
+		{ID: deletedMessageID, UserID: 2, FitGroupID: 1, Message: "지운 메시지", MessageTime: old.Add(time.Minute), MessageType: model.Chatting},
+		{ID: recentMessageID, UserID: 2, FitGroupID: 1, Message: "최근 메시지", MessageTime: now.Add(-time.Hour), MessageType: model.Chatting},
+	} {
+		if err := repos.Chat.SaveMessage(msg); err != nil {
+			t.Fatalf("SaveMessage %s: %v", msg.ID, err)
+		}
+	}
+	if err := repos.Chat.SoftDeleteMessage(deletedMessageID, "2"); err != nil {
+		t.Fatalf("SoftDeleteMessage: %v", err)
+	}
+	if _, err := retention.SetRetentionPolicy(1, 30, 1); err != nil {
+		t.Fatalf("SetRetentionPolicy: %v", err)
+	}
+
+	report, err := retention.RunRetention(context.Background())
+	if err != nil {
+		t.Fatalf("RunRetention: %v", err)
+	}
+	if report.ArchivedMessages != 2 || report.FitGroupCount != 1 || len(report.ArchiveObjects) != 1 {
+		t.Fatalf("report = %+v, want 2 messages in 1 archive object", report)
+	}
+	if got := storedMessageIDs(repos); !reflect.DeepEqual(got, []string{recentMessageID}) {
+		t.Fatalf("messages after archive = %v, want only the recent message", got)
+	}
+
+	// 아카이브한 날만 복원
+	restored, err := retention.RestoreArchive(1, 1, old.Add(-time.Hour), old.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("RestoreArchive: %v", err)
+	}
+	if restored != 2 {
+		t.Fatalf("restored = %d, want 2", restored)
+	}
+	if got := storedMessageIDs(repos); !reflect.DeepEqual(got, []string{oldMessageID, deletedMessageID, recentMessageID}) {
+		t.Fatalf("messages after restore = %v", got)
+	}
+	first, err := repos.Chat.GetMessageByID(oldMessageID)
+	if err != nil || first.Message != "첫 메시지" || !first.MessageTime.Equal(old) || first.DeletedAt != nil {
+		t.Fatalf("old message = %+v, %v, want restored as is", first, err)
+	}
+	deleted, err := repos.Chat.GetMessageByID(deletedMessageID)
+	if err != nil || deleted.DeletedAt == nil {
+		t.Fatalf("deleted message = %+v, %v, want restored as deleted", deleted, err)
+	}
+
+	// 복원한 메시지는 다시 아카이브하지 않고, 같은 구간을 다시 복원해도 중복되지 않음
+	report, err = retention.RunRetention(context.Background())
+	if err != nil || report.ArchivedMessages != 0 {
+		t.Fatalf("second RunRetention = %+v, %v, want nothing archived", report, err)
+	}
+	if restored, err := retention.RestoreArchive(1, 1, old.Add(-time.Hour), old.Add(time.Hour)); err != nil || restored != 0 {
+		t.Fatalf("second RestoreArchive = %d, %v, want 0", restored, err)
+	}
+	if got := storedMessageIDs(repos); !reflect.DeepEqual(got, []string{oldMessageID, deletedMessageID, recentMessageID}) {
+		t.Fatalf("messages after second run = %v", got)
+	}
+}
+
+func TestRetentionRequiresFitLeader(t *testing.T) {
+	repos := persistence.NewMemoryRepositories(persistence.NewMemoryStore())
+	saveUsers(t, repos, 1, 2)
+	seedFitGroup(t, repos, 1, 1)
+	retention := newRetentionService(t, repos)
+	now := time.Now()
+
+	tests := []struct {
+		name string
+		run  func() error
+	}{
+		{"보관 기간 설정", func() error { _, err := retention.SetRetentionPolicy(1, 30, 2); return err }},
+		{"보관 기간 삭제", func() error { return retention.DeleteRetentionPolicy(1, 2) }},
+		{"아카이브 복원", func() error { _, err := retention.RestoreArchive(1, 2, now.Add(-time.Hour), now); return err }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.run(); !errors.Is(err, ErrNotFitLeader) {
+				t.Fatalf("err = %v, want ErrNotFitLeader", err)
+			}
+		})
+	}
+	if policies, _ := retention.GetRetentionPolicies(); len(policies) != 0 {
+		t.Fatalf("policies = %+v, want none saved by non-leader", policies)
+	}
+}
+
+func TestRetentionWithoutArchiveStorage(t *testing.T) {
+	repos := persistence.NewMemoryRepositories(persistence.NewMemoryStore())
+	retention := NewRetentionService(repos.Chat, repos.Retention, repos.FitGroup, repos.Poll, nil, 30)
+
+	if _, err := retention.RunRetention(context.Background()); !errors.Is(err, ErrArchiveNotConfigured) {
+		t.Fatalf("RunRetention err = %v, want ErrArchiveNotConfigured", err)
+	}
+	if _, err := retention.RestoreArchive(1, 1, time.Now().Add(-time.Hour), time.Now()); !errors.Is(err, ErrArchiveNotConfigured) {
+		t.Fatalf("RestoreArchive err = %v, want ErrArchiveNotConfigured", err)
+	}
+}
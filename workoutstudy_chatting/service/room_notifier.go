@@ -0,0 +1,20 @@
+package service
+
+import "workoutstudy_chatting/model"
+
+// RoomNotifier 는 서비스 레이어에서 접속 중인 채팅방에 이벤트를 전달하기 위한 인터페이스입니다.
+// 웹소켓 Room 을 관리하는 handler 패키지에서 구현합니다.
+type RoomNotifier interface {
+	Publish(fitGroupID int, event model.RoomEvent)
+	// Post 는 서버가 만든 채팅 메시지(투표 결과 등)를 보낸 사람 구분 없이 채팅방 전체에 전달합니다.
+	Post(fitGroupID int, msg model.ChatMessage)
+	Kick(fitGroupID, userID int, reason string)
+}
+
+// MembershipNotifier 는 Kafka 로 받은 fit mate 가입/탈퇴, fit group 삭제를 사용자 웹소켓 구독에 반영하기 위한 인터페이스입니다.
+// RoomNotifier 와 마찬가지로 handler 패키지에서 구현합니다.
+type MembershipNotifier interface {
+	MemberJoined(fitGroupID, userID int)
+	MemberLeft(fitGroupID, userID int)
+	FitGroupRemoved(fitGroupID int)
+}
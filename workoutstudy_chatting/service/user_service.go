@@ -3,6 +3,7 @@ package service
 import (
 	"fmt"
 	"log"
+	"time"
 	"workoutstudy_chatting/model"
 	"workoutstudy_chatting/persistence"
 )
@@ -14,6 +15,7 @@ type UserUseCase interface {
 	GetUserByID(userID int) (*model.User, error)
 	HandleUserCreateEvent(user *model.UserCreateEvent) error
 	HandleUserInfoEvent(apiResponse model.GetUserInfoApiResponse) error
+	ReconcileUser(apiResponse model.GetUserInfoApiResponse) (bool, error)
 }
 
 // 컴파일 타임에 인터페이스 구현 확인
@@ -93,3 +95,23 @@ func (s *UserService) HandleUserInfoEvent(apiResponse model.GetUserInfoApiRespon
 
 	return nil
 }
+
+// ReconcileUser 는 DB 에 있는 사용자의 닉네임을 auth 서비스의 사용자 정보에 맞추고 수정했는지 반환합니다.
+// state 는 HandleUserInfoEvent 와 같이 반영하지 않습니다.
+func (s *UserService) ReconcileUser(apiResponse model.GetUserInfoApiResponse) (bool, error) {
+	user, err := s.repo.GetUserByID(apiResponse.UserID)
+	if err != nil {
+		return false, err
+	}
+	if user.Nickname == apiResponse.Nickname {
+		return false, nil
+	}
+
+	user.Nickname = apiResponse.Nickname
+	user.UpdatedAt = time.Now()
+	user.UpdatedBy = apiResponse.Nickname
+	if _, err := s.repo.UpdateUser(user); err != nil {
+		return false, err
+	}
+	return true, nil
+}
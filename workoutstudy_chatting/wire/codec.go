@@ -0,0 +1,50 @@
+package wire
+
+import (
+	"errors"
+	"workoutstudy_chatting/model"
+)
+
+// 채팅 웹소켓 subprotocol. 클라이언트가 요청하지 않으면 JSON 을 사용합니다.
+const (
+	JSON     = "json.v1"
+	MsgPack  = "msgpack.v1"
+	Protobuf = "protobuf.v1"
+)
+
+// Subprotocols 는 웹소켓 업그레이드 시 서버가 지원하는 subprotocol 입니다. 클라이언트가 여러 개를 요청하면 앞에 있는 것을 선택합니다.
+var Subprotocols = []string{Protobuf, MsgPack, JSON}
+
+var ErrInvalidFrame = errors.New("invalid frame")
+
+// Inbound 는 클라이언트가 보낸 프레임입니다. Poll 과 Chat 중 하나만 설정됩니다.
+type Inbound struct {
+	FitGroupID int // 사용자 웹소켓에서 대상 채팅방. 채팅방 웹소켓에서는 무시
+	Poll       *model.PollFrame
+	Chat       *model.ChatMessage
+}
+
+// Codec 은 채팅 프레임을 subprotocol 형식으로 인코딩/디코딩합니다.
+type Codec interface {
+	Name() string
+	// Binary 가 true 면 웹소켓 바이너리 메시지, false 면 텍스트 메시지로 보냅니다.
+	Binary() bool
+	Encode(frame interface{}) ([]byte, error)
+	Decode(data []byte) (Inbound, error)
+}
+
+// ForSubprotocol 은 업그레이드 시 선택된 subprotocol 의 Codec 을 반환합니다. 선택되지 않았으면 JSON 입니다.
+func ForSubprotocol(name string) Codec {
+	switch name {
+	case MsgPack:
+		return msgpackCodec{}
+	case Protobuf:
+		return protobufCodec{}
+	default:
+		return jsonCodec{}
+	}
+}
+
+func isPollFrame(frameType string) bool {
+	return frameType == model.FramePollVote || frameType == model.FramePollClose
+}
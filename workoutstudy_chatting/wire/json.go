@@ -0,0 +1,47 @@
+package wire
+
+import (
+	"encoding/json"
+	"fmt"
+	"workoutstudy_chatting/model"
+)
+
+type jsonCodec struct{}
+
+func (jsonCodec) Name() string { return JSON }
+func (jsonCodec) Binary() bool { return false }
+
+func (jsonCodec) Encode(frame interface{}) ([]byte, error) {
+	return json.Marshal(frame)
+}
+
+// Decode 는 type 필드로 투표 프레임과 채팅 메시지를 구분합니다.
+func (jsonCodec) Decode(data []byte) (Inbound, error) {
+	var probe struct {
+		Type       string `json:"type"`
+		FitGroupID int    `json:"fitGroupId"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return Inbound{}, fmt.Errorf("%w: %v", ErrInvalidFrame, err)
+	}
+	in := Inbound{FitGroupID: probe.FitGroupID}
+	if isPollFrame(probe.Type) {
+		var poll model.PollFrame
+		if err := json.Unmarshal(data, &poll); err != nil {
+			return Inbound{}, fmt.Errorf("%w: %v", ErrInvalidFrame, err)
+		}
+		in.Poll = &poll
+		return in, nil
+	}
+	var chat model.ChatMessage
+	if err := json.Unmarshal(data, &chat); err != nil {
+		return Inbound{}, fmt.Errorf("%w: %v", ErrInvalidFrame, err)
+	}
+	in.Chat = &chat
+	return in, nil
+}
+
+// DecodeJSON 은 REST 요청 본문처럼 subprotocol 협상 없이 받은 JSON 프레임을 디코딩합니다.
+func DecodeJSON(data []byte) (Inbound, error) {
+	return jsonCodec{}.Decode(data)
+}
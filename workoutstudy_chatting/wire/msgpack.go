@@ -0,0 +1,55 @@
+package wire
+
+import (
+	"fmt"
+	"reflect"
+	"workoutstudy_chatting/model"
+
+	"github.com/ugorji/go/codec"
+)
+
+// msgpackHandle 은 JSON 과 같은 필드 이름(json 태그)을 사용합니다. 시간은 msgpack timestamp 확장 타입입니다.
+var msgpackHandle = func() *codec.MsgpackHandle {
+	h := &codec.MsgpackHandle{WriteExt: true}
+	h.MapType = reflect.TypeOf(map[string]interface{}(nil))
+	h.RawToString = true
+	return h
+}()
+
+type msgpackCodec struct{}
+
+func (msgpackCodec) Name() string { return MsgPack }
+func (msgpackCodec) Binary() bool { return true }
+
+func (msgpackCodec) Encode(frame interface{}) ([]byte, error) {
+	var out []byte
+	if err := codec.NewEncoderBytes(&out, msgpackHandle).Encode(frame); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (msgpackCodec) Decode(data []byte) (Inbound, error) {
+	var probe struct {
+		Type       string `json:"type"`
+		FitGroupID int    `json:"fitGroupId"`
+	}
+	if err := codec.NewDecoderBytes(data, msgpackHandle).Decode(&probe); err != nil {
+		return Inbound{}, fmt.Errorf("%w: %v", ErrInvalidFrame, err)
+	}
+	in := Inbound{FitGroupID: probe.FitGroupID}
+	if isPollFrame(probe.Type) {
+		var poll model.PollFrame
+		if err := codec.NewDecoderBytes(data, msgpackHandle).Decode(&poll); err != nil {
+			return Inbound{}, fmt.Errorf("%w: %v", ErrInvalidFrame, err)
+		}
+		in.Poll = &poll
+		return in, nil
+	}
+	var chat model.ChatMessage
+	if err := codec.NewDecoderBytes(data, msgpackHandle).Decode(&chat); err != nil {
+		return Inbound{}, fmt.Errorf("%w: %v", ErrInvalidFrame, err)
+	}
+	in.Chat = &chat
+	return in, nil
+}
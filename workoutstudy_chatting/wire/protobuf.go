@@ -0,0 +1,317 @@
+package wire
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+	"workoutstudy_chatting/model"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// protobufCodec 은 wire/proto/chat.proto 의 Envelope 를 인코딩/디코딩합니다.
+// 스키마가 작아 코드 생성 없이 protowire 로 필드를 직접 읽고 씁니다.
+type protobufCodec struct{}
+
+func (protobufCodec) Name() string { return Protobuf }
+func (protobufCodec) Binary() bool { return true }
+
+// Envelope 필드 번호
+const (
+	envChatMessage  protowire.Number = 1
+	envPollFrame    protowire.Number = 2
+	envError        protowire.Number = 3
+	envRoomEvent    protowire.Number = 4
+	envCommandReply protowire.Number = 5
+	envRoom         protowire.Number = 6
+	envSubscription protowire.Number = 7
+	envJSON         protowire.Number = 15
+)
+
+// 클라이언트가 보내는 message_time 형식. JSON 과 같음
+const clientMessageTimeLayout = "2006-01-02T15:04:05.999999999"
+
+func (c protobufCodec) Encode(frame interface{}) ([]byte, error) {
+	switch f := frame.(type) {
+	case model.ChatMessage:
+		return encodeChatMessageEnvelope(f)
+	case *model.ChatMessage:
+		return encodeChatMessageEnvelope(*f)
+	case model.ChatErrorFrame:
+		var b []byte
+		b = appendString(b, 1, string(f.Code))
+		b = appendString(b, 2, f.Message)
+		b = appendString(b, 3, f.MessageID)
+		b = appendInt(b, 4, f.RetryAfterMs)
+		return appendMessage(nil, envError, b), nil
+	case model.RoomEvent:
+		var b []byte
+		b = appendString(b, 1, string(f.Type))
+		b = appendInt(b, 2, int64(f.FitGroupID))
+		b = appendString(b, 3, f.MessageID)
+		b = appendInt(b, 4, int64(f.TargetUserID))
+		b = appendInt(b, 5, int64(f.ActorUserID))
+		if f.Payload != nil {
+			payload, err := json.Marshal(f.Payload)
+			if err != nil {
+				return nil, err
+			}
+			b = appendBytes(b, 6, payload)
+		}
+		return appendMessage(nil, envRoomEvent, b), nil
+	case model.CommandReply:
+		return encodeCommandReplyEnvelope(f), nil
+	case *model.CommandReply:
+		return encodeCommandReplyEnvelope(*f), nil
+	case model.UserSocketRoomFrame:
+		inner, err := c.Encode(f.Frame)
+		if err != nil {
+			return nil, err
+		}
+		var b []byte
+		b = appendInt(b, 1, int64(f.FitGroupID))
+		b = appendMessage(b, 2, inner)
+		return appendMessage(nil, envRoom, b), nil
+	case model.SubscriptionFrame:
+		var b []byte
+		b = appendString(b, 1, f.Type)
+		b = appendPackedInts(b, 2, f.FitGroupIDs)
+		return appendMessage(nil, envSubscription, b), nil
+	default:
+		data, err := json.Marshal(frame)
+		if err != nil {
+			return nil, err
+		}
+		return appendMessage(nil, envJSON, data), nil
+	}
+}
+
+func encodeChatMessageEnvelope(m model.ChatMessage) ([]byte, error) {
+	var b []byte
+	b = appendString(b, 1, m.ID)
+	b = appendInt(b, 2, int64(m.UserID))
+	b = appendInt(b, 3, int64(m.FitGroupID))
+	b = appendInt(b, 4, int64(m.FitMateID))
+	b = appendString(b, 5, m.Message)
+	if !m.MessageTime.IsZero() {
+		b = appendString(b, 6, m.MessageTime.Format(time.RFC3339Nano))
+	}
+	b = appendString(b, 7, string(m.MessageType))
+	if m.DeletedAt != nil {
+		b = appendString(b, 8, m.DeletedAt.Format(time.RFC3339Nano))
+	}
+	if m.Poll != nil {
+		poll, err := json.Marshal(m.Poll)
+		if err != nil {
+			return nil, err
+		}
+		b = appendBytes(b, 9, poll)
+	}
+	return appendMessage(nil, envChatMessage, b), nil
+}
+
+func encodeCommandReplyEnvelope(r model.CommandReply) []byte {
+	var b []byte
+	b = appendString(b, 1, r.Command)
+	b = appendString(b, 2, r.Message)
+	b = appendString(b, 3, r.MessageID)
+	return appendMessage(nil, envCommandReply, b)
+}
+
+// Decode 는 클라이언트가 보내는 ChatMessage, PollFrame 만 허용합니다.
+func (protobufCodec) Decode(data []byte) (Inbound, error) {
+	var in Inbound
+	err := consumeFields(data, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		if typ != protowire.BytesType || (num != envChatMessage && num != envPollFrame) {
+			return protowire.ConsumeFieldValue(num, typ, b), nil
+		}
+		msg, n := protowire.ConsumeBytes(b)
+		if n < 0 {
+			return n, nil
+		}
+		if num == envChatMessage {
+			chat, err := decodeChatMessage(msg)
+			if err != nil {
+				return 0, err
+			}
+			in = Inbound{FitGroupID: chat.FitGroupID, Chat: &chat}
+		} else {
+			poll, fitGroupID, err := decodePollFrame(msg)
+			if err != nil {
+				return 0, err
+			}
+			in = Inbound{FitGroupID: fitGroupID, Poll: &poll}
+		}
+		return n, nil
+	})
+	if err != nil {
+		return Inbound{}, err
+	}
+	if in.Chat == nil && in.Poll == nil {
+		return Inbound{}, fmt.Errorf("%w: empty envelope", ErrInvalidFrame)
+	}
+	return in, nil
+}
+
+func decodeChatMessage(data []byte) (model.ChatMessage, error) {
+	var m model.ChatMessage
+	var messageTime string
+	err := consumeFields(data, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		switch {
+		case typ == protowire.VarintType && num >= 2 && num <= 4:
+			v, n := protowire.ConsumeVarint(b)
+			switch num {
+			case 2:
+				m.UserID = int(int64(v))
+			case 3:
+				m.FitGroupID = int(int64(v))
+			case 4:
+				m.FitMateID = int(int64(v))
+			}
+			return n, nil
+		case typ == protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			switch num {
+			case 1:
+				m.ID = string(v)
+			case 5:
+				m.Message = string(v)
+			case 6:
+				messageTime = string(v)
+			case 7:
+				m.MessageType = model.MessageType(v)
+			case 9:
+				if n >= 0 {
+					var poll model.ChatPoll
+					if err := json.Unmarshal(v, &poll); err != nil {
+						return 0, fmt.Errorf("%w: poll: %v", ErrInvalidFrame, err)
+					}
+					m.Poll = &poll
+				}
+			}
+			return n, nil
+		default:
+			return protowire.ConsumeFieldValue(num, typ, b), nil
+		}
+	})
+	if err != nil {
+		return model.ChatMessage{}, err
+	}
+
+	// JSON 과 같이 메시지 시간은 필수
+	t, err := time.Parse(clientMessageTimeLayout, messageTime)
+	if err != nil {
+		if t, err = time.Parse(time.RFC3339Nano, messageTime); err != nil {
+			return model.ChatMessage{}, fmt.Errorf("%w: message_time: %v", ErrInvalidFrame, err)
+		}
+	}
+	m.MessageTime = t
+	return m, nil
+}
+
+func decodePollFrame(data []byte) (model.PollFrame, int, error) {
+	var f model.PollFrame
+	var fitGroupID int
+	err := consumeFields(data, func(num protowire.Number, typ protowire.Type, b []byte) (int, error) {
+		switch {
+		case num == 1 && typ == protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			f.Type = string(v)
+			return n, nil
+		case num == 2 && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			f.PollID = int(int64(v))
+			return n, nil
+		case num == 3 && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			f.OptionIDs = append(f.OptionIDs, int(int64(v)))
+			return n, nil
+		case num == 3 && typ == protowire.BytesType:
+			packed, n := protowire.ConsumeBytes(b)
+			for len(packed) > 0 && n >= 0 {
+				v, m := protowire.ConsumeVarint(packed)
+				if m < 0 {
+					return m, nil
+				}
+				f.OptionIDs = append(f.OptionIDs, int(int64(v)))
+				packed = packed[m:]
+			}
+			return n, nil
+		case num == 4 && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			fitGroupID = int(int64(v))
+			return n, nil
+		default:
+			return protowire.ConsumeFieldValue(num, typ, b), nil
+		}
+	})
+	if err != nil {
+		return model.PollFrame{}, 0, err
+	}
+	if !isPollFrame(f.Type) {
+		return model.PollFrame{}, 0, fmt.Errorf("%w: unknown poll frame type %q", ErrInvalidFrame, f.Type)
+	}
+	return f, fitGroupID, nil
+}
+
+// consumeFields 는 메시지의 필드마다 fn 을 호출합니다. fn 은 태그 뒤 값의 길이(실패 시 음수)를 반환합니다.
+func consumeFields(data []byte, fn func(num protowire.Number, typ protowire.Type, b []byte) (int, error)) error {
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return fmt.Errorf("%w: %v", ErrInvalidFrame, protowire.ParseError(n))
+		}
+		data = data[n:]
+		m, err := fn(num, typ, data)
+		if err != nil {
+			return err
+		}
+		if m < 0 {
+			return fmt.Errorf("%w: %v", ErrInvalidFrame, protowire.ParseError(m))
+		}
+		data = data[m:]
+	}
+	return nil
+}
+
+// proto3 와 같이 기본값(0, 빈 문자열)인 필드는 쓰지 않습니다.
+func appendString(b []byte, num protowire.Number, v string) []byte {
+	if v == "" {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, v)
+}
+
+func appendBytes(b []byte, num protowire.Number, v []byte) []byte {
+	if len(v) == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, v)
+}
+
+func appendInt(b []byte, num protowire.Number, v int64) []byte {
+	if v == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, uint64(v))
+}
+
+func appendPackedInts(b []byte, num protowire.Number, vs []int) []byte {
+	if len(vs) == 0 {
+		return b
+	}
+	var packed []byte
+	for _, v := range vs {
+		packed = protowire.AppendVarint(packed, uint64(int64(v)))
+	}
+	return appendBytes(b, num, packed)
+}
+
+// appendMessage 는 oneof 선택을 나타내야 하므로 비어 있어도 씁니다.
+func appendMessage(b []byte, num protowire.Number, msg []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, msg)
+}
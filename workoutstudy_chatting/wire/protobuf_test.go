@@ -0,0 +1,284 @@
+package wire
+
+import (
+	"reflect"
+	"testing"
+	"time"
+	"workoutstudy_chatting/model"
+
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// chatProtoDescriptor 는 proto/chat.proto 의 Envelope, ChatMessage, PollFrame, ErrorFrame 정의를 옮긴 것입니다.
+// 표준 protobuf 구현(dynamicpb)으로 읽고 쓴 메시지와 protobufCodec 이 호환되는지 확인합니다.
+const chatProtoDescriptor = `
+name: "chat.proto"
+package: "workoutstudy.chat.v1"
+syntax: "proto3"
+message_type {
+  name: "Envelope"
+  field { name: "chat_message" number: 1 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".workoutstudy.chat.v1.ChatMessage" oneof_index: 0 }
+  field { name: "poll_frame" number: 2 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".workoutstudy.chat.v1.PollFrame" oneof_index: 0 }
+  field { name: "error" number: 3 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".workoutstudy.chat.v1.ErrorFrame" oneof_index: 0 }
+  field { name: "json" number: 15 label: LABEL_OPTIONAL type: TYPE_BYTES oneof_index: 0 }
+  oneof_decl { name: "frame" }
+}
+message_type {
+  name: "ChatMessage"
+  field { name: "message_id" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING }
+  field { name: "user_id" number: 2 label: LABEL_OPTIONAL type: TYPE_INT64 }
+  field { name: "fit_group_id" number: 3 label: LABEL_OPTIONAL type: TYPE_INT64 }
+  field { name: "fit_mate_id" number: 4 label: LABEL_OPTIONAL type: TYPE_INT64 }
+  field { name: "message" number: 5 label: LABEL_OPTIONAL type: TYPE_STRING }
+  field { name: "message_time" number: 6 label: LABEL_OPTIONAL type: TYPE_STRING }
+  field { name: "message_type" number: 7 label: LABEL_OPTIONAL type: TYPE_STRING }
+  field { name: "deleted_at" number: 8 label: LABEL_OPTIONAL type: TYPE_STRING }
+  field { name: "poll_json" number: 9 label: LABEL_OPTIONAL type: TYPE_BYTES }
+}
+message_type {
+  name: "PollFrame"
+  field { name: "type" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING }
+  field { name: "poll_id" number: 2 label: LABEL_OPTIONAL type: TYPE_INT64 }
+  field { name: "option_ids" number: 3 label: LABEL_REPEATED type: TYPE_INT64 }
+  field { name: "fit_group_id" number: 4 label: LABEL_OPTIONAL type: TYPE_INT64 }
+}
+message_type {
+  name: "ErrorFrame"
+  field { name: "code" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING }
+  field { name: "message" number: 2 label: LABEL_OPTIONAL type: TYPE_STRING }
+  field { name: "message_id" number: 3 label: LABEL_OPTIONAL type: TYPE_STRING }
+  field { name: "retry_after_ms" number: 4 label: LABEL_OPTIONAL type: TYPE_INT64 }
+}
+`
+
+func chatProtoMessage(t *testing.T, name string) *dynamicpb.Message {
+	t.Helper()
+	var fd descriptorpb.FileDescriptorProto
+	if err := prototext.Unmarshal([]byte(chatProtoDescriptor), &fd); err != nil {
+		t.Fatalf("parse descriptor: %v", err)
+	}
+	file, err := protodesc.NewFile(&fd, nil)
+	if err != nil {
+		t.Fatalf("build descriptor: %v", err)
+	}
+	desc := file.Messages().ByName(protoreflect.Name(name))
+	if desc == nil {
+		t.Fatalf("message %s not found", name)
+	}
+	return dynamicpb.NewMessage(desc)
+}
+
+// fields 는 설정된 필드를 이름별 값으로 모읍니다. 하위 메시지는 같은 형식의 map 으로 바꿉니다.
+func fields(m protoreflect.Message) map[string]interface{} {
+	out := make(map[string]interface{})
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsList():
+			var list []int64
+			for i := 0; i < v.List().Len(); i++ {
+				list = append(list, v.List().Get(i).Int())
+			}
+			out[string(fd.Name())] = list
+		case fd.Kind() == protoreflect.MessageKind:
+			out[string(fd.Name())] = fields(v.Message())
+		case fd.Kind() == protoreflect.BytesKind:
+			out[string(fd.Name())] = string(v.Bytes())
+		default:
+			out[string(fd.Name())] = v.Interface()
+		}
+		return true
+	})
+	return out
+}
+
+func TestProtobufEncodeMatchesChatProto(t *testing.T) {
+	messageTime := time.Date(2024, 5, 1, 9, 30, 0, 123000000, time.UTC)
+	deletedAt := time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		frame interface{}
+		want  map[string]interface{}
+	}{
+		{
+			name: "채팅 메시지",
+			frame: model.ChatMessage{
+				ID: "8f8c5b9e-6c1f-4f59-9a8f-3f0d1c2b4a10", UserID: 7, FitGroupID: 3, FitMateID: 11,
+				Message: "안녕하세요", MessageTime: messageTime, MessageType: model.Chatting,
+			},
+			want: map[string]interface{}{
+				"chat_message": map[string]interface{}{
+					"message_id":   "8f8c5b9e-6c1f-4f59-9a8f-3f0d1c2b4a10",
+					"user_id":      int64(7),
+					"fit_group_id": int64(3),
+					"fit_mate_id":  int64(11),
+					"message":      "안녕하세요",
+					"message_time": "2024-05-01T09:30:00.123Z",
+					"message_type": "CHATTING",
+				},
+			},
+		},
+		{
+			name: "삭제된 메시지는 기본값 필드를 쓰지 않음",
+			frame: &model.ChatMessage{
+				ID: "m1", FitGroupID: 3, MessageTime: messageTime, MessageType: model.Chatting, DeletedAt: &deletedAt,
+			},
+			want: map[string]interface{}{
+				"chat_message": map[string]interface{}{
+					"message_id":   "m1",
+					"fit_group_id": int64(3),
+					"message_time": "2024-05-01T09:30:00.123Z",
+					"message_type": "CHATTING",
+					"deleted_at":   "2024-05-02T00:00:00Z",
+				},
+			},
+		},
+		{
+			name:  "에러 프레임",
+			frame: model.ChatErrorFrame{Type: "ERROR", Code: model.ErrorRateLimited, Message: "too fast", MessageID: "m1", RetryAfterMs: 1500},
+			want: map[string]interface{}{
+				"error": map[string]interface{}{
+					"code":           string(model.ErrorRateLimited),
+					"message":        "too fast",
+					"message_id":     "m1",
+					"retry_after_ms": int64(1500),
+				},
+			},
+		},
+		{
+			name:  "정의되지 않은 프레임은 JSON",
+			frame: map[string]string{"type": "PONG"},
+			want:  map[string]interface{}{"json": `{"type":"PONG"}`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := protobufCodec{}.Encode(tt.frame)
+			if err != nil {
+				t.Fatalf("Encode: %v", err)
+			}
+			envelope := chatProtoMessage(t, "Envelope")
+			if err := proto.Unmarshal(data, envelope); err != nil {
+				t.Fatalf("unmarshal with chat.proto: %v", err)
+			}
+			if got := fields(envelope); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("fields = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProtobufDecodeFromChatProto(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(envelope *dynamicpb.Message) // 표준 구현으로 클라이언트 프레임 작성
+		want  Inbound
+	}{
+		{
+			name: "클라이언트 형식 메시지 시간",
+			build: func(envelope *dynamicpb.Message) {
+				msg := setFields(t, "ChatMessage", map[string]protoreflect.Value{
+					"message_id":   protoreflect.ValueOfString("m1"),
+					"user_id":      protoreflect.ValueOfInt64(7),
+					"fit_group_id": protoreflect.ValueOfInt64(3),
+					"message":      protoreflect.ValueOfString("hi"),
+					"message_time": protoreflect.ValueOfString("2024-05-01T09:30:00.5"),
+					"message_type": protoreflect.ValueOfString("CHATTING"),
+				})
+				envelope.Set(envelope.Descriptor().Fields().ByName("chat_message"), protoreflect.ValueOfMessage(msg))
+			},
+			want: Inbound{FitGroupID: 3, Chat: &model.ChatMessage{
+				ID: "m1", UserID: 7, FitGroupID: 3, Message: "hi",
+				MessageTime: time.Date(2024, 5, 1, 9, 30, 0, 500000000, time.UTC), MessageType: model.Chatting,
+			}},
+		},
+		{
+			name: "투표 프레임 (packed option_ids)",
+			build: func(envelope *dynamicpb.Message) {
+				msg := setFields(t, "PollFrame", map[string]protoreflect.Value{
+					"type":         protoreflect.ValueOfString(model.FramePollVote),
+					"poll_id":      protoreflect.ValueOfInt64(42),
+					"fit_group_id": protoreflect.ValueOfInt64(3),
+				})
+				options := msg.Mutable(msg.Descriptor().Fields().ByName("option_ids")).List()
+				options.Append(protoreflect.ValueOfInt64(1))
+				options.Append(protoreflect.ValueOfInt64(300))
+				envelope.Set(envelope.Descriptor().Fields().ByName("poll_frame"), protoreflect.ValueOfMessage(msg))
+			},
+			want: Inbound{FitGroupID: 3, Poll: &model.PollFrame{Type: model.FramePollVote, PollID: 42, OptionIDs: []int{1, 300}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			envelope := chatProtoMessage(t, "Envelope")
+			tt.build(envelope)
+			data, err := proto.Marshal(envelope)
+			if err != nil {
+				t.Fatalf("marshal with chat.proto: %v", err)
+			}
+			got, err := protobufCodec{}.Decode(data)
+			if err != nil {
+				t.Fatalf("Decode: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Decode = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProtobufChatMessageRoundTrip(t *testing.T) {
+	in := model.ChatMessage{
+		ID: "m1", UserID: 7, FitGroupID: 3, FitMateID: 11, Message: "인증 완료",
+		MessageTime: time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC), MessageType: model.Ticket,
+	}
+	data, err := protobufCodec{}.Encode(in)
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	out, err := protobufCodec{}.Decode(data)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if out.Chat == nil || !reflect.DeepEqual(*out.Chat, in) || out.FitGroupID != in.FitGroupID {
+		t.Fatalf("round trip = %+v, want %+v", out.Chat, in)
+	}
+}
+
+func TestProtobufDecodeInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"빈 envelope", nil},
+		{"잘린 태그", []byte{0x0a}},
+		{"잘린 메시지", []byte{0x0a, 0x05, 0x0a}},
+		{"서버 전용 프레임", []byte{0x1a, 0x00}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := (protobufCodec{}).Decode(tt.data); err == nil {
+				t.Fatal("Decode succeeded, want error")
+			}
+		})
+	}
+}
+
+func setFields(t *testing.T, name string, values map[string]protoreflect.Value) *dynamicpb.Message {
+	t.Helper()
+	msg := chatProtoMessage(t, name)
+	for field, v := range values {
+		fd := msg.Descriptor().Fields().ByName(protoreflect.Name(field))
+		if fd == nil {
+			t.Fatalf("%s has no field %s", name, field)
+		}
+		msg.Set(fd, v)
+	}
+	return msg
+}